@@ -1,6 +1,12 @@
 package minty
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
 
 // Core attribute helper functions for common HTML attributes.
 // These functions return Attribute instances that can be applied to elements.
@@ -19,6 +25,70 @@ func Class(value string) Attribute {
 	return StringAttribute{Name: "class", Value: value}
 }
 
+// Classes creates a class attribute from several class strings, for
+// classes that depend on state. Each string may hold several classes;
+// they are joined with single spaces, empty strings and stray whitespace
+// are dropped and a class repeated anywhere is kept only once, in its
+// first position.
+//
+//	mi.Classes("tab", mi.ClassIf(active, "active"), mi.ClassIf(!active, "text-gray-500"))
+func Classes(classes ...string) Attribute {
+	seen := make(map[string]bool)
+	var tokens []string
+	for _, class := range classes {
+		for _, token := range strings.Fields(class) {
+			if !seen[token] {
+				seen[token] = true
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return Class(strings.Join(tokens, " "))
+}
+
+// ClassIf returns class when cond is true and "" otherwise, for Classes.
+func ClassIf(cond bool, class string) string {
+	if cond {
+		return class
+	}
+	return ""
+}
+
+// noAttribute is an attribute that changes nothing.
+type noAttribute struct{}
+
+func (noAttribute) Apply(*Element) {}
+
+// AttrIf returns attr when condition holds and an attribute that does
+// nothing otherwise, so conditional attributes can stay in the argument list.
+//
+//	b.Nav(mi.AttrIf(!b.IsHTMX(), mi.HtmxBoost(true)), links)
+func AttrIf(condition bool, attr Attribute) Attribute {
+	if condition && attr != nil {
+		return attr
+	}
+	return noAttribute{}
+}
+
+// attributeList applies several attributes in order.
+type attributeList []Attribute
+
+func (l attributeList) Apply(e *Element) {
+	for _, attr := range l {
+		if attr != nil {
+			attr.Apply(e)
+		}
+	}
+}
+
+// Attrs combines attributes into one, for passing a slice built elsewhere
+// without copying it into a []interface{}. Nil attributes are skipped.
+//
+//	b.Input(mi.Attrs(fieldAttrs...), mi.AttrIf(required, mi.Required()))
+func Attrs(attrs ...Attribute) Attribute {
+	return attributeList(attrs)
+}
+
 // ID creates an id attribute.
 func ID(value string) Attribute {
 	return StringAttribute{Name: "id", Value: value}
@@ -80,9 +150,10 @@ func Translate(value bool) Attribute {
 
 // Link and navigation attributes
 
-// Href creates an href attribute for links.
+// Href creates an href attribute for links. URLs that would run code are
+// replaced by "#", see SafeURL.
 func Href(url string) Attribute {
-	return StringAttribute{Name: "href", Value: url}
+	return StringAttribute{Name: "href", Value: SafeURL(url)}
 }
 
 // Target creates a target attribute for links.
@@ -123,6 +194,56 @@ func DataAttr(name, value string) Attribute {
 	return Data(name, value)
 }
 
+// strictDataAttrs is set by StrictDataAttrs.
+var strictDataAttrs atomic.Bool
+
+// StrictDataAttrs makes DataAttrs panic on a key that is not a valid
+// data-* name, to catch mistakes in development. By default such keys are
+// skipped.
+func StrictDataAttrs(strict bool) {
+	strictDataAttrs.Store(strict)
+}
+
+// DataAttrs creates a data-* attribute for every entry of attrs, applied in
+// key order. Keys are the names without "data-" and may only hold lower-case
+// letters, digits, '-', '_' and '.'; other keys are skipped, or panic under
+// StrictDataAttrs. Render WithCanonicalAttributes to get them in order in
+// the output too.
+//
+//	b.Tr(mi.DataAttrs(map[string]string{"status": row.Status, "type": row.Type}), ...)
+func DataAttrs(attrs map[string]string) Attribute {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		if !isDataAttrName(key) {
+			if strictDataAttrs.Load() {
+				panic(fmt.Sprintf("minty: invalid data attribute name %q", key))
+			}
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	list := make(attributeList, len(keys))
+	for i, key := range keys {
+		list[i] = Data(key, attrs[key])
+	}
+	return list
+}
+
+// isDataAttrName reports whether name is safe after "data-".
+func isDataAttrName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
 // Meta attributes
 
 // Name creates a name attribute.
@@ -152,9 +273,10 @@ func HttpEquiv(value string) Attribute {
 
 // Form attributes
 
-// Action creates an action attribute for forms.
+// Action creates an action attribute for forms. URLs that would run code
+// are replaced by "#", see SafeURL.
 func Action(url string) Attribute {
-	return StringAttribute{Name: "action", Value: url}
+	return StringAttribute{Name: "action", Value: SafeURL(url)}
 }
 
 // Method creates a method attribute for forms.
@@ -326,9 +448,10 @@ func Form(value string) Attribute {
 	return StringAttribute{Name: "form", Value: value}
 }
 
-// Formaction creates a formaction attribute.
+// Formaction creates a formaction attribute. URLs that would run code are
+// replaced by "#", see SafeURL.
 func Formaction(value string) Attribute {
-	return StringAttribute{Name: "formaction", Value: value}
+	return StringAttribute{Name: "formaction", Value: SafeURL(value)}
 }
 
 // Formenctype creates a formenctype attribute.
@@ -358,9 +481,10 @@ func List(value string) Attribute {
 
 // Media attributes
 
-// Src creates a src attribute for images and media elements.
+// Src creates a src attribute for images and media elements. URLs that
+// would run code leave it empty, see SafeURL.
 func Src(url string) Attribute {
-	return StringAttribute{Name: "src", Value: url}
+	return StringAttribute{Name: "src", Value: safeSrc(url)}
 }
 
 // Alt creates an alt attribute for images.
@@ -383,9 +507,10 @@ func Crossorigin(value string) Attribute {
 	return StringAttribute{Name: "crossorigin", Value: value}
 }
 
-// Poster creates a poster attribute for video.
+// Poster creates a poster attribute for video. URLs that would run code
+// leave it empty, see SafeURL.
 func Poster(url string) Attribute {
-	return StringAttribute{Name: "poster", Value: url}
+	return StringAttribute{Name: "poster", Value: safeSrc(url)}
 }
 
 // Preload creates a preload attribute.
@@ -408,6 +533,50 @@ func Media(value string) Attribute {
 	return StringAttribute{Name: "media", Value: value}
 }
 
+// Loading creates a loading attribute ("lazy" or "eager").
+func Loading(value string) Attribute {
+	return StringAttribute{Name: "loading", Value: value}
+}
+
+// Decoding creates a decoding attribute ("async", "sync" or "auto").
+func Decoding(value string) Attribute {
+	return StringAttribute{Name: "decoding", Value: value}
+}
+
+// SrcSetEntry is one candidate in a srcset: a URL with either a width
+// descriptor (Width, e.g. 640 → "640w") or a pixel density (Density, e.g. 2 → "2x").
+type SrcSetEntry struct {
+	URL     string
+	Width   int
+	Density float64
+}
+
+// String formats the entry as a srcset candidate.
+func (e SrcSetEntry) String() string {
+	switch {
+	case e.Width > 0:
+		return fmt.Sprintf("%s %dw", e.URL, e.Width)
+	case e.Density > 0:
+		return fmt.Sprintf("%s %gx", e.URL, e.Density)
+	default:
+		return e.URL
+	}
+}
+
+// SrcSet creates a srcset attribute from structured entries.
+func SrcSet(entries []SrcSetEntry) Attribute {
+	return Srcset(srcSetValue(entries))
+}
+
+// srcSetValue joins srcset entries with commas.
+func srcSetValue(entries []SrcSetEntry) string {
+	parts := make([]string, len(entries))
+	for i, e := range entries {
+		parts[i] = e.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
 // Table attributes
 
 // Colspan creates a colspan attribute.
@@ -501,11 +670,23 @@ func HtmxSwap(strategy string) Attribute {
 	return StringAttribute{Name: "hx-swap", Value: strategy}
 }
 
-// HtmxSwapOOB creates an hx-swap-oob attribute for out-of-band swaps.
+// HtmxSwapOOB creates an hx-swap-oob attribute for out-of-band swaps. The
+// element is swapped into the page element with the same id, whatever the
+// request's hx-target and hx-swap, so one response can update several
+// panels. value is "true" or a swap strategy such as "innerHTML" or
+// "beforeend:#log".
+//
+//	b.Div(mi.ID("clock"), mi.OOB(), now.Format("15:04"))
 func HtmxSwapOOB(value string) Attribute {
 	return StringAttribute{Name: "hx-swap-oob", Value: value}
 }
 
+// OOB marks an element for an out-of-band swap replacing the element with
+// its id, hx-swap-oob="true".
+func OOB() Attribute {
+	return HtmxSwapOOB("true")
+}
+
 // HTMX Triggering
 
 // HtmxTrigger creates an hx-trigger attribute to specify what triggers the request.
@@ -513,6 +694,21 @@ func HtmxTrigger(trigger string) Attribute {
 	return StringAttribute{Name: "hx-trigger", Value: trigger}
 }
 
+// HtmxTriggerDebounced creates an hx-trigger that waits until event has
+// stopped firing for ms milliseconds, e.g. HtmxTriggerDebounced("keyup", 300).
+// Debouncing reduces requests but does not order responses; pair it with
+// HtmxSync("this:replace") for search-as-you-type. See DebouncedTrigger.
+func HtmxTriggerDebounced(event string, ms int) Attribute {
+	return HtmxTrigger(DebouncedTrigger(event, ms))
+}
+
+// HtmxPollVisible creates an hx-trigger that polls every interval (e.g. "5s")
+// only while the page is visible, and refreshes immediately when the tab
+// becomes visible again. See VisiblePollTrigger.
+func HtmxPollVisible(interval string) Attribute {
+	return HtmxTrigger(VisiblePollTrigger(interval))
+}
+
 // HtmxOn creates an hx-on:* attribute to specify event handlers. The hx-on*
 // attributes allow you to embed scripts inline to respond to events directly on
 // an element; similar to the onevent properties found in HTML, such as onClick.
@@ -556,6 +752,31 @@ func HtmxVals(values string) Attribute {
 	return StringAttribute{Name: "hx-vals", Value: values}
 }
 
+// HtmxHeadersMap creates an hx-headers attribute from a map, encoded as
+// JSON.
+//
+//	mi.HtmxHeadersMap(map[string]string{"X-CSRF-Token": token})
+func HtmxHeadersMap(headers map[string]string) Attribute {
+	return HtmxHeaders(mustJSON("HtmxHeadersMap", headers))
+}
+
+// HtmxValsMap creates an hx-vals attribute from a map, encoded as JSON.
+// It panics on values encoding/json cannot encode, such as channels.
+//
+//	mi.HtmxValsMap(map[string]interface{}{"assetId": asset.ID, "archived": true})
+func HtmxValsMap(values map[string]interface{}) Attribute {
+	return HtmxVals(mustJSON("HtmxValsMap", values))
+}
+
+// mustJSON encodes v as JSON for an attribute helper, panicking on error.
+func mustJSON(helper string, v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic("minty: " + helper + ": " + err.Error())
+	}
+	return string(data)
+}
+
 // HtmxInclude creates an hx-include attribute to include additional form data.
 func HtmxInclude(selector string) Attribute {
 	return StringAttribute{Name: "hx-include", Value: selector}
@@ -575,23 +796,81 @@ func HtmxReplaceURL(url string) Attribute {
 
 // HTMX Synchronization
 
-// HtmxSync creates an hx-sync attribute for request synchronization.
+// HtmxSync creates an hx-sync attribute for request synchronization. The
+// value is an element selector (this, closest form, #id) and a strategy:
+//
+//	"this:replace"      abort the in-flight request and send the new one;
+//	                    only the latest response is swapped (search, filters)
+//	"this:drop"         ignore new requests while one is in flight (default)
+//	"this:queue last"   finish the current request, then send only the
+//	                    most recent queued one (saving as the user edits)
+//	"closest form:abort" on an input: skip validation requests while the
+//	                    form submits, and abort them if a submit starts
+//
+// Responses can arrive out of order only when requests overlap, so every
+// strategy prevents stale results from overwriting fresh ones; "queue all"
+// also keeps order but still sends every request.
 func HtmxSync(value string) Attribute {
 	return StringAttribute{Name: "hx-sync", Value: value}
 }
 
 // HTMX Extensions
 
-// HtmxExt creates an hx-ext attribute for HTMX extensions.
-func HtmxExt(extensions string) Attribute {
-	return StringAttribute{Name: "hx-ext", Value: extensions}
+// HtmxExt creates an hx-ext attribute enabling HTMX extensions on the
+// element and its descendants, e.g. HtmxExt("sse") or HtmxExt("sse", "ws").
+func HtmxExt(names ...string) Attribute {
+	return StringAttribute{Name: "hx-ext", Value: strings.Join(names, ", ")}
+}
+
+// SseConnect creates an sse-connect attribute, opening a server-sent events
+// stream for the htmx SSE extension. Children pick events from it with
+// SseSwap, or with an "sse:<event>" trigger to issue a request:
+//
+//	b.Div(mi.HtmxExt("sse"), mi.SseConnect("/dashboard/events"),
+//	    b.Div(mi.ID("gauge-cpu"), mi.SseSwap("cpu"), cpuGauge),
+//	    b.Div(mi.ID("gauge-mem"), mi.SseSwap("memory"), memGauge),
+//	)
+//
+// The server then sends each gauge's HTML as an event named after it.
+func SseConnect(url string) Attribute {
+	return StringAttribute{Name: "sse-connect", Value: url}
+}
+
+// SseSwap creates an sse-swap attribute: the element's content is replaced
+// by the data of each server-sent event with this name, or names separated
+// by commas.
+func SseSwap(event string) Attribute {
+	return StringAttribute{Name: "sse-swap", Value: event}
+}
+
+// WsConnect creates a ws-connect attribute, opening a WebSocket for the
+// htmx WS extension. Messages from the server are swapped in by the ids of
+// their top-level elements, as out-of-band swaps are.
+//
+//	b.Div(mi.HtmxExt("ws"), mi.WsConnect("/chat"),
+//	    b.Div(mi.ID("messages")),
+//	    b.Form(mi.WsSend(), b.Input(mi.Name("message"))),
+//	)
+func WsConnect(url string) Attribute {
+	return StringAttribute{Name: "ws-connect", Value: url}
+}
+
+// WsSend creates a ws-send attribute: the form or element sends its values
+// as JSON over the nearest WsConnect socket instead of making a request.
+func WsSend() Attribute {
+	return BooleanAttribute{Name: "ws-send"}
 }
 
 // HTMX Boost
 
-// HtmxBoost creates an hx-boost attribute for progressive enhancement.
-func HtmxBoost() Attribute {
-	return BooleanAttribute{Name: "hx-boost"}
+// HtmxBoost creates an hx-boost attribute for progressive enhancement:
+// with true, links and forms inside the element load through htmx instead
+// of as full pages; false turns boosting off again inside a boosted region.
+func HtmxBoost(enabled bool) Attribute {
+	if enabled {
+		return StringAttribute{Name: "hx-boost", Value: "true"}
+	}
+	return StringAttribute{Name: "hx-boost", Value: "false"}
 }
 
 // HtmxPreserve creates an hx-preserve attribute to preserve elements during swaps.
@@ -669,6 +948,9 @@ func HxTarget(selector string) Attribute { return HtmxTarget(selector) }
 // HxSwap is an alias for HtmxSwap
 func HxSwap(strategy string) Attribute { return HtmxSwap(strategy) }
 
+// HxSwapOOB is an alias for HtmxSwapOOB
+func HxSwapOOB(value string) Attribute { return HtmxSwapOOB(value) }
+
 // HxTrigger is an alias for HtmxTrigger
 func HxTrigger(trigger string) Attribute { return HtmxTrigger(trigger) }
 
@@ -687,6 +969,12 @@ func HxVals(values string) Attribute { return HtmxVals(values) }
 // HxInclude is an alias for HtmxInclude
 func HxInclude(selector string) Attribute { return HtmxInclude(selector) }
 
+// HxSync is an alias for HtmxSync
+func HxSync(value string) Attribute { return HtmxSync(value) }
+
+// HxTriggerDebounced is an alias for HtmxTriggerDebounced
+func HxTriggerDebounced(event string, ms int) Attribute { return HtmxTriggerDebounced(event, ms) }
+
 // =====================================================
 // SVG ATTRIBUTES
 // =====================================================
@@ -1,12 +1,60 @@
 package minty
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
+	"sync"
+	"sync/atomic"
 )
 
 // Builder provides methods for creating HTML elements with the Minty pattern.
-type Builder struct{}
+// Render and RenderToString give every render its own Builder, so state
+// such as the UniqueID counter is scoped to one page.
+type Builder struct {
+	ids     atomic.Int64
+	request *http.Request   // Set by RenderRequest
+	ctx     context.Context // Set by RenderContext
+	nonce   string          // Set by WithNonce
+}
+
+// UniqueID returns prefix followed by a number that is unique among the IDs
+// this builder has handed out, e.g. "dyn-1", "dyn-2". Within one Render the
+// sequence restarts from 1, so the same page gets the same IDs each time.
+// Components should use it when the caller leaves their id empty.
+func (b *Builder) UniqueID(prefix string) string {
+	return prefix + "-" + strconv.FormatInt(b.ids.Add(1), 10)
+}
+
+// builders recycles the Builders of Render and the other render functions.
+var builders = sync.Pool{
+	New: func() interface{} { return new(Builder) },
+}
+
+// acquireBuilder returns a reset Builder from the pool.
+func acquireBuilder() *Builder {
+	return builders.Get().(*Builder)
+}
+
+// releaseBuilder resets b and returns it to the pool. The render using it
+// must be complete: the pool may hand b to another render straight away.
+func releaseBuilder(b *Builder) {
+	b.Reset()
+	builders.Put(b)
+}
+
+// Reset clears the builder's state, the UniqueID counter, request, context
+// and nonce, as for a new render. The render functions reset their builders
+// when they are done and reuse them for later renders, so a template must
+// not keep its b, or anything that calls it, beyond its render.
+func (b *Builder) Reset() {
+	b.ids.Store(0)
+	b.request = nil
+	b.ctx = nil
+	b.nonce = ""
+}
 
 // createElement creates an element with the given tag and processes mixed arguments.
 func (b *Builder) createElement(tag string, selfClosing bool, args ...interface{}) Node {
@@ -26,7 +74,7 @@ func (b *Builder) createElement(tag string, selfClosing bool, args ...interface{
 			v.Apply(element)
 		case Node:
 			if !selfClosing {
-				element.Children = append(element.Children, v)
+				element.Children = append(element.Children, b.resolve(v))
 			}
 		case string:
 			if !selfClosing {
@@ -50,6 +98,63 @@ func (b *Builder) createElement(tag string, selfClosing bool, args ...interface{
 	return element
 }
 
+// ErrInvalidTagName is returned when rendering an element made with Elem,
+// Void or Element whose tag name is not a valid element name.
+var ErrInvalidTagName = errors.New("minty: invalid tag name")
+
+// Elem creates an element with any tag name, for custom elements such as
+// <my-widget> and tags without a method of their own. Arguments are handled
+// as by the other element methods. A tag name that is not a valid element
+// name, e.g. one with spaces or quotes, fails the render with
+// ErrInvalidTagName.
+//
+//	b.Elem("asset-map", mi.Attr("region", "emea"), b.P("Loading map…"))
+func (b *Builder) Elem(tag string, args ...interface{}) Node {
+	if !isValidTagName(tag) {
+		return errorNode{err: fmt.Errorf("%w: %q", ErrInvalidTagName, tag)}
+	}
+	return b.createElement(tag, false, args...)
+}
+
+// Void creates a self-closing element with any tag name, like Elem; only
+// the attributes among args are used.
+//
+//	b.Void("gauge-tick", mi.Attr("value", "75"))
+func (b *Builder) Void(tag string, args ...interface{}) Node {
+	if !isValidTagName(tag) {
+		return errorNode{err: fmt.Errorf("%w: %q", ErrInvalidTagName, tag)}
+	}
+	return b.createElement(tag, true, args...)
+}
+
+// Element is Elem for XML vocabularies rendered with WithXML, where names
+// may carry a namespace prefix such as atom:link.
+//
+//	b.Element("url",
+//	    b.Element("loc", "https://example.com/about"),
+//	    b.Element("lastmod", "2025-03-10"),
+//	)
+func (b *Builder) Element(tag string, children ...interface{}) Node {
+	return b.Elem(tag, children...)
+}
+
+// isValidTagName reports whether tag is a valid element name: an ASCII
+// letter followed by letters, digits, '-', '_', '.', ':' or non-ASCII
+// characters, as custom element names allow.
+func isValidTagName(tag string) bool {
+	for i, r := range tag {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+		case i == 0:
+			return false
+		case r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' || r == ':' || r >= 0x80:
+		default:
+			return false
+		}
+	}
+	return tag != ""
+}
+
 // Logic for creating HTML elements
 
 // If returns the Node if the condition is true, otherwise returns nil.
@@ -110,12 +215,16 @@ func (b *Builder) Link(attrs ...Attribute) Node {
 
 // Script creates a <script> element.
 func (b *Builder) Script(children ...interface{}) Node {
-	return b.createElement("script", false, children...)
+	element := b.createElement("script", false, children...)
+	b.applyNonce(element.(*Element))
+	return element
 }
 
 // Style creates a <style> element.
 func (b *Builder) Style(children ...interface{}) Node {
-	return b.createElement("style", false, children...)
+	element := b.createElement("style", false, children...)
+	b.applyNonce(element.(*Element))
+	return element
 }
 
 // Base creates a <base> element (self-closing).
@@ -755,5 +864,7 @@ func (b *Builder) SvgText(children ...interface{}) Node {
 	return b.createElement("text", false, children...)
 }
 
-// Global builder instance using standard Minty alias pattern
+// Global builder instance using standard Minty alias pattern. Nodes built
+// with it share its UniqueID counter across renders; the nodes of Each and
+// the other control helpers are built with the render's own builder instead.
 var B = &Builder{}
@@ -0,0 +1,46 @@
+package minty
+
+import (
+	"sort"
+)
+
+// WithCanonicalAttributes writes every element's attributes in a fixed
+// order: id, class, the remaining attributes alphabetically, then boolean
+// attributes (those rendered as disabled="disabled") alphabetically. The
+// same tree always renders to the same bytes, so golden-file tests do not
+// depend on map order or on how the attribute arguments were ordered.
+// Browsers ignore attribute order, so the page behaves the same.
+//
+//	html := mi.RenderToString(page, mi.WithCanonicalAttributes())
+func WithCanonicalAttributes() RenderOption {
+	return func(c *renderConfig) { c.canonical = true }
+}
+
+// canonicalAttributeOrder returns the attribute names in canonical order.
+func canonicalAttributeOrder(attrs map[string]string) []string {
+	rank := func(key string) int {
+		switch {
+		case key == "id":
+			return 0
+		case key == "class":
+			return 1
+		case attrs[key] == key:
+			return 3
+		default:
+			return 2
+		}
+	}
+
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := rank(keys[i]), rank(keys[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,257 @@
+package minty
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Series is one named set of values in a chart, one value per category.
+type Series struct {
+	Name   string
+	Values []float64
+	Color  string // Optional; defaults to the next token color
+}
+
+// ChartOptions configures a BarChart.
+type ChartOptions struct {
+	ID          string
+	Class       string
+	Title       string                 // Accessible name of the chart
+	Labels      []string               // Category labels along the x-axis
+	Width       int                    // viewBox width (default 600); the chart scales to its container
+	Height      int                    // viewBox height (default 300)
+	Stacked     bool                   // Stack series instead of grouping them side by side
+	YTicks      int                    // Number of y-axis ticks and gridlines; 0 hides the axis
+	Max         float64                // y-axis maximum; 0 rounds the data maximum up to a nice value
+	ValueLabels bool                   // Print each value above (or inside, when stacked) its bar
+	Format      func(v float64) string // Formats ticks and value labels; default trims to 2 decimals
+	Tokens      *ThemeTokens           // Colors; default DefaultThemeTokens()
+}
+
+// BarChart renders series as a responsive SVG bar chart. Multiple series are
+// grouped side by side, or stacked with Stacked, and get a legend. Negative
+// values are drawn as zero.
+//
+//	mi.BarChart([]mi.Series{
+//	    {Name: "2024", Values: []float64{120, 95, 140}},
+//	    {Name: "2025", Values: []float64{135, 110, 150}},
+//	}, mi.ChartOptions{Labels: []string{"Q1", "Q2", "Q3"}, YTicks: 4, ValueLabels: true})
+func BarChart(series []Series, opts ChartOptions) H {
+	return func(b *Builder) Node {
+		width, height := opts.Width, opts.Height
+		if width <= 0 {
+			width = 600
+		}
+		if height <= 0 {
+			height = 300
+		}
+		tokens := DefaultThemeTokens()
+		if opts.Tokens != nil {
+			tokens = *opts.Tokens
+		}
+		format := opts.Format
+		if format == nil {
+			format = chartNumber
+		}
+
+		categories := len(opts.Labels)
+		for _, s := range series {
+			if len(s.Values) > categories {
+				categories = len(s.Values)
+			}
+		}
+
+		// Plot area, leaving room for the legend, y-axis and x labels
+		top, left, right, bottom := 10.0, 10.0, 10.0, 24.0
+		if len(series) > 1 {
+			top += 24
+		}
+		if opts.YTicks > 0 {
+			left = 48
+		}
+		plotW := float64(width) - left - right
+		plotH := float64(height) - top - bottom
+
+		max := opts.Max
+		if max <= 0 {
+			max = niceChartMax(chartDataMax(series, categories, opts.Stacked))
+		}
+		scale := func(v float64) float64 { return plotH * math.Max(v, 0) / max }
+
+		palette := chartPalette(tokens)
+		colors := make([]string, len(series))
+		for i, s := range series {
+			colors[i] = s.Color
+			if colors[i] == "" {
+				colors[i] = palette[i%len(palette)]
+			}
+		}
+
+		svg := []interface{}{
+			Attr("viewBox", fmt.Sprintf("0 0 %d %d", width, height)),
+			Attr("width", "100%"),
+			Attr("role", "img"),
+			Attr("font-family", tokens.FontFamily),
+		}
+		if opts.Title != "" {
+			svg = append(svg, AriaLabel(opts.Title), b.Title(opts.Title))
+		}
+
+		// Y-axis ticks and gridlines
+		if opts.YTicks > 0 {
+			grid := []interface{}{Class("minty-chart-grid")}
+			for i := 0; i <= opts.YTicks; i++ {
+				v := max * float64(i) / float64(opts.YTicks)
+				y := top + plotH - scale(v)
+				grid = append(grid,
+					b.Line(Attr("x1", chartNumber(left)), Attr("x2", chartNumber(left+plotW)),
+						Attr("y1", chartNumber(y)), Attr("y2", chartNumber(y)),
+						Attr("stroke", tokens.Colors.Border)),
+					b.SvgText(Attr("x", chartNumber(left-6)), Attr("y", chartNumber(y)),
+						Attr("text-anchor", "end"), Attr("dominant-baseline", "middle"),
+						Attr("font-size", "11"), Attr("fill", tokens.Colors.Muted), format(v)),
+				)
+			}
+			svg = append(svg, b.G(grid...))
+		}
+
+		// Bars
+		if categories > 0 && len(series) > 0 {
+			groupW := plotW / float64(categories)
+			barArea := groupW * 0.8
+			barW := barArea
+			if !opts.Stacked {
+				barW = barArea / float64(len(series))
+			}
+
+			bars := []interface{}{Class("minty-chart-bars")}
+			for c := 0; c < categories; c++ {
+				x0 := left + float64(c)*groupW + (groupW-barArea)/2
+				base := top + plotH
+				for i, s := range series {
+					if c >= len(s.Values) {
+						continue
+					}
+					v := s.Values[c]
+					h := scale(v)
+					x := x0 + float64(i)*barW
+					y := base - h
+					if opts.Stacked {
+						x = x0
+						base -= h
+					}
+
+					label := format(v)
+					if s.Name != "" {
+						label = s.Name + ": " + label
+					}
+					if c < len(opts.Labels) {
+						label = opts.Labels[c] + ", " + label
+					}
+					// Rect is self-closing, so the tooltip title goes on a group
+					bar := []interface{}{
+						b.Title(label),
+						b.Rect(
+							Attr("x", chartNumber(x)), Attr("y", chartNumber(y)),
+							Attr("width", chartNumber(barW)), Attr("height", chartNumber(h)),
+							Attr("fill", colors[i]), Attr("rx", "2"),
+						),
+					}
+					if opts.ValueLabels {
+						textY, fill, baseline := y-4, tokens.Colors.Text, "auto"
+						if opts.Stacked {
+							textY, fill, baseline = y+h/2, tokens.Colors.Surface, "middle"
+						}
+						bar = append(bar, b.SvgText(
+							Attr("x", chartNumber(x+barW/2)), Attr("y", chartNumber(textY)),
+							Attr("text-anchor", "middle"), Attr("dominant-baseline", baseline),
+							Attr("font-size", "10"), Attr("fill", fill), format(v),
+						))
+					}
+					bars = append(bars, b.G(bar...))
+				}
+				if c < len(opts.Labels) {
+					bars = append(bars, b.SvgText(
+						Attr("x", chartNumber(left+float64(c)*groupW+groupW/2)),
+						Attr("y", chartNumber(float64(height)-6)),
+						Attr("text-anchor", "middle"), Attr("font-size", "11"),
+						Attr("fill", tokens.Colors.Muted), opts.Labels[c],
+					))
+				}
+			}
+			svg = append(svg, b.G(bars...))
+		}
+
+		// Legend for multiple series
+		if len(series) > 1 {
+			legend := []interface{}{Class("minty-chart-legend")}
+			x := left
+			for i, s := range series {
+				legend = append(legend,
+					b.Rect(Attr("x", chartNumber(x)), Attr("y", "8"), Attr("width", "12"),
+						Attr("height", "12"), Attr("rx", "2"), Attr("fill", colors[i])),
+					b.SvgText(Attr("x", chartNumber(x+16)), Attr("y", "14"),
+						Attr("dominant-baseline", "middle"), Attr("font-size", "12"),
+						Attr("fill", tokens.Colors.Text), s.Name),
+				)
+				x += 16 + float64(len(s.Name))*7 + 16
+			}
+			svg = append(svg, b.G(legend...))
+		}
+
+		container := []interface{}{Class(strings.TrimSpace("minty-chart " + opts.Class))}
+		if opts.ID != "" {
+			container = append(container, ID(opts.ID))
+		}
+		return b.Div(append(container, b.Svg(svg...))...)
+	}
+}
+
+// chartPalette returns the token colors used for series without a color.
+func chartPalette(t ThemeTokens) []string {
+	c := t.Colors
+	return []string{c.Primary, c.Success, c.Warning, c.Danger, c.Info, c.Secondary}
+}
+
+// chartDataMax returns the largest bar, or the largest stack when stacked.
+func chartDataMax(series []Series, categories int, stacked bool) float64 {
+	max := 0.0
+	for c := 0; c < categories; c++ {
+		sum := 0.0
+		for _, s := range series {
+			if c >= len(s.Values) || s.Values[c] <= 0 {
+				continue
+			}
+			if stacked {
+				sum += s.Values[c]
+			} else if s.Values[c] > max {
+				max = s.Values[c]
+			}
+		}
+		if sum > max {
+			max = sum
+		}
+	}
+	return max
+}
+
+// niceChartMax rounds v up to 1, 2, 2.5 or 5 times a power of ten.
+func niceChartMax(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, step := range []float64{1, 2, 2.5, 5, 10} {
+		if v <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// chartNumber formats a coordinate or value with at most two decimals.
+func chartNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
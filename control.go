@@ -1,7 +1,65 @@
 package minty
 
+import "io"
+
 // Control flow helpers for conditional rendering
 
+// deferredNode is a template from Each or another control helper returning
+// []Node. The helpers run outside any template, so the template is built
+// later with the builder of the element or render the node is placed in,
+// see Builder.resolve, and so gets that render's UniqueID counter, request
+// and nonce.
+type deferredNode struct {
+	template H
+}
+
+// Render builds the template with the global B, for nodes rendered on
+// their own rather than inside an element or a Render.
+func (d *deferredNode) Render(w io.Writer) error {
+	return d.template(B).Render(w)
+}
+
+// resolve builds the deferred nodes in node, or in the children of a
+// fragment node, with b. A fragment holding deferred nodes is replaced by a
+// new one, so fragments shared between renders are never changed and every
+// render builds its own nodes. Other nodes are returned as they are. A
+// template returning nil resolves to an empty fragment.
+func (b *Builder) resolve(node Node) Node {
+	switch n := node.(type) {
+	case *deferredNode:
+		if built := n.template(b); built != nil {
+			return b.resolve(built)
+		}
+		return NewFragment()
+	case *Fragment:
+		if !hasDeferred(n.Children) {
+			return node
+		}
+		children := make([]Node, len(n.Children))
+		for i, child := range n.Children {
+			children[i] = b.resolve(child)
+		}
+		return &Fragment{Children: children}
+	}
+	return node
+}
+
+// hasDeferred reports whether nodes, or fragments among them, hold
+// deferred nodes.
+func hasDeferred(nodes []Node) bool {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *deferredNode:
+			return true
+		case *Fragment:
+			if hasDeferred(n.Children) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // If returns the template if the condition is true, otherwise returns an empty fragment.
 func If(condition bool, template H) H {
 	if condition {
@@ -26,7 +84,11 @@ func Unless(condition bool, template H) H {
 }
 
 // Each renders a slice of items using the provided renderer function.
-// Returns a slice of Nodes that can be spread into parent elements.
+// Returns a slice of Nodes that can be spread into parent elements. The
+// nodes are placeholders rather than the built elements: each template runs
+// with the builder of the render the node ends up in, once per render, so
+// do not type-assert them to *Element. The other helpers returning []Node,
+// such as Filter and Range, work the same way.
 func Each[T any](items []T, renderer func(T) H) []Node {
 	if len(items) == 0 {
 		return []Node{}
@@ -34,9 +96,7 @@ func Each[T any](items []T, renderer func(T) H) []Node {
 	
 	nodes := make([]Node, 0, len(items))
 	for _, item := range items {
-		template := renderer(item)
-		node := template(B) // Use global builder instance
-		nodes = append(nodes, node)
+		nodes = append(nodes, &deferredNode{template: renderer(item)})
 	}
 	return nodes
 }
@@ -49,9 +109,7 @@ func EachWithIndex[T any](items []T, renderer func(int, T) H) []Node {
 	
 	nodes := make([]Node, 0, len(items))
 	for i, item := range items {
-		template := renderer(i, item)
-		node := template(B) // Use global builder instance
-		nodes = append(nodes, node)
+		nodes = append(nodes, &deferredNode{template: renderer(i, item)})
 	}
 	return nodes
 }
@@ -63,12 +121,16 @@ func Map[T any](items []T, renderer func(T) H) H {
 			return NewFragment()
 		}
 		
-		nodes := Each(items, renderer)
+		nodes := make([]Node, 0, len(items))
+		for _, item := range items {
+			nodes = append(nodes, renderer(item)(b))
+		}
 		return NewFragment(nodes...)
 	}
 }
 
-// Filter renders only items that match the predicate condition.
+// Filter renders only items that match the predicate condition, as
+// placeholder nodes like Each.
 func Filter[T any](items []T, predicate func(T) bool, renderer func(T) H) []Node {
 	if len(items) == 0 {
 		return []Node{}
@@ -77,15 +139,18 @@ func Filter[T any](items []T, predicate func(T) bool, renderer func(T) H) []Node
 	var nodes []Node
 	for _, item := range items {
 		if predicate(item) {
-			template := renderer(item)
-			node := template(B)
-			nodes = append(nodes, node)
+			nodes = append(nodes, &deferredNode{template: renderer(item)})
 		}
 	}
 	return nodes
 }
 
 // Range generates a sequence of numbers and renders each using the renderer.
+// The sequence runs from start up to but not including end, and is empty
+// when start >= end; use RangeStep to count in other steps or downwards.
+// The nodes are placeholders, built when they render, as with Each.
+//
+//	mi.NewFragment(mi.Range(1, totalPages+1, pageButton)...)
 func Range(start, end int, renderer func(int) H) []Node {
 	if start >= end {
 		return []Node{}
@@ -93,9 +158,26 @@ func Range(start, end int, renderer func(int) H) []Node {
 	
 	nodes := make([]Node, 0, end-start)
 	for i := start; i < end; i++ {
-		template := renderer(i)
-		node := template(B)
-		nodes = append(nodes, node)
+		nodes = append(nodes, &deferredNode{template: renderer(i)})
+	}
+	return nodes
+}
+
+// RangeStep is Range counting by step: from start up to but not including
+// end for a positive step, or down to but not including end for a negative
+// one, e.g. RangeStep(10, 0, -2, ...) renders 10, 8, 6, 4 and 2. It renders
+// nothing when step is zero or points away from end.
+func RangeStep(start, end, step int, renderer func(int) H) []Node {
+	nodes := []Node{}
+	switch {
+	case step > 0:
+		for i := start; i < end; i += step {
+			nodes = append(nodes, &deferredNode{template: renderer(i)})
+		}
+	case step < 0:
+		for i := start; i > end; i += step {
+			nodes = append(nodes, &deferredNode{template: renderer(i)})
+		}
 	}
 	return nodes
 }
@@ -121,6 +203,26 @@ func When[T comparable](value T, cases []WhenCase[T], defaultTemplate H) H {
 	}
 }
 
+// Switch renders the template for value from cases, or fallback when there
+// is none. Only the selected template runs. With no match and a nil
+// fallback it renders nothing.
+//
+//	mi.Switch(asset.Status, map[string]mi.H{
+//	    "active":  activeBadge,
+//	    "retired": retiredBadge,
+//	}, defaultBadge)
+func Switch[T comparable](value T, cases map[T]H, fallback H) H {
+	if template := cases[value]; template != nil {
+		return template
+	}
+	if fallback != nil {
+		return fallback
+	}
+	return func(b *Builder) Node {
+		return NewFragment()
+	}
+}
+
 // Repeat renders the same template multiple times.
 func Repeat(count int, template H) []Node {
 	if count <= 0 {
@@ -129,8 +231,7 @@ func Repeat(count int, template H) []Node {
 	
 	nodes := make([]Node, 0, count)
 	for i := 0; i < count; i++ {
-		node := template(B)
-		nodes = append(nodes, node)
+		nodes = append(nodes, &deferredNode{template: template})
 	}
 	return nodes
 }
@@ -176,9 +277,7 @@ func GroupBy[T any, K comparable](items []T, keyFn func(T) K, renderer func(K, [
 	
 	nodes := make([]Node, 0, len(groups))
 	for _, key := range order {
-		template := renderer(key, groups[key])
-		node := template(B)
-		nodes = append(nodes, node)
+		nodes = append(nodes, &deferredNode{template: renderer(key, groups[key])})
 	}
 	
 	return nodes
@@ -252,9 +351,7 @@ func Chunk[T any](items []T, chunkSize int, renderer func([]T) H) []Node {
 		}
 		
 		chunk := items[i:end]
-		template := renderer(chunk)
-		node := template(B)
-		nodes = append(nodes, node)
+		nodes = append(nodes, &deferredNode{template: renderer(chunk)})
 	}
 	
 	return nodes
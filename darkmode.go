@@ -2,6 +2,7 @@ package minty
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 )
 
@@ -41,6 +42,7 @@ type DarkModeConfig struct {
 
 	// Persistence
 	StorageKey string // localStorage key, default "theme"
+	CookieName string // Preference cookie also written, so the server can render the theme; "" for none
 	Default    string // "system", "light", or "dark"
 
 	// UI
@@ -62,6 +64,18 @@ func DarkModeStorage(key string) DarkModeOption {
 	}
 }
 
+// DarkModeCookie also stores the preference in the named cookie, which
+// the server reads with IsDark or HTMLAttr to render the right theme on
+// first paint instead of waiting for the script.
+//
+//	darkMode := mi.DarkModeTailwind(mi.DarkModeCookie("theme"))
+//	b.Html(darkMode.HTMLAttr(b.Request()), ...)
+func DarkModeCookie(name string) DarkModeOption {
+	return func(c *DarkModeConfig) {
+		c.CookieName = name
+	}
+}
+
 // DarkModeDefault sets the default theme when no preference is stored.
 // Valid values: "system" (follows OS preference), "light", "dark"
 func DarkModeDefault(d string) DarkModeOption {
@@ -214,9 +228,15 @@ func (dm *DarkMode) ScriptRaw() string {
 //	    mi.Attr("title", "Toggle dark mode"),
 //	)
 func (dm *DarkMode) Toggle(b *Builder, attrs ...interface{}) Node {
+	// Under WithNonce the script binds the button, as a Content-Security-Policy
+	// with nonces blocks inline event handlers
+	onclick := Attr("onclick", dm.toggleFunctionName()+"()")
+	if b.Nonce() != "" {
+		onclick = Data("minty-dark-toggle", dm.toggleFunctionName())
+	}
 	buttonAttrs := []interface{}{
 		Type("button"),
-		Attr("onclick", dm.toggleFunctionName()+"()"),
+		onclick,
 		Attr("aria-label", "Toggle dark mode"),
 	}
 	buttonAttrs = append(buttonAttrs, attrs...)
@@ -232,6 +252,59 @@ func (dm *DarkMode) Toggle(b *Builder, attrs ...interface{}) Node {
 	return b.Button(buttonAttrs...)
 }
 
+// IsDark reports whether the page for r should render dark, from the
+// preference cookie set with DarkModeCookie and the default. It is false
+// when that depends on the OS setting, which only the script can see.
+func (dm *DarkMode) IsDark(r *http.Request) bool {
+	return dm.requestTheme(r) == "dark"
+}
+
+// HTMLAttr returns the class or attribute for the <html> element that the
+// script would set for r, so the first paint already has the right theme.
+// It returns nil when the preference cookie (see DarkModeCookie) is not
+// set and the default is "system", leaving the choice to the script.
+//
+//	b.Html(darkMode.HTMLAttr(b.Request()), b.Head(darkMode.Script(b)), ...)
+func (dm *DarkMode) HTMLAttr(r *http.Request) Attribute {
+	c := dm.config
+	switch theme := dm.requestTheme(r); {
+	case theme == "dark" && c.UseClass:
+		return Class(c.ClassName)
+	case theme == "dark":
+		return Attr(c.AttrName, c.DarkValue)
+	case theme == "light" && !c.UseClass:
+		return Attr(c.AttrName, c.LightValue)
+	}
+	return nil
+}
+
+// requestTheme returns "dark" or "light" for r, or "" when it follows the
+// OS setting.
+func (dm *DarkMode) requestTheme(r *http.Request) string {
+	pref := ""
+	if dm.config.CookieName != "" {
+		pref = PrefFromRequest(r, dm.config.CookieName)
+	}
+	if pref != "dark" && pref != "light" && pref != "system" {
+		pref = dm.config.Default
+	}
+	if pref == "dark" || pref == "light" {
+		return pref
+	}
+	return ""
+}
+
+// savedJS returns a JavaScript expression for the stored preference: the
+// localStorage value, falling back to the preference cookie.
+func (dm *DarkMode) savedJS() string {
+	c := dm.config
+	saved := fmt.Sprintf("localStorage.getItem('%s')", escapeJSString(c.StorageKey))
+	if c.CookieName != "" {
+		saved += " || " + prefCookieReadJS(c.CookieName)
+	}
+	return saved
+}
+
 // ToggleID returns the ID of the icon element, useful for custom toggle implementations.
 func (dm *DarkMode) ToggleID() string {
 	return dm.config.IconID
@@ -264,6 +337,9 @@ func (dm *DarkMode) generateScript() string {
 	}
 
 	sb.WriteString(fmt.Sprintf("    localStorage.setItem('%s', isDark ? 'dark' : 'light');\n", c.StorageKey))
+	if c.CookieName != "" {
+		sb.WriteString("    " + prefCookieJS(c.CookieName, "isDark ? 'dark' : 'light'") + "\n")
+	}
 	sb.WriteString(fmt.Sprintf("    %s(isDark);\n", updateFn))
 	sb.WriteString("}\n\n")
 
@@ -276,7 +352,7 @@ func (dm *DarkMode) generateScript() string {
 	// IMMEDIATE initialization (runs synchronously before body renders)
 	// This prevents flash of wrong theme on page load/navigation
 	sb.WriteString("(function() {\n")
-	sb.WriteString(fmt.Sprintf("    const saved = localStorage.getItem('%s');\n", c.StorageKey))
+	sb.WriteString(fmt.Sprintf("    const saved = %s;\n", dm.savedJS()))
 	sb.WriteString("    const prefersDark = window.matchMedia('(prefers-color-scheme: dark)').matches;\n")
 
 	// Determine initial state based on default setting
@@ -304,6 +380,11 @@ func (dm *DarkMode) generateScript() string {
 	// Update icon after DOM is ready (icon element needs to exist)
 	sb.WriteString("document.addEventListener('DOMContentLoaded', function() {\n")
 	sb.WriteString(fmt.Sprintf("    %s(window.__darkModeInit);\n", updateFn))
+	sb.WriteString("});\n\n")
+
+	// Toggles rendered under WithNonce have no onclick
+	sb.WriteString("document.addEventListener('click', function(e) {\n")
+	sb.WriteString(fmt.Sprintf("    if (e.target.closest && e.target.closest('[data-minty-dark-toggle=\"%s\"]')) %s();\n", toggleFn, toggleFn))
 	sb.WriteString("});\n")
 
 	return sb.String()
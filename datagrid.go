@@ -0,0 +1,332 @@
+package minty
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+// Column describes one column of a DataGrid. A cell shows the first of
+// Cell, Format, Money and Value that is set.
+type Column[T any] struct {
+	Header string                // Column heading, also the card label on small screens
+	Cell   func(item T) Node     // Renders the cell
+	Format func(item T) string   // Display text, e.g. a formatted number; Value still feeds Filter
+	Money  func(item T) mt.Money // Amount shown with Money.Format, right-aligned and summed by Totals
+	Value  func(item T) string   // Raw value, used for text cells and filter attributes
+	Filter string                // Row data-* attribute name for mintydyn server-rendered filtering
+	Class  string                // Optional class applied to the th and td
+	Align  string                // "left", "center" or "right"; Money columns default to "right"
+}
+
+// DataGridOptions configures a DataGrid.
+type DataGridOptions struct {
+	ID           string // Grid id; also scopes the responsive CSS
+	Class        string // Extra classes for the table element
+	Caption      string // Optional table caption
+	Breakpoint   string // Width below which rows restack as cards (default "640px")
+	RowClass     string // Row class (default "dyn-data-row", mintydyn's default rowSelector)
+	EmptyMessage string // Shown in a single row when there are no rows
+	ExpandLabel  string // DataGridWithDetail: accessible name of the row toggle (default "Show details")
+	SingleExpand bool   // DataGridWithDetail: opening one detail row closes the others
+	Totals       bool   // Adds a footer row with the sum of each Money column
+	TotalsLabel  string // Heading of the totals row (default "Total")
+}
+
+// DataGrid renders rows as a <table> that restacks each row into a labeled
+// card below the breakpoint. The restacking is CSS-only: every cell carries
+// its column header in data-label, shown via ::before on small screens.
+//
+// Columns with a Filter name add data-* attributes to each row, so the grid
+// works with mintydyn server-rendered filtering without extra markup:
+//
+//	mi.DataGrid(assets, []mi.Column[Asset]{
+//	    {Header: "Name", Value: func(a Asset) string { return a.Name }, Filter: "name"},
+//	    {Header: "Type", Value: func(a Asset) string { return a.Type }, Filter: "type"},
+//	}, mi.DataGridOptions{ID: "assets"})
+//
+// Money columns format and right-align amounts; Totals adds a footer that
+// sums them per currency:
+//
+//	{Header: "Total", Money: func(l Line) mt.Money { return l.Total }}
+func DataGrid[T any](rows []T, cols []Column[T], opts DataGridOptions) H {
+	return dataGrid(rows, cols, nil, opts)
+}
+
+// DataGridWithDetail renders a DataGrid whose rows expand to show detail,
+// e.g. an order's line items, in a full-width row beneath them. Each row
+// gets a disclosure button in a leading column, carrying aria-expanded and
+// aria-controls; detail rows start hidden. With SingleExpand, opening one
+// row closes the others.
+//
+//	mi.DataGridWithDetail(orders, orderColumns, func(o Order) mi.H {
+//	    return lineItemsTable(o.Items)
+//	}, mi.DataGridOptions{ID: "orders", SingleExpand: true})
+func DataGridWithDetail[T any](rows []T, cols []Column[T], detail func(item T) H, opts DataGridOptions) H {
+	return dataGrid(rows, cols, detail, opts)
+}
+
+// dataGrid renders a DataGrid, with detail rows when detail is not nil.
+func dataGrid[T any](rows []T, cols []Column[T], detail func(item T) H, opts DataGridOptions) H {
+	return func(b *Builder) Node {
+		breakpoint := opts.Breakpoint
+		if breakpoint == "" {
+			breakpoint = "640px"
+		}
+		rowClass := opts.RowClass
+		if rowClass == "" {
+			rowClass = "dyn-data-row"
+		}
+
+		scope := ".minty-data-grid"
+		if opts.ID != "" {
+			scope = "#" + opts.ID
+		}
+
+		tableArgs := []interface{}{Class(strings.TrimSpace("minty-data-grid " + opts.Class))}
+		if opts.ID != "" {
+			tableArgs = append(tableArgs, ID(opts.ID))
+		}
+		detailPrefix, expandLabel, span := opts.ID, opts.ExpandLabel, len(cols)
+		if detail != nil {
+			if detailPrefix == "" {
+				detailPrefix = b.UniqueID("minty-data-grid")
+			}
+			if expandLabel == "" {
+				expandLabel = "Show details"
+			}
+			if opts.SingleExpand {
+				tableArgs = append(tableArgs, Data("single-expand", ""))
+			}
+			span++
+		}
+		if opts.Caption != "" {
+			tableArgs = append(tableArgs, b.Caption(opts.Caption))
+		}
+
+		headerCells := make([]interface{}, 0, span)
+		if detail != nil {
+			headerCells = append(headerCells, b.Th(Attr("scope", "col"), Class("minty-data-grid-toggle"),
+				b.Span(Class("minty-data-grid-sr"), "Details")))
+		}
+		for _, col := range cols {
+			headerCells = append(headerCells, b.Th(Attr("scope", "col"), classAttr(col.class()), col.Header))
+		}
+		tableArgs = append(tableArgs, b.Thead(b.Tr(headerCells...)))
+
+		bodyRows := make([]interface{}, 0, len(rows))
+		for i, row := range rows {
+			rowArgs := []interface{}{Class(rowClass)}
+			cells := make([]interface{}, 0, span)
+			detailID := detailPrefix + "-detail-" + strconv.Itoa(i)
+			if detail != nil {
+				cells = append(cells, b.Td(Class("minty-data-grid-toggle"), b.Button(
+					Type("button"),
+					Class("minty-data-grid-expand"),
+					Attr("aria-expanded", "false"),
+					Attr("aria-controls", detailID),
+					AriaLabel(expandLabel),
+				)))
+			}
+			for _, col := range cols {
+				value := ""
+				if col.Value != nil {
+					value = col.Value(row)
+				} else if col.Money != nil {
+					value = strconv.FormatFloat(col.Money(row).MajorUnit(), 'f', -1, 64)
+				}
+				if col.Filter != "" {
+					rowArgs = append(rowArgs, Data(col.Filter, value))
+				}
+
+				class := col.class()
+				var content interface{} = value
+				switch {
+				case col.Cell != nil:
+					content = col.Cell(row)
+				case col.Format != nil:
+					content = col.Format(row)
+				case col.Money != nil:
+					amount := col.Money(row)
+					content = amount.Format()
+					if amount.IsNegative() {
+						class = strings.TrimSpace(class + " minty-negative")
+					}
+				}
+				cells = append(cells, b.Td(Data("label", col.Header), classAttr(class), content))
+			}
+			bodyRows = append(bodyRows, b.Tr(append(rowArgs, cells...)...))
+			if detail != nil {
+				bodyRows = append(bodyRows, b.Tr(ID(detailID), Class("minty-data-grid-detail"), Hidden(),
+					b.Td(Attr("colspan", strconv.Itoa(span)), detail(row)(b)),
+				))
+			}
+		}
+		if len(rows) == 0 && opts.EmptyMessage != "" {
+			bodyRows = append(bodyRows, b.Tr(Class("minty-data-grid-empty"),
+				b.Td(Attr("colspan", fmt.Sprintf("%d", span)), opts.EmptyMessage),
+			))
+		}
+		tableArgs = append(tableArgs, b.Tbody(bodyRows...))
+		if opts.Totals {
+			tableArgs = append(tableArgs, b.Tfoot(dataGridTotals(b, rows, cols, detail != nil, opts.TotalsLabel)))
+		}
+
+		if detail == nil {
+			return NewFragment(
+				b.Style(Raw(dataGridCSS(scope, breakpoint))),
+				b.Table(tableArgs...),
+			)
+		}
+		return NewFragment(
+			b.Style(Raw(dataGridCSS(scope, breakpoint)+dataGridDetailCSS)),
+			b.Table(tableArgs...),
+			b.Script(Raw(dataGridDetailJS)),
+		)
+	}
+}
+
+// dataGridTotals renders the totals row: the label in the first cell, unless
+// that column holds money, and the sum of each Money column. A column with
+// several currencies shows one total per currency rather than adding them.
+func dataGridTotals[T any](b *Builder, rows []T, cols []Column[T], toggle bool, label string) Node {
+	if label == "" {
+		label = "Total"
+	}
+	cells := make([]interface{}, 0, len(cols)+2)
+	cells = append(cells, Class("minty-data-grid-totals"))
+	if toggle {
+		cells = append(cells, b.Td(Class("minty-data-grid-toggle")))
+	}
+	for i, col := range cols {
+		if col.Money == nil {
+			if i == 0 {
+				cells = append(cells, b.Th(Attr("scope", "row"), classAttr(col.class()), label))
+			} else {
+				cells = append(cells, b.Td(classAttr(col.class())))
+			}
+			continue
+		}
+		class := col.class()
+		var content []interface{}
+		for _, total := range MoneyTotals(rows, col.Money) {
+			if total.IsNegative() {
+				class = strings.TrimSpace(class + " minty-negative")
+			}
+			content = append(content, b.Span(Class("minty-data-grid-total"), total.Format()))
+		}
+		cells = append(cells, b.Td(append([]interface{}{Data("label", label+" "+col.Header), classAttr(class)}, content...)...))
+	}
+	return b.Tr(cells...)
+}
+
+// MoneyTotals sums money over rows, one total per currency in the order the
+// currencies first appear. Amounts in different currencies are never added
+// together; the result has more than one entry when rows mix currencies.
+func MoneyTotals[T any](rows []T, money func(item T) mt.Money) []mt.Money {
+	var totals []mt.Money
+	index := make(map[string]int)
+	for _, row := range rows {
+		amount := money(row)
+		i, ok := index[amount.Currency]
+		if !ok {
+			index[amount.Currency] = len(totals)
+			totals = append(totals, mt.Money{Currency: amount.Currency})
+			i = len(totals) - 1
+		}
+		totals[i].Amount += amount.Amount
+	}
+	return totals
+}
+
+// SumMoney sums money over rows, e.g. for a totals line outside a DataGrid.
+// It fails when the rows mix currencies. With no rows the sum is the zero
+// Money.
+func SumMoney[T any](rows []T, money func(item T) mt.Money) (mt.Money, error) {
+	totals := MoneyTotals(rows, money)
+	switch len(totals) {
+	case 0:
+		return mt.Money{}, nil
+	case 1:
+		return totals[0], nil
+	default:
+		currencies := make([]string, len(totals))
+		for i, total := range totals {
+			currencies[i] = total.Currency
+		}
+		return mt.Money{}, fmt.Errorf("cannot sum different currencies: %s", strings.Join(currencies, ", "))
+	}
+}
+
+// class returns the column's classes including its alignment.
+func (col Column[T]) class() string {
+	align := col.Align
+	if align == "" && col.Money != nil {
+		align = "right"
+	}
+	switch align {
+	case "center", "right":
+		return strings.TrimSpace(col.Class + " minty-align-" + align)
+	}
+	return col.Class
+}
+
+// classAttr returns a class attribute, or nil when the class is empty.
+func classAttr(class string) Attribute {
+	if class == "" {
+		return nil
+	}
+	return Class(class)
+}
+
+// dataGridCSS returns the card-on-mobile rules for a grid scope.
+func dataGridCSS(scope, breakpoint string) string {
+	return fmt.Sprintf(`%[1]s { width: 100%%; border-collapse: collapse; }
+%[1]s .minty-align-right { text-align: right; font-variant-numeric: tabular-nums; }
+%[1]s .minty-align-center { text-align: center; }
+%[1]s .minty-data-grid-total { display: block; }
+@media (max-width: %[2]s) {
+  %[1]s thead { position: absolute; width: 1px; height: 1px; overflow: hidden; clip: rect(0, 0, 0, 0); }
+  %[1]s, %[1]s tbody, %[1]s tfoot, %[1]s tr, %[1]s td, %[1]s tfoot th { display: block; width: 100%%; }
+  %[1]s tr { margin-bottom: 1rem; border: 1px solid #e2e8f0; border-radius: 8px; padding: 0.5rem; }
+  %[1]s td { display: flex; justify-content: space-between; gap: 1rem; padding: 0.25rem 0; text-align: right; }
+  %[1]s td::before { content: attr(data-label); font-weight: 600; text-align: left; }
+}
+`, scope, breakpoint)
+}
+
+// dataGridDetailCSS styles the row toggle and detail rows.
+const dataGridDetailCSS = `.minty-data-grid-sr { position: absolute; width: 1px; height: 1px; overflow: hidden; clip: rect(0, 0, 0, 0); white-space: nowrap; }
+.minty-data-grid-toggle { width: 2.5rem; }
+.minty-data-grid-expand { width: 1.75rem; height: 1.75rem; padding: 0; border: 0; background: none; color: inherit; cursor: pointer; }
+.minty-data-grid-expand::before { content: "\25B8"; display: inline-block; transition: transform 0.15s; }
+.minty-data-grid-expand[aria-expanded="true"]::before { transform: rotate(90deg); }
+.minty-data-grid-detail[hidden] { display: none !important; }
+.minty-data-grid-detail > td { background: var(--minty-background, #f8fafc); }
+@media (prefers-reduced-motion: reduce) { .minty-data-grid-expand::before { transition: none; } }
+`
+
+// dataGridDetailJS toggles detail rows with one delegated listener per page.
+const dataGridDetailJS = `(function(){
+if (window.MintyDataGridDetail) return;
+window.MintyDataGridDetail = true;
+function setExpanded(button, open) {
+    var detail = document.getElementById(button.getAttribute('aria-controls'));
+    button.setAttribute('aria-expanded', open ? 'true' : 'false');
+    if (detail) detail.hidden = !open;
+}
+document.addEventListener('click', function(e) {
+    var button = e.target.closest ? e.target.closest('.minty-data-grid-expand') : null;
+    if (!button) return;
+    var open = button.getAttribute('aria-expanded') !== 'true';
+    var table = button.closest('table');
+    if (open && table && table.hasAttribute('data-single-expand')) {
+        table.querySelectorAll('.minty-data-grid-expand[aria-expanded="true"]').forEach(function(other) {
+            if (other.closest('table') === table) setExpanded(other, false);
+        });
+    }
+    setExpanded(button, open);
+});
+})();`
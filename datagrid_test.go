@@ -0,0 +1,134 @@
+package minty
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+func TestDataGrid(t *testing.T) {
+	type asset struct {
+		Name string
+		Type string
+	}
+	rows := []asset{{"Laptop", "hardware"}, {"Editor", "software"}}
+	cols := []Column[asset]{
+		{Header: "Name", Value: func(a asset) string { return a.Name }},
+		{Header: "Type", Value: func(a asset) string { return a.Type }, Filter: "type"},
+	}
+
+	html := RenderToString(DataGrid(rows, cols, DataGridOptions{ID: "assets", Breakpoint: "600px"}))
+
+	for _, want := range []string{
+		`<th`, `>Name</th>`,
+		`data-label="Type"`,
+		`data-type="software"`,
+		`dyn-data-row`,
+		`@media (max-width: 600px)`,
+		`#assets td::before`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("DataGrid output missing %q\n%s", want, html)
+		}
+	}
+
+	empty := RenderToString(DataGrid(nil, cols, DataGridOptions{EmptyMessage: "No assets"}))
+	if !strings.Contains(empty, `colspan="2"`) || !strings.Contains(empty, "No assets") {
+		t.Errorf("empty DataGrid missing message: %s", empty)
+	}
+}
+
+func TestDataGridWithDetail(t *testing.T) {
+	type order struct {
+		Number string
+		Items  []string
+	}
+	rows := []order{{"1001", []string{"Widget", "Bolt"}}, {"1002", nil}}
+	cols := []Column[order]{{Header: "Number", Value: func(o order) string { return o.Number }}}
+	detail := func(o order) H {
+		return func(b *Builder) Node { return b.P(strings.Join(o.Items, ", ")) }
+	}
+
+	html := RenderToString(DataGridWithDetail(rows, cols, detail, DataGridOptions{ID: "orders", SingleExpand: true}))
+	for _, want := range []string{
+		`data-single-expand`,
+		`aria-controls="orders-detail-0"`,
+		`aria-expanded="false"`,
+		`aria-label="Show details"`,
+		`id="orders-detail-1"`,
+		`hidden="hidden"`,
+		`colspan="2"`,
+		`<p>Widget, Bolt</p>`,
+		`window.MintyDataGridDetail`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("DataGridWithDetail output missing %q\n%s", want, html)
+		}
+	}
+
+	plain := RenderToString(DataGrid(rows, cols, DataGridOptions{}))
+	if strings.Contains(plain, "minty-data-grid-expand") || strings.Contains(plain, "<script") {
+		t.Errorf("plain DataGrid should not render detail toggles: %s", plain)
+	}
+	auto := RenderToString(DataGridWithDetail(rows, cols, detail, DataGridOptions{}))
+	if !strings.Contains(auto, `aria-controls="minty-data-grid-1-detail-0"`) {
+		t.Errorf("detail ids without a grid ID: %s", auto)
+	}
+}
+
+func TestDataGridMoneyColumns(t *testing.T) {
+	type line struct {
+		Item  string
+		Qty   int
+		Total mt.Money
+	}
+	rows := []line{
+		{"Widget", 2, mt.Money{Amount: 2500, Currency: "USD"}},
+		{"Refund", 1, mt.Money{Amount: -4000, Currency: "USD"}},
+	}
+	cols := []Column[line]{
+		{Header: "Item", Value: func(l line) string { return l.Item }},
+		{Header: "Qty", Format: func(l line) string { return strconv.Itoa(l.Qty) + " pcs" }, Align: "center"},
+		{Header: "Total", Money: func(l line) mt.Money { return l.Total }, Filter: "total"},
+	}
+
+	html := RenderToString(DataGrid(rows, cols, DataGridOptions{ID: "lines", Totals: true}))
+	for _, want := range []string{
+		`>2 pcs</td>`,
+		`minty-align-center`,
+		`class="minty-align-right"`,
+		`>$25.00</td>`,
+		`class="minty-align-right minty-negative"`,
+		`>-$40.00</td>`,
+		`data-total="-40"`,
+		`<tfoot>`,
+		`<th scope="row">Total</th>`,
+		`<span class="minty-data-grid-total">-$15.00</span>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("DataGrid money output missing %q\n%s", want, html)
+		}
+	}
+
+	total, err := SumMoney(rows, func(l line) mt.Money { return l.Total })
+	if err != nil || total != (mt.Money{Amount: -1500, Currency: "USD"}) {
+		t.Errorf("SumMoney = %v, %v", total, err)
+	}
+
+	mixed := append(rows, line{"Import", 1, mt.Money{Amount: 1000, Currency: "EUR"}})
+	if _, err := SumMoney(mixed, func(l line) mt.Money { return l.Total }); err == nil {
+		t.Error("SumMoney should refuse mixed currencies")
+	}
+	totals := MoneyTotals(mixed, func(l line) mt.Money { return l.Total })
+	if len(totals) != 2 || totals[0].Amount != -1500 || totals[1] != (mt.Money{Amount: 1000, Currency: "EUR"}) {
+		t.Errorf("MoneyTotals = %v", totals)
+	}
+	html = RenderToString(DataGrid(mixed, cols, DataGridOptions{Totals: true, TotalsLabel: "Sum"}))
+	for _, want := range []string{`>Sum</th>`, `>-$15.00</span>`, `>` + totals[1].Format() + `</span>`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("mixed-currency totals missing %q\n%s", want, html)
+		}
+	}
+}
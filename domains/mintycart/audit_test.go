@@ -0,0 +1,47 @@
+package mintycart
+
+import (
+	"context"
+	"testing"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+func TestServiceAuditEvents(t *testing.T) {
+	es := NewEcommerceService()
+	var events []mt.AuditEvent
+	es.SetAuditSink(mt.AuditSinkFunc(func(e mt.AuditEvent) { events = append(events, e) }))
+	es.SetAuditContext(mt.ContextWithActor(context.Background(), "alice"))
+
+	product, err := es.CreateProduct("Widget", "A widget", "W-1", "tools",
+		mt.NewMoney(10, mt.CurrencyUSD), 1, Inventory{Quantity: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := es.UpdateProductInventory(product.ID, -2); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	created, updated := events[0], events[1]
+	if created.Action != mt.AuditCreate || created.EntityType != "product" || created.Actor != "alice" {
+		t.Errorf("unexpected create event: %+v", created)
+	}
+	if updated.Action != mt.AuditUpdate || updated.EntityID != product.ID {
+		t.Errorf("unexpected update event: %+v", updated)
+	}
+	change, ok := updated.Changes["inventory"]
+	if !ok {
+		t.Fatalf("inventory change not recorded: %+v", updated.Changes)
+	}
+	before := change.Before.(map[string]interface{})["quantity"]
+	after := change.After.(map[string]interface{})["quantity"]
+	if before != float64(5) || after != float64(3) {
+		t.Errorf("inventory quantity change = %v -> %v, want 5 -> 3", before, after)
+	}
+	if _, ok := updated.Changes["name"]; ok {
+		t.Error("unchanged fields should not be recorded")
+	}
+}
@@ -0,0 +1,221 @@
+package mintycart
+
+import (
+	"errors"
+	"sort"
+)
+
+// =====================================================
+// CART EDITOR (Undo/Redo Journal)
+// =====================================================
+
+// DefaultCartHistoryDepth is the history depth used when NewCartEditor
+// is given a non-positive depth.
+const DefaultCartHistoryDepth = 50
+
+// ReserveFunc reserves (positive quantity) or releases (negative quantity)
+// inventory for a product. It is called by CartEditor whenever a mutation,
+// undo or redo changes the quantity of a product held in the cart.
+type ReserveFunc func(productID string, quantity int) error
+
+// CartEditor wraps a cart and journals add/remove/quantity mutations so
+// they can be undone and redone. The UI calls the editor instead of the
+// package-level cart functions.
+type CartEditor struct {
+	cart     *Cart
+	reserve  ReserveFunc
+	maxDepth int
+	undo     []cartMutation
+	redo     []cartMutation
+}
+
+// cartMutation records the cart items before and after a single operation.
+type cartMutation struct {
+	op     string
+	before []CartItem
+	after  []CartItem
+}
+
+// NewCartEditor creates an editor for the given cart. maxDepth bounds the
+// number of undoable operations; reserve may be nil when inventory is not
+// reserved while items sit in the cart.
+func NewCartEditor(cart *Cart, maxDepth int, reserve ReserveFunc) *CartEditor {
+	if maxDepth <= 0 {
+		maxDepth = DefaultCartHistoryDepth
+	}
+	return &CartEditor{
+		cart:     cart,
+		reserve:  reserve,
+		maxDepth: maxDepth,
+	}
+}
+
+// Cart returns the cart being edited
+func (ce *CartEditor) Cart() *Cart {
+	return ce.cart
+}
+
+// AddItem adds a product to the cart and records the operation
+func (ce *CartEditor) AddItem(product Product, quantity int) error {
+	return ce.apply("add", func() error {
+		return AddItemToCart(ce.cart, product, quantity)
+	})
+}
+
+// RemoveItem removes an item from the cart and records the operation
+func (ce *CartEditor) RemoveItem(itemID string) error {
+	return ce.apply("remove", func() error {
+		return RemoveItemFromCart(ce.cart, itemID)
+	})
+}
+
+// UpdateQuantity changes an item's quantity and records the operation
+func (ce *CartEditor) UpdateQuantity(itemID string, quantity int) error {
+	return ce.apply("quantity", func() error {
+		return UpdateItemQuantity(ce.cart, itemID, quantity)
+	})
+}
+
+// Undo reverses the most recent operation
+func (ce *CartEditor) Undo() error {
+	if len(ce.undo) == 0 {
+		return errors.New("nothing to undo")
+	}
+
+	m := ce.undo[len(ce.undo)-1]
+	if err := ce.restore(m.after, m.before); err != nil {
+		return err
+	}
+
+	ce.undo = ce.undo[:len(ce.undo)-1]
+	ce.redo = append(ce.redo, m)
+	return nil
+}
+
+// Redo replays the most recently undone operation
+func (ce *CartEditor) Redo() error {
+	if len(ce.redo) == 0 {
+		return errors.New("nothing to redo")
+	}
+
+	m := ce.redo[len(ce.redo)-1]
+	if err := ce.restore(m.before, m.after); err != nil {
+		return err
+	}
+
+	ce.redo = ce.redo[:len(ce.redo)-1]
+	ce.undo = append(ce.undo, m)
+	return nil
+}
+
+// CanUndo reports whether there is an operation to undo
+func (ce *CartEditor) CanUndo() bool { return len(ce.undo) > 0 }
+
+// CanRedo reports whether there is an operation to redo
+func (ce *CartEditor) CanRedo() bool { return len(ce.redo) > 0 }
+
+// UndoOperation returns the kind of operation Undo would reverse
+// ("add", "remove" or "quantity"), or "" if there is none.
+func (ce *CartEditor) UndoOperation() string {
+	if len(ce.undo) == 0 {
+		return ""
+	}
+	return ce.undo[len(ce.undo)-1].op
+}
+
+// RedoOperation returns the kind of operation Redo would replay,
+// or "" if there is none.
+func (ce *CartEditor) RedoOperation() string {
+	if len(ce.redo) == 0 {
+		return ""
+	}
+	return ce.redo[len(ce.redo)-1].op
+}
+
+// ClearHistory discards all undo and redo entries
+func (ce *CartEditor) ClearHistory() {
+	ce.undo = nil
+	ce.redo = nil
+}
+
+// apply runs a mutation, reserves inventory for the change and journals it.
+// If the reservation fails the cart is rolled back and nothing is recorded.
+func (ce *CartEditor) apply(op string, mutate func() error) error {
+	before := copyCartItems(ce.cart.Items)
+	if err := mutate(); err != nil {
+		return err
+	}
+	after := copyCartItems(ce.cart.Items)
+
+	if err := ce.reserveDelta(before, after); err != nil {
+		ce.setItems(before) // The totals of before were valid
+		return err
+	}
+
+	ce.undo = append(ce.undo, cartMutation{op: op, before: before, after: after})
+	if len(ce.undo) > ce.maxDepth {
+		ce.undo = ce.undo[len(ce.undo)-ce.maxDepth:]
+	}
+	ce.redo = nil
+	return nil
+}
+
+// restore moves the cart from one item snapshot to another,
+// reconciling inventory reservations along the way.
+func (ce *CartEditor) restore(from, to []CartItem) error {
+	if err := ce.reserveDelta(from, to); err != nil {
+		return err
+	}
+	return ce.setItems(to)
+}
+
+// setItems replaces the cart items and recalculates totals
+func (ce *CartEditor) setItems(items []CartItem) error {
+	ce.cart.Items = copyCartItems(items)
+	return RecalculateCartTotals(ce.cart)
+}
+
+// reserveDelta reserves or releases inventory for the per-product quantity
+// difference between two snapshots. Partial reservations are released again
+// if any product fails.
+func (ce *CartEditor) reserveDelta(from, to []CartItem) error {
+	if ce.reserve == nil {
+		return nil
+	}
+
+	delta := make(map[string]int)
+	for _, item := range from {
+		delta[item.ProductID] -= item.Quantity
+	}
+	for _, item := range to {
+		delta[item.ProductID] += item.Quantity
+	}
+
+	productIDs := make([]string, 0, len(delta))
+	for id, qty := range delta {
+		if qty != 0 {
+			productIDs = append(productIDs, id)
+		}
+	}
+	sort.Strings(productIDs)
+
+	for i, id := range productIDs {
+		if err := ce.reserve(id, delta[id]); err != nil {
+			for _, done := range productIDs[:i] {
+				ce.reserve(done, -delta[done])
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// copyCartItems returns an independent copy of a cart item slice
+func copyCartItems(items []CartItem) []CartItem {
+	if items == nil {
+		return nil
+	}
+	out := make([]CartItem, len(items))
+	copy(out, items)
+	return out
+}
@@ -0,0 +1,179 @@
+package mintycart
+
+import (
+	"errors"
+	"testing"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+func editorTestProducts() (Product, Product) {
+	widget := Product{
+		ID:        "p-widget",
+		Name:      "Widget",
+		Price:     mt.NewMoney(12.50, mt.CurrencyUSD),
+		Weight:    1.5,
+		Inventory: Inventory{Quantity: 10},
+	}
+	gadget := Product{
+		ID:        "p-gadget",
+		Name:      "Gadget",
+		Price:     mt.NewMoney(40.00, mt.CurrencyUSD),
+		Weight:    3,
+		Inventory: Inventory{Quantity: 10},
+	}
+	return widget, gadget
+}
+
+type cartTotals struct {
+	subtotal, tax, shipping, total int64
+	items                          int
+}
+
+func totalsOf(c *Cart) cartTotals {
+	return cartTotals{c.Subtotal.Amount, c.Tax.Amount, c.Shipping.Amount, c.Total.Amount, len(c.Items)}
+}
+
+func TestCartEditorUndoRestoresTotals(t *testing.T) {
+	widget, gadget := editorTestProducts()
+	cart := &Cart{ID: "cart-1"}
+	if err := RecalculateCartTotals(cart); err != nil {
+		t.Fatal(err)
+	}
+	editor := NewCartEditor(cart, 10, nil)
+
+	var snapshots []cartTotals
+	snapshots = append(snapshots, totalsOf(editor.Cart()))
+
+	if err := editor.AddItem(widget, 2); err != nil {
+		t.Fatalf("AddItem: %v", err)
+	}
+	snapshots = append(snapshots, totalsOf(editor.Cart()))
+
+	if err := editor.AddItem(gadget, 1); err != nil {
+		t.Fatalf("AddItem: %v", err)
+	}
+	snapshots = append(snapshots, totalsOf(editor.Cart()))
+
+	itemID := editor.Cart().Items[0].ID
+	if err := editor.UpdateQuantity(itemID, 5); err != nil {
+		t.Fatalf("UpdateQuantity: %v", err)
+	}
+	snapshots = append(snapshots, totalsOf(editor.Cart()))
+
+	if err := editor.RemoveItem(itemID); err != nil {
+		t.Fatalf("RemoveItem: %v", err)
+	}
+	final := totalsOf(editor.Cart())
+
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if err := editor.Undo(); err != nil {
+			t.Fatalf("Undo: %v", err)
+		}
+		if got := totalsOf(editor.Cart()); got != snapshots[i] {
+			t.Errorf("after undo to step %d: got %+v, want %+v", i, got, snapshots[i])
+		}
+	}
+
+	if editor.CanUndo() {
+		t.Error("CanUndo() = true after undoing everything")
+	}
+	if err := editor.Undo(); err == nil {
+		t.Error("Undo() with empty history should fail")
+	}
+
+	for i := 1; i < len(snapshots); i++ {
+		if err := editor.Redo(); err != nil {
+			t.Fatalf("Redo: %v", err)
+		}
+		if got := totalsOf(editor.Cart()); got != snapshots[i] {
+			t.Errorf("after redo to step %d: got %+v, want %+v", i, got, snapshots[i])
+		}
+	}
+	if err := editor.Redo(); err != nil {
+		t.Fatalf("Redo: %v", err)
+	}
+	if got := totalsOf(editor.Cart()); got != final {
+		t.Errorf("after full redo: got %+v, want %+v", got, final)
+	}
+}
+
+func TestCartEditorNewMutationClearsRedo(t *testing.T) {
+	widget, gadget := editorTestProducts()
+	editor := NewCartEditor(&Cart{}, 10, nil)
+
+	editor.AddItem(widget, 1)
+	editor.Undo()
+	if !editor.CanRedo() {
+		t.Fatal("CanRedo() = false after undo")
+	}
+
+	editor.AddItem(gadget, 1)
+	if editor.CanRedo() {
+		t.Error("CanRedo() = true after a new mutation")
+	}
+}
+
+func TestCartEditorHistoryDepth(t *testing.T) {
+	widget, _ := editorTestProducts()
+	editor := NewCartEditor(&Cart{}, 2, nil)
+
+	for i := 0; i < 4; i++ {
+		if err := editor.AddItem(widget, 1); err != nil {
+			t.Fatalf("AddItem: %v", err)
+		}
+	}
+
+	undone := 0
+	for editor.CanUndo() {
+		editor.Undo()
+		undone++
+	}
+	if undone != 2 {
+		t.Errorf("undone %d operations, want 2", undone)
+	}
+	if got := editor.Cart().Items[0].Quantity; got != 2 {
+		t.Errorf("quantity after undo = %d, want 2", got)
+	}
+}
+
+func TestCartEditorReservations(t *testing.T) {
+	widget, gadget := editorTestProducts()
+	reserved := map[string]int{}
+	reserve := func(productID string, quantity int) error {
+		if reserved[productID]+quantity > 10 {
+			return errors.New("insufficient inventory")
+		}
+		reserved[productID] += quantity
+		return nil
+	}
+	editor := NewCartEditor(&Cart{}, 10, reserve)
+
+	editor.AddItem(widget, 3)
+	editor.AddItem(gadget, 2)
+	editor.RemoveItem(editor.Cart().Items[0].ID)
+
+	if reserved["p-widget"] != 0 || reserved["p-gadget"] != 2 {
+		t.Fatalf("reservations after remove = %v", reserved)
+	}
+
+	editor.Undo()
+	if reserved["p-widget"] != 3 {
+		t.Errorf("widget reserved after undo = %d, want 3", reserved["p-widget"])
+	}
+
+	editor.Undo()
+	editor.Undo()
+	if reserved["p-widget"] != 0 || reserved["p-gadget"] != 0 {
+		t.Errorf("reservations after full undo = %v", reserved)
+	}
+
+	// A failed reservation leaves the cart untouched and unrecorded
+	reserved["p-widget"] = 9
+	if err := editor.AddItem(widget, 2); err == nil {
+		t.Fatal("AddItem should fail when reservation fails")
+	}
+	if len(editor.Cart().Items) != 0 || editor.CanUndo() {
+		t.Error("failed mutation should not change the cart or history")
+	}
+}
@@ -0,0 +1,291 @@
+package mintycart
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+// =====================================================
+// GENERATED SAMPLE DATA (seedable fixtures)
+// =====================================================
+
+// The generators build larger, realistic data sets than the Sample*
+// functions. Output depends only on the rng and the base time, so
+//
+//	rng := rand.New(rand.NewSource(42))
+//	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	products := mintycart.GenerateProducts(200, rng, base)
+//
+// yields the same products on every run. Timestamps are derived from base
+// instead of the clock, IDs are sequential ("prd_0001") and every generated
+// record passes validation.
+
+// fixtureCategory describes the products generated for one category.
+type fixtureCategory struct {
+	name     string
+	skuCode  string
+	brands   []string
+	nouns    []string
+	minPrice int64 // cents
+	maxPrice int64
+	maxLbs   float64
+}
+
+var fixtureCategories = []fixtureCategory{
+	{"Electronics", "EL", []string{"TechCorp", "Voltix", "Nimbus"},
+		[]string{"Headphones", "Speaker", "Charger", "Keyboard", "Webcam"}, 1999, 29999, 3},
+	{"Food & Beverages", "FB", []string{"Mountain Roasters", "Green Leaf", "Harvest Co"},
+		[]string{"Coffee Beans", "Green Tea", "Granola", "Olive Oil", "Dark Chocolate"}, 399, 4999, 5},
+	{"Sports & Fitness", "SF", []string{"ZenFit", "Peak", "Stride"},
+		[]string{"Yoga Mat", "Water Bottle", "Resistance Bands", "Jump Rope", "Foam Roller"}, 999, 12999, 8},
+	{"Home & Kitchen", "HK", []string{"Hearth", "Casa", "Oakline"},
+		[]string{"Chef Knife", "Cutting Board", "Table Lamp", "Throw Blanket", "Tea Kettle"}, 1499, 19999, 10},
+}
+
+var fixtureAdjectives = []string{"Classic", "Premium", "Compact", "Organic", "Deluxe", "Everyday", "Pro", "Eco"}
+
+var fixtureFirstNames = []string{"Jane", "John", "Maria", "Wei", "Aisha", "Lars", "Priya", "Diego", "Emma", "Kofi"}
+
+var fixtureLastNames = []string{"Smith", "Garcia", "Chen", "Okafor", "Nilsen", "Patel", "Rossi", "Kim", "Novak", "Brown"}
+
+var fixtureCities = []struct{ city, state, postal string }{
+	{"Springfield", "IL", "62701"},
+	{"Portland", "OR", "97201"},
+	{"Austin", "TX", "73301"},
+	{"Madison", "WI", "53703"},
+	{"Raleigh", "NC", "27601"},
+}
+
+var fixtureStreets = []string{"Oak Ave", "Main St", "Maple Dr", "Cedar Ln", "Elm St", "Park Rd"}
+
+// fixtureOrderStatuses are weighted towards completed orders.
+var fixtureOrderStatuses = []string{
+	OrderStatusDelivered, OrderStatusDelivered, OrderStatusDelivered, OrderStatusShipped,
+	OrderStatusProcessing, OrderStatusPending, OrderStatusCancelled, OrderStatusReturned,
+}
+
+func pick[T any](rng *rand.Rand, values []T) T {
+	return values[rng.Intn(len(values))]
+}
+
+// randomCents returns an amount between min and max ending in 99 cents.
+func randomCents(rng *rand.Rand, min, max int64) int64 {
+	return (min+rng.Int63n(max-min+1))/100*100 + 99
+}
+
+// GenerateProducts returns n active products across several categories.
+func GenerateProducts(n int, rng *rand.Rand, base time.Time) []Product {
+	products := make([]Product, n)
+	for i := range products {
+		category := pick(rng, fixtureCategories)
+		noun := pick(rng, category.nouns)
+		brand := pick(rng, category.brands)
+		created := base.Add(-time.Duration(rng.Intn(365*24)) * time.Hour)
+		updated := created.Add(time.Duration(rng.Int63n(int64(base.Sub(created)) + 1)))
+
+		product := Product{
+			ID:          fmt.Sprintf("prd_%04d", i+1),
+			Name:        pick(rng, fixtureAdjectives) + " " + noun,
+			Description: fmt.Sprintf("%s %s from %s", category.name, strings.ToLower(noun), brand),
+			SKU:         fmt.Sprintf("%s-%04d", category.skuCode, i+1),
+			Price:       mt.Money{Amount: randomCents(rng, category.minPrice, category.maxPrice), Currency: mt.CurrencyUSD},
+			Category:    category.name,
+			Brand:       brand,
+			Weight:      float64(1+rng.Intn(int(category.maxLbs*10))) / 10,
+			Dimensions: Dimensions{
+				Length: float64(2 + rng.Intn(20)),
+				Width:  float64(2 + rng.Intn(12)),
+				Height: float64(1 + rng.Intn(8)),
+				Unit:   "inches",
+			},
+			Inventory: Inventory{LowStockLevel: 5 + rng.Intn(11)},
+			Status:    mt.StatusActive,
+			Metadata:  make(map[string]string),
+		}
+		setInventoryQuantity(&product, rng.Intn(120))
+		product.CreatedAt = created
+		product.UpdatedAt = updated
+		product.Inventory.LastUpdated = updated
+		products[i] = product
+	}
+	return products
+}
+
+// GenerateCustomers returns n active customers, each with a billing and a
+// matching shipping address.
+func GenerateCustomers(n int, rng *rand.Rand, base time.Time) []Customer {
+	customers := make([]Customer, n)
+	for i := range customers {
+		first, last := pick(rng, fixtureFirstNames), pick(rng, fixtureLastNames)
+		name := first + " " + last
+		place := pick(rng, fixtureCities)
+		billing := mt.Address{
+			Type:       mt.AddressBilling,
+			Name:       name,
+			Street1:    fmt.Sprintf("%d %s", 100+rng.Intn(900), pick(rng, fixtureStreets)),
+			City:       place.city,
+			State:      place.state,
+			PostalCode: place.postal,
+			Country:    "US",
+		}
+		shipping := billing
+		shipping.Type = mt.AddressShipping
+
+		customers[i] = Customer{
+			ID:               fmt.Sprintf("cust_%04d", i+1),
+			Name:             name,
+			Email:            fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(first), strings.ToLower(last), i+1),
+			Addresses:        []mt.Address{billing, shipping},
+			Phone:            fmt.Sprintf("555-%04d", rng.Intn(10000)),
+			TotalSpent:       mt.Money{Currency: mt.CurrencyUSD},
+			PreferredPayment: pick(rng, []string{"credit_card", "paypal"}),
+			CreatedAt:        base.Add(-time.Duration(30*24+rng.Intn(2*365*24)) * time.Hour),
+			Status:           mt.StatusActive,
+			Metadata:         make(map[string]string),
+		}
+	}
+	return customers
+}
+
+// GenerateOrders returns n orders placed by the given customers for the
+// given products, with one to four items each. Totals are computed with the
+// cart rules (RecalculateCartTotals), payments and timestamps follow each
+// order's status, and the customers' OrderCount, TotalSpent, LoyaltyPoints
+// and LastOrderAt are updated in place to match. Cancelled and returned
+// orders do not count towards TotalSpent. A cart cannot mix currencies, so
+// only products priced in the first product's currency are ordered. It
+// fails when an order's totals cannot be computed.
+func GenerateOrders(n int, products []Product, customers []Customer, rng *rand.Rand, base time.Time) ([]Order, error) {
+	if len(products) == 0 || len(customers) == 0 {
+		return nil, nil
+	}
+	currency := strings.ToUpper(products[0].Price.Currency)
+	sameCurrency := products[:0:0]
+	for _, product := range products {
+		if strings.ToUpper(product.Price.Currency) == currency {
+			sameCurrency = append(sameCurrency, product)
+		}
+	}
+	products = sameCurrency
+
+	orders := make([]Order, n)
+	for i := range orders {
+		c := rng.Intn(len(customers))
+		customer := customers[c]
+		placed := base.Add(-time.Duration(1+rng.Intn(90*24)) * time.Hour)
+		if placed.Before(customer.CreatedAt) {
+			placed = customer.CreatedAt.Add(time.Hour)
+		}
+
+		cart := Cart{CustomerID: customer.ID}
+		for j, count := 0, 1+rng.Intn(4); j < count; j++ {
+			product := products[rng.Intn(len(products))]
+			quantity := 1 + rng.Intn(3)
+			cart.Items = append(cart.Items, CartItem{
+				ID:        fmt.Sprintf("ci_%04d_%d", i+1, j+1),
+				ProductID: product.ID,
+				Product:   product,
+				Quantity:  quantity,
+				Price:     product.Price,
+				Total:     product.Price.Mul(int64(quantity)),
+				AddedAt:   placed,
+			})
+		}
+		if err := RecalculateCartTotals(&cart); err != nil {
+			return nil, fmt.Errorf("order %d: %w", i+1, err)
+		}
+
+		items := make([]OrderItem, len(cart.Items))
+		for j, item := range cart.Items {
+			items[j] = OrderItem{
+				ID:        fmt.Sprintf("oi_%04d_%d", i+1, j+1),
+				ProductID: item.ProductID,
+				Product:   item.Product,
+				Quantity:  item.Quantity,
+				Price:     item.Price,
+				Total:     item.Total,
+			}
+		}
+
+		order := Order{
+			ID:              fmt.Sprintf("ord_%04d", i+1),
+			Number:          fmt.Sprintf("ORD-%s-%04d", placed.Format("20060102"), i+1),
+			CustomerID:      customer.ID,
+			Customer:        customer,
+			Items:           items,
+			BillingAddress:  customer.GetBillingAddress(),
+			ShippingAddress: customer.GetShippingAddress(),
+			Subtotal:        cart.Subtotal,
+			Tax:             cart.Tax,
+			Shipping:        cart.Shipping,
+			Discount:        mt.Money{Currency: cart.Subtotal.Currency},
+			Total:           cart.Total,
+			Status:          pick(rng, fixtureOrderStatuses),
+			CreatedAt:       placed,
+			UpdatedAt:       placed,
+			Metadata:        make(map[string]string),
+		}
+		generateOrderHistory(&order, customer.PreferredPayment, rng)
+		orders[i] = order
+
+		customers[c].OrderCount++
+		if customers[c].LastOrderAt == nil || placed.After(*customers[c].LastOrderAt) {
+			last := placed
+			customers[c].LastOrderAt = &last
+		}
+		if order.Status != OrderStatusCancelled && order.Status != OrderStatusReturned {
+			customers[c].TotalSpent.Amount += order.Total.Amount
+			customers[c].LoyaltyPoints += int(order.Total.Amount / 100)
+		}
+	}
+	return orders, nil
+}
+
+// generateOrderHistory fills in the payment, tracking number and timestamps
+// implied by the order's status.
+func generateOrderHistory(order *Order, method string, rng *rand.Rand) {
+	at := order.CreatedAt
+	step := func(maxHours int) time.Time {
+		at = at.Add(time.Duration(1+rng.Intn(maxHours)) * time.Hour)
+		return at
+	}
+
+	if order.Status == OrderStatusPending {
+		return
+	}
+	processed := step(6)
+	order.Payment = Payment{
+		ID:            "pay_" + strings.TrimPrefix(order.ID, "ord_"),
+		Method:        method,
+		Status:        "completed",
+		Amount:        order.Total,
+		TransactionID: fmt.Sprintf("TXN_%s_%06d", strings.TrimPrefix(order.ID, "ord_"), rng.Intn(1000000)),
+		ProcessedAt:   &processed,
+	}
+	if method == "credit_card" {
+		order.Payment.CardLast4 = fmt.Sprintf("%04d", rng.Intn(10000))
+		order.Payment.CardBrand = pick(rng, []string{"visa", "mastercard", "amex"})
+	}
+
+	switch order.Status {
+	case OrderStatusCancelled:
+		order.Payment.Status = "refunded"
+	case OrderStatusShipped, OrderStatusDelivered, OrderStatusReturned:
+		shipped := step(48)
+		order.ShippedAt = &shipped
+		order.Metadata["tracking_number"] = fmt.Sprintf("TRK%09d", rng.Intn(1000000000))
+		if order.Status != OrderStatusShipped {
+			delivered := step(96)
+			order.DeliveredAt = &delivered
+		}
+		if order.Status == OrderStatusReturned {
+			order.Payment.Status = "refunded"
+			step(240)
+		}
+	}
+	order.UpdatedAt = at
+}
@@ -0,0 +1,64 @@
+package mintycart
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGenerateFixtures(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	generate := func() ([]Product, []Customer, []Order) {
+		rng := rand.New(rand.NewSource(7))
+		products := GenerateProducts(20, rng, base)
+		customers := GenerateCustomers(5, rng, base)
+		orders, err := GenerateOrders(30, products, customers, rng, base)
+		if err != nil {
+			t.Fatalf("GenerateOrders: %v", err)
+		}
+		return products, customers, orders
+	}
+
+	products, customers, orders := generate()
+	products2, customers2, orders2 := generate()
+	if !reflect.DeepEqual(products, products2) || !reflect.DeepEqual(customers, customers2) ||
+		!reflect.DeepEqual(orders, orders2) {
+		t.Fatal("the same seed and base time should generate identical data")
+	}
+
+	for _, p := range products {
+		if errs := ValidateProduct(p); errs.HasErrors() {
+			t.Errorf("product %s invalid: %v", p.ID, errs)
+		}
+	}
+	productIDs := make(map[string]bool)
+	for _, p := range products {
+		productIDs[p.ID] = true
+	}
+	orderCounts := make(map[string]int)
+	for _, o := range orders {
+		if errs := ValidateOrder(o); errs.HasErrors() {
+			t.Errorf("order %s invalid: %v", o.ID, errs)
+		}
+		var subtotal int64
+		for _, item := range o.Items {
+			if !productIDs[item.ProductID] {
+				t.Errorf("order %s references unknown product %s", o.ID, item.ProductID)
+			}
+			subtotal += item.Total.Amount
+		}
+		if o.Subtotal.Amount != subtotal || o.Total.Amount != o.Subtotal.Amount+o.Tax.Amount+o.Shipping.Amount {
+			t.Errorf("order %s totals do not add up: %+v", o.ID, o)
+		}
+		if o.CreatedAt.After(base) {
+			t.Errorf("order %s placed after the base time", o.ID)
+		}
+		orderCounts[o.CustomerID]++
+	}
+	for _, c := range customers {
+		if c.OrderCount != orderCounts[c.ID] {
+			t.Errorf("customer %s OrderCount = %d, want %d", c.ID, c.OrderCount, orderCounts[c.ID])
+		}
+	}
+}
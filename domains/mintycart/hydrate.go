@@ -0,0 +1,133 @@
+package mintycart
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// =====================================================
+// PRODUCT LOADING (Eager / Lazy)
+// =====================================================
+
+// Cart and order items embed their Product by default, which is convenient
+// for display but copies the whole product, images and metadata included,
+// into every item. In lazy mode items keep only ProductID and the product
+// is resolved when it is needed: HydrateCart and HydrateOrder fill it in,
+// DehydrateCart and DehydrateOrder clear it again. An item whose Product is
+// not loaded marshals to JSON without the "product" key.
+
+// ProductResolver looks up a product by ID for hydration.
+type ProductResolver func(productID string) (*Product, error)
+
+// Hydrated reports whether the item's Product is loaded
+func (item CartItem) Hydrated() bool {
+	return item.Product.ID != ""
+}
+
+// Hydrated reports whether the item's Product is loaded
+func (item OrderItem) Hydrated() bool {
+	return item.Product.ID != ""
+}
+
+// MarshalJSON omits the product of items that are not hydrated
+func (item CartItem) MarshalJSON() ([]byte, error) {
+	type plain CartItem
+	if item.Hydrated() {
+		return json.Marshal(plain(item))
+	}
+	return json.Marshal(struct {
+		plain
+		Product *Product `json:"product,omitempty"`
+	}{plain: plain(item)})
+}
+
+// MarshalJSON omits the product of items that are not hydrated
+func (item OrderItem) MarshalJSON() ([]byte, error) {
+	type plain OrderItem
+	if item.Hydrated() {
+		return json.Marshal(plain(item))
+	}
+	return json.Marshal(struct {
+		plain
+		Product *Product `json:"product,omitempty"`
+	}{plain: plain(item)})
+}
+
+// HydrateCart loads the Product of every cart item that lacks one.
+func HydrateCart(cart *Cart, resolve ProductResolver) error {
+	for i := range cart.Items {
+		item := &cart.Items[i]
+		if item.Hydrated() {
+			continue
+		}
+		product, err := resolve(item.ProductID)
+		if err != nil {
+			return fmt.Errorf("hydrating cart item %s: %w", item.ID, err)
+		}
+		item.Product = *product
+	}
+	return nil
+}
+
+// HydrateOrder loads the Product of every order item that lacks one. The
+// current product is loaded; the item's Price still records what was paid.
+func HydrateOrder(order *Order, resolve ProductResolver) error {
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.Hydrated() {
+			continue
+		}
+		product, err := resolve(item.ProductID)
+		if err != nil {
+			return fmt.Errorf("hydrating order item %s: %w", item.ID, err)
+		}
+		item.Product = *product
+	}
+	return nil
+}
+
+// DehydrateCart drops the embedded products, keeping only ProductID
+func DehydrateCart(cart *Cart) {
+	for i := range cart.Items {
+		cart.Items[i].Product = Product{}
+	}
+}
+
+// DehydrateOrder drops the embedded products, keeping only ProductID
+func DehydrateOrder(order *Order) {
+	for i := range order.Items {
+		order.Items[i].Product = Product{}
+	}
+}
+
+// SetLazyProducts switches the service between eager loading, the default,
+// where cart and order items embed their Product, and lazy loading, where
+// they store only ProductID. Operations that need product data, such as
+// inventory checks and shipping weights, hydrate the cart for their duration.
+// Call HydrateCart or HydrateOrder before displaying a lazy cart or order.
+func (es *EcommerceService) SetLazyProducts(lazy bool) {
+	es.lazyProducts = lazy
+}
+
+// HydrateCart loads the products of the cart's items from the catalog
+func (es *EcommerceService) HydrateCart(cart *Cart) error {
+	return HydrateCart(cart, es.GetProduct)
+}
+
+// HydrateOrder loads the products of the order's items from the catalog
+func (es *EcommerceService) HydrateOrder(order *Order) error {
+	return HydrateOrder(order, es.GetProduct)
+}
+
+// withProducts runs fn with the cart hydrated, dehydrating it afterwards in
+// lazy mode.
+func (es *EcommerceService) withProducts(cart *Cart, fn func() error) error {
+	if !es.lazyProducts {
+		return fn()
+	}
+	if err := es.HydrateCart(cart); err != nil {
+		return err
+	}
+	defer DehydrateCart(cart)
+	return fn()
+}
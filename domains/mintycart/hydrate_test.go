@@ -0,0 +1,73 @@
+package mintycart
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+func TestLazyProducts(t *testing.T) {
+	es := NewEcommerceService()
+	es.SetLazyProducts(true)
+	product, err := es.CreateProduct("Widget", "A widget", "W-1", "tools",
+		mt.NewMoney(10, mt.CurrencyUSD), 2, Inventory{Quantity: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cart, err := es.CreateCart("cust-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := es.AddToCart(cart.ID, product.ID, 2); err != nil {
+		t.Fatal(err)
+	}
+	if cart, err = es.GetCart(cart.ID); err != nil {
+		t.Fatal(err)
+	}
+	item := cart.Items[0]
+	if item.Hydrated() || item.ProductID != product.ID {
+		t.Fatalf("lazy item should hold only the product ID: %+v", item)
+	}
+	// Shipping is computed while hydrated: $5 base + 4 lb at $0.50
+	if cart.Shipping.Amount != 700 {
+		t.Errorf("shipping = %d, want 700", cart.Shipping.Amount)
+	}
+	if err := es.UpdateCartItemQuantity(cart.ID, item.ID, 6); err == nil {
+		t.Error("inventory check should still apply in lazy mode")
+	}
+
+	data, err := json.Marshal(cart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"product"`) {
+		t.Errorf("lazy cart JSON should omit the product: %s", data)
+	}
+
+	if err := es.HydrateCart(cart); err != nil {
+		t.Fatal(err)
+	}
+	if cart.Items[0].Product.Name != "Widget" {
+		t.Errorf("hydrated product = %+v", cart.Items[0].Product)
+	}
+	data, _ = json.Marshal(cart)
+	if !strings.Contains(string(data), `"product":{`) {
+		t.Errorf("hydrated cart JSON should embed the product: %s", data)
+	}
+
+	DehydrateCart(cart)
+	if cart.Items[0].Hydrated() {
+		t.Error("DehydrateCart kept the product")
+	}
+}
+
+func TestHydrateOrderUnknownProduct(t *testing.T) {
+	es := NewEcommerceService()
+	order := Order{Items: []OrderItem{{ID: "oi-1", ProductID: "missing"}}}
+	if err := es.HydrateOrder(&order); err == nil {
+		t.Error("expected an error for an unknown product")
+	}
+}
@@ -0,0 +1,315 @@
+package mintycart
+
+import (
+	"io"
+	"strings"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+// =====================================================
+// CSV / JSON IMPORT
+// =====================================================
+
+// The importers read one record at a time and validate it with the same
+// validators the service uses, so bad rows are reported with their line
+// numbers instead of being inserted. Records that fail validation are
+// skipped; everything else is passed on. They do not assign IDs or touch
+// a service: callers decide how valid records are stored.
+//
+// CSV files need a header row. Column names are matched case-insensitively
+// and unknown columns are ignored. Money columns accept values such as
+// "$1,299.00" or "24.99 USD" (see mt.ParseMoney); a "currency" column sets
+// the currency of bare amounts, which otherwise default to USD.
+//
+// JSON files hold an array of objects in the types' JSON form. Errors from
+// JSON imports carry the 1-based record number in Line.
+
+// ScanProductsCSV streams products from CSV with the columns id, name,
+// description, sku, price, currency, category, brand, weight, length,
+// width, height, dimension_unit, quantity, low_stock_level and status,
+// calling fn for each valid product.
+func ScanProductsCSV(r io.Reader, fn func(Product) error) mt.ValidationErrors {
+	return scanCSV(r, productFromRow, ValidateProduct, fn)
+}
+
+// ParseProductsCSV reads all valid products from CSV, see ScanProductsCSV.
+func ParseProductsCSV(r io.Reader) ([]Product, mt.ValidationErrors) {
+	var products []Product
+	errs := ScanProductsCSV(r, collectInto(&products))
+	return products, errs
+}
+
+// ParseProductsJSON reads all valid products from a JSON array.
+func ParseProductsJSON(r io.Reader) ([]Product, mt.ValidationErrors) {
+	var products []Product
+	errs := scanJSON(r, ValidateProduct, collectInto(&products))
+	return products, errs
+}
+
+// ScanCustomersCSV streams customers from CSV with the columns id, name,
+// email, phone, status, payment_terms, credit_limit and currency, plus an
+// optional primary address in street1, street2, city, state, postal_code
+// and country, calling fn for each valid customer.
+func ScanCustomersCSV(r io.Reader, fn func(Customer) error) mt.ValidationErrors {
+	return scanCSV(r, customerFromRow, ValidateCustomer, fn)
+}
+
+// ParseCustomersCSV reads all valid customers from CSV, see ScanCustomersCSV.
+func ParseCustomersCSV(r io.Reader) ([]Customer, mt.ValidationErrors) {
+	var customers []Customer
+	errs := ScanCustomersCSV(r, collectInto(&customers))
+	return customers, errs
+}
+
+// ParseCustomersJSON reads all valid customers from a JSON array.
+func ParseCustomersJSON(r io.Reader) ([]Customer, mt.ValidationErrors) {
+	var customers []Customer
+	errs := scanJSON(r, ValidateCustomer, collectInto(&customers))
+	return customers, errs
+}
+
+// ScanOrdersCSV streams orders from CSV with one row per order item.
+// Consecutive rows with the same number form one order; order-level
+// columns are read from its first row. The columns are number, status,
+// customer_id, customer_name, customer_email, currency, product_id, sku,
+// quantity and price, and billing_ and shipping_ prefixed street1, street2,
+// city, state, postal_code and country. The shipping address defaults to
+// the billing address. Totals are computed from the items; tax and
+// shipping columns are added when present.
+func ScanOrdersCSV(r io.Reader, fn func(Order) error) mt.ValidationErrors {
+	var all mt.ValidationErrors
+	var current *Order
+	var currentLine int
+	var currentErrs mt.ValidationErrors
+
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		order := *current
+		current = nil
+		errs := append(currentErrs, ValidateOrder(order)...)
+		if errs.HasErrors() {
+			all = append(all, errs.AtLine(currentLine)...)
+			return nil
+		}
+		return fn(order)
+	}
+
+	err := mt.EachCSVRow(r, func(row mt.ImportRow) error {
+		number := row.Get("number")
+		if current == nil || number != current.Number {
+			if err := flush(); err != nil {
+				return err
+			}
+			currentLine, currentErrs = row.Line, nil
+			current = orderFromRow(row, &currentErrs)
+		}
+
+		var errs mt.ValidationErrors
+		item := orderItemFromRow(row, current.Subtotal.Currency, &errs)
+		if item.ProductID == "" && item.Product.SKU == "" {
+			errs.Add("product_id", "Product ID or SKU is required")
+		}
+		if item.Quantity <= 0 {
+			errs.Add("quantity", "Quantity must be greater than zero")
+		}
+		if errs.HasErrors() {
+			currentErrs = append(currentErrs, errs.AtLine(row.Line)...)
+			return nil
+		}
+		current.Items = append(current.Items, item)
+		current.Subtotal.Amount += item.Total.Amount
+		current.Total.Amount += item.Total.Amount
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		all.Add("file", err.Error())
+	}
+	return all
+}
+
+// ParseOrdersCSV reads all valid orders from CSV, see ScanOrdersCSV.
+func ParseOrdersCSV(r io.Reader) ([]Order, mt.ValidationErrors) {
+	var orders []Order
+	errs := ScanOrdersCSV(r, collectInto(&orders))
+	return orders, errs
+}
+
+// ParseOrdersJSON reads all valid orders from a JSON array.
+func ParseOrdersJSON(r io.Reader) ([]Order, mt.ValidationErrors) {
+	var orders []Order
+	errs := scanJSON(r, ValidateOrder, collectInto(&orders))
+	return orders, errs
+}
+
+// scanCSV builds and validates one record per CSV row
+func scanCSV[T any](r io.Reader, build func(mt.ImportRow, *mt.ValidationErrors) T,
+	validate func(T) mt.ValidationErrors, fn func(T) error) mt.ValidationErrors {
+	var all mt.ValidationErrors
+	err := mt.EachCSVRow(r, func(row mt.ImportRow) error {
+		var errs mt.ValidationErrors
+		item := build(row, &errs)
+		errs = append(errs, validate(item)...)
+		if errs.HasErrors() {
+			all = append(all, errs.AtLine(row.Line)...)
+			return nil
+		}
+		return fn(item)
+	})
+	if err != nil {
+		all.Add("file", err.Error())
+	}
+	return all
+}
+
+// scanJSON validates one record per element of a JSON array
+func scanJSON[T any](r io.Reader, validate func(T) mt.ValidationErrors, fn func(T) error) mt.ValidationErrors {
+	var all mt.ValidationErrors
+	err := mt.EachJSONRecord(r, func(record int, item T, err error) error {
+		if err != nil {
+			all = append(all, mt.ValidationError{Field: "record", Message: err.Error(), Line: record})
+			return nil
+		}
+		if errs := validate(item); errs.HasErrors() {
+			all = append(all, errs.AtLine(record)...)
+			return nil
+		}
+		return fn(item)
+	})
+	if err != nil {
+		all.Add("file", err.Error())
+	}
+	return all
+}
+
+// collectInto returns a callback appending each record to out
+func collectInto[T any](out *[]T) func(T) error {
+	return func(item T) error {
+		*out = append(*out, item)
+		return nil
+	}
+}
+
+// rowCurrency returns the row's currency column, defaulting to USD
+func rowCurrency(row mt.ImportRow) string {
+	if currency := row.Get("currency"); currency != "" {
+		return strings.ToUpper(currency)
+	}
+	return mt.CurrencyUSD
+}
+
+// rowStatus returns the row's status column, or def when it is empty
+func rowStatus(row mt.ImportRow, def string) string {
+	if status := row.Get("status"); status != "" {
+		return strings.ToLower(status)
+	}
+	return def
+}
+
+// rowAddress reads an address from columns with the given prefix
+func rowAddress(row mt.ImportRow, prefix, addressType string) mt.Address {
+	return mt.Address{
+		Type:       addressType,
+		Name:       row.Get(prefix + "name"),
+		Street1:    row.Get(prefix + "street1"),
+		Street2:    row.Get(prefix + "street2"),
+		City:       row.Get(prefix + "city"),
+		State:      row.Get(prefix + "state"),
+		PostalCode: row.Get(prefix + "postal_code"),
+		Country:    row.Get(prefix + "country"),
+	}
+}
+
+func productFromRow(row mt.ImportRow, errs *mt.ValidationErrors) Product {
+	product := Product{
+		ID:          row.Get("id"),
+		Name:        row.Get("name"),
+		Description: row.Get("description"),
+		SKU:         row.Get("sku"),
+		Price:       row.Money("price", rowCurrency(row), errs),
+		Category:    row.Get("category"),
+		Brand:       row.Get("brand"),
+		Weight:      row.Float("weight", errs),
+		Dimensions: Dimensions{
+			Length: row.Float("length", errs),
+			Width:  row.Float("width", errs),
+			Height: row.Float("height", errs),
+			Unit:   row.Get("dimension_unit"),
+		},
+		Inventory: Inventory{LowStockLevel: row.Int("low_stock_level", errs)},
+		Status:    rowStatus(row, mt.StatusActive),
+		Metadata:  make(map[string]string),
+	}
+	quantity := row.Int("quantity", errs)
+	if err := setInventoryQuantity(&product, quantity); err != nil {
+		product.Inventory.Quantity = quantity // Reported by ValidateProduct
+	}
+	return product
+}
+
+func customerFromRow(row mt.ImportRow, errs *mt.ValidationErrors) Customer {
+	customer := Customer{
+		ID:           row.Get("id"),
+		Name:         row.Get("name"),
+		Email:        row.Get("email"),
+		Phone:        row.Get("phone"),
+		PaymentTerms: strings.ToLower(row.Get("payment_terms")),
+		Status:       rowStatus(row, mt.StatusActive),
+		Metadata:     make(map[string]string),
+	}
+	if row.Has("credit_limit") {
+		customer.CreditLimit = row.Money("credit_limit", rowCurrency(row), errs)
+	}
+	if address := rowAddress(row, "", "primary"); address.Street1 != "" || address.City != "" {
+		address.Name = customer.Name
+		customer.Addresses = []mt.Address{address}
+	}
+	return customer
+}
+
+func orderFromRow(row mt.ImportRow, errs *mt.ValidationErrors) *Order {
+	currency := rowCurrency(row)
+	order := &Order{
+		Number:     row.Get("number"),
+		CustomerID: row.Get("customer_id"),
+		Customer: Customer{
+			ID:    row.Get("customer_id"),
+			Name:  row.Get("customer_name"),
+			Email: row.Get("customer_email"),
+		},
+		BillingAddress: rowAddress(row, "billing_", mt.AddressBilling),
+		Subtotal:       mt.Money{Currency: currency},
+		Tax:            row.Money("tax", currency, errs),
+		Shipping:       row.Money("shipping", currency, errs),
+		Discount:       mt.Money{Currency: currency},
+		Status:         rowStatus(row, OrderStatusPending),
+		Metadata:       make(map[string]string),
+	}
+	order.ShippingAddress = rowAddress(row, "shipping_", mt.AddressShipping)
+	if order.ShippingAddress.Street1 == "" && order.ShippingAddress.City == "" {
+		order.ShippingAddress = order.BillingAddress
+		order.ShippingAddress.Type = mt.AddressShipping
+	}
+	order.Total = mt.Money{Amount: order.Tax.Amount + order.Shipping.Amount, Currency: currency}
+	return order
+}
+
+func orderItemFromRow(row mt.ImportRow, currency string, errs *mt.ValidationErrors) OrderItem {
+	item := OrderItem{
+		ProductID: row.Get("product_id"),
+		Product:   Product{ID: row.Get("product_id"), SKU: row.Get("sku")},
+		Quantity:  row.Int("quantity", errs),
+		Price:     row.Money("price", currency, errs),
+	}
+	if item.Price.Currency != currency {
+		errs.Add("price", "Price currency must match the order currency "+currency)
+	}
+	item.Product.Price = item.Price
+	item.Total = mt.Money{Amount: item.Price.Amount * int64(item.Quantity), Currency: currency}
+	return item
+}
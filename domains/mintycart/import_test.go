@@ -0,0 +1,89 @@
+package mintycart
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseProductsCSV(t *testing.T) {
+	csv := `Name,SKU,Category,Price,Weight,Quantity,Low Stock Level
+Widget,W-1,tools,"$1,299.00",2.5,10,3
+,W-2,tools,5.00,1,1,0
+Gadget,G-1,tools,12.50 EUR,1,-1,0
+Bolt,B-1,hardware,abc,1,5,0
+`
+	products, errs := ParseProductsCSV(strings.NewReader(csv))
+	if len(products) != 1 {
+		t.Fatalf("got %d products, want 1: %v", len(products), errs)
+	}
+	p := products[0]
+	if p.Price.Amount != 129900 || p.Price.Currency != "USD" || p.Inventory.Quantity != 10 || p.Inventory.Status != "in_stock" {
+		t.Errorf("unexpected product: %+v", p)
+	}
+
+	lines := map[int]string{}
+	for _, e := range errs {
+		lines[e.Line] = e.Field
+	}
+	for line, field := range map[int]string{3: "name", 4: "inventory.quantity", 5: "price"} {
+		if lines[line] != field {
+			t.Errorf("line %d: got error on %q, want %q (all: %v)", line, lines[line], field, errs)
+		}
+	}
+}
+
+func TestParseProductsCSVCurrencyDecimals(t *testing.T) {
+	csv := `Name,SKU,Category,Price,Weight,Quantity,Low Stock Level
+Teapot,T-1,kitchen,"¥1,000",1,5,0
+Dates,D-1,food,12.345 BHD,1,5,0
+Cup,C-1,kitchen,150.5 JPY,1,5,0
+`
+	products, errs := ParseProductsCSV(strings.NewReader(csv))
+	if len(products) != 2 || len(errs) == 0 || errs[0].Line != 4 {
+		t.Fatalf("got %d products, errors %v; want 2 and a price error on line 4", len(products), errs)
+	}
+	if got := products[0].Price; got.Amount != 1000 || got.Format() != "¥1,000" {
+		t.Errorf("JPY price = %+v (%s), want 1000 yen", got, got.Format())
+	}
+	if got := products[1].Price; got.Amount != 12345 || got.Format() != "12.345 BHD" {
+		t.Errorf("BHD price = %+v (%s), want 12345 fils", got, got.Format())
+	}
+}
+
+func TestParseOrdersCSV(t *testing.T) {
+	csv := `number,customer_id,customer_name,customer_email,billing_street1,billing_city,product_id,quantity,price
+1001,c1,Ann,ann@example.com,1 Main St,Springfield,p1,2,10.00
+1001,,,,,,p2,1,5.00
+1002,c2,Bob,bob@example.com,,,p1,1,10.00
+`
+	orders, errs := ParseOrdersCSV(strings.NewReader(csv))
+	if len(orders) != 1 {
+		t.Fatalf("got %d orders, want 1: %v", len(orders), errs)
+	}
+	o := orders[0]
+	if len(o.Items) != 2 || o.Total.Amount != 2500 || o.ShippingAddress.City != "Springfield" {
+		t.Errorf("unexpected order: %+v", o)
+	}
+	if len(errs) == 0 || errs[0].Line != 4 {
+		t.Errorf("want address errors for the order on line 4, got %v", errs)
+	}
+}
+
+func TestParseCustomersJSON(t *testing.T) {
+	data := `[
+		{"name": "Ann", "email": "ann@example.com"},
+		{"name": "", "email": "nope"},
+		{"name": 42}
+	]`
+	customers, errs := ParseCustomersJSON(strings.NewReader(data))
+	if len(customers) != 1 || customers[0].Name != "Ann" {
+		t.Errorf("unexpected customers: %+v", customers)
+	}
+	records := map[int]bool{}
+	for _, e := range errs {
+		records[e.Line] = true
+	}
+	if !records[2] || !records[3] || records[1] {
+		t.Errorf("want errors for records 2 and 3, got %v", errs)
+	}
+}
@@ -3,11 +3,15 @@
 package mintycart
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
+	"strings"
 	"time"
 
+	mh "github.com/ha1tch/minty/mintyhook"
 	mt "github.com/ha1tch/minty/mintytypes"
 )
 
@@ -57,6 +61,7 @@ type Inventory struct {
 	LowStockLevel int    `json:"low_stock_level"`
 	Status        string `json:"status"` // in_stock, low_stock, out_of_stock
 	LastUpdated   time.Time `json:"last_updated"`
+	Locations     map[string]int `json:"locations,omitempty"` // Optional per-location stock; Quantity is their total
 }
 
 // Cart represents a shopping cart
@@ -84,6 +89,7 @@ type CartItem struct {
 	Price     mt.Money `json:"price"`     // Price at time of adding to cart
 	Total     mt.Money `json:"total"`     // Price * Quantity
 	AddedAt   time.Time     `json:"added_at"`
+	Location  string        `json:"location,omitempty"` // Preferred stock location, if any
 }
 
 // Order represents a customer order
@@ -122,7 +128,7 @@ type OrderItem struct {
 // Payment represents payment information
 type Payment struct {
 	ID            string        `json:"id"`
-	Method        string        `json:"method"`        // credit_card, paypal, bank_transfer
+	Method        string        `json:"method"`        // credit_card, paypal, bank_transfer, account
 	Status        string        `json:"status"`        // pending, completed, failed, refunded
 	Amount        mt.Money `json:"amount"`
 	TransactionID string        `json:"transaction_id"`
@@ -142,12 +148,39 @@ type Customer struct {
 	TotalSpent     mt.Money      `json:"total_spent"`
 	OrderCount     int                `json:"order_count"`
 	PreferredPayment string           `json:"preferred_payment"`
+	PaymentTerms   string             `json:"payment_terms,omitempty"` // e.g. net30; empty or "cash" for cash customers
+	CreditLimit    mt.Money           `json:"credit_limit,omitempty"`
 	CreatedAt      time.Time          `json:"created_at"`
 	LastOrderAt    *time.Time         `json:"last_order_at,omitempty"`
 	Status         string             `json:"status"`
 	Metadata       map[string]string  `json:"metadata,omitempty"`
 }
 
+// AvailableAt returns the stock available at a location. Without per-location
+// stock, or with an empty location, it returns the total quantity.
+func (p Product) AvailableAt(location string) int {
+	if location == "" || len(p.Inventory.Locations) == 0 {
+		return p.Inventory.Quantity
+	}
+	return p.Inventory.Locations[location]
+}
+
+// StockLocations returns the locations holding stock for the product, sorted
+func (p Product) StockLocations() []string {
+	locations := make([]string, 0, len(p.Inventory.Locations))
+	for location := range p.Inventory.Locations {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+	return locations
+}
+
+// OnCreditTerms reports whether the customer may charge orders to their
+// account, subject to their CreditLimit.
+func (c Customer) OnCreditTerms() bool {
+	return c.PaymentTerms != "" && c.PaymentTerms != PaymentTermsCash
+}
+
 // Implement mt.Customer interface
 func (c Customer) GetID() string                { return c.ID }
 func (c Customer) GetName() string              { return c.Name }
@@ -297,6 +330,52 @@ func (s OrderStatus) GetDescription() string {
 	}
 }
 
+// Payment terms and the on-account payment method used by credit customers
+const (
+	PaymentTermsCash     = "cash"
+	PaymentMethodAccount = "account"
+)
+
+// Order status values
+const (
+	OrderStatusPending    = mt.StatusPending
+	OrderStatusProcessing = "processing"
+	OrderStatusShipped    = "shipped"
+	OrderStatusDelivered  = "delivered"
+	OrderStatusCancelled  = mt.StatusCancelled
+	OrderStatusReturned   = "returned"
+)
+
+// orderTransitions declares the order status state machine:
+// pending → processing → shipped → delivered, with cancellation before
+// shipping and returns once the order has left the warehouse.
+var orderTransitions = map[string][]string{
+	OrderStatusPending:    {OrderStatusProcessing, OrderStatusCancelled},
+	OrderStatusProcessing: {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped:    {OrderStatusDelivered, OrderStatusReturned},
+	OrderStatusDelivered:  {OrderStatusReturned},
+	OrderStatusCancelled:  {},
+	OrderStatusReturned:   {},
+}
+
+// AllowedTransitions returns the statuses an order in the given status may move to
+func AllowedTransitions(status string) []string {
+	allowed := orderTransitions[status]
+	result := make([]string, len(allowed))
+	copy(result, allowed)
+	return result
+}
+
+// CanTransitionOrder reports whether an order may move between two statuses
+func CanTransitionOrder(from, to string) bool {
+	for _, allowed := range orderTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
 // =====================================================
 // PURE BUSINESS LOGIC FUNCTIONS
 // =====================================================
@@ -309,12 +388,10 @@ func ValidateProduct(product Product) mt.ValidationErrors {
 	
 	mt.ValidateRequired("name", product.Name, "Product Name", &errors)
 	mt.ValidateRequired("sku", product.SKU, "SKU", &errors)
+	mt.ValidateLength("sku", product.SKU, 3, 32, "SKU", &errors)
 	mt.ValidateRequired("category", product.Category, "Category", &errors)
 	mt.ValidateMoneyAmount("price", product.Price, "Price", &errors)
-	
-	if product.Weight <= 0 {
-		errors.Add("weight", "Weight must be greater than zero")
-	}
+	mt.ValidateRange("weight", product.Weight, 0.1, 1000, "Weight", &errors)
 	
 	if product.Inventory.Quantity < 0 {
 		errors.Add("inventory.quantity", "Inventory quantity cannot be negative")
@@ -323,10 +400,55 @@ func ValidateProduct(product Product) mt.ValidationErrors {
 	return errors
 }
 
-// UpdateInventory updates product inventory
+// UpdateInventory updates product inventory. For multi-location inventory,
+// decreases are drawn from locations in name order; increases need a
+// location, see UpdateInventoryAt.
 func UpdateInventory(product *Product, quantityChange int) error {
-	newQuantity := product.Inventory.Quantity + quantityChange
+	return UpdateInventoryAt(product, "", quantityChange)
+}
+
+// UpdateInventoryAt updates product inventory at a specific location
+func UpdateInventoryAt(product *Product, location string, quantityChange int) error {
+	if len(product.Inventory.Locations) == 0 {
+		if location != "" {
+			return fmt.Errorf("product has no stock location %s", location)
+		}
+		return setInventoryQuantity(product, product.Inventory.Quantity+quantityChange)
+	}
+	
+	if location != "" {
+		newQuantity := product.Inventory.Locations[location] + quantityChange
+		if newQuantity < 0 {
+			return fmt.Errorf("insufficient inventory at %s", location)
+		}
+		product.Inventory.Locations[location] = newQuantity
+	} else {
+		if quantityChange > 0 {
+			return errors.New("a location is required to add stock to multi-location inventory")
+		}
+		if product.Inventory.Quantity+quantityChange < 0 {
+			return errors.New("insufficient inventory")
+		}
+		remaining := -quantityChange
+		for _, loc := range product.StockLocations() {
+			take := product.Inventory.Locations[loc]
+			if take > remaining {
+				take = remaining
+			}
+			product.Inventory.Locations[loc] -= take
+			remaining -= take
+		}
+	}
 	
+	total := 0
+	for _, qty := range product.Inventory.Locations {
+		total += qty
+	}
+	return setInventoryQuantity(product, total)
+}
+
+// setInventoryQuantity sets the total quantity and refreshes stock status
+func setInventoryQuantity(product *Product, newQuantity int) error {
 	if newQuantity < 0 {
 		return errors.New("insufficient inventory")
 	}
@@ -369,8 +491,9 @@ func ValidateCart(cart Cart) mt.ValidationErrors {
 	}
 	
 	// Validate cart totals
-	calculatedSubtotal := CalculateSubtotal(cart.Items)
-	if cart.Subtotal.Amount != calculatedSubtotal.Amount {
+	if calculatedSubtotal, err := CalculateSubtotal(cart.Items); err != nil {
+		errors.Add("currency", "Cart items must all be priced in the same currency")
+	} else if matches, err := cart.Subtotal.Equal(calculatedSubtotal); err != nil || !matches {
 		errors.Add("subtotal", "Cart subtotal does not match calculated total")
 	}
 	
@@ -379,21 +502,39 @@ func ValidateCart(cart Cart) mt.ValidationErrors {
 
 // AddItemToCart adds an item to the cart or updates quantity if item exists
 func AddItemToCart(cart *Cart, product Product, quantity int) error {
+	return AddItemToCartFrom(cart, product, quantity, "")
+}
+
+// AddItemToCartFrom adds an item fulfilled from a preferred stock location.
+// An empty location checks the product's total availability.
+func AddItemToCartFrom(cart *Cart, product Product, quantity int, location string) error {
 	if quantity <= 0 {
 		return errors.New("quantity must be greater than zero")
 	}
 	
-	if product.Inventory.Quantity < quantity {
+	if product.AvailableAt(location) < quantity {
+		if location != "" {
+			return fmt.Errorf("insufficient inventory at %s", location)
+		}
 		return errors.New("insufficient inventory")
 	}
 	
+	price := upperCurrency(product.Price)
+	if len(cart.Items) > 0 && upperCurrency(cart.Items[0].Price).Currency != price.Currency {
+		return fmt.Errorf("cannot add %s item to %s cart", price.Currency, cart.Items[0].Price.Currency)
+	}
+	
 	// Check if item already exists in cart
 	for i, item := range cart.Items {
-		if item.ProductID == product.ID {
+		if item.ProductID == product.ID && item.Location == location {
+			previous := cart.Items[i]
 			cart.Items[i].Quantity += quantity
-			cart.Items[i].Total.Amount = cart.Items[i].Price.Amount * int64(cart.Items[i].Quantity)
+			cart.Items[i].Total = cart.Items[i].Price.Mul(int64(cart.Items[i].Quantity))
+			if err := RecalculateCartTotals(cart); err != nil {
+				cart.Items[i] = previous
+				return err
+			}
 			cart.UpdatedAt = time.Now()
-			RecalculateCartTotals(cart)
 			return nil
 		}
 	}
@@ -404,14 +545,18 @@ func AddItemToCart(cart *Cart, product Product, quantity int) error {
 		ProductID: product.ID,
 		Product:   product,
 		Quantity:  quantity,
-		Price:     product.Price,
-		Total:     mt.Money{Amount: product.Price.Amount * int64(quantity), Currency: product.Price.Currency},
+		Price:     price,
+		Total:     price.Mul(int64(quantity)),
 		AddedAt:   time.Now(),
+		Location:  location,
 	}
 	
 	cart.Items = append(cart.Items, cartItem)
+	if err := RecalculateCartTotals(cart); err != nil {
+		cart.Items = cart.Items[:len(cart.Items)-1]
+		return err
+	}
 	cart.UpdatedAt = time.Now()
-	RecalculateCartTotals(cart)
 	
 	return nil
 }
@@ -422,8 +567,7 @@ func RemoveItemFromCart(cart *Cart, itemID string) error {
 		if item.ID == itemID {
 			cart.Items = append(cart.Items[:i], cart.Items[i+1:]...)
 			cart.UpdatedAt = time.Now()
-			RecalculateCartTotals(cart)
-			return nil
+			return RecalculateCartTotals(cart)
 		}
 	}
 	return errors.New("item not found in cart")
@@ -442,52 +586,95 @@ func UpdateItemQuantity(cart *Cart, itemID string, newQuantity int) error {
 			}
 			
 			cart.Items[i].Quantity = newQuantity
-			cart.Items[i].Total.Amount = cart.Items[i].Price.Amount * int64(newQuantity)
+			cart.Items[i].Total = cart.Items[i].Price.Mul(int64(newQuantity))
 			cart.UpdatedAt = time.Now()
-			RecalculateCartTotals(cart)
-			return nil
+			return RecalculateCartTotals(cart)
 		}
 	}
 	return errors.New("item not found in cart")
 }
 
-// RecalculateCartTotals recalculates all cart totals
-func RecalculateCartTotals(cart *Cart) {
-	cart.Subtotal = CalculateSubtotal(cart.Items)
-	cart.Tax = CalculateTax(cart.Subtotal, 0.08) // 8% tax rate
-	cart.Shipping = CalculateShipping(cart.Items)
+// RecalculateCartTotals recalculates all cart totals. It fails, leaving
+// the totals unchanged, when items are priced in different currencies.
+func RecalculateCartTotals(cart *Cart) error {
+	subtotal, err := CalculateSubtotal(cart.Items)
+	if err != nil {
+		return fmt.Errorf("cart %s: %w", cart.ID, err)
+	}
+	tax, err := CalculateTax(subtotal, 0.08) // 8% tax rate
+	if err != nil {
+		return fmt.Errorf("cart %s: %w", cart.ID, err)
+	}
+	shipping, err := CalculateShipping(cart.Items)
+	if err != nil {
+		return fmt.Errorf("cart %s: %w", cart.ID, err)
+	}
 	
-	cart.Total.Amount = cart.Subtotal.Amount + cart.Tax.Amount + cart.Shipping.Amount
-	cart.Total.Currency = cart.Subtotal.Currency
+	total, err := subtotal.Add(tax)
+	if err == nil {
+		total, err = total.Add(shipping)
+	}
+	if err != nil {
+		return fmt.Errorf("cart %s: %w", cart.ID, err)
+	}
+	cart.Subtotal = subtotal
+	cart.Tax = tax
+	cart.Shipping = shipping
+	cart.Total = total
+	return nil
 }
 
-// CalculateSubtotal calculates subtotal from cart items
-func CalculateSubtotal(items []CartItem) mt.Money {
+// CalculateSubtotal calculates subtotal from cart items. It fails when
+// items are priced in different currencies; currency codes are compared
+// regardless of case.
+func CalculateSubtotal(items []CartItem) (mt.Money, error) {
 	var subtotal mt.Money
-	for _, item := range items {
-		subtotal.Amount += item.Total.Amount
+	for i, item := range items {
+		total := upperCurrency(item.Total)
+		if i == 0 {
+			subtotal.Currency = total.Currency
+		}
+		var err error
+		if subtotal, err = subtotal.Add(total); err != nil {
+			return mt.Money{}, fmt.Errorf("cart item %s: %w", item.ID, err)
+		}
 	}
-	return subtotal
+	return subtotal, nil
 }
 
-// CalculateTax calculates tax amount
-func CalculateTax(subtotal mt.Money, taxRate float64) mt.Money {
-	taxAmount := float64(subtotal.Amount) * taxRate
-	return mt.Money{Amount: int64(taxAmount), Currency: subtotal.Currency}
+// upperCurrency returns m with its currency code in upper case, as
+// mt.NewMoney builds it, so prices entered as "usd" add up with "USD".
+func upperCurrency(m mt.Money) mt.Money {
+	m.Currency = strings.ToUpper(m.Currency)
+	return m
 }
 
-// CalculateShipping calculates shipping cost based on weight and value
-func CalculateShipping(items []CartItem) mt.Money {
+// CalculateTax calculates the tax on subtotal at taxRate, rounded half up
+// to a whole minor unit. It fails for a negative or non-finite rate.
+func CalculateTax(subtotal mt.Money, taxRate float64) (mt.Money, error) {
+	if taxRate < 0 || math.IsNaN(taxRate) || math.IsInf(taxRate, 0) {
+		return mt.Money{}, fmt.Errorf("invalid tax rate %v", taxRate)
+	}
+	return upperCurrency(subtotal).MulFloat(taxRate, mt.RoundHalfUp), nil
+}
+
+// CalculateShipping calculates shipping cost based on weight and value. It
+// fails when items are priced in different currencies.
+func CalculateShipping(items []CartItem) (mt.Money, error) {
 	totalWeight := CalculateShippingWeight(items)
-	
-	var subtotal mt.Money
-	for _, item := range items {
-		subtotal.Amount += item.Total.Amount
+	subtotal, err := CalculateSubtotal(items)
+	if err != nil {
+		return mt.Money{}, err
 	}
 	
 	// Free shipping for orders over $100
-	if subtotal.Amount >= 10000 { // $100 in cents
-		return mt.Money{Amount: 0, Currency: subtotal.Currency}
+	freeShippingThreshold := mt.Money{Amount: 10000, Currency: subtotal.Currency} // $100 in cents
+	below, err := subtotal.LessThan(freeShippingThreshold)
+	if err != nil {
+		return mt.Money{}, err
+	}
+	if !below {
+		return mt.Money{Amount: 0, Currency: subtotal.Currency}, nil
 	}
 	
 	// Base shipping cost + weight-based cost
@@ -495,7 +682,24 @@ func CalculateShipping(items []CartItem) mt.Money {
 	weightCost := totalWeight * 0.50
 	totalShipping := baseCost + weightCost
 	
-	return mt.NewMoney(totalShipping, subtotal.Currency)
+	return mt.NewMoney(totalShipping, subtotal.Currency), nil
+}
+
+// Customer Business Logic
+
+// ValidateCustomer validates customer data
+func ValidateCustomer(customer Customer) mt.ValidationErrors {
+	var errors mt.ValidationErrors
+
+	mt.ValidateRequired("name", customer.Name, "Customer Name", &errors)
+	mt.ValidateEmail("email", customer.Email, "Email", &errors)
+	mt.ValidatePhone("phone", customer.Phone, "Phone", &errors)
+
+	if customer.CreditLimit.IsNegative() {
+		errors.Add("credit_limit", "Credit limit cannot be negative")
+	}
+
+	return errors
 }
 
 // Order Business Logic
@@ -561,8 +765,32 @@ func CreateOrderFromCart(cart Cart, customer Customer, billingAddr, shippingAddr
 	return order
 }
 
+// TransitionOrder moves an order to a new status, rejecting transitions the
+// state machine does not allow and stamping ShippedAt/DeliveredAt.
+func TransitionOrder(order *Order, to string) error {
+	if !CanTransitionOrder(order.Status, to) {
+		return fmt.Errorf("cannot transition order from %s to %s", order.Status, to)
+	}
+	
+	now := time.Now()
+	switch to {
+	case OrderStatusShipped:
+		order.ShippedAt = &now
+	case OrderStatusDelivered:
+		order.DeliveredAt = &now
+	}
+	
+	order.Status = to
+	order.UpdatedAt = now
+	return nil
+}
+
 // ProcessPayment processes payment for an order
 func ProcessPayment(order *Order, paymentMethod string) error {
+	if !CanTransitionOrder(order.Status, OrderStatusProcessing) {
+		return fmt.Errorf("cannot process payment for order in %s status", order.Status)
+	}
+	
 	// Simulate payment processing
 	payment := Payment{
 		ID:            generateID("pay"),
@@ -577,27 +805,24 @@ func ProcessPayment(order *Order, paymentMethod string) error {
 		payment.CardBrand = "visa"
 	}
 	
+	// Orders charged to a credit account stay unpaid until invoiced and settled
+	if paymentMethod == PaymentMethodAccount {
+		payment.Status = mt.StatusPending
+	}
+	
 	now := time.Now()
 	payment.ProcessedAt = &now
 	
 	order.Payment = payment
-	order.Status = "processing"
-	order.UpdatedAt = time.Now()
-	
-	return nil
+	return TransitionOrder(order, OrderStatusProcessing)
 }
 
 // ShipOrder marks an order as shipped
 func ShipOrder(order *Order, trackingNumber string) error {
-	if order.Status != "processing" {
-		return errors.New("order must be in processing status to ship")
+	if err := TransitionOrder(order, OrderStatusShipped); err != nil {
+		return err
 	}
 	
-	order.Status = "shipped"
-	now := time.Now()
-	order.ShippedAt = &now
-	order.UpdatedAt = time.Now()
-	
 	if order.Metadata == nil {
 		order.Metadata = make(map[string]string)
 	}
@@ -617,6 +842,12 @@ type EcommerceService struct {
 	carts      []Cart
 	orders     []Order
 	customers  []Customer
+	audit      mt.AuditSink
+	auditCtx   context.Context
+	webhooks   mh.Dispatcher
+	lazyProducts bool // Items store only ProductID, see SetLazyProducts
+	txs        []*ecommerceSnapshot // Open InTx transactions, outermost first
+	pending    []func() // Audit events and webhooks held until the transaction commits
 }
 
 // NewEcommerceService creates a new e-commerce service
@@ -630,6 +861,62 @@ func NewEcommerceService() *EcommerceService {
 	}
 }
 
+// Audit Operations
+
+// SetAuditSink sets the sink notified of create, update and status-change
+// operations. A nil sink disables auditing.
+func (es *EcommerceService) SetAuditSink(sink mt.AuditSink) {
+	es.audit = sink
+}
+
+// SetAuditContext sets the context audit events take their actor from (see
+// mt.ContextWithActor), typically once per request.
+func (es *EcommerceService) SetAuditContext(ctx context.Context) {
+	es.auditCtx = ctx
+}
+
+// auditSnapshot captures an entity before a change, when auditing is enabled
+func (es *EcommerceService) auditSnapshot(entity interface{}) map[string]interface{} {
+	if es.audit == nil {
+		return nil
+	}
+	return mt.AuditSnapshot(entity)
+}
+
+// recordAudit sends an audit event to the sink, if one is set
+func (es *EcommerceService) recordAudit(entityType, entityID, action string, before, after interface{}) {
+	if es.audit == nil {
+		return
+	}
+	ctx := es.auditCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	event := mt.NewAuditEvent(ctx, entityType, entityID, action, before, after)
+	es.notify(func() { es.audit.Record(event) })
+}
+
+// Webhook Operations
+
+// SetWebhookDispatcher sets the dispatcher notified of order events:
+// "order.created" when an order is placed and "order.<status>", e.g.
+// "order.shipped" or "order.cancelled", on each later status change. The
+// payload is the order. A nil dispatcher disables webhooks. Dispatch
+// errors do not fail the operation; deliver through a mh.Queue to keep
+// receivers out of the request path and to report failures.
+func (es *EcommerceService) SetWebhookDispatcher(dispatcher mh.Dispatcher) {
+	es.webhooks = dispatcher
+}
+
+// dispatchWebhook sends an event to the dispatcher, if one is set
+func (es *EcommerceService) dispatchWebhook(event string, payload interface{}) {
+	if es.webhooks == nil {
+		return
+	}
+	dispatcher := es.webhooks
+	es.notify(func() { _ = dispatcher.Dispatch(event, payload) })
+}
+
 // Product Operations
 
 func (es *EcommerceService) CreateProduct(name, description, sku, category string, 
@@ -655,13 +942,14 @@ func (es *EcommerceService) CreateProduct(name, description, sku, category strin
 	}
 	
 	es.products = append(es.products, product)
+	es.recordAudit("product", product.ID, mt.AuditCreate, nil, product)
 	return &product, nil
 }
 
 func (es *EcommerceService) GetProduct(productID string) (*Product, error) {
 	for i, product := range es.products {
 		if product.ID == productID {
-			return &es.products[i], nil
+			return es.productAt(i), nil
 		}
 	}
 	return nil, errors.New("product not found")
@@ -670,7 +958,7 @@ func (es *EcommerceService) GetProduct(productID string) (*Product, error) {
 func (es *EcommerceService) GetProductsBySKU(sku string) (*Product, error) {
 	for i, product := range es.products {
 		if product.SKU == sku {
-			return &es.products[i], nil
+			return es.productAt(i), nil
 		}
 	}
 	return nil, errors.New("product not found")
@@ -706,7 +994,26 @@ func (es *EcommerceService) UpdateProductInventory(productID string, quantityCha
 		return err
 	}
 	
-	return UpdateInventory(product, quantityChange)
+	before := es.auditSnapshot(product)
+	if err := UpdateInventory(product, quantityChange); err != nil {
+		return err
+	}
+	es.recordAudit("product", product.ID, mt.AuditUpdate, before, product)
+	return nil
+}
+
+func (es *EcommerceService) UpdateProductInventoryAt(productID, location string, quantityChange int) error {
+	product, err := es.GetProduct(productID)
+	if err != nil {
+		return err
+	}
+	
+	before := es.auditSnapshot(product)
+	if err := UpdateInventoryAt(product, location, quantityChange); err != nil {
+		return err
+	}
+	es.recordAudit("product", product.ID, mt.AuditUpdate, before, product)
+	return nil
 }
 
 // Cart Operations
@@ -724,13 +1031,14 @@ func (es *EcommerceService) CreateCart(customerID string) (*Cart, error) {
 	}
 	
 	es.carts = append(es.carts, cart)
+	es.recordAudit("cart", cart.ID, mt.AuditCreate, nil, cart)
 	return &cart, nil
 }
 
 func (es *EcommerceService) GetCart(cartID string) (*Cart, error) {
 	for i, cart := range es.carts {
 		if cart.ID == cartID {
-			return &es.carts[i], nil
+			return es.cartAt(i), nil
 		}
 	}
 	return nil, errors.New("cart not found")
@@ -739,7 +1047,7 @@ func (es *EcommerceService) GetCart(cartID string) (*Cart, error) {
 func (es *EcommerceService) GetCartByCustomer(customerID string) (*Cart, error) {
 	for i, cart := range es.carts {
 		if cart.CustomerID == customerID && cart.Status == mt.StatusActive {
-			return &es.carts[i], nil
+			return es.cartAt(i), nil
 		}
 	}
 	return nil, errors.New("active cart not found for customer")
@@ -756,7 +1064,31 @@ func (es *EcommerceService) AddToCart(cartID, productID string, quantity int) er
 		return err
 	}
 	
-	return AddItemToCart(cart, *product, quantity)
+	before := es.auditSnapshot(cart)
+	if err := es.withProducts(cart, func() error { return AddItemToCart(cart, *product, quantity) }); err != nil {
+		return err
+	}
+	es.recordAudit("cart", cart.ID, mt.AuditUpdate, before, cart)
+	return nil
+}
+
+func (es *EcommerceService) AddToCartFrom(cartID, productID string, quantity int, location string) error {
+	cart, err := es.GetCart(cartID)
+	if err != nil {
+		return err
+	}
+	
+	product, err := es.GetProduct(productID)
+	if err != nil {
+		return err
+	}
+	
+	before := es.auditSnapshot(cart)
+	if err := es.withProducts(cart, func() error { return AddItemToCartFrom(cart, *product, quantity, location) }); err != nil {
+		return err
+	}
+	es.recordAudit("cart", cart.ID, mt.AuditUpdate, before, cart)
+	return nil
 }
 
 func (es *EcommerceService) RemoveFromCart(cartID, itemID string) error {
@@ -765,7 +1097,12 @@ func (es *EcommerceService) RemoveFromCart(cartID, itemID string) error {
 		return err
 	}
 	
-	return RemoveItemFromCart(cart, itemID)
+	before := es.auditSnapshot(cart)
+	if err := es.withProducts(cart, func() error { return RemoveItemFromCart(cart, itemID) }); err != nil {
+		return err
+	}
+	es.recordAudit("cart", cart.ID, mt.AuditUpdate, before, cart)
+	return nil
 }
 
 func (es *EcommerceService) UpdateCartItemQuantity(cartID, itemID string, quantity int) error {
@@ -774,7 +1111,12 @@ func (es *EcommerceService) UpdateCartItemQuantity(cartID, itemID string, quanti
 		return err
 	}
 	
-	return UpdateItemQuantity(cart, itemID, quantity)
+	before := es.auditSnapshot(cart)
+	if err := es.withProducts(cart, func() error { return UpdateItemQuantity(cart, itemID, quantity) }); err != nil {
+		return err
+	}
+	es.recordAudit("cart", cart.ID, mt.AuditUpdate, before, cart)
+	return nil
 }
 
 // Order Operations
@@ -797,31 +1139,76 @@ func (es *EcommerceService) CreateOrder(cartID string, customer Customer,
 		return nil, errors
 	}
 	
-	// Process payment
-	if err := ProcessPayment(&order, paymentMethod); err != nil {
-		return nil, err
+	// Charges to a credit account must fit within the customer's credit limit
+	if paymentMethod == PaymentMethodAccount {
+		if !customer.OnCreditTerms() {
+			return nil, errors.New("customer is not on credit terms")
+		}
+		outstanding, err := es.OutstandingBalance(customer.ID, customer.CreditLimit.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if err := mt.CheckCredit(customer.CreditLimit, outstanding, order.Total); err != nil {
+			return nil, err
+		}
 	}
 	
-	// Update inventory
-	for _, item := range order.Items {
-		if err := es.UpdateProductInventory(item.ProductID, -item.Quantity); err != nil {
-			// In a real system, this would require transaction rollback
-			return nil, fmt.Errorf("inventory update failed for product %s: %w", item.ProductID, err)
+	// Update inventory, take payment, mark the cart as ordered and record
+	// the order as one transaction, so a failure part-way leaves no stock
+	// taken and an order is only charged once its stock is
+	err = es.InTx(func(tx *EcommerceTx) error {
+		for _, item := range order.Items {
+			if err := tx.UpdateProductInventory(item.ProductID, -item.Quantity); err != nil {
+				return fmt.Errorf("inventory update failed for product %s: %w", item.ProductID, err)
+			}
 		}
+		
+		if err := ProcessPayment(&order, paymentMethod); err != nil {
+			return err
+		}
+		
+		cart, err := tx.GetCart(cartID)
+		if err != nil {
+			return err
+		}
+		cartBefore := tx.auditSnapshot(cart)
+		cart.Status = "ordered"
+		cart.UpdatedAt = time.Now()
+		tx.recordAudit("cart", cart.ID, mt.AuditStatusChange, cartBefore, cart)
+		
+		tx.orders = append(tx.orders, order)
+		tx.recordAudit("order", order.ID, mt.AuditCreate, nil, order)
+		tx.dispatchWebhook("order.created", order)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	
-	// Mark cart as ordered
-	cart.Status = "ordered"
-	cart.UpdatedAt = time.Now()
-	
-	es.orders = append(es.orders, order)
 	return &order, nil
 }
 
+// OutstandingBalance sums the customer's unpaid on-account orders in the given
+// currency. Cancelled and returned orders no longer count against credit.
+func (es *EcommerceService) OutstandingBalance(customerID, currency string) (mt.Money, error) {
+	outstanding := mt.Money{Currency: strings.ToUpper(currency)}
+	for _, order := range es.orders {
+		if order.CustomerID != customerID || order.Payment.Method != PaymentMethodAccount ||
+			order.Payment.Status != mt.StatusPending ||
+			order.Status == OrderStatusCancelled || order.Status == OrderStatusReturned {
+			continue
+		}
+		var err error
+		if outstanding, err = outstanding.Add(upperCurrency(order.Total)); err != nil {
+			return mt.Money{}, fmt.Errorf("outstanding balance for customer %s: %w", customerID, err)
+		}
+	}
+	return outstanding, nil
+}
+
 func (es *EcommerceService) GetOrder(orderID string) (*Order, error) {
 	for i, order := range es.orders {
 		if order.ID == orderID {
-			return &es.orders[i], nil
+			return es.orderAt(i), nil
 		}
 	}
 	return nil, errors.New("order not found")
@@ -869,7 +1256,28 @@ func (es *EcommerceService) ShipOrder(orderID, trackingNumber string) error {
 		return err
 	}
 	
-	return ShipOrder(order, trackingNumber)
+	before := es.auditSnapshot(order)
+	if err := ShipOrder(order, trackingNumber); err != nil {
+		return err
+	}
+	es.recordAudit("order", order.ID, mt.AuditStatusChange, before, order)
+	es.dispatchWebhook("order."+order.Status, *order)
+	return nil
+}
+
+func (es *EcommerceService) TransitionOrder(orderID, status string) error {
+	order, err := es.GetOrder(orderID)
+	if err != nil {
+		return err
+	}
+	
+	before := es.auditSnapshot(order)
+	if err := TransitionOrder(order, status); err != nil {
+		return err
+	}
+	es.recordAudit("order", order.ID, mt.AuditStatusChange, before, order)
+	es.dispatchWebhook("order."+order.Status, *order)
+	return nil
 }
 
 // Customer Operations
@@ -884,22 +1292,19 @@ func (es *EcommerceService) CreateCustomer(name, email string) (*Customer, error
 		Metadata:  make(map[string]string),
 	}
 	
-	var errors mt.ValidationErrors
-	mt.ValidateRequired("name", customer.Name, "Customer Name", &errors)
-	mt.ValidateEmail("email", customer.Email, "Email", &errors)
-	
-	if errors.HasErrors() {
+	if errors := ValidateCustomer(customer); errors.HasErrors() {
 		return nil, errors
 	}
 	
 	es.customers = append(es.customers, customer)
+	es.recordAudit("customer", customer.ID, mt.AuditCreate, nil, customer)
 	return &customer, nil
 }
 
 func (es *EcommerceService) GetCustomer(customerID string) (*Customer, error) {
 	for i, customer := range es.customers {
 		if customer.ID == customerID {
-			return &es.customers[i], nil
+			return es.customerAt(i), nil
 		}
 	}
 	return nil, errors.New("customer not found")
@@ -0,0 +1,320 @@
+package mintycart
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+func TestRecalculateCartTotals(t *testing.T) {
+	widget, gadget := editorTestProducts()
+	cart := &Cart{ID: "cart-1"}
+	if err := AddItemToCart(cart, widget, 2); err != nil {
+		t.Fatalf("AddItemToCart: %v", err)
+	}
+	if err := AddItemToCart(cart, gadget, 1); err != nil {
+		t.Fatalf("AddItemToCart: %v", err)
+	}
+
+	want := mt.Money{Amount: 6500, Currency: mt.CurrencyUSD}
+	if cart.Subtotal != want {
+		t.Errorf("Subtotal = %+v, want %+v", cart.Subtotal, want)
+	}
+	if cart.Shipping.Currency != mt.CurrencyUSD || cart.Total.Currency != mt.CurrencyUSD {
+		t.Errorf("Shipping and Total should carry the cart currency: %+v, %+v", cart.Shipping, cart.Total)
+	}
+	if got := cart.Subtotal.Amount + cart.Tax.Amount + cart.Shipping.Amount; cart.Total.Amount != got {
+		t.Errorf("Total = %d, want %d", cart.Total.Amount, got)
+	}
+
+	euroGadget := gadget
+	euroGadget.ID = "p-euro-gadget"
+	euroGadget.Price = mt.NewMoney(40.00, mt.CurrencyEUR)
+	if err := AddItemToCart(cart, euroGadget, 1); err == nil {
+		t.Error("adding an item in another currency should fail")
+	}
+}
+
+func TestCalculateTax(t *testing.T) {
+	tests := []struct {
+		subtotal int64
+		rate     float64
+		want     int64
+	}{
+		{1999, 0.08, 160}, // 159.92
+		{1250, 0.08, 100},
+		{1875, 0.08, 150}, // 150.00
+		{3119, 0.08, 250}, // 249.52
+		{6, 0.25, 2},      // 1.5 rounds half up
+	}
+	for _, tt := range tests {
+		tax, err := CalculateTax(mt.Money{Amount: tt.subtotal, Currency: "usd"}, tt.rate)
+		if want := (mt.Money{Amount: tt.want, Currency: mt.CurrencyUSD}); err != nil || tax != want {
+			t.Errorf("CalculateTax(%d, %v) = %+v, %v, want %+v", tt.subtotal, tt.rate, tax, err, want)
+		}
+	}
+	if _, err := CalculateTax(mt.NewMoney(10, mt.CurrencyUSD), -0.08); err == nil {
+		t.Error("a negative tax rate should fail")
+	}
+}
+
+func TestCartCurrencyCase(t *testing.T) {
+	widget, _ := editorTestProducts()
+	widget.Price = mt.Money{Amount: 1250, Currency: "usd"}
+	cart := &Cart{ID: "cart-1", CustomerID: "c-1"}
+	if err := AddItemToCart(cart, widget, 2); err != nil {
+		t.Fatalf("AddItemToCart with a lower-case currency: %v", err)
+	}
+	if cart.Items[0].Price.Currency != mt.CurrencyUSD || cart.Total.Currency != mt.CurrencyUSD {
+		t.Errorf("currency should be normalized to USD: item %+v, total %+v", cart.Items[0].Price, cart.Total)
+	}
+	if errs := ValidateCart(*cart); errs.HasErrors() {
+		t.Errorf("ValidateCart = %v, want no errors", errs)
+	}
+}
+
+func TestMixedCurrencyCart(t *testing.T) {
+	widget, gadget := editorTestProducts()
+	cart := Cart{ID: "cart-1", CustomerID: "c-1", Items: []CartItem{
+		{ID: "i-1", Product: widget, Quantity: 1, Price: widget.Price, Total: widget.Price},
+		{ID: "i-2", Product: gadget, Quantity: 1, Price: mt.NewMoney(40, mt.CurrencyEUR), Total: mt.NewMoney(40, mt.CurrencyEUR)},
+	}}
+
+	if _, err := CalculateSubtotal(cart.Items); err == nil {
+		t.Error("CalculateSubtotal of a mixed cart should fail")
+	}
+	if _, err := CalculateShipping(cart.Items); err == nil {
+		t.Error("CalculateShipping of a mixed cart should fail")
+	}
+	if err := RecalculateCartTotals(&cart); err == nil {
+		t.Error("RecalculateCartTotals of a mixed cart should fail")
+	}
+	if _, ok := ValidateCart(cart).First("currency"); !ok {
+		t.Errorf("ValidateCart should report the currency mismatch: %v", ValidateCart(cart))
+	}
+}
+
+func TestOrderJSONMoney(t *testing.T) {
+	widget, _ := editorTestProducts()
+	order := Order{
+		ID:    "order-1",
+		Items: []OrderItem{{ProductID: widget.ID, Product: widget, Quantity: 2, Price: widget.Price, Total: widget.Price.Mul(2)}},
+		Total: widget.Price.Mul(2),
+	}
+	data, err := json.Marshal(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"price":{"amount":"12.50","currency":"USD"}`, `"total":{"amount":"25.00","currency":"USD"}`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("order JSON missing %s: %s", want, data)
+		}
+	}
+
+	var back Order
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Total != order.Total || back.Items[0].Product.Price != widget.Price {
+		t.Errorf("round trip = %+v, want %+v", back, order)
+	}
+}
+
+func TestCreateOrderCreditLimit(t *testing.T) {
+	usd := func(amount float64) mt.Money { return mt.NewMoney(amount, mt.CurrencyUSD) }
+	credit := Customer{ID: "cust-1", Name: "Acme", Email: "ap@acme.example", PaymentTerms: "net30", CreditLimit: usd(1000)}
+	cash := Customer{ID: "cust-2", Name: "Ann", Email: "ann@example.com", PaymentTerms: PaymentTermsCash}
+	euroLimit := credit
+	euroLimit.CreditLimit = mt.NewMoney(1000, mt.CurrencyEUR)
+	address := mt.Address{Street1: "1 Main St", City: "Springfield", State: "IL", PostalCode: "62701", Country: "US"}
+
+	tests := []struct {
+		desc     string
+		customer Customer
+		earlier  int // Units already ordered on account
+		quantity int
+		method   string
+		wantErr  string // Empty when the order should be placed
+	}{
+		{"within the limit", credit, 2, 3, PaymentMethodAccount, ""},
+		{"over the limit", credit, 7, 3, PaymentMethodAccount, "$244.00 remaining"}, // $756.00 with tax outstanding
+		{"card orders are not checked", credit, 7, 20, "credit_card", ""},
+		{"cash customer paying by card", cash, 0, 20, "credit_card", ""},
+		{"cash customer on account", cash, 0, 1, PaymentMethodAccount, "not on credit terms"},
+		{"limit in another currency", euroLimit, 0, 1, PaymentMethodAccount, "currency mismatch"},
+	}
+	for _, tt := range tests {
+		es := NewEcommerceService()
+		product, err := es.CreateProduct("Widget", "A widget", "W-1", "tools", usd(100), 1, Inventory{Quantity: 100})
+		if err != nil {
+			t.Fatal(err)
+		}
+		order := func(customer Customer, quantity int, method string) (*Order, error) {
+			cart, _ := es.CreateCart(customer.ID)
+			if err := es.AddToCart(cart.ID, product.ID, quantity); err != nil {
+				t.Fatalf("%s: AddToCart: %v", tt.desc, err)
+			}
+			return es.CreateOrder(cart.ID, customer, address, address, method)
+		}
+		if tt.earlier > 0 {
+			if _, err := order(tt.customer, tt.earlier, PaymentMethodAccount); err != nil {
+				t.Fatalf("%s: earlier order: %v", tt.desc, err)
+			}
+		}
+
+		_, err = order(tt.customer, tt.quantity, tt.method)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: CreateOrder = %v, want success", tt.desc, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%s: CreateOrder = %v, want an error containing %q", tt.desc, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTransitionOrder(t *testing.T) {
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{OrderStatusPending, OrderStatusProcessing, true},
+		{OrderStatusPending, OrderStatusShipped, false},
+		{OrderStatusPending, OrderStatusDelivered, false},
+		{OrderStatusProcessing, OrderStatusShipped, true},
+		{OrderStatusProcessing, OrderStatusCancelled, true},
+		{OrderStatusShipped, OrderStatusCancelled, false},
+		{OrderStatusShipped, OrderStatusDelivered, true},
+		{OrderStatusDelivered, OrderStatusCancelled, false},
+		{OrderStatusDelivered, OrderStatusReturned, true},
+		{OrderStatusCancelled, OrderStatusProcessing, false},
+		{"unknown", OrderStatusProcessing, false},
+	}
+	for _, tt := range tests {
+		order := &Order{Status: tt.from}
+		err := TransitionOrder(order, tt.to)
+		if (err == nil) != tt.ok || CanTransitionOrder(tt.from, tt.to) != tt.ok {
+			t.Errorf("%s -> %s: TransitionOrder = %v, want allowed %v", tt.from, tt.to, err, tt.ok)
+		}
+		if !tt.ok && order.Status != tt.from {
+			t.Errorf("%s -> %s: rejected transition changed the status to %s", tt.from, tt.to, order.Status)
+		}
+	}
+
+	allowed := AllowedTransitions(OrderStatusShipped)
+	allowed[0] = OrderStatusCancelled
+	if AllowedTransitions(OrderStatusShipped)[0] != OrderStatusDelivered {
+		t.Error("AllowedTransitions should return a copy")
+	}
+}
+
+func TestOrderLifecycleTimestamps(t *testing.T) {
+	order := &Order{Status: OrderStatusPending, Total: mt.NewMoney(20, mt.CurrencyUSD)}
+	if err := ShipOrder(order, "TRK-1"); err == nil {
+		t.Fatal("ShipOrder should not ship an unpaid order")
+	}
+	if order.ShippedAt != nil || order.Metadata["tracking_number"] != "" {
+		t.Error("a rejected ShipOrder changed the order")
+	}
+
+	if err := ProcessPayment(order, "credit_card"); err != nil {
+		t.Fatalf("ProcessPayment: %v", err)
+	}
+	if order.Status != OrderStatusProcessing || order.Payment.Status != "completed" {
+		t.Errorf("after payment: status %s, payment %s", order.Status, order.Payment.Status)
+	}
+	if err := ProcessPayment(order, "credit_card"); err == nil {
+		t.Error("ProcessPayment should not charge an order twice")
+	}
+
+	if err := ShipOrder(order, "TRK-1"); err != nil {
+		t.Fatalf("ShipOrder: %v", err)
+	}
+	if order.Status != OrderStatusShipped || order.ShippedAt == nil || order.DeliveredAt != nil || order.Metadata["tracking_number"] != "TRK-1" {
+		t.Errorf("after shipping: %+v", order)
+	}
+	if err := TransitionOrder(order, OrderStatusDelivered); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if order.DeliveredAt == nil || order.DeliveredAt.Before(*order.ShippedAt) {
+		t.Errorf("DeliveredAt = %v, want set after ShippedAt %v", order.DeliveredAt, order.ShippedAt)
+	}
+	if err := TransitionOrder(order, OrderStatusCancelled); err == nil || order.Status != OrderStatusDelivered {
+		t.Errorf("cancelling a delivered order = %v, status %s", err, order.Status)
+	}
+}
+
+func TestMultiLocationInventory(t *testing.T) {
+	product := &Product{ID: "p-1", Price: mt.NewMoney(5, mt.CurrencyUSD), Inventory: Inventory{
+		Quantity:  10,
+		Locations: map[string]int{"b-east": 3, "a-west": 2, "c-north": 5},
+	}}
+	stock := func() map[string]int {
+		out := map[string]int{"total": product.Inventory.Quantity}
+		for location, quantity := range product.Inventory.Locations {
+			out[location] = quantity
+		}
+		return out
+	}
+
+	// Decreases without a location draw on locations in name order
+	if err := UpdateInventory(product, -4); err != nil {
+		t.Fatalf("UpdateInventory: %v", err)
+	}
+	if want := map[string]int{"total": 6, "a-west": 0, "b-east": 1, "c-north": 5}; !reflect.DeepEqual(stock(), want) {
+		t.Errorf("after drawing 4: %v, want %v", stock(), want)
+	}
+	if err := UpdateInventory(product, -7); err == nil || product.Inventory.Quantity != 6 {
+		t.Errorf("drawing more than the total = %v, stock %v", err, stock())
+	}
+
+	// Increases need a location
+	if err := UpdateInventory(product, 2); err == nil || product.Inventory.Quantity != 6 {
+		t.Errorf("increase without a location = %v, stock %v", err, stock())
+	}
+	if err := UpdateInventoryAt(product, "a-west", 2); err != nil || product.Inventory.Locations["a-west"] != 2 || product.Inventory.Quantity != 8 {
+		t.Errorf("increase at a-west = %v, stock %v", err, stock())
+	}
+	if err := UpdateInventoryAt(product, "b-east", -2); err == nil || !strings.Contains(err.Error(), "b-east") {
+		t.Errorf("overdrawing b-east = %v, want an error naming it", err)
+	}
+
+	if got := []int{product.AvailableAt("c-north"), product.AvailableAt(""), product.AvailableAt("d-south")}; !reflect.DeepEqual(got, []int{5, 8, 0}) {
+		t.Errorf("AvailableAt c-north, any, d-south = %v, want [5 8 0]", got)
+	}
+	cart := &Cart{ID: "cart-1", CustomerID: "c-1"}
+	if err := AddItemToCartFrom(cart, *product, 2, "b-east"); err == nil || !strings.Contains(err.Error(), "b-east") {
+		t.Errorf("AddItemToCartFrom beyond b-east's stock = %v", err)
+	}
+	if err := AddItemToCartFrom(cart, *product, 4, "c-north"); err != nil {
+		t.Fatalf("AddItemToCartFrom c-north: %v", err)
+	}
+	if err := AddItemToCartFrom(cart, *product, 1, "a-west"); err != nil || len(cart.Items) != 2 || cart.Items[1].Location != "a-west" {
+		t.Errorf("items from two locations = %v, %+v", err, cart.Items)
+	}
+}
+
+func TestSingleLocationInventory(t *testing.T) {
+	product := &Product{ID: "p-1", Price: mt.NewMoney(5, mt.CurrencyUSD), Inventory: Inventory{Quantity: 5, LowStockLevel: 2}}
+	if err := UpdateInventory(product, -3); err != nil || product.Inventory.Quantity != 2 || product.Inventory.Status != "low_stock" {
+		t.Errorf("decrease = %v, quantity %d (%s)", err, product.Inventory.Quantity, product.Inventory.Status)
+	}
+	if err := UpdateInventory(product, 4); err != nil || product.Inventory.Quantity != 6 {
+		t.Errorf("increase without a location = %v, quantity %d", err, product.Inventory.Quantity)
+	}
+	if err := UpdateInventoryAt(product, "main", 1); err == nil {
+		t.Error("a location should be rejected for a product without locations")
+	}
+	if product.AvailableAt("main") != 6 {
+		t.Errorf("AvailableAt = %d, want the total 6", product.AvailableAt("main"))
+	}
+	cart := &Cart{ID: "cart-1", CustomerID: "c-1"}
+	if err := AddItemToCartFrom(cart, *product, 6, "main"); err != nil {
+		t.Errorf("AddItemToCartFrom falls back to the total: %v", err)
+	}
+	if err := AddItemToCartFrom(cart, *product, 7, ""); err == nil {
+		t.Error("AddItemToCartFrom beyond the total should fail")
+	}
+}
@@ -0,0 +1,231 @@
+package mintycart
+
+import (
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+// =====================================================
+// TRANSACTIONS
+// =====================================================
+
+// EcommerceTx is the service inside a transaction started by InTx. It offers
+// every service operation; changes made through it are undone together if
+// the transaction fails.
+type EcommerceTx struct {
+	*EcommerceService
+}
+
+// ecommerceSnapshot holds the service's collections as they were when a
+// transaction began, and pending, the number of deferred notifications
+// queued before it.
+type ecommerceSnapshot struct {
+	products   cowSnapshot[Product]
+	categories cowSnapshot[Category]
+	carts      cowSnapshot[Cart]
+	orders     cowSnapshot[Order]
+	customers  cowSnapshot[Customer]
+	pending    int
+}
+
+// cowSnapshot is a copy-on-write snapshot of one collection: the slice
+// itself, so rollback restores it in place and pointers handed out earlier
+// stay valid, and copies of the elements handed out for change since. Only
+// those elements are copied, the first time they are handed out.
+type cowSnapshot[T any] struct {
+	items []T
+	saved map[int]T
+}
+
+// save keeps a copy of items[i] unless one is kept already. Elements added
+// since the snapshot need none, as rollback drops them.
+func (s *cowSnapshot[T]) save(items []T, i int, clone func(T) T) {
+	if i >= len(s.items) {
+		return
+	}
+	if _, ok := s.saved[i]; ok {
+		return
+	}
+	if s.saved == nil {
+		s.saved = make(map[int]T)
+	}
+	s.saved[i] = clone(items[i])
+}
+
+// restore puts the saved elements back and returns the slice.
+func (s *cowSnapshot[T]) restore() []T {
+	for i, item := range s.saved {
+		s.items[i] = item
+	}
+	return s.items
+}
+
+// InTx runs fn as one all-or-nothing operation: if fn returns an error or
+// panics, every product, category, cart, order and customer change it made
+// is rolled back and the error returned. Changes are tracked through the
+// pointers tx's getters hand out, such as tx.GetProduct, so entities changed
+// inside fn must be fetched inside fn. Audit events and webhooks raised
+// inside fn are held back until the outermost transaction commits, so
+// receivers never hear of changes that were undone. Transactions nest; a
+// failing inner transaction rolls back only its own changes.
+//
+//	err := es.InTx(func(tx *mintycart.EcommerceTx) error {
+//	    if err := tx.UpdateProductInventory(a, -1); err != nil {
+//	        return err
+//	    }
+//	    return tx.UpdateProductInventory(b, -1)
+//	})
+//
+// The service is not safe for concurrent use, inside a transaction or out.
+func (es *EcommerceService) InTx(fn func(tx *EcommerceTx) error) error {
+	snapshot := es.snapshot()
+	es.txs = append(es.txs, snapshot)
+	committed := false
+	defer func() {
+		es.txs = es.txs[:len(es.txs)-1]
+		if !committed {
+			es.restore(snapshot)
+		}
+		if len(es.txs) == 0 {
+			pending := es.pending
+			es.pending = nil
+			if committed {
+				for _, notify := range pending {
+					notify()
+				}
+			}
+		}
+	}()
+
+	if err := fn(&EcommerceTx{es}); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// notify runs a notification now, or when the current transaction commits.
+func (es *EcommerceService) notify(f func()) {
+	if len(es.txs) > 0 {
+		es.pending = append(es.pending, f)
+		return
+	}
+	f()
+}
+
+// snapshot captures the collections for rollback.
+func (es *EcommerceService) snapshot() *ecommerceSnapshot {
+	return &ecommerceSnapshot{
+		products:   cowSnapshot[Product]{items: es.products},
+		categories: cowSnapshot[Category]{items: es.categories},
+		carts:      cowSnapshot[Cart]{items: es.carts},
+		orders:     cowSnapshot[Order]{items: es.orders},
+		customers:  cowSnapshot[Customer]{items: es.customers},
+		pending:    len(es.pending),
+	}
+}
+
+// restore rolls the collections back to a snapshot and drops the
+// notifications queued since.
+func (es *EcommerceService) restore(s *ecommerceSnapshot) {
+	es.products = s.products.restore()
+	es.categories = s.categories.restore()
+	es.carts = s.carts.restore()
+	es.orders = s.orders.restore()
+	es.customers = s.customers.restore()
+	es.pending = es.pending[:s.pending]
+}
+
+// productAt returns the product at i for the caller to change, saving it
+// first for the open transactions. The getters handing out pointers into
+// the collections use these.
+func (es *EcommerceService) productAt(i int) *Product {
+	for _, tx := range es.txs {
+		tx.products.save(es.products, i, cloneProduct)
+	}
+	return &es.products[i]
+}
+
+// cartAt is productAt for carts.
+func (es *EcommerceService) cartAt(i int) *Cart {
+	for _, tx := range es.txs {
+		tx.carts.save(es.carts, i, cloneCart)
+	}
+	return &es.carts[i]
+}
+
+// orderAt is productAt for orders.
+func (es *EcommerceService) orderAt(i int) *Order {
+	for _, tx := range es.txs {
+		tx.orders.save(es.orders, i, cloneOrder)
+	}
+	return &es.orders[i]
+}
+
+// customerAt is productAt for customers.
+func (es *EcommerceService) customerAt(i int) *Customer {
+	for _, tx := range es.txs {
+		tx.customers.save(es.customers, i, cloneCustomer)
+	}
+	return &es.customers[i]
+}
+
+func cloneProduct(p Product) Product {
+	if p.Images != nil {
+		p.Images = append([]ProductImage(nil), p.Images...)
+	}
+	p.Metadata = cloneStrings(p.Metadata)
+	if p.Inventory.Locations != nil {
+		locations := make(map[string]int, len(p.Inventory.Locations))
+		for location, quantity := range p.Inventory.Locations {
+			locations[location] = quantity
+		}
+		p.Inventory.Locations = locations
+	}
+	return p
+}
+
+func cloneCart(c Cart) Cart {
+	if c.Items != nil {
+		items := make([]CartItem, len(c.Items))
+		for i, item := range c.Items {
+			item.Product = cloneProduct(item.Product)
+			items[i] = item
+		}
+		c.Items = items
+	}
+	c.Metadata = cloneStrings(c.Metadata)
+	return c
+}
+
+func cloneOrder(o Order) Order {
+	if o.Items != nil {
+		items := make([]OrderItem, len(o.Items))
+		for i, item := range o.Items {
+			item.Product = cloneProduct(item.Product)
+			items[i] = item
+		}
+		o.Items = items
+	}
+	o.Customer = cloneCustomer(o.Customer)
+	o.Metadata = cloneStrings(o.Metadata)
+	return o
+}
+
+func cloneCustomer(c Customer) Customer {
+	if c.Addresses != nil {
+		c.Addresses = append([]mt.Address(nil), c.Addresses...)
+	}
+	c.Metadata = cloneStrings(c.Metadata)
+	return c
+}
+
+func cloneStrings(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	clone := make(map[string]string, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
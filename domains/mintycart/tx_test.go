@@ -0,0 +1,135 @@
+package mintycart
+
+import (
+	"errors"
+	"testing"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+func TestCreateOrderRollsBackInventory(t *testing.T) {
+	es := NewEcommerceService()
+	widget, _ := es.CreateProduct("Widget", "A widget", "W-1", "tools",
+		mt.NewMoney(10, mt.CurrencyUSD), 1, Inventory{Quantity: 5, Locations: map[string]int{"main": 5}})
+	bolt, _ := es.CreateProduct("Bolt", "A bolt", "B-1", "tools",
+		mt.NewMoney(1, mt.CurrencyUSD), 1, Inventory{Quantity: 1})
+	cart, _ := es.CreateCart("cust-1")
+	if err := es.AddToCart(cart.ID, widget.ID, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := es.AddToCart(cart.ID, bolt.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+	// The last bolt sells elsewhere before checkout
+	if err := es.UpdateProductInventory(bolt.ID, -1); err != nil {
+		t.Fatal(err)
+	}
+
+	var events []mt.AuditEvent
+	es.SetAuditSink(mt.AuditSinkFunc(func(e mt.AuditEvent) { events = append(events, e) }))
+	address := mt.Address{Street1: "1 Main St", City: "Springfield", State: "IL", PostalCode: "62701", Country: "US"}
+	_, err := es.CreateOrder(cart.ID, Customer{ID: "cust-1", Name: "Ann", Email: "ann@example.com"},
+		address, address, "credit_card")
+	if err == nil {
+		t.Fatal("CreateOrder should fail when a product is out of stock")
+	}
+
+	widget, _ = es.GetProduct(widget.ID)
+	if widget.Inventory.Quantity != 5 || widget.Inventory.Locations["main"] != 5 {
+		t.Errorf("widget stock = %d (%v), want 5 restored", widget.Inventory.Quantity, widget.Inventory.Locations)
+	}
+	cart, _ = es.GetCart(cart.ID)
+	if cart.Status != mt.StatusActive || len(es.GetAllOrders()) != 0 {
+		t.Errorf("cart status %q with %d orders, want an active cart and no orders", cart.Status, len(es.GetAllOrders()))
+	}
+	if len(events) != 0 {
+		t.Errorf("rolled-back changes were audited: %+v", events)
+	}
+}
+
+func TestInTxNested(t *testing.T) {
+	es := NewEcommerceService()
+	product, _ := es.CreateProduct("Widget", "A widget", "W-1", "tools",
+		mt.NewMoney(10, mt.CurrencyUSD), 1, Inventory{Quantity: 5})
+	product, _ = es.GetProduct(product.ID)
+	var events []mt.AuditEvent
+	es.SetAuditSink(mt.AuditSinkFunc(func(e mt.AuditEvent) { events = append(events, e) }))
+
+	errInner := errors.New("inner failed")
+	err := es.InTx(func(tx *EcommerceTx) error {
+		if err := tx.UpdateProductInventory(product.ID, -1); err != nil {
+			return err
+		}
+		err := tx.InTx(func(tx *EcommerceTx) error {
+			if _, err := tx.CreateCustomer("Bob", "bob@example.com"); err != nil {
+				return err
+			}
+			tx.UpdateProductInventory(product.ID, -3)
+			return errInner
+		})
+		if !errors.Is(err, errInner) {
+			t.Errorf("inner InTx = %v", err)
+		}
+		if len(events) != 0 {
+			t.Error("audit events should wait for the outer commit")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if product.Inventory.Quantity != 4 || len(es.GetAllCustomers()) != 0 {
+		t.Errorf("stock %d with %d customers, want the outer change only", product.Inventory.Quantity, len(es.GetAllCustomers()))
+	}
+	if len(events) != 1 || events[0].EntityID != product.ID {
+		t.Errorf("got events %+v, want the outer inventory update", events)
+	}
+
+	func() {
+		defer func() { recover() }()
+		es.InTx(func(tx *EcommerceTx) error {
+			tx.UpdateProductInventory(product.ID, -4)
+			panic("boom")
+		})
+	}()
+	if product.Inventory.Quantity != 4 {
+		t.Errorf("stock after panic = %d, want 4", product.Inventory.Quantity)
+	}
+}
+
+func TestInTxCopyOnWrite(t *testing.T) {
+	es := NewEcommerceService()
+	for _, sku := range []string{"A-1", "B-1", "C-1"} {
+		es.CreateProduct("Part "+sku, "A part", sku, "tools", mt.NewMoney(5, mt.CurrencyUSD), 1,
+			Inventory{Quantity: 10, Locations: map[string]int{"main": 10}})
+	}
+	first := es.GetAllProducts()[0].ID
+
+	errFail := errors.New("fail")
+	err := es.InTx(func(tx *EcommerceTx) error {
+		if err := tx.UpdateProductInventoryAt(first, "main", -4); err != nil {
+			return err
+		}
+		if saved := len(es.txs[0].products.saved); saved != 1 || es.txs[0].carts.saved != nil {
+			t.Errorf("snapshot copied %d products and carts %v, want only the changed product", saved, es.txs[0].carts.saved)
+		}
+		// Grow the slice past its capacity, then change the product again
+		for i := 0; i < 8; i++ {
+			tx.CreateProduct("Extra", "An extra", "X", "tools", mt.NewMoney(1, mt.CurrencyUSD), 1, Inventory{Quantity: 1})
+		}
+		if err := tx.UpdateProductInventoryAt(first, "main", -1); err != nil {
+			return err
+		}
+		return errFail
+	})
+	if !errors.Is(err, errFail) {
+		t.Fatalf("InTx = %v", err)
+	}
+
+	product, _ := es.GetProduct(first)
+	if len(es.GetAllProducts()) != 3 || product.Inventory.Quantity != 10 || product.Inventory.Locations["main"] != 10 {
+		t.Errorf("%d products, stock %d (%v) after rollback, want 3 and 10", len(es.GetAllProducts()),
+			product.Inventory.Quantity, product.Inventory.Locations)
+	}
+}
@@ -0,0 +1,94 @@
+package mintycart
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	mh "github.com/ha1tch/minty/mintyhook"
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+func TestServiceWebhooks(t *testing.T) {
+	const secret = "whsec_test"
+	var (
+		mu        sync.Mutex
+		received  []mt.WebhookEvent
+		failFirst = true
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failFirst {
+			failFirst = false
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := mh.Verify(secret, r.Header.Get(mh.SignatureHeader), body, time.Minute); err != nil {
+			t.Errorf("signature: %v", err)
+		}
+		if err := mh.Verify("wrong", r.Header.Get(mh.SignatureHeader), body, time.Minute); err == nil {
+			t.Error("signature verified with the wrong secret")
+		}
+		var event mt.WebhookEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Errorf("envelope: %v", err)
+		}
+		if r.Header.Get(mh.EventHeader) != event.Type || r.Header.Get(mh.IDHeader) != event.ID {
+			t.Errorf("headers do not match envelope %+v: %v", event, r.Header)
+		}
+		received = append(received, event)
+	}))
+	defer server.Close()
+
+	var failures []error
+	queue := mh.NewQueue(&mh.Endpoint{
+		URL:    server.URL,
+		Secret: secret,
+		Retry:  mh.Retry{Attempts: 2, Backoff: time.Millisecond},
+	}, 10, 1, func(e mt.WebhookEvent, err error) { failures = append(failures, err) })
+
+	es := NewEcommerceService()
+	es.SetWebhookDispatcher(queue)
+	product, err := es.CreateProduct("Widget", "A widget", "W-1", "tools",
+		mt.NewMoney(10, mt.CurrencyUSD), 1, Inventory{Quantity: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cart, err := es.CreateCart("cust-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := es.AddToCart(cart.ID, product.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+	address := mt.Address{Street1: "1 Main St", City: "Springfield", State: "IL", PostalCode: "62701", Country: "US"}
+	order, err := es.CreateOrder(cart.ID, Customer{ID: "cust-1", Name: "Ann", Email: "ann@example.com"},
+		address, address, "credit_card")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := es.ShipOrder(order.ID, "TRK-1"); err != nil {
+		t.Fatal(err)
+	}
+	queue.Close()
+
+	if len(failures) != 0 {
+		t.Fatalf("deliveries failed: %v", failures)
+	}
+	if len(received) != 2 || received[0].Type != "order.created" || received[1].Type != "order.shipped" {
+		t.Fatalf("received %+v, want order.created then order.shipped", received)
+	}
+	data, _ := received[1].Data.(map[string]interface{})
+	if data["id"] != order.ID || data["status"] != OrderStatusShipped || received[1].ID == received[0].ID {
+		t.Errorf("unexpected shipped event: %+v", received[1])
+	}
+	if err := queue.Dispatch("order.created", order); err != mh.ErrQueueClosed {
+		t.Errorf("Dispatch after Close = %v", err)
+	}
+}
@@ -3,12 +3,14 @@
 package mintyfin
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
 	"strings"
 	"time"
 
+	mh "github.com/ha1tch/minty/mintyhook"
 	mt "github.com/ha1tch/minty/mintytypes"
 )
 
@@ -76,7 +78,7 @@ type Customer struct {
 	Addresses      []mt.Address  `json:"addresses"`
 	AccountNumber  string             `json:"account_number"`
 	CreditRating   string             `json:"credit_rating"`
-	PaymentTerms   string             `json:"payment_terms"`
+	PaymentTerms   string             `json:"payment_terms"` // e.g. net30; empty or "cash" for cash customers
 	CreditLimit    mt.Money      `json:"credit_limit"`
 	TotalSpent     mt.Money      `json:"total_spent"`
 	CreatedAt      time.Time          `json:"created_at"`
@@ -121,6 +123,12 @@ func (c Customer) GetShippingAddress() mt.Address {
 	return c.GetPrimaryAddress()
 }
 
+// OnCreditTerms reports whether the customer is invoiced on credit, and so
+// subject to their CreditLimit. Cash customers are not credit checked.
+func (c Customer) OnCreditTerms() bool {
+	return c.PaymentTerms != "" && c.PaymentTerms != PaymentTermsCash
+}
+
 // Portfolio represents an investment portfolio
 type Portfolio struct {
 	ID          string           `json:"id"`
@@ -146,6 +154,9 @@ type Position struct {
 	UpdatedAt time.Time     `json:"updated_at"`
 }
 
+// PaymentTermsCash marks customers who pay up front rather than on credit
+const PaymentTermsCash = "cash"
+
 // =====================================================
 // STATUS IMPLEMENTATIONS
 // =====================================================
@@ -250,6 +261,9 @@ func (s TransactionStatus) GetDescription() string {
 func CalculateAccountBalance(transactions []Transaction) mt.Money {
 	var balance mt.Money
 	for _, txn := range transactions {
+		if balance.Currency == "" {
+			balance.Currency = txn.Amount.Currency
+		}
 		if txn.Type == "credit" {
 			balance.Amount += txn.Amount.Amount
 		} else if txn.Type == "debit" {
@@ -280,7 +294,7 @@ func ValidateAccount(account Account) mt.ValidationErrors {
 		}
 	}
 	
-	if account.Balance.Amount < 0 && account.Type != "credit" {
+	if account.Balance.IsNegative() && account.Type != "credit" {
 		errors.Add("balance", "Account balance cannot be negative for this account type")
 	}
 	
@@ -294,7 +308,11 @@ func ProcessAccountTransaction(account *Account, transaction Transaction) error
 	}
 	
 	if transaction.Type == "debit" {
-		if account.Balance.Amount < transaction.Amount.Amount && account.Type != "credit" {
+		insufficient, err := account.Balance.LessThan(transaction.Amount)
+		if err != nil {
+			return err
+		}
+		if insufficient && account.Type != "credit" {
 			return errors.New("insufficient funds for debit transaction")
 		}
 		account.Balance.Amount -= transaction.Amount.Amount
@@ -353,6 +371,7 @@ func ValidateInvoice(invoice Invoice) mt.ValidationErrors {
 	var errors mt.ValidationErrors
 	
 	mt.ValidateRequired("number", invoice.Number, "Invoice Number", &errors)
+	mt.ValidateLength("number", invoice.Number, 1, 32, "Invoice Number", &errors)
 	mt.ValidateRequired("customer.name", invoice.Customer.Name, "Customer Name", &errors)
 	mt.ValidateMoneyAmount("amount", invoice.Amount, "Amount", &errors)
 	
@@ -364,15 +383,8 @@ func ValidateInvoice(invoice Invoice) mt.ValidationErrors {
 	
 	if len(invoice.Items) == 0 {
 		errors.Add("items", "Invoice must have at least one item")
-	}
-	
-	// Validate that invoice amount matches sum of items
-	var itemsTotal mt.Money
-	for _, item := range invoice.Items {
-		itemsTotal.Amount += item.Total.Amount
-	}
-	
-	if itemsTotal.Amount != invoice.Amount.Amount {
+	} else if matches, err := CalculateInvoiceTotal(invoice.Items).Equal(invoice.Amount); err != nil || !matches {
+		// The invoice amount must match the sum of its items, in the same currency
 		errors.Add("amount", "Invoice amount must match sum of item totals")
 	}
 	
@@ -390,7 +402,11 @@ func ProcessPayment(invoice *Invoice, paymentAmount mt.Money) error {
 			paymentAmount.Currency, invoice.Amount.Currency)
 	}
 	
-	if paymentAmount.Amount != invoice.Amount.Amount {
+	equal, err := paymentAmount.Equal(invoice.Amount)
+	if err != nil {
+		return err
+	}
+	if !equal {
 		return errors.New("payment amount must match invoice amount")
 	}
 	
@@ -405,6 +421,9 @@ func ProcessPayment(invoice *Invoice, paymentAmount mt.Money) error {
 func CalculateInvoiceTotal(items []InvoiceItem) mt.Money {
 	var total mt.Money
 	for _, item := range items {
+		if total.Currency == "" {
+			total.Currency = item.Total.Currency
+		}
 		total.Amount += item.Total.Amount
 	}
 	return total
@@ -420,6 +439,9 @@ type FinanceService struct {
 	transactions []Transaction
 	invoices     []Invoice
 	customers    []Customer
+	audit        mt.AuditSink
+	auditCtx     context.Context
+	webhooks     mh.Dispatcher
 }
 
 // NewFinanceService creates a new finance service
@@ -432,6 +454,59 @@ func NewFinanceService() *FinanceService {
 	}
 }
 
+// Audit Operations
+
+// SetAuditSink sets the sink notified of create, update and status-change
+// operations. A nil sink disables auditing.
+func (fs *FinanceService) SetAuditSink(sink mt.AuditSink) {
+	fs.audit = sink
+}
+
+// SetAuditContext sets the context audit events take their actor from (see
+// mt.ContextWithActor), typically once per request.
+func (fs *FinanceService) SetAuditContext(ctx context.Context) {
+	fs.auditCtx = ctx
+}
+
+// auditSnapshot captures an entity before a change, when auditing is enabled
+func (fs *FinanceService) auditSnapshot(entity interface{}) map[string]interface{} {
+	if fs.audit == nil {
+		return nil
+	}
+	return mt.AuditSnapshot(entity)
+}
+
+// recordAudit sends an audit event to the sink, if one is set
+func (fs *FinanceService) recordAudit(entityType, entityID, action string, before, after interface{}) {
+	if fs.audit == nil {
+		return
+	}
+	ctx := fs.auditCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	fs.audit.Record(mt.NewAuditEvent(ctx, entityType, entityID, action, before, after))
+}
+
+// Webhook Operations
+
+// SetWebhookDispatcher sets the dispatcher notified of invoice events:
+// "invoice.created" and "invoice.paid". The payload is the invoice. A nil
+// dispatcher disables webhooks. Dispatch errors do not fail the operation;
+// deliver through a mh.Queue to keep receivers out of the request path
+// and to report failures.
+func (fs *FinanceService) SetWebhookDispatcher(dispatcher mh.Dispatcher) {
+	fs.webhooks = dispatcher
+}
+
+// dispatchWebhook sends an event to the dispatcher, if one is set
+func (fs *FinanceService) dispatchWebhook(event string, payload interface{}) {
+	if fs.webhooks == nil {
+		return
+	}
+	_ = fs.webhooks.Dispatch(event, payload)
+}
+
 // Account Operations
 
 func (fs *FinanceService) CreateAccount(name, accountType string, initialBalance mt.Money, customerID string) (*Account, error) {
@@ -451,6 +526,7 @@ func (fs *FinanceService) CreateAccount(name, accountType string, initialBalance
 	}
 	
 	fs.accounts = append(fs.accounts, account)
+	fs.recordAudit("account", account.ID, mt.AuditCreate, nil, account)
 	return &account, nil
 }
 
@@ -479,8 +555,10 @@ func (fs *FinanceService) UpdateAccountBalance(accountID string, transactions []
 		return err
 	}
 	
+	before := fs.auditSnapshot(account)
 	account.Balance = CalculateAccountBalance(transactions)
 	account.UpdatedAt = time.Now()
+	fs.recordAudit("account", account.ID, mt.AuditUpdate, before, account)
 	return nil
 }
 
@@ -494,6 +572,35 @@ func (fs *FinanceService) GetTotalBalance() mt.Money {
 	return total
 }
 
+// GetTotalBalancesByCurrency sums active account balances per currency.
+func (fs *FinanceService) GetTotalBalancesByCurrency() map[string]mt.Money {
+	totals := make(map[string]mt.Money)
+	for _, account := range fs.accounts {
+		if account.Status != mt.StatusActive {
+			continue
+		}
+		total := totals[account.Balance.Currency]
+		total.Currency = account.Balance.Currency
+		total.Amount += account.Balance.Amount
+		totals[account.Balance.Currency] = total
+	}
+	return totals
+}
+
+// GetTotalBalanceIn rolls active account balances up into one reporting
+// currency. Each currency's total is converted once, then summed.
+func (fs *FinanceService) GetTotalBalanceIn(currency string, rates mt.ExchangeRateSource) (mt.Money, error) {
+	total := mt.Money{Currency: strings.ToUpper(currency)}
+	for _, subtotal := range fs.GetTotalBalancesByCurrency() {
+		converted, err := subtotal.Convert(total.Currency, rates, mt.RoundHalfUp)
+		if err != nil {
+			return mt.Money{}, err
+		}
+		total.Amount += converted.Amount
+	}
+	return total, nil
+}
+
 func (fs *FinanceService) GetAllAccounts() []Account {
 	return fs.accounts
 }
@@ -528,11 +635,14 @@ func (fs *FinanceService) CreateTransaction(accountID string, amount mt.Money,
 		return nil, err
 	}
 	
+	before := fs.auditSnapshot(account)
 	if err := ProcessAccountTransaction(account, transaction); err != nil {
 		return nil, err
 	}
+	fs.recordAudit("account", account.ID, mt.AuditUpdate, before, account)
 	
 	fs.transactions = append(fs.transactions, transaction)
+	fs.recordAudit("transaction", transaction.ID, mt.AuditCreate, nil, transaction)
 	return &transaction, nil
 }
 
@@ -593,7 +703,19 @@ func (fs *FinanceService) CreateInvoice(number string, customer Customer,
 		return nil, errors
 	}
 	
+	if customer.OnCreditTerms() {
+		outstanding, err := fs.OutstandingBalance(customer.ID, customer.CreditLimit.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if err := mt.CheckCredit(customer.CreditLimit, outstanding, invoice.Amount); err != nil {
+			return nil, err
+		}
+	}
+	
 	fs.invoices = append(fs.invoices, invoice)
+	fs.recordAudit("invoice", invoice.ID, mt.AuditCreate, nil, invoice)
+	fs.dispatchWebhook("invoice.created", invoice)
 	return &invoice, nil
 }
 
@@ -611,12 +733,31 @@ func (fs *FinanceService) GetAllInvoices() []Invoice {
 	return fs.invoices
 }
 
+// OutstandingBalance sums the customer's unpaid invoices in the given currency.
+// It returns an error if an unpaid invoice is in another currency.
+func (fs *FinanceService) OutstandingBalance(customerID, currency string) (mt.Money, error) {
+	outstanding := mt.Money{Currency: currency}
+	for _, invoice := range fs.invoices {
+		if invoice.Customer.ID != customerID || invoice.Status == "paid" || invoice.Status == mt.StatusCancelled {
+			continue
+		}
+		var err error
+		if outstanding, err = outstanding.Add(invoice.Amount); err != nil {
+			return mt.Money{}, fmt.Errorf("outstanding balance for customer %s: %w", customerID, err)
+		}
+	}
+	return outstanding, nil
+}
+
 func (fs *FinanceService) PayInvoice(invoiceID string, paymentAmount mt.Money) error {
 	for i, invoice := range fs.invoices {
 		if invoice.ID == invoiceID {
+			before := fs.auditSnapshot(invoice)
 			if err := ProcessPayment(&fs.invoices[i], paymentAmount); err != nil {
 				return err
 			}
+			fs.recordAudit("invoice", invoice.ID, mt.AuditStatusChange, before, fs.invoices[i])
+			fs.dispatchWebhook("invoice."+fs.invoices[i].Status, fs.invoices[i])
 			return nil
 		}
 	}
@@ -0,0 +1,152 @@
+package mintyfin
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	mh "github.com/ha1tch/minty/mintyhook"
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+// invoiceItems returns a single line costing amount.
+func invoiceItems(amount mt.Money) []InvoiceItem {
+	return []InvoiceItem{{ID: "item-1", Description: "Consulting", Quantity: 1, UnitPrice: amount, Total: amount}}
+}
+
+func TestCreateInvoiceCreditLimit(t *testing.T) {
+	due := time.Now().AddDate(0, 0, 30)
+	usd := func(amount float64) mt.Money { return mt.NewMoney(amount, mt.CurrencyUSD) }
+	credit := Customer{ID: "cust-1", Name: "Acme", PaymentTerms: "net30", CreditLimit: usd(1000)}
+	cash := Customer{ID: "cust-2", Name: "Walk-in", PaymentTerms: PaymentTermsCash}
+	euroLimit := credit
+	euroLimit.CreditLimit = mt.NewMoney(1000, mt.CurrencyEUR)
+
+	tests := []struct {
+		desc     string
+		customer Customer
+		existing []mt.Money // Unpaid invoices already raised
+		paid     bool       // Pay the existing invoices first
+		amount   mt.Money
+		wantErr  string // Empty when the invoice should be created
+	}{
+		{"within the limit", credit, []mt.Money{usd(800)}, false, usd(200), ""},
+		{"over the limit", credit, []mt.Money{usd(800)}, false, usd(300), "$200.00 remaining"},
+		{"nothing remaining", credit, []mt.Money{usd(600), usd(600)}, false, usd(1), "$0.00 remaining"},
+		{"paid invoices free credit", credit, []mt.Money{usd(900)}, true, usd(900), ""},
+		{"cash customer is not checked", cash, []mt.Money{usd(5000)}, false, usd(5000), ""},
+		{"terms left empty count as cash", Customer{ID: "cust-3", Name: "Old"}, nil, false, usd(5000), ""},
+		{"limit in another currency", euroLimit, nil, false, usd(10), "currency mismatch"},
+	}
+	for _, tt := range tests {
+		fs := NewFinanceService()
+		existing := tt.customer
+		existing.PaymentTerms = PaymentTermsCash // Raise the prior invoices unchecked
+		for i, amount := range tt.existing {
+			invoice, err := fs.CreateInvoice(fmt.Sprintf("INV-%d", i+1), existing, invoiceItems(amount), due)
+			if err != nil {
+				t.Fatalf("%s: existing invoice: %v", tt.desc, err)
+			}
+			if tt.paid {
+				if err := fs.PayInvoice(invoice.ID, amount); err != nil {
+					t.Fatalf("%s: PayInvoice: %v", tt.desc, err)
+				}
+			}
+		}
+
+		_, err := fs.CreateInvoice("INV-NEW", tt.customer, invoiceItems(tt.amount), due)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: CreateInvoice = %v, want success", tt.desc, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%s: CreateInvoice = %v, want an error containing %q", tt.desc, err, tt.wantErr)
+		}
+		if created := len(fs.GetAllInvoices()) - len(tt.existing); (created == 1) != (tt.wantErr == "") {
+			t.Errorf("%s: %d invoices created", tt.desc, created)
+		}
+	}
+}
+
+func TestOutstandingBalance(t *testing.T) {
+	fs := NewFinanceService()
+	customer := Customer{ID: "cust-1", Name: "Acme"}
+	due := time.Now().AddDate(0, 0, 30)
+	for _, amount := range []mt.Money{mt.NewMoney(100, mt.CurrencyUSD), mt.NewMoney(50, mt.CurrencyUSD)} {
+		if _, err := fs.CreateInvoice("INV-1", customer, invoiceItems(amount), due); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fs.CreateInvoice("INV-2", Customer{ID: "cust-2", Name: "Other"}, invoiceItems(mt.NewMoney(70, mt.CurrencyUSD)), due)
+
+	if got, err := fs.OutstandingBalance("cust-1", mt.CurrencyUSD); err != nil || got != mt.NewMoney(150, mt.CurrencyUSD) {
+		t.Errorf("OutstandingBalance = %+v, %v, want $150.00", got, err)
+	}
+	if _, err := fs.OutstandingBalance("cust-1", mt.CurrencyEUR); err == nil {
+		t.Error("OutstandingBalance in another currency than the invoices should fail")
+	}
+}
+
+func TestProcessPayment(t *testing.T) {
+	amount := mt.NewMoney(120, mt.CurrencyUSD)
+	tests := []struct {
+		desc    string
+		payment mt.Money
+		ok      bool
+	}{
+		{"exact amount", amount, true},
+		{"short payment", mt.NewMoney(100, mt.CurrencyUSD), false},
+		{"other currency", mt.NewMoney(120, mt.CurrencyEUR), false},
+	}
+	for _, tt := range tests {
+		invoice := &Invoice{Amount: amount, Status: mt.StatusPending}
+		if err := ProcessPayment(invoice, tt.payment); (err == nil) != tt.ok || (invoice.Status == "paid") != tt.ok {
+			t.Errorf("%s: ProcessPayment = %v, status %s", tt.desc, err, invoice.Status)
+		}
+	}
+	paid := &Invoice{Amount: amount, Status: "paid"}
+	if err := ProcessPayment(paid, amount); err == nil {
+		t.Error("paying a paid invoice should fail")
+	}
+}
+
+func TestInvoiceWebhooks(t *testing.T) {
+	var events []string
+	var paid Invoice
+	fs := NewFinanceService()
+	fs.SetWebhookDispatcher(mh.DispatcherFunc(func(event string, payload interface{}) error {
+		events = append(events, event)
+		if event == "invoice.paid" {
+			paid, _ = payload.(Invoice)
+		}
+		return errors.New("receiver down") // Must not fail the operation
+	}))
+
+	amount := mt.NewMoney(120, mt.CurrencyUSD)
+	invoice, err := fs.CreateInvoice("INV-1", Customer{ID: "cust-1", Name: "Acme"}, invoiceItems(amount), time.Now().AddDate(0, 0, 30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.PayInvoice(invoice.ID, mt.NewMoney(100, mt.CurrencyUSD)); err == nil {
+		t.Fatal("a short payment should fail")
+	}
+	if err := fs.PayInvoice(invoice.ID, amount); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"invoice.created", "invoice.paid"}; !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+	if paid.ID != invoice.ID || paid.Status != "paid" {
+		t.Errorf("invoice.paid payload = %+v, want the paid invoice", paid)
+	}
+
+	fs.SetWebhookDispatcher(nil)
+	if _, err := fs.CreateInvoice("INV-2", Customer{ID: "cust-1", Name: "Acme"}, invoiceItems(amount), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Errorf("dispatched %v after the dispatcher was removed", events[2:])
+	}
+}
@@ -0,0 +1,305 @@
+// Package mintyins provides pure insurance quoting logic for the Minty System.
+// This package contains NO UI dependencies and focuses solely on business logic.
+package mintyins
+
+import (
+	"errors"
+	"fmt"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+// =====================================================
+// PURE BUSINESS TYPES (No UI Dependencies)
+// =====================================================
+
+// Coverage types
+const (
+	CoverageAuto     = "auto"
+	CoverageHome     = "home"
+	CoverageLife     = "life"
+	CoverageBusiness = "business"
+)
+
+// Health statuses for life cover
+const (
+	HealthExcellent = "excellent"
+	HealthGood      = "good"
+	HealthFair      = "fair"
+	HealthPoor      = "poor"
+)
+
+// Plan is an insurance product at one tier, priced per year.
+type Plan struct {
+	ID            string   `json:"id"`
+	CoverageType  string   `json:"coverage_type"`
+	Name          string   `json:"name"`
+	Tier          string   `json:"tier"`           // basic, standard, premium
+	BasePrice     mt.Money `json:"base_price"`     // Annual premium before adjustments
+	Deductible    mt.Money `json:"deductible"`     // Amount the insured pays per claim
+	CoverageLimit mt.Money `json:"coverage_limit"` // Maximum payout
+}
+
+// QuoteInput holds the applicant's answers. Only the fields for the plan's
+// coverage type are used.
+type QuoteInput struct {
+	CoverageType string `json:"coverage_type"`
+
+	// Auto
+	DrivingYears  int `json:"driving_years,omitempty"`
+	AccidentCount int `json:"accident_count,omitempty"` // In the last five years
+
+	// Home
+	PropertyValue mt.Money `json:"property_value,omitempty"`
+	YearBuilt     int      `json:"year_built,omitempty"`
+	HasPool       bool     `json:"has_pool,omitempty"`
+	HasAlarm      bool     `json:"has_alarm,omitempty"`
+
+	// Life
+	HealthStatus   string   `json:"health_status,omitempty"`
+	Smoker         bool     `json:"smoker,omitempty"`
+	CoverageAmount mt.Money `json:"coverage_amount,omitempty"`
+
+	// Business
+	Employees   int  `json:"employees,omitempty"`
+	HasPremises bool `json:"has_premises,omitempty"`
+}
+
+// Adjustment kinds
+const (
+	AdjustmentBase      = "base"
+	AdjustmentRisk      = "risk"
+	AdjustmentDiscount  = "discount"
+	AdjustmentSurcharge = "surcharge"
+)
+
+// Adjustment is one line of a premium breakdown. Amount is negative for
+// discounts.
+type Adjustment struct {
+	Kind        string   `json:"kind"`
+	Code        string   `json:"code"` // Stable identifier, e.g. "accidents"
+	Description string   `json:"description"`
+	Rate        int64    `json:"rate,omitempty"` // Basis points of the base price; 0 for the base line
+	Amount      mt.Money `json:"amount"`
+}
+
+// =====================================================
+// RATING RULES
+// =====================================================
+
+// Rates are in basis points of the plan's base price (2500 = 25%). Every
+// adjustment is taken from the base price rather than compounded, so the
+// breakdown adds up line by line.
+const (
+	accidentRate       = 2500 // Per accident, up to maxRatedAccidents
+	maxRatedAccidents  = 4
+	newDriverRate      = 3000 // Under newDriverYears of driving experience
+	newDriverYears     = 3
+	poolRate           = 1000
+	alarmDiscountRate  = 1000
+	oldBuildingRate    = 1500 // Built before oldBuildingYear
+	oldBuildingYear    = 1950
+	propertyValueStep  = 5000000 // Cents: every full $50,000 above the threshold
+	propertyValueFloor = 25000000
+	propertyValueRate  = 500 // Per step
+	smokerRate         = 5000
+	employeeThreshold  = 10
+	employeeRate       = 200 // Per employee above the threshold
+	premisesRate       = 1500
+)
+
+var healthRates = map[string]int64{
+	HealthExcellent: -1000,
+	HealthGood:      0,
+	HealthFair:      2500,
+	HealthPoor:      6000,
+}
+
+// =====================================================
+// PURE BUSINESS LOGIC FUNCTIONS
+// =====================================================
+
+var (
+	// ErrCoverageMismatch is returned when the input is for a different
+	// coverage type than the plan.
+	ErrCoverageMismatch = errors.New("quote input does not match the plan's coverage type")
+	// ErrCoverageExceedsLimit is returned when the requested life cover is
+	// above the plan's limit.
+	ErrCoverageExceedsLimit = errors.New("requested coverage exceeds the plan limit")
+)
+
+// ValidateQuoteInput validates an applicant's answers.
+func ValidateQuoteInput(input QuoteInput) mt.ValidationErrors {
+	var errors mt.ValidationErrors
+
+	switch input.CoverageType {
+	case CoverageAuto, CoverageHome, CoverageLife, CoverageBusiness:
+	default:
+		errors.Add("coverage_type", "Unknown coverage type")
+	}
+	if input.DrivingYears < 0 {
+		errors.Add("driving_years", "Driving years cannot be negative")
+	}
+	if input.AccidentCount < 0 {
+		errors.Add("accident_count", "Accident count cannot be negative")
+	}
+	if input.PropertyValue.IsNegative() {
+		errors.Add("property_value", "Property value cannot be negative")
+	}
+	if input.CoverageType == CoverageLife {
+		if _, ok := healthRates[input.HealthStatus]; !ok {
+			errors.Add("health_status", "Health status must be excellent, good, fair or poor")
+		}
+	}
+	if input.Employees < 0 {
+		errors.Add("employees", "Employees cannot be negative")
+	}
+
+	return errors
+}
+
+// CalculatePremium prices a quote. It returns the annual premium and the
+// itemized breakdown, starting with the plan's base price followed by each
+// risk adjustment, surcharge and discount that applied. Amounts are in the
+// plan's currency and rounded half away from zero to the cent.
+func CalculatePremium(input QuoteInput, plan Plan) (mt.Money, []Adjustment, error) {
+	if errs := ValidateQuoteInput(input); errs.HasErrors() {
+		return mt.Money{}, nil, errs
+	}
+	if input.CoverageType != plan.CoverageType {
+		return mt.Money{}, nil, fmt.Errorf("%w: %s input for %s plan %s",
+			ErrCoverageMismatch, input.CoverageType, plan.CoverageType, plan.ID)
+	}
+
+	base := plan.BasePrice
+	adjustments := []Adjustment{{
+		Kind:        AdjustmentBase,
+		Code:        "base",
+		Description: plan.Name + " base premium",
+		Amount:      base,
+	}}
+	add := func(kind, code, description string, rate int64) {
+		if rate == 0 {
+			return
+		}
+		adjustments = append(adjustments, Adjustment{
+			Kind:        kind,
+			Code:        code,
+			Description: description,
+			Rate:        rate,
+			Amount:      percentOf(base, rate),
+		})
+	}
+
+	switch input.CoverageType {
+	case CoverageAuto:
+		if accidents := min(input.AccidentCount, maxRatedAccidents); accidents > 0 {
+			add(AdjustmentRisk, "accidents", fmt.Sprintf("%d accident(s) in the last five years", accidents),
+				int64(accidents)*accidentRate)
+		}
+		if input.DrivingYears < newDriverYears {
+			add(AdjustmentSurcharge, "new_driver", fmt.Sprintf("Less than %d years of driving experience", newDriverYears),
+				newDriverRate)
+		}
+
+	case CoverageHome:
+		if input.PropertyValue.Currency != "" && input.PropertyValue.Currency != base.Currency {
+			return mt.Money{}, nil, fmt.Errorf("property value in %s, plan priced in %s",
+				input.PropertyValue.Currency, base.Currency)
+		}
+		if steps := (input.PropertyValue.Amount - propertyValueFloor) / propertyValueStep; steps > 0 {
+			add(AdjustmentRisk, "property_value", "Property value above "+
+				mt.Money{Amount: propertyValueFloor, Currency: base.Currency}.Format(), steps*propertyValueRate)
+		}
+		if input.YearBuilt > 0 && input.YearBuilt < oldBuildingYear {
+			add(AdjustmentRisk, "building_age", fmt.Sprintf("Built before %d", oldBuildingYear), oldBuildingRate)
+		}
+		if input.HasPool {
+			add(AdjustmentSurcharge, "pool", "Swimming pool", poolRate)
+		}
+		if input.HasAlarm {
+			add(AdjustmentDiscount, "alarm", "Monitored alarm", -alarmDiscountRate)
+		}
+
+	case CoverageLife:
+		if !plan.CoverageLimit.IsZero() && input.CoverageAmount.Amount > plan.CoverageLimit.Amount {
+			return mt.Money{}, nil, fmt.Errorf("%w: %s requested, %s available",
+				ErrCoverageExceedsLimit, input.CoverageAmount.Format(), plan.CoverageLimit.Format())
+		}
+		rate := healthRates[input.HealthStatus]
+		kind := AdjustmentRisk
+		if rate < 0 {
+			kind = AdjustmentDiscount
+		}
+		add(kind, "health", "Health: "+input.HealthStatus, rate)
+		if input.Smoker {
+			add(AdjustmentSurcharge, "smoker", "Smoker", smokerRate)
+		}
+
+	case CoverageBusiness:
+		if extra := input.Employees - employeeThreshold; extra > 0 {
+			add(AdjustmentRisk, "employees", fmt.Sprintf("%d employees above %d", extra, employeeThreshold),
+				int64(extra)*employeeRate)
+		}
+		if input.HasPremises {
+			add(AdjustmentSurcharge, "premises", "Business premises", premisesRate)
+		}
+	}
+
+	total := mt.Money{Currency: base.Currency}
+	for _, adj := range adjustments {
+		total.Amount += adj.Amount.Amount
+	}
+	return total, adjustments, nil
+}
+
+// percentOf returns rate basis points of m, rounded half away from zero.
+func percentOf(m mt.Money, rate int64) mt.Money {
+	product := m.Amount * rate
+	amount := product / 10000
+	if remainder := product % 10000; remainder >= 5000 {
+		amount++
+	} else if remainder <= -5000 {
+		amount--
+	}
+	return mt.Money{Amount: amount, Currency: m.Currency}
+}
+
+// =====================================================
+// SAMPLE DATA
+// =====================================================
+
+// SamplePlans returns a basic, standard and premium plan for each coverage
+// type, priced in USD.
+func SamplePlans() []Plan {
+	usd := func(major float64) mt.Money { return mt.NewMoney(major, mt.CurrencyUSD) }
+	tiers := []struct {
+		tier  string
+		scale float64
+	}{{"basic", 1}, {"standard", 1.5}, {"premium", 2.2}}
+	coverage := []struct {
+		kind, name              string
+		base, deductible, limit float64
+	}{
+		{CoverageAuto, "Auto", 600, 1000, 50000},
+		{CoverageHome, "Home", 900, 2500, 300000},
+		{CoverageLife, "Life", 300, 0, 250000},
+		{CoverageBusiness, "Business", 1500, 5000, 1000000},
+	}
+
+	var plans []Plan
+	for _, c := range coverage {
+		for _, t := range tiers {
+			plans = append(plans, Plan{
+				ID:            c.kind + "-" + t.tier,
+				CoverageType:  c.kind,
+				Name:          c.name + " " + t.tier,
+				Tier:          t.tier,
+				BasePrice:     usd(c.base * t.scale),
+				Deductible:    usd(c.deductible / t.scale),
+				CoverageLimit: usd(c.limit * t.scale),
+			})
+		}
+	}
+	return plans
+}
@@ -0,0 +1,85 @@
+package mintyins
+
+import (
+	"errors"
+	"testing"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+func TestCalculatePremium(t *testing.T) {
+	home := Plan{ID: "home-basic", CoverageType: CoverageHome, Name: "Home basic",
+		BasePrice: mt.NewMoney(900, mt.CurrencyUSD)}
+
+	total, breakdown, err := CalculatePremium(QuoteInput{
+		CoverageType:  CoverageHome,
+		PropertyValue: mt.NewMoney(380000, mt.CurrencyUSD),
+		HasPool:       true,
+		HasAlarm:      true,
+	}, home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// $900 base, +10% for two $50k steps above $250k, +10% pool, -10% alarm
+	want := map[string]int64{"base": 90000, "property_value": 9000, "pool": 9000, "alarm": -9000}
+	if len(breakdown) != len(want) {
+		t.Fatalf("breakdown = %+v", breakdown)
+	}
+	for _, adj := range breakdown {
+		if adj.Amount.Amount != want[adj.Code] {
+			t.Errorf("%s = %d, want %d", adj.Code, adj.Amount.Amount, want[adj.Code])
+		}
+	}
+	if total.Amount != 99000 || total.Currency != mt.CurrencyUSD {
+		t.Errorf("total = %+v, want 99000 USD", total)
+	}
+}
+
+func TestCalculatePremiumRounding(t *testing.T) {
+	auto := Plan{ID: "auto", CoverageType: CoverageAuto, BasePrice: mt.Money{Amount: 1001, Currency: mt.CurrencyUSD}}
+	total, breakdown, err := CalculatePremium(QuoteInput{CoverageType: CoverageAuto, DrivingYears: 1, AccidentCount: 9}, auto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Accidents are capped at 4 (100% = 1001), new driver 30% of 1001 = 300.3
+	if breakdown[1].Amount.Amount != 1001 || breakdown[2].Amount.Amount != 300 || total.Amount != 2302 {
+		t.Errorf("breakdown = %+v, total %d", breakdown, total.Amount)
+	}
+	if got := percentOf(mt.Money{Amount: -15}, 5000); got.Amount != -8 {
+		t.Errorf("percentOf(-15, 50%%) = %d, want -8", got.Amount)
+	}
+}
+
+func TestCalculatePremiumErrors(t *testing.T) {
+	life := Plan{ID: "life", CoverageType: CoverageLife, BasePrice: mt.NewMoney(300, mt.CurrencyUSD),
+		CoverageLimit: mt.NewMoney(100000, mt.CurrencyUSD)}
+
+	if _, _, err := CalculatePremium(QuoteInput{CoverageType: CoverageAuto}, life); !errors.Is(err, ErrCoverageMismatch) {
+		t.Errorf("mismatch err = %v", err)
+	}
+	_, _, err := CalculatePremium(QuoteInput{CoverageType: CoverageLife, HealthStatus: HealthGood,
+		CoverageAmount: mt.NewMoney(200000, mt.CurrencyUSD)}, life)
+	if !errors.Is(err, ErrCoverageExceedsLimit) {
+		t.Errorf("limit err = %v", err)
+	}
+	var verrs mt.ValidationErrors
+	if _, _, err := CalculatePremium(QuoteInput{CoverageType: CoverageLife}, life); !errors.As(err, &verrs) {
+		t.Errorf("missing health status should fail validation, got %v", err)
+	}
+
+	total, breakdown, err := CalculatePremium(QuoteInput{CoverageType: CoverageLife, HealthStatus: HealthExcellent, Smoker: true}, life)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if breakdown[1].Kind != AdjustmentDiscount || total.Amount != 42000 {
+		t.Errorf("breakdown = %+v, total %d", breakdown, total.Amount)
+	}
+}
+
+func TestSamplePlans(t *testing.T) {
+	for _, plan := range SamplePlans() {
+		if _, _, err := CalculatePremium(QuoteInput{CoverageType: plan.CoverageType, HealthStatus: HealthGood}, plan); err != nil {
+			t.Errorf("%s: %v", plan.ID, err)
+		}
+	}
+}
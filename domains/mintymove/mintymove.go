@@ -3,11 +3,14 @@
 package mintymove
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"time"
 
+	mh "github.com/ha1tch/minty/mintyhook"
 	mt "github.com/ha1tch/minty/mintytypes"
 )
 
@@ -27,6 +30,7 @@ type Shipment struct {
 	Carrier         string           `json:"carrier"`
 	Service         string           `json:"service"`
 	Weight          float64          `json:"weight"`
+	WeightUnit      WeightUnit       `json:"weight_unit,omitempty"` // default lb
 	Cost            mt.Money    `json:"cost"`
 	Items           []ShipmentItem   `json:"items"`
 	CreatedAt       time.Time        `json:"created_at"`
@@ -89,9 +93,11 @@ type Vehicle struct {
 
 // VehicleCapacity represents vehicle capacity constraints
 type VehicleCapacity struct {
-	Weight     float64 `json:"weight"`      // maximum weight in lbs
-	Volume     float64 `json:"volume"`      // maximum volume in cubic feet
+	Weight     float64 `json:"weight"`      // maximum weight in WeightUnit
+	Volume     float64 `json:"volume"`      // maximum volume in VolumeUnit
 	ItemCount  int     `json:"item_count"`  // maximum number of items
+	WeightUnit WeightUnit `json:"weight_unit,omitempty"` // default lb
+	VolumeUnit VolumeUnit `json:"volume_unit,omitempty"` // default ft3
 }
 
 // Location represents a geographic location
@@ -167,6 +173,193 @@ func (c Customer) GetShippingAddress() mt.Address {
 	return c.GetPrimaryAddress()
 }
 
+// =====================================================
+// UNITS OF MEASURE
+// =====================================================
+
+// WeightUnit is the unit a weight is expressed in. The empty unit means
+// WeightLb, which is what weights were before units were recorded.
+type WeightUnit string
+
+// LengthUnit is the unit a dimension is expressed in. The empty unit means
+// LengthIn.
+type LengthUnit string
+
+// VolumeUnit is the unit a volume is expressed in. The empty unit means
+// VolumeCuFt.
+type VolumeUnit string
+
+// Supported units
+const (
+	WeightLb WeightUnit = "lb"
+	WeightOz WeightUnit = "oz"
+	WeightKg WeightUnit = "kg"
+	WeightG  WeightUnit = "g"
+
+	LengthIn LengthUnit = "in"
+	LengthFt LengthUnit = "ft"
+	LengthCm LengthUnit = "cm"
+	LengthM  LengthUnit = "m"
+
+	VolumeCuIn VolumeUnit = "in3"
+	VolumeCuFt VolumeUnit = "ft3"
+	VolumeL    VolumeUnit = "l"
+	VolumeCuM  VolumeUnit = "m3"
+)
+
+// Exact conversion factors to grams, millimetres and millilitres. Imperial
+// units are defined in metric terms, so these are exact rather than rounded.
+var (
+	gramsPer = map[WeightUnit]float64{
+		WeightLb: 453.59237,
+		WeightOz: 28.349523125,
+		WeightKg: 1000,
+		WeightG:  1,
+	}
+	millimetresPer = map[LengthUnit]float64{
+		LengthIn: 25.4,
+		LengthFt: 304.8,
+		LengthCm: 10,
+		LengthM:  1000,
+	}
+	millilitresPer = map[VolumeUnit]float64{
+		VolumeCuIn: 16.387064,
+		VolumeCuFt: 28316.846592,
+		VolumeL:    1000,
+		VolumeCuM:  1000000,
+	}
+)
+
+// ErrUnknownUnit is returned when a unit is not one of the supported units.
+var ErrUnknownUnit = errors.New("unknown unit")
+
+func (u WeightUnit) orDefault() WeightUnit {
+	if u == "" {
+		return WeightLb
+	}
+	return u
+}
+
+func (u LengthUnit) orDefault() LengthUnit {
+	if u == "" {
+		return LengthIn
+	}
+	return u
+}
+
+func (u VolumeUnit) orDefault() VolumeUnit {
+	if u == "" {
+		return VolumeCuFt
+	}
+	return u
+}
+
+// Valid reports whether u is a supported weight unit (or empty).
+func (u WeightUnit) Valid() bool {
+	_, ok := gramsPer[u.orDefault()]
+	return ok
+}
+
+// Valid reports whether u is a supported length unit (or empty).
+func (u LengthUnit) Valid() bool {
+	_, ok := millimetresPer[u.orDefault()]
+	return ok
+}
+
+// Valid reports whether u is a supported volume unit (or empty).
+func (u VolumeUnit) Valid() bool {
+	_, ok := millilitresPer[u.orDefault()]
+	return ok
+}
+
+// convertUnit converts through the base unit and rounds to nine decimal
+// places, so round trips such as kg -> lb -> kg give back the value entered
+// instead of 0.30000000000000004-style noise.
+func convertUnit(v, fromFactor, toFactor float64) float64 {
+	if fromFactor == toFactor {
+		return v
+	}
+	return math.Round(v*fromFactor/toFactor*1e9) / 1e9
+}
+
+// ConvertWeightErr converts v from one weight unit to another, returning
+// ErrUnknownUnit if either unit is not supported.
+func ConvertWeightErr(v float64, from, to WeightUnit) (float64, error) {
+	f, ok := gramsPer[from.orDefault()]
+	if !ok {
+		return 0, fmt.Errorf("%w: weight %q", ErrUnknownUnit, from)
+	}
+	t, ok := gramsPer[to.orDefault()]
+	if !ok {
+		return 0, fmt.Errorf("%w: weight %q", ErrUnknownUnit, to)
+	}
+	return convertUnit(v, f, t), nil
+}
+
+// ConvertWeight converts v from one weight unit to another. It returns NaN
+// for an unknown unit, so comparisons against the result fail; use
+// ConvertWeightErr where the units come from user input.
+func ConvertWeight(v float64, from, to WeightUnit) float64 {
+	out, err := ConvertWeightErr(v, from, to)
+	if err != nil {
+		return math.NaN()
+	}
+	return out
+}
+
+// ConvertDimensionErr converts v from one length unit to another, returning
+// ErrUnknownUnit if either unit is not supported.
+func ConvertDimensionErr(v float64, from, to LengthUnit) (float64, error) {
+	f, ok := millimetresPer[from.orDefault()]
+	if !ok {
+		return 0, fmt.Errorf("%w: length %q", ErrUnknownUnit, from)
+	}
+	t, ok := millimetresPer[to.orDefault()]
+	if !ok {
+		return 0, fmt.Errorf("%w: length %q", ErrUnknownUnit, to)
+	}
+	return convertUnit(v, f, t), nil
+}
+
+// ConvertDimension converts v from one length unit to another, returning NaN
+// for an unknown unit.
+func ConvertDimension(v float64, from, to LengthUnit) float64 {
+	out, err := ConvertDimensionErr(v, from, to)
+	if err != nil {
+		return math.NaN()
+	}
+	return out
+}
+
+// ConvertVolumeErr converts v from one volume unit to another, returning
+// ErrUnknownUnit if either unit is not supported.
+func ConvertVolumeErr(v float64, from, to VolumeUnit) (float64, error) {
+	f, ok := millilitresPer[from.orDefault()]
+	if !ok {
+		return 0, fmt.Errorf("%w: volume %q", ErrUnknownUnit, from)
+	}
+	t, ok := millilitresPer[to.orDefault()]
+	if !ok {
+		return 0, fmt.Errorf("%w: volume %q", ErrUnknownUnit, to)
+	}
+	return convertUnit(v, f, t), nil
+}
+
+// ConvertVolume converts v from one volume unit to another, returning NaN
+// for an unknown unit.
+func ConvertVolume(v float64, from, to VolumeUnit) float64 {
+	out, err := ConvertVolumeErr(v, from, to)
+	if err != nil {
+		return math.NaN()
+	}
+	return out
+}
+
+// WeightIn returns the shipment weight expressed in unit.
+func (s Shipment) WeightIn(unit WeightUnit) (float64, error) {
+	return ConvertWeightErr(s.Weight, s.WeightUnit, unit)
+}
+
 // =====================================================
 // STATUS IMPLEMENTATIONS
 // =====================================================
@@ -289,6 +482,9 @@ func ValidateShipment(shipment Shipment) mt.ValidationErrors {
 	if shipment.Weight <= 0 {
 		errors.Add("weight", "Weight must be greater than zero")
 	}
+	if !shipment.WeightUnit.Valid() {
+		errors.Add("weight_unit", fmt.Sprintf("Unknown weight unit %q", shipment.WeightUnit))
+	}
 	
 	if len(shipment.Items) == 0 {
 		errors.Add("items", "Shipment must have at least one item")
@@ -447,19 +643,152 @@ func ValidateVehicle(vehicle Vehicle) mt.ValidationErrors {
 	if vehicle.Capacity.Weight <= 0 {
 		errors.Add("capacity.weight", "Vehicle weight capacity must be greater than zero")
 	}
+	if !vehicle.Capacity.WeightUnit.Valid() {
+		errors.Add("capacity.weight_unit", fmt.Sprintf("Unknown weight unit %q", vehicle.Capacity.WeightUnit))
+	}
+	if !vehicle.Capacity.VolumeUnit.Valid() {
+		errors.Add("capacity.volume_unit", fmt.Sprintf("Unknown volume unit %q", vehicle.Capacity.VolumeUnit))
+	}
 	
 	return errors
 }
 
-// CheckVehicleCapacity checks if vehicle can handle shipment
+// CheckVehicleCapacity checks if vehicle can handle shipment. The shipment
+// weight is converted to the vehicle's capacity unit first; an unknown unit
+// on either side never fits.
 func CheckVehicleCapacity(vehicle Vehicle, shipment Shipment) bool {
-	totalWeight := shipment.Weight
+	totalWeight, err := shipment.WeightIn(vehicle.Capacity.WeightUnit)
+	if err != nil {
+		return false
+	}
 	totalItems := len(shipment.Items)
 	
 	return totalWeight <= vehicle.Capacity.Weight && 
 		   totalItems <= vehicle.Capacity.ItemCount
 }
 
+// ErrCapacityExceeded is wrapped by CapacityError.
+var ErrCapacityExceeded = errors.New("vehicle capacity exceeded")
+
+// Capacity constraints reported by CapacityError
+const (
+	CapacityWeight    = "weight"
+	CapacityItemCount = "item_count"
+)
+
+// CapacityError reports which vehicle constraint a load does not fit.
+type CapacityError struct {
+	Constraint string  // CapacityWeight or CapacityItemCount
+	Required   float64 // Combined load, in Unit
+	Available  float64 // Vehicle capacity, in Unit
+	Unit       string  // Weight unit, or "items"
+}
+
+func (e *CapacityError) Error() string {
+	return fmt.Sprintf("%v: %s %g %s exceeds %g", ErrCapacityExceeded, e.Constraint, e.Required, e.Unit, e.Available)
+}
+
+func (e *CapacityError) Unwrap() error { return ErrCapacityExceeded }
+
+// checkCombinedCapacity applies CheckVehicleCapacity's rules to several
+// shipments at once.
+func checkCombinedCapacity(vehicle Vehicle, shipments []Shipment) error {
+	unit := vehicle.Capacity.WeightUnit.orDefault()
+	totalWeight, totalItems := 0.0, 0
+	for _, shipment := range shipments {
+		weight, err := shipment.WeightIn(unit)
+		if err != nil {
+			return fmt.Errorf("shipment %s: %w", shipment.ID, err)
+		}
+		totalWeight += weight
+		totalItems += len(shipment.Items)
+	}
+	if totalWeight > vehicle.Capacity.Weight {
+		return &CapacityError{Constraint: CapacityWeight, Required: totalWeight,
+			Available: vehicle.Capacity.Weight, Unit: string(unit)}
+	}
+	if totalItems > vehicle.Capacity.ItemCount {
+		return &CapacityError{Constraint: CapacityItemCount, Required: float64(totalItems),
+			Available: float64(vehicle.Capacity.ItemCount), Unit: "items"}
+	}
+	return nil
+}
+
+// ConsolidateShipments combines shipments onto one route for vehicle. The
+// combined weight and item count must fit the vehicle's capacity, otherwise
+// a *CapacityError names the constraint that failed. The route picks up every
+// shipment first, then delivers them ordered by estimated delivery date, and
+// its cost is the sum of the shipment costs, which must share a currency.
+// The route is not stored; see LogisticsService.ConsolidateShipments.
+func ConsolidateShipments(shipments []Shipment, vehicle Vehicle) (*Route, error) {
+	if len(shipments) == 0 {
+		return nil, errors.New("no shipments to consolidate")
+	}
+	if err := checkCombinedCapacity(vehicle, shipments); err != nil {
+		return nil, err
+	}
+
+	// Pickups are scheduled every 30 minutes from now; a delivery is never
+	// scheduled before the last pickup.
+	const stopInterval = 30 * time.Minute
+	now := time.Now()
+	lastPickup := now.Add(time.Duration(len(shipments)-1) * stopInterval)
+
+	stops := make([]RouteStop, 0, 2*len(shipments))
+	cost := mt.Money{Currency: shipments[0].Cost.Currency}
+	for i, shipment := range shipments {
+		var err error
+		if cost, err = cost.Add(shipment.Cost); err != nil {
+			return nil, fmt.Errorf("shipment %s: %w", shipment.ID, err)
+		}
+		stops = append(stops, RouteStop{
+			ID:            fmt.Sprintf("stop_%s_pickup", shipment.ID),
+			Address:       shipment.Origin,
+			EstimatedTime: now.Add(time.Duration(i) * stopInterval),
+			Type:          "pickup",
+			Status:        mt.StatusPending,
+			Instructions:  "Pick up " + shipment.TrackingCode,
+		})
+	}
+	for _, shipment := range shipments {
+		due := shipment.EstimatedDate
+		if !due.After(lastPickup) {
+			due = lastPickup.Add(stopInterval)
+		}
+		stops = append(stops, RouteStop{
+			ID:            fmt.Sprintf("stop_%s_delivery", shipment.ID),
+			Address:       shipment.Destination,
+			EstimatedTime: due,
+			Type:          "delivery",
+			Status:        mt.StatusPending,
+			Instructions:  "Deliver " + shipment.TrackingCode,
+		})
+	}
+
+	distance := CalculateRouteDistance(stops)
+	route := Route{
+		ID:          generateID("rte"),
+		Name:        fmt.Sprintf("%s: %d shipments", vehicle.Name, len(shipments)),
+		Origin:      stops[0].Address,
+		Destination: shipments[len(shipments)-1].Destination,
+		Distance:    distance,
+		Duration:    time.Duration(distance*6) * time.Minute, // 6 minutes per mile
+		Cost:        cost,
+		Stops:       stops,
+		Status:      mt.StatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Metadata:    map[string]string{"vehicle_id": vehicle.ID},
+	}
+	OptimizeRoute(&route)
+	route.Destination = route.Stops[len(route.Stops)-1].Address
+
+	if errors := ValidateRoute(route); errors.HasErrors() {
+		return nil, errors
+	}
+	return &route, nil
+}
+
 // AssignDriverToVehicle assigns a driver to a vehicle
 func AssignDriverToVehicle(vehicle *Vehicle, driver Driver) error {
 	if driver.Status != mt.StatusActive {
@@ -482,6 +811,9 @@ type LogisticsService struct {
 	vehicles  []Vehicle
 	drivers   []Driver
 	customers []Customer
+	audit     mt.AuditSink
+	auditCtx  context.Context
+	webhooks  mh.Dispatcher
 }
 
 // NewLogisticsService creates a new logistics service
@@ -495,6 +827,60 @@ func NewLogisticsService() *LogisticsService {
 	}
 }
 
+// Audit Operations
+
+// SetAuditSink sets the sink notified of create, update and status-change
+// operations. A nil sink disables auditing.
+func (ls *LogisticsService) SetAuditSink(sink mt.AuditSink) {
+	ls.audit = sink
+}
+
+// SetAuditContext sets the context audit events take their actor from (see
+// mt.ContextWithActor), typically once per request.
+func (ls *LogisticsService) SetAuditContext(ctx context.Context) {
+	ls.auditCtx = ctx
+}
+
+// auditSnapshot captures an entity before a change, when auditing is enabled
+func (ls *LogisticsService) auditSnapshot(entity interface{}) map[string]interface{} {
+	if ls.audit == nil {
+		return nil
+	}
+	return mt.AuditSnapshot(entity)
+}
+
+// recordAudit sends an audit event to the sink, if one is set
+func (ls *LogisticsService) recordAudit(entityType, entityID, action string, before, after interface{}) {
+	if ls.audit == nil {
+		return
+	}
+	ctx := ls.auditCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ls.audit.Record(mt.NewAuditEvent(ctx, entityType, entityID, action, before, after))
+}
+
+// Webhook Operations
+
+// SetWebhookDispatcher sets the dispatcher notified of shipment events:
+// "shipment.created" and "shipment.<status>", e.g. "shipment.in_transit"
+// or "shipment.delivered", when the status changes. The payload is the
+// shipment. A nil dispatcher disables webhooks. Dispatch errors do not
+// fail the operation; deliver through a mh.Queue to keep receivers out of
+// the request path and to report failures.
+func (ls *LogisticsService) SetWebhookDispatcher(dispatcher mh.Dispatcher) {
+	ls.webhooks = dispatcher
+}
+
+// dispatchWebhook sends an event to the dispatcher, if one is set
+func (ls *LogisticsService) dispatchWebhook(event string, payload interface{}) {
+	if ls.webhooks == nil {
+		return
+	}
+	_ = ls.webhooks.Dispatch(event, payload)
+}
+
 // Shipment Operations
 
 func (ls *LogisticsService) CreateShipment(trackingCode string, origin, destination mt.Address,
@@ -526,6 +912,8 @@ func (ls *LogisticsService) CreateShipment(trackingCode string, origin, destinat
 	}
 	
 	ls.shipments = append(ls.shipments, shipment)
+	ls.recordAudit("shipment", shipment.ID, mt.AuditCreate, nil, shipment)
+	ls.dispatchWebhook("shipment.created", shipment)
 	return &shipment, nil
 }
 
@@ -553,7 +941,13 @@ func (ls *LogisticsService) UpdateShipmentStatus(shipmentID, status string) erro
 		return err
 	}
 	
+	before := ls.auditSnapshot(shipment)
+	previous := shipment.Status
 	UpdateShipmentStatus(shipment, status)
+	ls.recordAudit("shipment", shipment.ID, mt.AuditStatusChange, before, shipment)
+	if shipment.Status != previous {
+		ls.dispatchWebhook("shipment."+shipment.Status, *shipment)
+	}
 	return nil
 }
 
@@ -602,9 +996,35 @@ func (ls *LogisticsService) CreateRoute(name string, origin, destination mt.Addr
 	OptimizeRoute(&route)
 	
 	ls.routes = append(ls.routes, route)
+	ls.recordAudit("route", route.ID, mt.AuditCreate, nil, route)
 	return &route, nil
 }
 
+// ConsolidateShipments builds a route carrying the given shipments on the
+// vehicle (see the package-level ConsolidateShipments) and stores it.
+func (ls *LogisticsService) ConsolidateShipments(shipmentIDs []string, vehicleID string) (*Route, error) {
+	vehicle, err := ls.GetVehicle(vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	shipments := make([]Shipment, 0, len(shipmentIDs))
+	for _, id := range shipmentIDs {
+		shipment, err := ls.GetShipment(id)
+		if err != nil {
+			return nil, fmt.Errorf("shipment %s: %w", id, err)
+		}
+		shipments = append(shipments, *shipment)
+	}
+
+	route, err := ConsolidateShipments(shipments, *vehicle)
+	if err != nil {
+		return nil, err
+	}
+	ls.routes = append(ls.routes, *route)
+	ls.recordAudit("route", route.ID, mt.AuditCreate, nil, *route)
+	return route, nil
+}
+
 func (ls *LogisticsService) GetRoute(routeID string) (*Route, error) {
 	for i, route := range ls.routes {
 		if route.ID == routeID {
@@ -650,6 +1070,7 @@ func (ls *LogisticsService) CreateVehicle(name, vehicleType, licensePlate string
 	}
 	
 	ls.vehicles = append(ls.vehicles, vehicle)
+	ls.recordAudit("vehicle", vehicle.ID, mt.AuditCreate, nil, vehicle)
 	return &vehicle, nil
 }
 
@@ -695,6 +1116,7 @@ func (ls *LogisticsService) CreateDriver(name, email, phone, licenseNum string)
 	mt.ValidateRequired("name", driver.Name, "Driver Name", &errors)
 	mt.ValidateEmail("email", driver.Email, "Email", &errors)
 	mt.ValidateRequired("phone", driver.Phone, "Phone", &errors)
+	mt.ValidatePhone("phone", driver.Phone, "Phone", &errors)
 	mt.ValidateRequired("license_num", driver.LicenseNum, "License Number", &errors)
 	
 	if errors.HasErrors() {
@@ -702,6 +1124,7 @@ func (ls *LogisticsService) CreateDriver(name, email, phone, licenseNum string)
 	}
 	
 	ls.drivers = append(ls.drivers, driver)
+	ls.recordAudit("driver", driver.ID, mt.AuditCreate, nil, driver)
 	return &driver, nil
 }
 
@@ -836,7 +1259,7 @@ func PrepareVehicleForDisplay(vehicle Vehicle) VehicleDisplayData {
 		TypeIcon:      getVehicleTypeIcon(vehicle.Type),
 		StatusClass:   "status-" + getVehicleStatusSeverity(vehicle.Status),
 		StatusDisplay: getVehicleStatusDisplay(vehicle.Status),
-		CapacityUsed:  fmt.Sprintf("%.1f / %.1f lbs", 0.0, vehicle.Capacity.Weight), // Would calculate actual usage
+		CapacityUsed:  fmt.Sprintf("%.1f / %.1f %s", 0.0, vehicle.Capacity.Weight, vehicle.Capacity.WeightUnit.orDefault()), // Would calculate actual usage
 	}
 }
 
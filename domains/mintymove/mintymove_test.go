@@ -0,0 +1,142 @@
+package mintymove
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	mh "github.com/ha1tch/minty/mintyhook"
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+func TestConvertUnits(t *testing.T) {
+	tests := []struct {
+		desc      string
+		got, want float64
+	}{
+		{"kg to lb", ConvertWeight(1, WeightKg, WeightLb), 2.204622622},
+		{"kg to lb and back", ConvertWeight(ConvertWeight(0.3, WeightKg, WeightLb), WeightLb, WeightKg), 0.3},
+		{"empty weight unit is lb", ConvertWeight(1, "", WeightOz), 16},
+		{"in to ft", ConvertDimension(18, LengthIn, LengthFt), 1.5},
+		{"empty length unit is in", ConvertDimension(1, "", LengthCm), 2.54},
+		{"m3 to l", ConvertVolume(2, VolumeCuM, VolumeL), 2000},
+		{"empty volume unit is ft3", ConvertVolume(1, "", VolumeCuIn), 1728},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.desc, tt.got, tt.want)
+		}
+	}
+
+	if _, err := ConvertWeightErr(1, "stone", WeightKg); !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("ConvertWeightErr with an unknown unit = %v, want ErrUnknownUnit", err)
+	}
+	if _, err := ConvertVolumeErr(1, VolumeL, "gal"); !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("ConvertVolumeErr with an unknown unit = %v, want ErrUnknownUnit", err)
+	}
+	if !math.IsNaN(ConvertDimension(1, "yd", LengthM)) {
+		t.Error("ConvertDimension with an unknown unit should return NaN")
+	}
+}
+
+func TestCheckVehicleCapacityUnits(t *testing.T) {
+	van := Vehicle{Capacity: VehicleCapacity{Weight: 100, WeightUnit: WeightKg, ItemCount: 5}}
+	tests := []struct {
+		shipment Shipment
+		fits     bool
+	}{
+		{Shipment{Weight: 200, WeightUnit: WeightLb}, true}, // 90.7 kg
+		{Shipment{Weight: 250}, false},                      // 113.4 kg
+		{Shipment{Weight: 1, WeightUnit: "stone"}, false},
+		{Shipment{Weight: 1, Items: make([]ShipmentItem, 6)}, false},
+	}
+	for _, tt := range tests {
+		if got := CheckVehicleCapacity(van, tt.shipment); got != tt.fits {
+			t.Errorf("CheckVehicleCapacity(%v %s, %d items) = %v, want %v",
+				tt.shipment.Weight, tt.shipment.WeightUnit, len(tt.shipment.Items), got, tt.fits)
+		}
+	}
+}
+
+func TestConsolidateShipments(t *testing.T) {
+	van := Vehicle{ID: "v-1", Name: "Van 1", Capacity: VehicleCapacity{Weight: 100, WeightUnit: WeightKg, ItemCount: 3}}
+	shipment := func(id string, kg float64, items int, cost mt.Money) Shipment {
+		return Shipment{
+			ID:            id,
+			TrackingCode:  "TRK-" + id,
+			Origin:        mt.Address{City: "Leeds"},
+			Destination:   mt.Address{City: "York"},
+			EstimatedDate: time.Now().Add(48 * time.Hour),
+			Weight:        kg,
+			WeightUnit:    WeightKg,
+			Cost:          cost,
+			Items:         make([]ShipmentItem, items),
+		}
+	}
+	a := shipment("a", 40, 1, mt.NewMoney(12.50, mt.CurrencyEUR))
+	b := shipment("b", 50, 2, mt.NewMoney(7.25, mt.CurrencyEUR))
+
+	route, err := ConsolidateShipments([]Shipment{a, b}, van)
+	if err != nil {
+		t.Fatalf("ConsolidateShipments: %v", err)
+	}
+	if want := mt.NewMoney(19.75, mt.CurrencyEUR); route.Cost != want {
+		t.Errorf("Cost = %+v, want %+v", route.Cost, want)
+	}
+	if len(route.Stops) != 4 || route.Stops[0].Type != "pickup" || route.Stops[1].Type != "pickup" || route.Stops[3].Type != "delivery" {
+		t.Errorf("Stops = %+v, want two pickups then two deliveries", route.Stops)
+	}
+
+	var capacity *CapacityError
+	heavy := shipment("c", 20, 0, mt.NewMoney(1, mt.CurrencyEUR))
+	if _, err := ConsolidateShipments([]Shipment{a, b, heavy}, van); !errors.As(err, &capacity) || capacity.Constraint != CapacityWeight || capacity.Required != 110 {
+		t.Errorf("over weight: %v, want a weight CapacityError", err)
+	}
+	bulky := shipment("d", 1, 1, mt.NewMoney(1, mt.CurrencyEUR))
+	if _, err := ConsolidateShipments([]Shipment{a, b, bulky}, van); !errors.As(err, &capacity) || capacity.Constraint != CapacityItemCount {
+		t.Errorf("too many items: %v, want an item count CapacityError", err)
+	} else if !errors.Is(err, ErrCapacityExceeded) {
+		t.Error("CapacityError should wrap ErrCapacityExceeded")
+	}
+
+	dollars := shipment("e", 1, 0, mt.NewMoney(3, mt.CurrencyUSD))
+	if route, err := ConsolidateShipments([]Shipment{a, dollars}, van); err == nil {
+		t.Errorf("shipments in different currencies consolidated, cost %+v", route.Cost)
+	}
+	if _, err := ConsolidateShipments(nil, van); err == nil {
+		t.Error("consolidating no shipments should fail")
+	}
+}
+
+func TestShipmentWebhooks(t *testing.T) {
+	var events []string
+	var delivered Shipment
+	ls := NewLogisticsService()
+	ls.SetWebhookDispatcher(mh.DispatcherFunc(func(event string, payload interface{}) error {
+		events = append(events, event)
+		if event == "shipment.delivered" {
+			delivered, _ = payload.(Shipment)
+		}
+		return errors.New("receiver down") // Must not fail the operation
+	}))
+
+	address := mt.Address{Street1: "1 Main St", City: "Leeds", PostalCode: "LS1 1AA", Country: "GB"}
+	shipment, err := ls.CreateShipment("TRK-1", address, address, "ups", "ground", 2, []ShipmentItem{{ID: "i-1", Quantity: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range []string{"in_transit", "in_transit", "delivered"} {
+		if err := ls.UpdateShipmentStatus(shipment.ID, status); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Setting the same status again is not a change
+	if want := []string{"shipment.created", "shipment.in_transit", "shipment.delivered"}; !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+	if delivered.ID != shipment.ID || delivered.ActualDate == nil {
+		t.Errorf("shipment.delivered payload = %+v, want the delivered shipment", delivered)
+	}
+}
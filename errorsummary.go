@@ -0,0 +1,150 @@
+package minty
+
+import (
+	"strings"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+// =====================================================
+// ERROR SUMMARY
+// =====================================================
+
+// ErrorSummaryOptions configures an ErrorSummary.
+type ErrorSummaryOptions struct {
+	ID       string            // Summary id, default "error-summary"
+	Class    string            // Extra classes for the summary
+	Title    string            // Heading, default "There is a problem"
+	FieldIDs map[string]string // Input id of a field when it differs from the field name
+	NoFocus  bool              // Leave focus where it is, e.g. for a summary swapped in while typing
+}
+
+// ErrorSummary renders the errors of a form that failed validation as a
+// list at the top of the form, for re-rendered forms that would otherwise
+// leave keyboard and screen reader users unaware of what went wrong. The
+// summary is an alert that takes focus as soon as it is on the page, on
+// load or when swapped in by htmx, and each error links to its field:
+// following a link scrolls the field's label into view and focuses the
+// field. Fields are found by id, then by name. It renders nothing when
+// errs is empty.
+//
+// Mark the fields themselves with FieldErrorAttrs and FieldErrorMessage so
+// they are announced as invalid along with their message:
+//
+//	b.Form(
+//	    mi.ErrorSummary(errs, mi.ErrorSummaryOptions{})(b),
+//	    b.Label(mi.For("email"), "Email"),
+//	    b.Input(append(mi.FieldErrorAttrs(errs, "email"), mi.ID("email"), mi.Name("email"))...),
+//	    mi.FieldErrorMessage(errs, "email")(b),
+//	)
+func ErrorSummary(errs mt.ValidationErrors, opts ErrorSummaryOptions) H {
+	id := opts.ID
+	if id == "" {
+		id = "error-summary"
+	}
+	title := opts.Title
+	if title == "" {
+		title = "There is a problem"
+	}
+	return func(b *Builder) Node {
+		if len(errs) == 0 {
+			return NewFragment()
+		}
+
+		items := []interface{}{Class("minty-error-summary-list"), Style("margin: 0; padding-left: 1.25rem;")}
+		for _, err := range errs {
+			if err.Field == "" {
+				items = append(items, b.Li(err.Message))
+				continue
+			}
+			fieldID := opts.FieldIDs[err.Field]
+			if fieldID == "" {
+				fieldID = err.Field
+			}
+			items = append(items, b.Li(b.A(
+				Href("#"+fieldID),
+				Data("minty-error-field", err.Field),
+				Style("color: inherit;"),
+				err.Message,
+			)))
+		}
+
+		summary := b.Div(
+			ID(id),
+			Class(strings.TrimSpace("minty-error-summary message error "+opts.Class)),
+			Role("alert"),
+			Attr("aria-labelledby", id+"-title"),
+			TabIndex(-1),
+			Style("color: #d32f2f; background: #ffebee; border: 2px solid #d32f2f; padding: 1rem; border-radius: 4px; margin: 0 0 1rem;"),
+			b.H2(ID(id+"-title"), Class("minty-error-summary-title"), Style("margin: 0 0 0.5rem; font-size: 1.125rem;"), title),
+			b.Ul(items...),
+		)
+		script := errorSummaryJS
+		if !opts.NoFocus {
+			script += "\n(function() { var s = document.getElementById('" + escapeJSString(id) + "'); if (s) s.focus(); })();"
+		}
+		return NewFragment(summary, b.Script(Raw(script)))
+	}
+}
+
+// errorSummaryJS moves focus from a summary link to its field once per
+// page. The label is scrolled into view rather than the field, so the
+// question stays visible above its input.
+const errorSummaryJS = `(function() {
+if (window.MintyErrorSummary) return;
+window.MintyErrorSummary = true;
+document.addEventListener('click', function(e) {
+    var link = e.target.closest ? e.target.closest('[data-minty-error-field]') : null;
+    if (!link) return;
+    var field = document.getElementById(link.getAttribute('href').slice(1)) ||
+        document.getElementsByName(link.getAttribute('data-minty-error-field'))[0];
+    if (!field) return;
+    e.preventDefault();
+    var label = field.labels && field.labels[0];
+    (label || field).scrollIntoView();
+    field.focus({ preventScroll: true });
+});
+})();`
+
+// FieldErrorAttrs returns the attributes that mark a field as invalid,
+// aria-invalid and an aria-describedby pointing at its FieldErrorMessage,
+// or nothing when errs has no error for the field.
+func FieldErrorAttrs(errs mt.ValidationErrors, field string) []Attribute {
+	if len(errs.GetFieldErrors(field)) == 0 {
+		return nil
+	}
+	return []Attribute{Attr("aria-invalid", "true"), Attr("aria-describedby", field+"-error")}
+}
+
+// FieldErrorMessage renders a field's error messages below it, with the id
+// FieldErrorAttrs refers to, or nothing when the field has none.
+func FieldErrorMessage(errs mt.ValidationErrors, field string) H {
+	return func(b *Builder) Node {
+		messages := errs.GetFieldErrors(field)
+		if len(messages) == 0 {
+			return NewFragment()
+		}
+		args := []interface{}{ID(field + "-error")}
+		for _, message := range messages {
+			args = append(args, ErrorMessage(message)(b))
+		}
+		return b.Div(args...)
+	}
+}
+
+// FieldError renders the first of a field's error messages as a red error
+// block, or nothing when the field has none. It is FieldErrorMessage for
+// forms that show one message per field, and carries the same id, so it
+// pairs with FieldErrorAttrs:
+//
+//	b.Input(append(mi.FieldErrorAttrs(errs, "postcode"), mi.Name("postcode"))...),
+//	mi.FieldError(errs, "postcode")(b),
+func FieldError(errs mt.ValidationErrors, field string) H {
+	return func(b *Builder) Node {
+		message, ok := errs.First(field)
+		if !ok {
+			return NewFragment()
+		}
+		return b.Div(ID(field+"-error"), ErrorMessage(message)(b))
+	}
+}
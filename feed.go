@@ -0,0 +1,100 @@
+package minty
+
+import (
+	"time"
+)
+
+// =====================================================
+// RSS FEEDS
+// =====================================================
+
+// RSSChannel is an RSS 2.0 feed rendered by RSS.
+type RSSChannel struct {
+	Title       string
+	Link        string // URL of the site the feed belongs to
+	Description string
+	Language    string    // e.g. "en-us"
+	SelfURL     string    // URL of the feed itself, announced with atom:link rel="self"
+	Updated     time.Time // lastBuildDate; zero leaves it out
+	Items       []RSSItem
+}
+
+// RSSItem is one entry of an RSSChannel.
+type RSSItem struct {
+	Title       string
+	Link        string
+	Description string    // Plain text, or HTML, which is escaped as RSS expects
+	GUID        string    // Unique id of the entry, default Link
+	Published   time.Time // pubDate; zero leaves it out
+	Author      string    // Email address of the author, e.g. "ed@example.com (Ed)"
+	Categories  []string
+}
+
+// RSS renders an RSS 2.0 feed. Render it with RenderXML:
+//
+//	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+//	mi.RenderXML(mi.RSS(mi.RSSChannel{
+//	    Title: "Claims updates",
+//	    Link:  "https://example.com/claims",
+//	    Items: items,
+//	}), w)
+func RSS(channel RSSChannel) H {
+	return func(b *Builder) Node {
+		args := []interface{}{
+			b.Element("title", channel.Title),
+			b.Element("link", channel.Link),
+			b.Element("description", channel.Description),
+		}
+		if channel.Language != "" {
+			args = append(args, b.Element("language", channel.Language))
+		}
+		if !channel.Updated.IsZero() {
+			args = append(args, b.Element("lastBuildDate", channel.Updated.Format(time.RFC1123Z)))
+		}
+		if channel.SelfURL != "" {
+			args = append(args, b.Element("atom:link",
+				Href(channel.SelfURL), Rel("self"), Type("application/rss+xml")))
+		}
+		for _, item := range channel.Items {
+			args = append(args, RSSEntry(item)(b))
+		}
+
+		rss := []interface{}{Attr("version", "2.0")}
+		if channel.SelfURL != "" {
+			rss = append(rss, Attr("xmlns:atom", "http://www.w3.org/2005/Atom"))
+		}
+		return b.Element("rss", append(rss, b.Element("channel", args...))...)
+	}
+}
+
+// RSSEntry renders the <item> of an RSS feed, for feeds assembled by hand.
+func RSSEntry(item RSSItem) H {
+	return func(b *Builder) Node {
+		var args []interface{}
+		if item.Title != "" {
+			args = append(args, b.Element("title", item.Title))
+		}
+		if item.Link != "" {
+			args = append(args, b.Element("link", item.Link))
+		}
+		if item.Description != "" {
+			args = append(args, b.Element("description", item.Description))
+		}
+		if item.Author != "" {
+			args = append(args, b.Element("author", item.Author))
+		}
+		for _, category := range item.Categories {
+			args = append(args, b.Element("category", category))
+		}
+		switch {
+		case item.GUID != "":
+			args = append(args, b.Element("guid", Attr("isPermaLink", "false"), item.GUID))
+		case item.Link != "":
+			args = append(args, b.Element("guid", item.Link))
+		}
+		if !item.Published.IsZero() {
+			args = append(args, b.Element("pubDate", item.Published.Format(time.RFC1123Z)))
+		}
+		return b.Element("item", args...)
+	}
+}
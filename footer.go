@@ -0,0 +1,182 @@
+package minty
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =====================================================
+// SITE FOOTER
+// =====================================================
+
+// FooterColumn is a headed group of footer links.
+type FooterColumn struct {
+	Heading string
+	Links   []NavLink
+}
+
+// SocialLink is an icon link to a profile elsewhere. Label is the link's
+// accessible name; Icon, e.g. an inline SVG, is hidden from assistive
+// technology. Without an Icon the Label is shown as text.
+type SocialLink struct {
+	URL   string
+	Label string
+	Icon  H
+}
+
+// FooterOptions configures a Footer.
+type FooterOptions struct {
+	Columns   []FooterColumn
+	Copyright string       // Holder, e.g. "Acme Inc."; empty for no copyright line
+	Since     int          // First year of the copyright range, e.g. 2019
+	Year      int          // Current year; defaults to the year at render time
+	Legal     []NavLink    // e.g. Privacy and Terms, listed beside the copyright
+	Social    []SocialLink // Icon links to profiles elsewhere
+	BuiltWith H            // Free-form slot, e.g. "Built with minty"
+	Label     string       // Accessible name of the link navigation, default "Footer"
+	Class     string       // Extra classes for the <footer>
+}
+
+// Footer renders a site footer: link columns in a <nav>, then a bottom row
+// with the copyright line, legal links, social links and the BuiltWith
+// slot. Columns sit side by side on wide screens and stack on narrow ones.
+// Requires FooterStyles on the page.
+//
+//	mi.Footer(mi.FooterOptions{
+//	    Columns: []mi.FooterColumn{
+//	        {Heading: "Product", Links: []mi.NavLink{{URL: "/pricing", Text: "Pricing"}}},
+//	    },
+//	    Copyright: "Acme Inc.",
+//	    Since:     2019,
+//	    Legal:     []mi.NavLink{{URL: "/privacy", Text: "Privacy"}},
+//	})
+func Footer(opts FooterOptions) H {
+	return func(b *Builder) Node {
+		label := opts.Label
+		if label == "" {
+			label = "Footer"
+		}
+		linkList := func(class string, links []NavLink) Node {
+			items := []interface{}{Class(class)}
+			for _, link := range links {
+				items = append(items, b.Li(b.A(Href(link.URL), link.Text)))
+			}
+			return b.Ul(items...)
+		}
+
+		footer := []interface{}{Class(strings.TrimSpace("minty-footer " + opts.Class))}
+		if len(opts.Columns) > 0 {
+			nav := []interface{}{Class("minty-footer-columns"), AriaLabel(label)}
+			for _, column := range opts.Columns {
+				nav = append(nav, b.Div(Class("minty-footer-column"),
+					b.H2(Class("minty-footer-heading"), column.Heading),
+					linkList("minty-footer-links", column.Links),
+				))
+			}
+			footer = append(footer, b.Nav(nav...))
+		}
+
+		bottom := []interface{}{Class("minty-footer-bottom")}
+		if opts.Copyright != "" {
+			bottom = append(bottom, b.P(Class("minty-footer-copyright"), copyrightLine(opts)))
+		}
+		if len(opts.Legal) > 0 {
+			bottom = append(bottom, b.Nav(AriaLabel("Legal"), linkList("minty-footer-legal", opts.Legal)))
+		}
+		if len(opts.Social) > 0 {
+			social := []interface{}{Class("minty-footer-social")}
+			for _, link := range opts.Social {
+				content := []interface{}{Href(link.URL), Attr("rel", "me noopener")}
+				if link.Icon != nil {
+					content = append(content, AriaLabel(link.Label),
+						b.Span(Attr("aria-hidden", "true"), link.Icon(b)))
+				} else {
+					content = append(content, link.Label)
+				}
+				social = append(social, b.Li(b.A(content...)))
+			}
+			bottom = append(bottom, b.Ul(social...))
+		}
+		if opts.BuiltWith != nil {
+			bottom = append(bottom, b.Div(Class("minty-footer-built"), opts.BuiltWith(b)))
+		}
+		if len(bottom) > 1 {
+			footer = append(footer, b.Div(bottom...))
+		}
+
+		return b.Footer(footer...)
+	}
+}
+
+// copyrightLine returns e.g. "© 2019–2025 Acme Inc.".
+func copyrightLine(opts FooterOptions) string {
+	year := opts.Year
+	if year == 0 {
+		year = time.Now().Year()
+	}
+	years := strconv.Itoa(year)
+	if opts.Since > 0 && opts.Since < year {
+		years = strconv.Itoa(opts.Since) + "–" + years
+	}
+	return "© " + years + " " + opts.Copyright
+}
+
+// footerCSS lays out the footer with the theme tokens.
+const footerCSS = `.minty-footer {
+  border-top: 1px solid var(--minty-border, #e2e8f0);
+  background: var(--minty-surface, #ffffff);
+  color: var(--minty-muted, #64748b);
+  padding: var(--minty-space-5, 2rem) var(--minty-space-4, 1.5rem);
+  font-size: 0.875rem;
+}
+.minty-footer a { color: inherit; text-decoration: none; }
+.minty-footer a:hover, .minty-footer a:focus-visible { color: var(--minty-primary, #2563eb); text-decoration: underline; }
+.minty-footer ul { list-style: none; margin: 0; padding: 0; }
+.minty-footer-columns {
+  display: grid;
+  grid-template-columns: repeat(auto-fit, minmax(10rem, 1fr));
+  gap: var(--minty-space-4, 1.5rem);
+  margin-bottom: var(--minty-space-4, 1.5rem);
+}
+.minty-footer-heading {
+  margin: 0 0 0.5rem;
+  color: var(--minty-text, #0f172a);
+  font-size: 0.875rem;
+  font-weight: 600;
+}
+.minty-footer-links li + li { margin-top: 0.375rem; }
+.minty-footer-bottom {
+  display: flex;
+  flex-wrap: wrap;
+  align-items: center;
+  gap: 0.75rem 1.5rem;
+}
+.minty-footer-columns + .minty-footer-bottom {
+  padding-top: var(--minty-space-3, 1rem);
+  border-top: 1px solid var(--minty-border, #e2e8f0);
+}
+.minty-footer-columns:last-child { margin-bottom: 0; }
+.minty-footer-copyright { margin: 0; }
+.minty-footer-legal, .minty-footer-social { display: flex; flex-wrap: wrap; gap: 1rem; }
+.minty-footer-social svg { width: 1.25rem; height: 1.25rem; fill: currentColor; }
+.minty-footer-built { margin-left: auto; }
+.dark .minty-footer, [data-theme="dark"] .minty-footer, [data-bs-theme="dark"] .minty-footer {
+  --minty-surface: #0f172a;
+  --minty-border: #334155;
+  --minty-muted: #94a3b8;
+  --minty-text: #f1f5f9;
+}
+@media (max-width: 640px) {
+  .minty-footer-columns { grid-template-columns: 1fr; }
+  .minty-footer-bottom { flex-direction: column; align-items: flex-start; }
+  .minty-footer-built { margin-left: 0; }
+}
+`
+
+// FooterStyles emits the CSS used by Footer.
+func FooterStyles() H {
+	return func(b *Builder) Node {
+		return b.Style(Raw(footerCSS))
+	}
+}
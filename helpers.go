@@ -184,7 +184,7 @@ func Truthy(v interface{}) bool {
 //	})
 func IfTruthy(v interface{}, then H) Node {
 	if Truthy(v) {
-		return then(B)
+		return &deferredNode{template: then}
 	}
 	return nil
 }
@@ -199,9 +199,9 @@ func IfTruthy(v interface{}, then H) Node {
 //	)
 func IfTruthyElse(v interface{}, then, els H) Node {
 	if Truthy(v) {
-		return then(B)
+		return &deferredNode{template: then}
 	}
-	return els(B)
+	return &deferredNode{template: els}
 }
 
 // =============================================================================
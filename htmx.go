@@ -1,6 +1,8 @@
 package minty
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 	"strings"
 )
@@ -37,6 +39,58 @@ func GetHTMXCurrentURL(r *http.Request) string {
 	return r.Header.Get("HX-Current-URL")
 }
 
+// Request-aware Rendering
+
+// RenderRequest renders a template with the request available to components
+// through b.Request, b.IsHTMX and b.HTMXTarget, so a template can render a
+// partial for htmx requests and a full page otherwise without the handler
+// passing flags down. The request's context reaches components through
+// b.Context, so a user stored with WithUser is visible to b.User and b.Can.
+// A nil request renders like Render. Templates run by Each, Filter and the
+// other control helpers see the request too.
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    mi.RenderRequest(r, claimsPage(claims), w)
+//	}
+func RenderRequest(r *http.Request, template H, w io.Writer, opts ...RenderOption) error {
+	b := acquireBuilder()
+	defer releaseBuilder(b)
+	b.request = r
+	return renderWith(b, template, w, opts...)
+}
+
+// Request returns the request passed to RenderRequest, or nil.
+func (b *Builder) Request() *http.Request {
+	return b.request
+}
+
+// IsHTMX reports whether the page is being rendered for an htmx request.
+func (b *Builder) IsHTMX() bool {
+	return b.request != nil && IsHTMX(b.request)
+}
+
+// IsHTMXBoosted reports whether the page is being rendered for a boosted
+// htmx request.
+func (b *Builder) IsHTMXBoosted() bool {
+	return b.request != nil && IsHTMXBoosted(b.request)
+}
+
+// HTMXTarget returns the id of the element the htmx request targets, or ""
+// for full-page renders.
+func (b *Builder) HTMXTarget() string {
+	if b.request == nil {
+		return ""
+	}
+	return GetHTMXTarget(b.request)
+}
+
+// HtmxTargetIf sets hx-target only when condition holds.
+//
+//	b.A(mi.HxGet("/claims/7"), mi.HtmxTargetIf(b.IsHTMX(), "#detail"), "Claim 7")
+func HtmxTargetIf(condition bool, selector string) Attribute {
+	return AttrIf(condition, HtmxTarget(selector))
+}
+
 // HTMX Response Helpers
 
 // SetHTMXTrigger sets the HX-Trigger response header to trigger client-side events.
@@ -240,6 +294,7 @@ func LiveSearch(searchURL, targetSelector, placeholder string) H {
 			HtmxGet(searchURL),
 			HtmxTarget(targetSelector),
 			HtmxTrigger(HTMXTriggers.KeyUpDelayed),
+			HtmxSync("this:replace"),
 			HtmxIndicator("#search-spinner"),
 		)
 	}
@@ -287,12 +342,62 @@ func HTMXForm(method, action, targetSelector string, children ...interface{}) H
 	}
 }
 
+// VisiblePollTrigger returns an hx-trigger value that polls every interval
+// while the page is visible, using the Page Visibility API. Polling pauses
+// while document.hidden and a refresh fires as soon as the tab is shown.
+// Combine with other triggers as needed, e.g. "load, " + VisiblePollTrigger("5s").
+func VisiblePollTrigger(interval string) string {
+	return "every " + interval + " [document.visibilityState === 'visible'], " +
+		"visibilitychange[document.visibilityState === 'visible'] from:document"
+}
+
+// DebouncedTrigger returns an hx-trigger value that fires event only after
+// ms milliseconds without another occurrence. Comma-separated events are
+// each debounced; an existing delay: modifier is replaced. Keyboard and input
+// events also get the changed modifier, so keys that do not alter the value
+// (arrows, shift) send nothing.
+//
+//	DebouncedTrigger("keyup", 300)        // "keyup changed delay:300ms"
+//	DebouncedTrigger("input, change", 250) // "input changed delay:250ms, change delay:250ms"
+func DebouncedTrigger(event string, ms int) string {
+	var specs []string
+	for _, spec := range strings.Split(event, ",") {
+		fields := strings.Fields(spec)
+		if len(fields) == 0 {
+			continue
+		}
+		out := fields[:1:1]
+		hasChanged := false
+		for _, modifier := range fields[1:] {
+			if strings.HasPrefix(modifier, "delay:") {
+				continue
+			}
+			if modifier == "changed" {
+				hasChanged = true
+			}
+			out = append(out, modifier)
+		}
+		switch fields[0] {
+		case "keyup", "keydown", "keypress", "input":
+			if !hasChanged {
+				out = append(out[:1], append([]string{"changed"}, out[1:]...)...)
+			}
+		}
+		if ms > 0 {
+			out = append(out, fmt.Sprintf("delay:%dms", ms))
+		}
+		specs = append(specs, strings.Join(out, " "))
+	}
+	return strings.Join(specs, ", ")
+}
+
 // AutoRefresh creates an element that automatically refreshes via HTMX.
+// Polling pauses while the tab is hidden.
 func AutoRefresh(url string, interval string) H {
 	return func(b *Builder) Node {
 		return b.Div(
 			HtmxGet(url),
-			HtmxTrigger("load, every "+interval),
+			HtmxTrigger("load, "+VisiblePollTrigger(interval)),
 			HtmxTarget("this"),
 			HtmxSwap("outerHTML"),
 		)
@@ -372,6 +477,7 @@ func SearchWithFilters(searchURL string, filters []FilterOption) H {
 			HtmxGet(searchURL),
 			HtmxTarget("#search-results"),
 			HtmxTrigger("submit, change"),
+			HtmxSync("this:replace"),
 			
 			// Search input
 			b.Input(
@@ -0,0 +1,147 @@
+package minty
+
+import (
+	"embed"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// =====================================================
+// ICONS
+// =====================================================
+
+// iconFiles holds the built-in icon set: Heroicons outline, 24x24, one
+// <svg> document per icon, named after the file.
+//
+//go:embed icons/*.svg
+var iconFiles embed.FS
+
+// icons maps icon names to the inner markup of their <svg>. It is loaded
+// from iconFiles on first use and extended by RegisterIcon.
+var (
+	iconsOnce sync.Once
+	iconsMu   sync.RWMutex
+	icons     map[string]string
+)
+
+// loadIcons reads the built-in icon set.
+func loadIcons() {
+	entries, _ := iconFiles.ReadDir("icons")
+	set := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := iconFiles.ReadFile("icons/" + entry.Name())
+		if err != nil {
+			continue
+		}
+		set[strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))] = svgInner(string(data))
+	}
+	iconsMu.Lock()
+	defer iconsMu.Unlock()
+	for name, body := range icons {
+		set[name] = body // icons registered before the first load win
+	}
+	icons = set
+}
+
+// svgInner returns the markup between an <svg> element's tags, or doc
+// itself when it is not wrapped in one.
+func svgInner(doc string) string {
+	doc = strings.TrimSpace(doc)
+	if !strings.HasPrefix(doc, "<svg") {
+		return doc
+	}
+	start := strings.IndexByte(doc, '>')
+	end := strings.LastIndex(doc, "</svg>")
+	if start < 0 || end < start {
+		return doc
+	}
+	return strings.TrimSpace(doc[start+1 : end])
+}
+
+// iconBody returns the markup of a named icon.
+func iconBody(name string) (string, bool) {
+	iconsOnce.Do(loadIcons)
+	iconsMu.RLock()
+	defer iconsMu.RUnlock()
+	body, ok := icons[name]
+	return body, ok
+}
+
+// RegisterIcon adds an icon to the set used by Icon, or replaces a built-in
+// one. svgBody is the markup inside the <svg>, drawn on the same 24x24
+// canvas as the built-in outline icons, with stroke="currentColor"; give
+// filled shapes fill="currentColor" themselves. A whole <svg> document is
+// accepted too, in which case only its content is kept.
+//
+//	mi.RegisterIcon("anchor", `<circle cx="12" cy="5" r="2"/><path d="M12 7v14M5 14a7 7 0 0 0 14 0"/>`)
+func RegisterIcon(name, svgBody string) {
+	iconsMu.Lock()
+	defer iconsMu.Unlock()
+	if icons == nil {
+		icons = make(map[string]string)
+	}
+	icons[name] = svgInner(svgBody)
+}
+
+// IconNames returns the names of all available icons, sorted.
+func IconNames() []string {
+	iconsOnce.Do(loadIcons)
+	iconsMu.RLock()
+	defer iconsMu.RUnlock()
+	names := make([]string, 0, len(icons))
+	for name := range icons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Icon renders a named icon as inline SVG with the given classes, e.g.
+// Tailwind's "w-5 h-5". The icon inherits the text color. It is decorative,
+// hidden from screen readers, so the surrounding text or control must say
+// what it means; use LabeledIcon for an icon that stands on its own. An
+// unknown name renders an empty icon of the same size.
+//
+//	b.Button(mi.Icon("trash", "w-5 h-5")(b), "Delete")
+//
+// The built-in set is Heroicons' outline style; IconNames lists it and
+// RegisterIcon adds to it.
+func Icon(name, class string) H {
+	return icon(name, class, "")
+}
+
+// LabeledIcon renders an icon that conveys meaning by itself, such as a
+// status indicator, announced to screen readers as an image named label.
+//
+//	mi.LabeledIcon("check-circle", "w-5 h-5 text-green-600", "Paid")
+func LabeledIcon(name, class, label string) H {
+	return icon(name, class, label)
+}
+
+// icon renders an icon, decorative when label is empty.
+func icon(name, class, label string) H {
+	return func(b *Builder) Node {
+		body, ok := iconBody(name)
+		args := []interface{}{
+			Attr("xmlns", "http://www.w3.org/2000/svg"),
+			Attr("viewBox", "0 0 24 24"),
+			Attr("fill", "none"),
+			Attr("stroke", "currentColor"),
+			Attr("stroke-width", "1.5"),
+			classAttr(class),
+			Data("icon", name),
+			Attr("focusable", "false"),
+		}
+		if label == "" {
+			args = append(args, Attr("aria-hidden", "true"))
+		} else {
+			args = append(args, Attr("role", "img"), AriaLabel(label), b.Title(label))
+		}
+		if ok {
+			args = append(args, Raw(body))
+		}
+		return b.Svg(args...)
+	}
+}
@@ -0,0 +1,92 @@
+package minty
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// =====================================================
+// CONDITIONAL RENDERING
+// =====================================================
+
+// RenderIfChanged renders template and writes it to w only when its content
+// hash differs from prevHash, the hash returned by the previous call. It
+// returns the new hash and whether anything was written. Attributes are
+// rendered in canonical order so that identical content always hashes the
+// same.
+//
+//	hash, changed, err := mi.RenderIfChanged(r.Header.Get("X-Panel-Hash"), panel(stats), &buf)
+//	w.Header().Set("X-Panel-Hash", hash)
+//	if !changed {
+//	    w.WriteHeader(http.StatusNoContent) // htmx leaves the panel alone
+//	}
+//
+// ServeIfChanged does the same with standard ETag validation.
+func RenderIfChanged(prevHash string, template H, w io.Writer, opts ...RenderOption) (hash string, changed bool, err error) {
+	b := acquireBuilder()
+	defer releaseBuilder(b)
+	content, hash, err := renderHashed(b, template, opts)
+	if err != nil || hash == prevHash {
+		return hash, false, err
+	}
+	_, err = w.Write(content)
+	return hash, true, err
+}
+
+// ServeIfChanged renders template for r, like RenderRequest, as a response
+// validated by ETag: when the request's If-None-Match names the content's
+// hash, it answers 304 Not Modified without a body. The response is marked
+// Cache-Control: no-cache, so the browser revalidates every htmx poll on
+// its own and reuses the cached body when nothing has changed.
+//
+//	mux.HandleFunc("/dashboard/stats", func(w http.ResponseWriter, r *http.Request) {
+//	    mi.ServeIfChanged(w, r, statsPanel(loadStats()))
+//	})
+func ServeIfChanged(w http.ResponseWriter, r *http.Request, template H, opts ...RenderOption) error {
+	b := acquireBuilder()
+	defer releaseBuilder(b)
+	b.request = r
+	content, hash, err := renderHashed(b, template, opts)
+	if err != nil {
+		return err
+	}
+	header := w.Header()
+	header.Set("ETag", `"`+hash+`"`)
+	header.Set("Cache-Control", "no-cache")
+	if etagMatches(r.Header.Get("If-None-Match"), hash) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "text/html; charset=utf-8")
+	}
+	_, err = w.Write(content)
+	return err
+}
+
+// renderHashed renders template to memory and returns it with its hash.
+func renderHashed(b *Builder, template H, opts []RenderOption) ([]byte, string, error) {
+	var buf bytes.Buffer
+	opts = append(opts[:len(opts):len(opts)], WithCanonicalAttributes())
+	if err := renderWith(b, template, &buf, opts...); err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return buf.Bytes(), hex.EncodeToString(sum[:16]), nil
+}
+
+// etagMatches reports whether an If-None-Match header names hash. Weak
+// validators match too, since the comparison is of content.
+func etagMatches(ifNoneMatch, hash string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || strings.Trim(tag, `"`) == hash {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package minty
+
+import (
+	"errors"
+	"io"
+)
+
+// PictureSource is a <source> inside a <picture>, selected by format
+// (Type, e.g. "image/avif") and/or a media query.
+type PictureSource struct {
+	Type    string        // MIME type, e.g. "image/webp"
+	Media   string        // Optional media query
+	Entries []SrcSetEntry // Candidates for this source
+	Sizes   string        // Optional sizes, overrides PictureOptions.Sizes
+}
+
+// PictureOptions configures a responsive <picture>.
+type PictureOptions struct {
+	Src        string          // Fallback image URL
+	SrcSet     []SrcSetEntry   // Optional srcset for the fallback <img>
+	Sources    []PictureSource // Format/media alternatives, in preference order
+	Sizes      string          // Sizes for width-described candidates, e.g. "(max-width: 600px) 100vw, 50vw"
+	Alt        string          // Required unless Decorative
+	Decorative bool            // Purely decorative: renders alt="" intentionally
+	Width      int             // Intrinsic width, prevents layout shift
+	Height     int             // Intrinsic height, prevents layout shift
+	Eager      bool            // Load immediately (above-the-fold images) instead of lazily
+	Class      string          // Class for the <img>
+}
+
+// ErrMissingAlt is returned when rendering a Picture without alt text.
+var ErrMissingAlt = errors.New("minty: picture requires alt text (set Alt or Decorative)")
+
+// Picture renders a <picture> with <source> entries and a fallback <img>
+// using lazy loading, async decoding and explicit dimensions. Alt text is
+// enforced: without Alt (and not Decorative) rendering fails with ErrMissingAlt.
+func Picture(opts PictureOptions) H {
+	return func(b *Builder) Node {
+		if opts.Alt == "" && !opts.Decorative {
+			return errorNode{err: ErrMissingAlt}
+		}
+
+		var children []interface{}
+		for _, src := range opts.Sources {
+			attrs := []Attribute{SrcSet(src.Entries)}
+			if src.Type != "" {
+				attrs = append(attrs, Type(src.Type))
+			}
+			if src.Media != "" {
+				attrs = append(attrs, Media(src.Media))
+			}
+			if sizes := src.Sizes; sizes != "" {
+				attrs = append(attrs, Sizes(sizes))
+			} else if opts.Sizes != "" {
+				attrs = append(attrs, Sizes(opts.Sizes))
+			}
+			children = append(children, b.Source(attrs...))
+		}
+
+		imgAttrs := []Attribute{Src(opts.Src), Alt(opts.Alt), Decoding("async")}
+		if opts.Eager {
+			imgAttrs = append(imgAttrs, Loading("eager"))
+		} else {
+			imgAttrs = append(imgAttrs, Loading("lazy"))
+		}
+		if len(opts.SrcSet) > 0 {
+			imgAttrs = append(imgAttrs, SrcSet(opts.SrcSet))
+			if opts.Sizes != "" {
+				imgAttrs = append(imgAttrs, Sizes(opts.Sizes))
+			}
+		}
+		if opts.Width > 0 {
+			imgAttrs = append(imgAttrs, Width(opts.Width))
+		}
+		if opts.Height > 0 {
+			imgAttrs = append(imgAttrs, Height(opts.Height))
+		}
+		if opts.Class != "" {
+			imgAttrs = append(imgAttrs, Class(opts.Class))
+		}
+		children = append(children, b.Img(imgAttrs...))
+
+		return b.Picture(children...)
+	}
+}
+
+// errorNode is a node that fails to render, surfacing template errors
+// through the normal Render error path.
+type errorNode struct {
+	err error
+}
+
+// Render returns the node's error.
+func (n errorNode) Render(w io.Writer) error {
+	return n.err
+}
@@ -0,0 +1,73 @@
+package minty
+
+import (
+	"io"
+	"strings"
+)
+
+// =====================================================
+// INDENTED OUTPUT
+// =====================================================
+
+// WithIndent pretty-prints the HTML for reading and debugging: block
+// elements such as div, p and li start on their own line, and the children
+// of a block that contains blocks are indented one level further, by the
+// given string. Inline elements like span, a and strong stay on the line of
+// their text, and line breaks are only added next to block elements, where
+// whitespace does not render, so the page looks the same. The content of
+// pre, textarea, script and style is left untouched, as are Raw nodes, and
+// fragments add no indentation level. WithMinify is ignored, and so is
+// WithIndent itself together with WithXML or WithText.
+func WithIndent(indent string) RenderOption {
+	return func(c *renderConfig) { c.indent = indent }
+}
+
+// RenderIndented renders a template as indented HTML, see WithIndent.
+//
+//	mi.RenderIndented(quotePage(quote), os.Stdout, "  ")
+func RenderIndented(template H, w io.Writer, indent string) error {
+	return Render(template, w, WithIndent(indent))
+}
+
+// newline starts a new line at the current depth, unless nothing has been
+// written yet.
+func (w *renderWriter) newline() error {
+	w.breakNext = false
+	if !w.started {
+		w.started = true
+		return nil
+	}
+	_, err := io.WriteString(w.Writer, "\n"+strings.Repeat(w.indent, w.depth))
+	return err
+}
+
+// inline starts inline content, on a new line when it follows a block.
+func (w *renderWriter) inline() error {
+	if w.breakNext {
+		return w.newline()
+	}
+	w.started = true
+	return nil
+}
+
+// hasBlockChild reports whether children include a block element, looking
+// through fragments. Other nodes, such as Stream nodes, may hold blocks and
+// count as one.
+func hasBlockChild(children []Node) bool {
+	for _, child := range children {
+		switch n := child.(type) {
+		case *Element:
+			if blockElements[n.Tag] {
+				return true
+			}
+		case *Fragment:
+			if hasBlockChild(n.Children) {
+				return true
+			}
+		case *TextNode, *RawNode, *CommentNode, nil:
+		default:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+package minty
+
+import "sync/atomic"
+
+// =====================================================
+// LAYOUT PRIMITIVES
+// =====================================================
+
+// LayoutClasses maps the layout primitives Stack, Row, Cluster and Grid to
+// a CSS framework's classes. Gaps are steps on the spacing scale of
+// ThemeTokens, 0 (none) to 7 (3rem); frameworks with a shorter scale use
+// the nearest step. Class names are returned whole, never assembled, so
+// class scanners such as Tailwind's find them in this file.
+type LayoutClasses interface {
+	Stack(gap int) string             // Vertical flow
+	Row(gap int, align string) string // Horizontal, no wrapping
+	Cluster(gap int) string           // Horizontal, wrapping
+	Grid(columns, gap int) string     // Responsive columns
+	GridItem(columns int) string      // Wrapper for each grid child; "" for none
+}
+
+// layoutClasses holds the LayoutClasses installed by SetLayoutClasses.
+var layoutClasses atomic.Pointer[LayoutClasses]
+
+// SetLayoutClasses installs the classes used by Stack, Row, Cluster and
+// Grid, e.g. BootstrapLayout{} for a Bootstrap site. Pass nil to restore
+// the default, TailwindLayout{}.
+func SetLayoutClasses(l LayoutClasses) {
+	if l == nil {
+		layoutClasses.Store(nil)
+		return
+	}
+	layoutClasses.Store(&l)
+}
+
+// currentLayout returns the installed LayoutClasses.
+func currentLayout() LayoutClasses {
+	if l := layoutClasses.Load(); l != nil {
+		return *l
+	}
+	return TailwindLayout{}
+}
+
+// Stack lays children out top to bottom with gap between them.
+//
+//	mi.Stack(4, header, body, actions)
+func Stack(gap int, children ...H) H {
+	return layoutBox(currentLayout().Stack(gap), "", children)
+}
+
+// Row lays children out side by side without wrapping, aligned vertically
+// by align: "start", "center" (the default), "end", "baseline" or
+// "stretch".
+func Row(gap int, align string, children ...H) H {
+	return layoutBox(currentLayout().Row(gap, align), "", children)
+}
+
+// Cluster lays children out side by side, wrapping onto new lines as
+// needed, for tags, badges and button groups.
+func Cluster(gap int, children ...H) H {
+	return layoutBox(currentLayout().Cluster(gap), "", children)
+}
+
+// Grid lays children out in equal columns: one on phones, two from the
+// small breakpoint and the full number from the large breakpoint.
+//
+//	mi.Grid(3, 4, statCard(a), statCard(b), statCard(c))
+func Grid(columns, gap int, children ...H) H {
+	l := currentLayout()
+	return layoutBox(l.Grid(columns, gap), l.GridItem(columns), children)
+}
+
+// layoutBox renders children in a div with class, wrapping each child in a
+// div with itemClass when it is set. Nil children are skipped.
+func layoutBox(class, itemClass string, children []H) H {
+	return func(b *Builder) Node {
+		content := []interface{}{Class(class)}
+		for _, child := range children {
+			if child == nil {
+				continue
+			}
+			if itemClass != "" {
+				content = append(content, b.Div(Class(itemClass), child(b)))
+			} else {
+				content = append(content, child(b))
+			}
+		}
+		return b.Div(content...)
+	}
+}
+
+// layoutStep clamps n to an index into a table of length size.
+func layoutStep(n, size int) int {
+	if n < 0 {
+		return 0
+	}
+	if n >= size {
+		return size - 1
+	}
+	return n
+}
+
+// layoutAlign returns the alignment keyword, defaulting to center.
+func layoutAlign(align string) string {
+	switch align {
+	case "start", "end", "baseline", "stretch":
+		return align
+	default:
+		return "center"
+	}
+}
+
+// TailwindLayout is the default LayoutClasses, using Tailwind utilities.
+type TailwindLayout struct{}
+
+var (
+	tailwindGaps = [...]string{"gap-0", "gap-1", "gap-2", "gap-3", "gap-4", "gap-6", "gap-8", "gap-12"}
+
+	tailwindAlign = map[string]string{
+		"start": "items-start", "center": "items-center", "end": "items-end",
+		"baseline": "items-baseline", "stretch": "items-stretch",
+	}
+
+	tailwindColumns = [...]string{
+		"grid-cols-1",
+		"grid-cols-1 sm:grid-cols-2",
+		"grid-cols-1 sm:grid-cols-2 lg:grid-cols-3",
+		"grid-cols-1 sm:grid-cols-2 lg:grid-cols-4",
+		"grid-cols-1 sm:grid-cols-2 lg:grid-cols-5",
+		"grid-cols-1 sm:grid-cols-2 lg:grid-cols-6",
+		"grid-cols-1 sm:grid-cols-2 lg:grid-cols-7",
+		"grid-cols-1 sm:grid-cols-2 lg:grid-cols-8",
+		"grid-cols-1 sm:grid-cols-2 lg:grid-cols-9",
+		"grid-cols-1 sm:grid-cols-2 lg:grid-cols-10",
+		"grid-cols-1 sm:grid-cols-2 lg:grid-cols-11",
+		"grid-cols-1 sm:grid-cols-2 lg:grid-cols-12",
+	}
+)
+
+func (TailwindLayout) gap(gap int) string {
+	return tailwindGaps[layoutStep(gap, len(tailwindGaps))]
+}
+
+func (t TailwindLayout) Stack(gap int) string {
+	return "flex flex-col " + t.gap(gap)
+}
+
+func (t TailwindLayout) Row(gap int, align string) string {
+	return "flex flex-row " + tailwindAlign[layoutAlign(align)] + " " + t.gap(gap)
+}
+
+func (t TailwindLayout) Cluster(gap int) string {
+	return "flex flex-wrap items-center " + t.gap(gap)
+}
+
+func (t TailwindLayout) Grid(columns, gap int) string {
+	return "grid " + tailwindColumns[layoutStep(columns-1, len(tailwindColumns))] + " " + t.gap(gap)
+}
+
+func (TailwindLayout) GridItem(columns int) string {
+	return ""
+}
+
+// BootstrapLayout is the LayoutClasses for Bootstrap 5. Grid renders a
+// .row with row-cols-* and wraps each child in a .col.
+type BootstrapLayout struct{}
+
+var (
+	// Bootstrap's spacers are 0, .25, .5, 1, 1.5 and 3rem
+	bootstrapGaps = [...]string{"0", "1", "2", "2", "3", "4", "4", "5"}
+
+	bootstrapAlign = map[string]string{
+		"start": "align-items-start", "center": "align-items-center", "end": "align-items-end",
+		"baseline": "align-items-baseline", "stretch": "align-items-stretch",
+	}
+
+	bootstrapColumns = [...]string{
+		"row-cols-1",
+		"row-cols-1 row-cols-sm-2",
+		"row-cols-1 row-cols-sm-2 row-cols-lg-3",
+		"row-cols-1 row-cols-sm-2 row-cols-lg-4",
+		"row-cols-1 row-cols-sm-2 row-cols-lg-5",
+		"row-cols-1 row-cols-sm-2 row-cols-lg-6",
+	}
+)
+
+func (BootstrapLayout) gap(prefix string, gap int) string {
+	return prefix + bootstrapGaps[layoutStep(gap, len(bootstrapGaps))]
+}
+
+func (l BootstrapLayout) Stack(gap int) string {
+	return "vstack " + l.gap("gap-", gap)
+}
+
+func (l BootstrapLayout) Row(gap int, align string) string {
+	return "hstack " + bootstrapAlign[layoutAlign(align)] + " " + l.gap("gap-", gap)
+}
+
+func (l BootstrapLayout) Cluster(gap int) string {
+	return "d-flex flex-wrap align-items-center " + l.gap("gap-", gap)
+}
+
+func (l BootstrapLayout) Grid(columns, gap int) string {
+	return "row " + bootstrapColumns[layoutStep(columns-1, len(bootstrapColumns))] + " " + l.gap("g-", gap)
+}
+
+func (BootstrapLayout) GridItem(columns int) string {
+	return "col"
+}
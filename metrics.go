@@ -0,0 +1,102 @@
+package minty
+
+import (
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics describes a single render.
+type Metrics struct {
+	Nodes    int           // Nodes in the rendered tree (elements, text, raw, fragments)
+	Bytes    int64         // Bytes written to the output
+	Duration time.Duration // Time spent building and writing the tree
+	Err      error         // Render error, if any
+}
+
+// renderObserver holds the callback installed by SetRenderObserver.
+var renderObserver atomic.Pointer[func(Metrics)]
+
+// SetRenderObserver installs fn to be called with the metrics of every
+// Render, RenderToString and RenderWithMetrics call. Pass nil to remove it.
+// With no observer set, Render takes the plain path and collects nothing.
+func SetRenderObserver(fn func(Metrics)) {
+	if fn == nil {
+		renderObserver.Store(nil)
+		return
+	}
+	renderObserver.Store(&fn)
+}
+
+// RenderWithMetrics renders a template and reports node count, byte count
+// and elapsed time. The render observer, if any, is also notified.
+func RenderWithMetrics(template H, w io.Writer) (Metrics, error) {
+	b := acquireBuilder()
+	defer releaseBuilder(b)
+	return renderWithMetrics(b, template, w)
+}
+
+// renderWithMetrics is RenderWithMetrics with the given builder.
+func renderWithMetrics(b *Builder, template H, w io.Writer) (Metrics, error) {
+	m := measureRender(b, template, w)
+	if obs := renderObserver.Load(); obs != nil {
+		(*obs)(m)
+	}
+	return m, m.Err
+}
+
+// measureRender renders a template while collecting metrics.
+func measureRender(b *Builder, template H, w io.Writer) Metrics {
+	start := time.Now()
+	cw := &countingWriter{w: w}
+	var out io.Writer = cw
+	if mode, ok := w.(*renderWriter); ok {
+		// Count below the mode so the render keeps its state
+		cw.w = mode.Writer
+		mode.Writer = cw
+		defer func() { mode.Writer = cw.w }()
+		out = mode
+	}
+	node := b.resolve(template(b))
+	err := node.Render(out)
+	return Metrics{
+		Nodes:    countNodes(node),
+		Bytes:    cw.n,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+}
+
+// countNodes counts the nodes in a tree.
+func countNodes(node Node) int {
+	switch n := node.(type) {
+	case nil:
+		return 0
+	case *Element:
+		count := 1
+		for _, child := range n.Children {
+			count += countNodes(child)
+		}
+		return count
+	case *Fragment:
+		count := 1
+		for _, child := range n.Children {
+			count += countNodes(child)
+		}
+		return count
+	default:
+		return 1
+	}
+}
+
+// countingWriter counts bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
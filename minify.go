@@ -0,0 +1,373 @@
+package minty
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// RenderOption configures Render, RenderToString, RenderFile and
+// RenderStream.
+type RenderOption func(*renderConfig)
+
+type renderConfig struct {
+	minify    bool
+	minifyCSS bool
+	canonical bool
+	xml       bool
+	text      bool
+	indent    string
+	nonce     string
+	stream    StreamOptions // RenderStream only
+	fileMode  os.FileMode // RenderFile only
+	dirMode   os.FileMode // RenderFile only; zero means do not create directories
+}
+
+// WithMinify passes the rendered HTML through MinifyHTML before writing it,
+// except for the content of Raw nodes, which may be pre-formatted CSS or
+// JavaScript and is written as it is. The whole page is buffered first, so
+// use it for pages rather than for streamed responses.
+func WithMinify() RenderOption {
+	return func(c *renderConfig) { c.minify = true }
+}
+
+// WithMinifyCSS is WithMinify that also minifies the CSS of inline <style>
+// blocks with MinifyCSS, see MinifyHTMLWithCSS. It is a separate option
+// because it changes style content, which WithMinify leaves alone.
+func WithMinifyCSS() RenderOption {
+	return func(c *renderConfig) { c.minify, c.minifyCSS = true, true }
+}
+
+// RenderMinified renders a template as minified HTML, see WithMinify.
+//
+//	mi.RenderMinified(dashboard(data), w)
+func RenderMinified(template H, w io.Writer) error {
+	return Render(template, w, WithMinify())
+}
+
+// blockElements are elements whose surrounding whitespace never renders, so
+// MinifyHTML may drop it entirely instead of collapsing it to one space.
+var blockElements = map[string]bool{
+	"!doctype": true, "html": true, "head": true, "body": true, "title": true,
+	"meta": true, "link": true, "base": true, "div": true, "p": true,
+	"ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"table": true, "thead": true, "tbody": true, "tfoot": true, "tr": true,
+	"td": true, "th": true, "caption": true, "colgroup": true, "col": true,
+	"form": true, "fieldset": true, "legend": true, "section": true,
+	"article": true, "aside": true, "header": true, "footer": true,
+	"nav": true, "main": true, "h1": true, "h2": true, "h3": true, "h4": true,
+	"h5": true, "h6": true, "hr": true, "figure": true, "figcaption": true,
+	"blockquote": true, "pre": true, "address": true, "details": true, "summary": true,
+	"dialog": true, "option": true, "optgroup": true, "noscript": true,
+	"template": true,
+}
+
+// rawTextElements keep their content byte for byte.
+var rawTextElements = map[string]bool{
+	"pre": true, "textarea": true, "script": true, "style": true,
+}
+
+// MinifyHTML shrinks HTML without changing how it renders. Whitespace runs
+// collapse to a single space, and are dropped next to block-level tags where
+// they cannot render; a space between inline elements such as
+// "<b>a</b> <i>b</i>" is kept. HTML comments are removed except conditional
+// comments. The content of pre, textarea, script and style is left
+// untouched.
+func MinifyHTML(html string) string {
+	return minifyHTML(html, nil, false)
+}
+
+// MinifyHTMLWithCSS is MinifyHTML that also minifies inline <style> blocks
+// with MinifyCSS, for pages whose styles are known to be safe to rewrite.
+func MinifyHTMLWithCSS(html string) string {
+	return minifyHTML(html, nil, true)
+}
+
+// minifyHTML is MinifyHTML leaving the given byte ranges of html, the
+// output of Raw nodes, as they are, and minifying style blocks when css is
+// set.
+func minifyHTML(html string, verbatim [][2]int, css bool) string {
+	m := htmlMinifier{afterBlock: true, css: css}
+	m.out.Grow(len(html))
+	pos := 0
+	for _, span := range verbatim {
+		m.minify(html[pos:span[0]])
+		m.verbatim(html[span[0]:span[1]])
+		pos = span[1]
+	}
+	m.minify(html[pos:])
+	return m.out.String()
+}
+
+// htmlMinifier is the state of MinifyHTML, kept between the pieces of a
+// document split around verbatim ranges.
+type htmlMinifier struct {
+	out strings.Builder
+
+	// pendingSpace is collapsed whitespace not yet written: it is dropped if
+	// a block-level tag follows, written otherwise.
+	pendingSpace bool
+	// afterBlock reports whether the last thing written was a block-level
+	// tag, or nothing at all, so leading whitespace can be dropped.
+	afterBlock bool
+	// rawTag is the raw text element whose end tag is still to come.
+	rawTag string
+	// css minifies the content of style elements, see MinifyHTMLWithCSS.
+	css bool
+}
+
+// verbatim writes s as it is.
+func (m *htmlMinifier) verbatim(s string) {
+	if s == "" {
+		return
+	}
+	if m.rawTag == "" {
+		m.writePending()
+		m.afterBlock = false
+	}
+	m.out.WriteString(s)
+}
+
+// minify minifies the next piece of the document.
+func (m *htmlMinifier) minify(html string) {
+	for i := 0; i < len(html); {
+		if m.rawTag != "" {
+			i += m.rawText(html[i:])
+			continue
+		}
+
+		lt := strings.IndexByte(html[i:], '<')
+		if lt < 0 {
+			m.text(html[i:])
+			break
+		}
+		m.text(html[i : i+lt])
+		i += lt
+
+		// Comments
+		if strings.HasPrefix(html[i:], "<!--") {
+			end := strings.Index(html[i+4:], "-->")
+			if end < 0 {
+				end = len(html) - i - 4
+			} else {
+				end += 3
+			}
+			comment := html[i : i+4+end]
+			i += 4 + end
+			if strings.HasPrefix(comment, "<!--[if") || strings.HasSuffix(comment, "<![endif]-->") {
+				m.writePending()
+				m.out.WriteString(comment)
+				m.afterBlock = false
+			}
+			continue
+		}
+
+		name, closing, ok := tagName(html[i:])
+		if !ok {
+			// A "<" that does not start a tag is text
+			m.text("<")
+			i++
+			continue
+		}
+		end := tagEnd(html, i)
+		tag := html[i:end]
+		i = end
+
+		if blockElements[name] {
+			m.pendingSpace = false
+			m.out.WriteString(tag)
+			m.afterBlock = true
+		} else {
+			m.writePending()
+			m.out.WriteString(tag)
+			m.afterBlock = false
+		}
+
+		if !closing && rawTextElements[name] && !strings.HasSuffix(tag, "/>") {
+			m.rawTag = name
+		}
+	}
+}
+
+// rawText copies the content of the current raw text element up to its end
+// tag, or all of html when the end tag is not in it, and returns the number
+// of bytes consumed.
+func (m *htmlMinifier) rawText(html string) int {
+	end := indexFold(html, "</"+m.rawTag)
+	if end < 0 {
+		end = len(html)
+	}
+	content := html[:end]
+	if m.rawTag == "style" && m.css {
+		content = MinifyCSS(content)
+	}
+	m.out.WriteString(content)
+	if end < len(html) {
+		m.rawTag = ""
+	}
+	return end
+}
+
+// text writes text with whitespace collapsed.
+func (m *htmlMinifier) text(text string) {
+	for i := 0; i < len(text); i++ {
+		if isHTMLSpace(text[i]) {
+			if !m.afterBlock {
+				m.pendingSpace = true
+			}
+			continue
+		}
+		m.writePending()
+		m.out.WriteByte(text[i])
+		m.afterBlock = false
+	}
+}
+
+// writePending writes a pending collapsed space.
+func (m *htmlMinifier) writePending() {
+	if m.pendingSpace {
+		m.out.WriteByte(' ')
+		m.pendingSpace = false
+	}
+}
+
+// tagName returns the lower-cased name of the tag starting at s[0] == '<'.
+func tagName(s string) (name string, closing, ok bool) {
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		closing = true
+		i++
+	}
+	start := i
+	if i < len(s) && s[i] == '!' && !closing {
+		i++
+	}
+	for i < len(s) && (isASCIILetter(s[i]) || (i > start && (s[i] >= '0' && s[i] <= '9' || s[i] == '-'))) {
+		i++
+	}
+	if i == start || (s[start] == '!' && i == start+1) {
+		return "", false, false
+	}
+	return strings.ToLower(s[start:i]), closing, true
+}
+
+// tagEnd returns the index just past the '>' closing the tag at html[start],
+// skipping '>' inside quoted attribute values.
+func tagEnd(html string, start int) int {
+	var quote byte
+	for i := start + 1; i < len(html); i++ {
+		c := html[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1
+		}
+	}
+	return len(html)
+}
+
+// indexFold is strings.Index ignoring ASCII case.
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// MinifyCSS removes comments and redundant whitespace from a stylesheet.
+// Spaces are dropped around braces, semicolons, commas and declaration
+// colons, and the last semicolon of each block is removed. Quoted strings
+// are left alone, and a space before a colon in a selector is kept since
+// "a :hover" and "a:hover" are different selectors.
+func MinifyCSS(css string) string {
+	out := make([]byte, 0, len(css))
+	space := false
+	depth, stmt := 0, 0 // Block nesting, and where the current statement starts in out
+	// separated reports whether a space before c is redundant
+	separated := func(c byte) bool {
+		if len(out) == 0 || strings.IndexByte("{};,:(", out[len(out)-1]) >= 0 {
+			return true
+		}
+		// The colon of a declaration, as opposed to one in a selector
+		return c == ':' && depth > 0 && isCSSIdent(out[stmt:])
+	}
+
+	for i := 0; i < len(css); i++ {
+		c := css[i]
+		switch {
+		case c == '/' && i+1 < len(css) && css[i+1] == '*':
+			end := strings.Index(css[i+2:], "*/")
+			if end < 0 {
+				i = len(css)
+			} else {
+				i += end + 3
+			}
+			space = true
+		case isHTMLSpace(c):
+			space = true
+		case c == '{' || c == '}' || c == ';' || c == ',':
+			if c == '}' && len(out) > 0 && out[len(out)-1] == ';' {
+				out = out[:len(out)-1]
+			}
+			switch c {
+			case '{':
+				depth++
+			case '}':
+				depth--
+			}
+			out = append(out, c)
+			if c != ',' {
+				stmt = len(out)
+			}
+			space = false
+		default:
+			if space && !separated(c) {
+				out = append(out, ' ')
+			}
+			space = false
+			if c != '"' && c != '\'' {
+				out = append(out, c)
+				continue
+			}
+			// Copy a quoted string verbatim
+			j := i + 1
+			for j < len(css) && css[j] != c {
+				if css[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(css) {
+				j = len(css) - 1
+			}
+			out = append(out, css[i:j+1]...)
+			i = j
+		}
+	}
+	return string(out)
+}
+
+// isCSSIdent reports whether b is a non-empty property name.
+func isCSSIdent(b []byte) bool {
+	for _, c := range b {
+		if !isASCIILetter(c) && c != '-' {
+			return false
+		}
+	}
+	return len(b) > 0
+}
@@ -3,10 +3,13 @@
 package minty
 
 import (
+	"bytes"
 	"fmt"
 	"html"
 	"io"
+	"net/http"
 	"strings"
+	"sync"
 )
 
 // Node represents any HTML content that can be rendered.
@@ -25,39 +28,107 @@ type Element struct {
 	SelfClosing bool
 }
 
-// Render outputs the element as HTML.
+// Render outputs the element as HTML, or as XML or plain text under
+// WithXML and WithText. Under WithIndent, block elements start new lines.
 func (e *Element) Render(w io.Writer) error {
+	mode, _ := w.(*renderWriter)
+	if mode != nil && mode.text {
+		return e.renderText(mode)
+	}
+	xml := mode != nil && mode.xml
+	escape := html.EscapeString
+	if xml {
+		escape = xmlEscapeAttr
+	}
+	indent := mode != nil && mode.indent != "" && mode.verbatim == 0
+	block := indent && blockElements[e.Tag]
+	if block {
+		if err := mode.newline(); err != nil {
+			return err
+		}
+	} else if indent {
+		if err := mode.inline(); err != nil {
+			return err
+		}
+	}
+
 	// Write opening tag
 	if _, err := w.Write([]byte("<" + e.Tag)); err != nil {
 		return err
 	}
 
 	// Write attributes
-	for key, value := range e.Attributes {
-		if _, err := fmt.Fprintf(w, ` %s="%s"`, key, html.EscapeString(value)); err != nil {
-			return err
+	if mode != nil && mode.canonical {
+		for _, key := range canonicalAttributeOrder(e.Attributes) {
+			if _, err := fmt.Fprintf(w, ` %s="%s"`, key, escape(e.Attributes[key])); err != nil {
+				return err
+			}
+		}
+	} else {
+		for key, value := range e.Attributes {
+			if _, err := fmt.Fprintf(w, ` %s="%s"`, key, escape(value)); err != nil {
+				return err
+			}
 		}
 	}
 
+	if xml && (e.SelfClosing || len(e.Children) == 0) {
+		if _, err := w.Write([]byte("/>")); err != nil {
+			return err
+		}
+		mode.elementRendered()
+		return nil
+	}
 	if e.SelfClosing {
-		_, err := w.Write([]byte(" />"))
-		return err
+		if _, err := w.Write([]byte(" />")); err != nil {
+			return err
+		}
+		if block {
+			mode.breakNext = true
+		}
+		mode.elementRendered()
+		return nil
 	}
 
 	if _, err := w.Write([]byte(">")); err != nil {
 		return err
 	}
 
-	// Render children
+	// Render children, one per line under WithIndent when they include
+	// blocks, and untouched inside pre, textarea, script and style
+	nested := block && hasBlockChild(e.Children)
+	verbatim := indent && rawTextElements[e.Tag]
+	if nested {
+		mode.depth++
+		mode.breakNext = true
+	}
+	if verbatim {
+		mode.verbatim++
+	}
 	for _, child := range e.Children {
 		if err := child.Render(w); err != nil {
 			return err
 		}
 	}
+	if verbatim {
+		mode.verbatim--
+	}
+	if nested {
+		mode.depth--
+		if err := mode.newline(); err != nil {
+			return err
+		}
+	}
 
 	// Write closing tag
-	_, err := fmt.Fprintf(w, "</%s>", e.Tag)
-	return err
+	if _, err := fmt.Fprintf(w, "</%s>", e.Tag); err != nil {
+		return err
+	}
+	if block {
+		mode.breakNext = true
+	}
+	mode.elementRendered()
+	return nil
 }
 
 // TextNode represents escaped text content.
@@ -65,8 +136,19 @@ type TextNode struct {
 	Content string
 }
 
-// Render outputs the text with HTML escaping.
+// Render outputs the text with HTML escaping, XML escaping under WithXML
+// or as is under WithText.
 func (t *TextNode) Render(w io.Writer) error {
+	if mode, ok := w.(*renderWriter); ok && mode.text {
+		return mode.writeText(t.Content)
+	} else if ok && mode.xml {
+		_, err := io.WriteString(w, xmlEscapeText(t.Content))
+		return err
+	} else if ok && mode.indent != "" && mode.verbatim == 0 {
+		if err := mode.inline(); err != nil {
+			return err
+		}
+	}
 	escaped := html.EscapeString(t.Content)
 	_, err := w.Write([]byte(escaped))
 	return err
@@ -77,12 +159,59 @@ type RawNode struct {
 	Content string
 }
 
-// Render outputs unescaped content.
+// Render outputs unescaped content. Plain-text renders leave it out, since
+// it is markup, and WithMinify leaves it as it is.
 func (r *RawNode) Render(w io.Writer) error {
+	mode, ok := w.(*renderWriter)
+	if ok && mode.text {
+		return nil
+	} else if ok && mode.indent != "" && mode.verbatim == 0 {
+		if err := mode.inline(); err != nil {
+			return err
+		}
+	} else if ok && mode.minified != nil {
+		start := mode.minified.Len()
+		_, err := w.Write([]byte(r.Content))
+		mode.rawSpans = append(mode.rawSpans, [2]int{start, mode.minified.Len()})
+		return err
+	}
 	_, err := w.Write([]byte(r.Content))
 	return err
 }
 
+// CommentNode represents an HTML comment.
+type CommentNode struct {
+	Content string
+}
+
+// Render outputs the comment, with any "--" in it broken up so the text
+// cannot end the comment early. Plain-text renders leave it out, and
+// WithMinify removes it.
+func (c *CommentNode) Render(w io.Writer) error {
+	mode, ok := w.(*renderWriter)
+	if ok && mode.text {
+		return nil
+	} else if ok && mode.indent != "" && mode.verbatim == 0 {
+		if err := mode.inline(); err != nil {
+			return err
+		}
+	}
+	content := c.Content
+	for strings.Contains(content, "--") {
+		content = strings.ReplaceAll(content, "--", "- -")
+	}
+	_, err := io.WriteString(w, "<!-- "+content+" -->")
+	return err
+}
+
+// Comment creates an HTML comment, e.g. to mark where a component starts
+// when debugging htmx swaps.
+//
+//	mi.Comment("component: gauge-panel")
+func Comment(text string) Node {
+	return &CommentNode{Content: text}
+}
+
 // Fragment represents a collection of nodes without a wrapper element.
 type Fragment struct {
 	Children []Node
@@ -135,29 +264,147 @@ func Raw(content string) Node {
 	return &RawNode{Content: content}
 }
 
-// NewFragment creates a fragment containing the given nodes.
+// NewFragment creates a fragment containing the given nodes. Nested
+// fragments are flattened into its child list and nil nodes, such as those
+// from conditional helpers, are dropped, so renderers never see a nil child.
 func NewFragment(nodes ...Node) Node {
+	for _, node := range nodes {
+		if _, nested := node.(*Fragment); nested || node == nil {
+			return &Fragment{Children: flattenNodes(make([]Node, 0, len(nodes)), nodes)}
+		}
+	}
 	return &Fragment{Children: nodes}
 }
 
+// flattenNodes appends nodes to dst, inlining the children of fragments and
+// skipping nil nodes.
+func flattenNodes(dst []Node, nodes []Node) []Node {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case nil:
+		case *Fragment:
+			if n != nil {
+				dst = flattenNodes(dst, n.Children)
+			}
+		default:
+			dst = append(dst, node)
+		}
+	}
+	return dst
+}
+
 // Text creates a text node (for explicit text handling).
 func (b *Builder) Text(content string) Node {
 	return &TextNode{Content: content}
 }
 
 // Render renders a template to the provided writer.
-func Render(template H, w io.Writer) error {
-	node := template(B)
-	return node.Render(w)
+//
+//	mi.Render(page, w, mi.WithMinify())
+func Render(template H, w io.Writer, opts ...RenderOption) error {
+	b := acquireBuilder()
+	defer releaseBuilder(b)
+	return renderWith(b, template, w, opts...)
+}
+
+// renderWith renders a template with the given builder and options.
+func renderWith(b *Builder, template H, w io.Writer, opts ...RenderOption) error {
+	var config renderConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return renderConfigured(b, template, w, config, false)
 }
 
-// RenderToString renders a template and returns the HTML as a string.
-func RenderToString(template H) string {
+// renderConfigured renders a template in the mode config selects. When
+// stream is set, an http.Flusher w is flushed as config.stream asks.
+func renderConfigured(b *Builder, template H, w io.Writer, config renderConfig, stream bool) error {
+	b.nonce = config.nonce
+	if config.xml || config.text {
+		config.indent = ""
+	}
+	minify := config.minify && !config.xml && !config.text && config.indent == ""
+	out := w
 	var buf strings.Builder
-	if err := Render(template, &buf); err != nil {
+	if minify {
+		out = &buf
+	}
+	var mode *renderWriter
+	if config.canonical || config.xml || config.text || config.indent != "" || minify || stream {
+		mode = &renderWriter{Writer: out, canonical: config.canonical, xml: config.xml, text: config.text, indent: config.indent}
+		if minify {
+			mode.minified = &buf
+		}
+		if stream {
+			mode.flusher, _ = w.(http.Flusher)
+			mode.flushEvery = config.stream.FlushEvery
+		}
+		out = mode
+	}
+	if config.xml {
+		if _, err := io.WriteString(out, xmlHeader); err != nil {
+			return err
+		}
+	}
+	if err := render(b, template, out); err != nil {
+		return err
+	}
+	if (config.text || config.indent != "") && mode.started {
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	if minify {
+		_, err := io.WriteString(w, minifyHTML(buf.String(), mode.rawSpans, config.minifyCSS))
+		return err
+	}
+	return nil
+}
+
+// render renders a template without options.
+func render(b *Builder, template H, w io.Writer) error {
+	if renderObserver.Load() != nil {
+		_, err := renderWithMetrics(b, template, w)
+		return err
+	}
+	node := b.resolve(template(b))
+	return node.Render(w)
+}
+
+// renderBuffers recycles the buffers of RenderToBytes.
+var renderBuffers = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// RenderToBytes renders a template and returns the HTML, or the first error
+// met while rendering it, e.g. from a template that failed.
+//
+//	html, err := mi.RenderToBytes(quotePage(quote))
+//	if err != nil {
+//	    http.Error(w, "render failed", http.StatusInternalServerError)
+//	    return
+//	}
+//	w.Write(html)
+func RenderToBytes(template H, opts ...RenderOption) ([]byte, error) {
+	buf := renderBuffers.Get().(*bytes.Buffer)
+	defer func() {
+		buf.Reset()
+		renderBuffers.Put(buf)
+	}()
+	if err := Render(template, buf, opts...); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(buf.Bytes()), nil
+}
+
+// RenderToString renders a template and returns the HTML as a string, or
+// "" when rendering fails; use RenderToBytes to see the error.
+func RenderToString(template H, opts ...RenderOption) string {
+	html, err := RenderToBytes(template, opts...)
+	if err != nil {
 		return ""
 	}
-	return buf.String()
+	return string(html)
 }
 
 // Txt creates a text node (standalone function, alias for b.Text).
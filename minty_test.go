@@ -2,9 +2,22 @@ package minty
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	mt "github.com/ha1tch/minty/mintytypes"
 )
 
 // Smoke test - basic functionality works
@@ -713,6 +726,26 @@ func TestRangeFunction(t *testing.T) {
 	}
 }
 
+func TestRangeStep(t *testing.T) {
+	page := func(i int) H {
+		return func(b *Builder) Node { return b.Span(i) }
+	}
+	render := func(nodes []Node) string {
+		return RenderToString(func(b *Builder) Node { return NewFragment(nodes...) })
+	}
+	if got := render(RangeStep(10, 0, -3, page)); got != "<span>10</span><span>7</span><span>4</span><span>1</span>" {
+		t.Errorf("RangeStep down = %s", got)
+	}
+	if got := render(RangeStep(1, 6, 2, page)); got != "<span>1</span><span>3</span><span>5</span>" {
+		t.Errorf("RangeStep up = %s", got)
+	}
+	for _, nodes := range [][]Node{RangeStep(0, 5, 0, page), RangeStep(5, 0, 1, page), RangeStep(0, 5, -1, page), Range(3, 3, page)} {
+		if nodes == nil || len(nodes) != 0 {
+			t.Errorf("expected an empty slice, got %v", nodes)
+		}
+	}
+}
+
 func TestWhenFunction(t *testing.T) {
 	status := "active"
 	
@@ -743,6 +776,30 @@ func TestWhenFunction(t *testing.T) {
 	}
 }
 
+func TestSwitch(t *testing.T) {
+	calls := 0
+	badge := func(text string) H {
+		return func(b *Builder) Node {
+			calls++
+			return b.Span(text)
+		}
+	}
+	cases := map[string]H{"active": badge("Active"), "retired": badge("Retired")}
+
+	if html := RenderToString(Switch("retired", cases, badge("Unknown"))); html != "<span>Retired</span>" {
+		t.Errorf("Switch = %s", html)
+	}
+	if calls != 1 {
+		t.Errorf("Switch ran %d templates, want 1", calls)
+	}
+	if html := RenderToString(Switch("lost", cases, badge("Unknown"))); html != "<span>Unknown</span>" {
+		t.Errorf("Switch fallback = %s", html)
+	}
+	if html := RenderToString(Switch("lost", cases, nil)); html != "" {
+		t.Errorf("Switch without match or fallback = %q", html)
+	}
+}
+
 func TestJoinFunction(t *testing.T) {
 	items := []string{"apple", "banana", "cherry"}
 	
@@ -787,3 +844,1485 @@ func TestRepeatFunction(t *testing.T) {
 		t.Errorf("Expected %q, got %q", expected, html)
 	}
 }
+
+func TestPrintHelpers(t *testing.T) {
+	html := RenderToString(func(b *Builder) Node {
+		return b.Div(Class("card"), PageBreakBefore(), AvoidBreakInside(), "x")
+	})
+	if !strings.Contains(html, `class="card print-break-before print-avoid-break"`) {
+		t.Errorf("print classes not appended: %s", html)
+	}
+
+	invoice := RenderToString(InvoiceDocument(InvoiceData{
+		Number: "INV-1",
+		BillTo: []string{"Acme & Co", "1 Main St"},
+		Items:  []InvoiceLine{{Description: "Widget", Quantity: "2", UnitPrice: "$5.00", Amount: "$10.00"}},
+		Totals: []InvoiceTotal{{Label: "Total", Amount: "$10.00"}},
+	}))
+	for _, want := range []string{"@media print", "INV-1", "Acme &amp; Co", "1 Main St", "invoice-grand-total", "<tfoot"} {
+		if !strings.Contains(invoice, want) {
+			t.Errorf("InvoiceDocument missing %q", want)
+		}
+	}
+}
+
+func TestPicture(t *testing.T) {
+	html := RenderToString(Picture(PictureOptions{
+		Src: "/img/shoe.jpg",
+		Alt: "Running shoe",
+		Sources: []PictureSource{
+			{Type: "image/avif", Entries: []SrcSetEntry{{URL: "/img/shoe-640.avif", Width: 640}, {URL: "/img/shoe-1280.avif", Width: 1280}}},
+		},
+		Sizes:  "(max-width: 600px) 100vw, 50vw",
+		Width:  640,
+		Height: 480,
+	}))
+
+	for _, want := range []string{
+		`<picture>`,
+		`srcset="/img/shoe-640.avif 640w, /img/shoe-1280.avif 1280w"`,
+		`type="image/avif"`,
+		`loading="lazy"`,
+		`decoding="async"`,
+		`width="640"`,
+		`alt="Running shoe"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Picture output missing %q: %s", want, html)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := Render(Picture(PictureOptions{Src: "/img/x.jpg"}), &buf); err != ErrMissingAlt {
+		t.Errorf("Picture without alt: err = %v, want ErrMissingAlt", err)
+	}
+	if got := RenderToString(Picture(PictureOptions{Src: "/img/x.jpg", Decorative: true})); !strings.Contains(got, `alt=""`) {
+		t.Errorf("decorative Picture should render empty alt: %s", got)
+	}
+}
+
+func TestHtmxPollVisible(t *testing.T) {
+	html := RenderToString(func(b *Builder) Node {
+		return b.Div(HtmxGet("/api/stats"), HtmxPollVisible("3s"))
+	})
+	for _, want := range []string{
+		"every 3s [document.visibilityState === &#39;visible&#39;]",
+		"visibilitychange[document.visibilityState === &#39;visible&#39;] from:document",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HtmxPollVisible missing %q: %s", want, html)
+		}
+	}
+}
+
+func TestRenderWithMetrics(t *testing.T) {
+	template := func(b *Builder) Node {
+		return b.Ul(b.Li("one"), b.Li("two"))
+	}
+
+	var buf bytes.Buffer
+	m, err := RenderWithMetrics(template, &buf)
+	if err != nil {
+		t.Fatalf("RenderWithMetrics: %v", err)
+	}
+	// ul + 2 li + 2 text nodes
+	if m.Nodes != 5 {
+		t.Errorf("Nodes = %d, want 5", m.Nodes)
+	}
+	if m.Bytes != int64(buf.Len()) {
+		t.Errorf("Bytes = %d, want %d", m.Bytes, buf.Len())
+	}
+
+	var observed []Metrics
+	SetRenderObserver(func(m Metrics) { observed = append(observed, m) })
+	defer SetRenderObserver(nil)
+
+	RenderToString(template)
+	if len(observed) != 1 || observed[0].Nodes != 5 {
+		t.Errorf("observer calls = %+v, want one call with 5 nodes", observed)
+	}
+
+	SetRenderObserver(nil)
+	RenderToString(template)
+	if len(observed) != 1 {
+		t.Error("observer called after being removed")
+	}
+}
+
+func TestThemeSwitch(t *testing.T) {
+	html := RenderToString(ThemeSwitch(ThemeSwitchOptions{DarkMode: DarkModeTailwind(DarkModeStorage("prefs"))}))
+	for _, want := range []string{
+		`type="radio"`,
+		`value="system"`,
+		`value="light"`,
+		`value="dark"`,
+		`var key = 'prefs'`,
+		`classList.toggle('dark', dark)`,
+		`prefers-color-scheme: dark`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("ThemeSwitch output missing %q", want)
+		}
+	}
+
+	head := RenderToString(ThemeSwitchScript(ThemeSwitchOptions{DarkMode: DarkModeBootstrap()}))
+	if !strings.Contains(head, `html.setAttribute('data-bs-theme', dark ? 'dark' : 'light')`) {
+		t.Errorf("ThemeSwitchScript should use the DarkMode attribute: %s", head)
+	}
+}
+
+func TestPrefCookies(t *testing.T) {
+	rec := httptest.NewRecorder()
+	SetPrefCookie(rec, "sidebar", "collapsed; left", PrefCookieOptions{Secure: true})
+	cookie := rec.Result().Cookies()[0]
+	if !cookie.Secure || cookie.SameSite != http.SameSiteLaxMode || cookie.Path != "/" || cookie.MaxAge != 365*24*60*60 || cookie.HttpOnly {
+		t.Errorf("unexpected preference cookie: %+v", cookie)
+	}
+	req := httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(cookie)
+	if got := PrefFromRequest(req, "sidebar"); got != "collapsed; left" {
+		t.Errorf("PrefFromRequest = %q", got)
+	}
+	if got := PrefFromRequest(req, "missing"); got != "" {
+		t.Errorf("PrefFromRequest without cookie = %q", got)
+	}
+
+	dm := DarkModeTailwind(DarkModeCookie("theme"))
+	dark := httptest.NewRequest("GET", "/", nil)
+	dark.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+	if !dm.IsDark(dark) || RenderToString(func(b *Builder) Node { return b.Html(dm.HTMLAttr(dark)) }) != `<html class="dark"></html>` {
+		t.Error("DarkMode should render the cookie's dark theme")
+	}
+	if dm.IsDark(req) || dm.HTMLAttr(req) != nil {
+		t.Error("DarkMode without a cookie should leave the system theme to the script")
+	}
+	bs := DarkModeBootstrap(DarkModeCookie("theme"), DarkModeDefault("dark"))
+	light := httptest.NewRequest("GET", "/", nil)
+	light.AddCookie(&http.Cookie{Name: "theme", Value: "light"})
+	if !bs.IsDark(req) || RenderToString(func(b *Builder) Node { return b.Html(bs.HTMLAttr(light)) }) != `<html data-bs-theme="light"></html>` {
+		t.Error("DarkMode should fall back to its default and honour a light cookie")
+	}
+
+	script := dm.ScriptRaw()
+	if !strings.Contains(script, `document.cookie = 'theme='`) || !strings.Contains(script, `(?:^|;\s*)theme=`) {
+		t.Errorf("DarkMode script should read and write the cookie: %s", script)
+	}
+	if strings.Contains(DarkModeTailwind().ScriptRaw(), "document.cookie") {
+		t.Error("DarkMode without DarkModeCookie should not touch cookies")
+	}
+	if html := RenderToString(ThemeSwitch(ThemeSwitchOptions{DarkMode: dm})); !strings.Contains(html, `document.cookie = 'theme='`) {
+		t.Errorf("ThemeSwitch should write the cookie: %s", html)
+	}
+}
+
+func TestIcon(t *testing.T) {
+	html := RenderToString(Icon("shield-check", "w-5 h-5"))
+	for _, want := range []string{`<svg `, `viewBox="0 0 24 24"`, `class="w-5 h-5"`, `aria-hidden="true"`, `stroke="currentColor"`, `<path `} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Icon output missing %q: %s", want, html)
+		}
+	}
+	if strings.Contains(html, `role="img"`) {
+		t.Errorf("decorative Icon should not be an image: %s", html)
+	}
+
+	labeled := RenderToString(LabeledIcon("check-circle", "", "Paid"))
+	if !strings.Contains(labeled, `role="img"`) || !strings.Contains(labeled, `aria-label="Paid"`) || !strings.Contains(labeled, `<title>Paid</title>`) || strings.Contains(labeled, "aria-hidden") {
+		t.Errorf("LabeledIcon should be a named image: %s", labeled)
+	}
+
+	if missing := RenderToString(Icon("no-such-icon", "w-5")); strings.Contains(missing, "<path") || !strings.Contains(missing, `class="w-5"`) {
+		t.Errorf("unknown icon should render empty: %s", missing)
+	}
+
+	RegisterIcon("test-dot", `<svg viewBox="0 0 24 24"><circle cx="12" cy="12" r="4" fill="currentColor"/></svg>`)
+	if custom := RenderToString(Icon("test-dot", "")); !strings.Contains(custom, `<circle cx="12" cy="12" r="4" fill="currentColor"/></svg>`) {
+		t.Errorf("registered icon not rendered: %s", custom)
+	}
+	names := IconNames()
+	for _, want := range []string{"bell", "home", "test-dot", "truck"} {
+		if i := sort.SearchStrings(names, want); i == len(names) || names[i] != want {
+			t.Errorf("IconNames missing %q", want)
+		}
+	}
+}
+
+func TestSteps(t *testing.T) {
+	steps := []ProgressStep{
+		{Label: "Details", Href: "/quote/details"},
+		{Label: "Coverage", Description: "Choose a plan", Href: "/quote/coverage"},
+		{Label: "Review", Href: "/quote/review"},
+	}
+	html := RenderToString(Steps(steps, 1, StepsOptions{Navigate: "completed"}))
+	for _, want := range []string{
+		`class="minty-steps"`,
+		`aria-label="Progress"`,
+		`<ol class="minty-steps-list">`,
+		`href="/quote/details"`,
+		`data-icon="check"`,
+		`(completed)`,
+		`aria-current="step"`,
+		`(current)`,
+		`<span class="minty-step-description">Choose a plan</span>`,
+		`>3</span>`,
+		`(not started)`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Steps output missing %q\n%s", want, html)
+		}
+	}
+	if strings.Contains(html, `href="/quote/coverage"`) || strings.Contains(html, `href="/quote/review"`) {
+		t.Errorf("only completed steps should link: %s", html)
+	}
+	if strings.Count(html, `aria-current`) != 1 {
+		t.Errorf("exactly one step should be current: %s", html)
+	}
+
+	all := RenderToString(Steps(steps, 0, StepsOptions{Navigate: "all", LinkAttrs: []Attribute{HtmxTarget("#wizard")}}))
+	if strings.Count(all, "<a ") != 3 || !strings.Contains(all, `hx-target="#wizard"`) {
+		t.Errorf("Navigate all should link every step: %s", all)
+	}
+	done := RenderToString(Steps(steps, len(steps), StepsOptions{}))
+	if strings.Count(done, `data-icon="check"`) != 3 || strings.Contains(done, "aria-current") || strings.Contains(done, "<a ") {
+		t.Errorf("finished Steps should show every step completed without links: %s", done)
+	}
+}
+
+func TestErrorSummary(t *testing.T) {
+	if html := RenderToString(ErrorSummary(nil, ErrorSummaryOptions{})); html != "" {
+		t.Errorf("empty ErrorSummary rendered %q", html)
+	}
+
+	errs := mt.ValidationErrors{
+		{Field: "email", Message: "Enter a valid email address"},
+		{Field: "dob", Message: "Enter your date of birth"},
+		{Message: "The quote has expired"},
+	}
+	html := RenderToString(ErrorSummary(errs, ErrorSummaryOptions{FieldIDs: map[string]string{"dob": "dob-day"}}))
+	for _, want := range []string{
+		`id="error-summary"`,
+		`role="alert"`,
+		`aria-labelledby="error-summary-title"`,
+		`tabindex="-1"`,
+		`>There is a problem</h2>`,
+		`href="#email"`,
+		`href="#dob-day"`,
+		`data-minty-error-field="dob"`,
+		`<li>The quote has expired</li>`,
+		`getElementById('error-summary'); if (s) s.focus();`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("ErrorSummary output missing %q\n%s", want, html)
+		}
+	}
+	quiet := RenderToString(ErrorSummary(errs, ErrorSummaryOptions{NoFocus: true}))
+	if strings.Contains(quiet, "s.focus()") {
+		t.Error("NoFocus summary still takes focus")
+	}
+
+	attrs := RenderToString(func(b *Builder) Node { return b.Input(FieldErrorAttrs(errs, "email")...) })
+	if !strings.Contains(attrs, `aria-invalid="true"`) || !strings.Contains(attrs, `aria-describedby="email-error"`) {
+		t.Errorf("FieldErrorAttrs = %s", attrs)
+	}
+	if FieldErrorAttrs(errs, "name") != nil || RenderToString(FieldErrorMessage(errs, "name")) != "" {
+		t.Error("a field without errors should not be marked")
+	}
+	if message := RenderToString(FieldErrorMessage(errs, "email")); !strings.Contains(message, `<div id="email-error">`) {
+		t.Errorf("FieldErrorMessage = %s", message)
+	}
+	field := RenderToString(FormField("Email", "email", "email", "x", "Enter a valid email address", true))
+	if !strings.Contains(field, `aria-describedby="email-error"`) || !strings.Contains(field, `id="email-error"`) {
+		t.Errorf("FormField error is not linked to its input: %s", field)
+	}
+
+	errs.Add("email", "Email is already registered")
+	if got := errs.ForField("email"); len(got) != 2 {
+		t.Errorf("ForField(email) = %v, want both messages", got)
+	}
+	single := RenderToString(FieldError(errs, "email"))
+	if !strings.Contains(single, `<div id="email-error">`) || !strings.Contains(single, "Enter a valid email address") ||
+		strings.Contains(single, "already registered") {
+		t.Errorf("FieldError should render only the first message: %s", single)
+	}
+	if html := RenderToString(FieldError(errs, "name")); html != "" {
+		t.Errorf("FieldError for a valid field = %q, want nothing", html)
+	}
+}
+
+func TestRenderXML(t *testing.T) {
+	rec := httptest.NewRecorder()
+	err := RenderXML(func(b *Builder) Node {
+		return b.Element("urlset",
+			b.Element("url", Attr("note", `Tom's "page"`), b.Element("loc", "https://example.com/?a=1&b=2")),
+			b.Element("changefreq"),
+			b.Input(Disabled()),
+			b.Element("title", "bad\x01char"),
+		)
+	}, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xml := rec.Body.String()
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`note="Tom&apos;s &quot;page&quot;"`,
+		`<loc>https://example.com/?a=1&amp;b=2</loc>`,
+		`<changefreq/>`,
+		`disabled="disabled"/>`,
+		"<title>bad�char</title>",
+	} {
+		if !strings.Contains(xml, want) {
+			t.Errorf("RenderXML output missing %q in %s", want, xml)
+		}
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/xml; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	rec = httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/rss+xml")
+	published := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	RenderXML(RSS(RSSChannel{
+		Title:   "Updates",
+		Link:    "https://example.com",
+		SelfURL: "https://example.com/feed.xml",
+		Items: []RSSItem{
+			{Title: "Released", Link: "https://example.com/released", Description: "<p>New</p>", Published: published},
+		},
+	}), rec, WithCanonicalAttributes())
+	feed := rec.Body.String()
+	for _, want := range []string{
+		`<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom"><channel>`,
+		`<atom:link href="https://example.com/feed.xml" rel="self" type="application/rss+xml"/>`,
+		`<description>&lt;p&gt;New&lt;/p&gt;</description>`,
+		`<guid>https://example.com/released</guid>`,
+		`<pubDate>Mon, 10 Mar 2025 09:00:00 +0000</pubDate>`,
+	} {
+		if !strings.Contains(feed, want) {
+			t.Errorf("RSS output missing %q in %s", want, feed)
+		}
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/rss+xml" {
+		t.Errorf("RenderXML replaced the Content-Type with %q", ct)
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	rec := httptest.NewRecorder()
+	err := RenderText(func(b *Builder) Node {
+		return b.Html(
+			b.Head(b.Title("Ignored")),
+			b.Body(
+				b.Script(Raw("alert(1)")),
+				b.H1("Your   order"),
+				b.P("Thanks for ", b.Strong("ordering"), ".", b.Br(), "Ship date: soon"),
+				b.Ul(b.Li("Kettle"), b.Li("Lamp")),
+				b.P(b.A(Href("https://example.com/orders/7"), "View order")),
+			),
+		)
+	}, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Your order\n\nThanks for ordering.\nShip date: soon\n\n- Kettle\n- Lamp\n\nView order (https://example.com/orders/7)\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("RenderText = %q, want %q", got, want)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	html := RenderToString(func(b *Builder) Node {
+		return b.Div(
+			b.P("Run   it:"),
+			b.Pre(b.Code("go  test\n  ./...")),
+			b.Textarea("line one\n\n  line three"),
+		)
+	}, WithText())
+	want = "Run it:\n\ngo  test\n  ./...\n\nline one\n\n  line three\n"
+	if html != want {
+		t.Errorf("pre and textarea in text = %q, want %q", html, want)
+	}
+}
+
+// flushCounter is a writer that counts flushes, as an http.ResponseWriter
+// would see them.
+type flushCounter struct {
+	strings.Builder
+	flushes int
+}
+
+func (f *flushCounter) Flush() { f.flushes++ }
+
+// failingWriter fails once more than limit bytes have been written.
+type failingWriter struct {
+	n, limit int
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	if f.n+len(p) > f.limit {
+		return 0, errors.New("connection closed")
+	}
+	f.n += len(p)
+	return len(p), nil
+}
+
+func TestRenderStream(t *testing.T) {
+	rows := []string{"Laptop", "Desk", "Chair"}
+	page := func(b *Builder) Node {
+		return b.Table(b.Tbody(
+			StreamEach(rows, func(name string) H {
+				return func(b *Builder) Node { return b.Tr(b.Td(Class("name"), name)) }
+			})(b),
+			b.Stream(func(emit func(Node) error) error {
+				return emit(b.Tr(b.Td("Total: 3")))
+			}),
+		))
+	}
+
+	out := &flushCounter{}
+	if err := RenderStream(page, out, WithStreamOptions(StreamOptions{FlushEvery: 2})); err != nil {
+		t.Fatal(err)
+	}
+	if want := RenderToString(page); out.String() != want {
+		t.Errorf("RenderStream = %s, want %s", out.String(), want)
+	}
+	if !strings.Contains(out.String(), `<tr><td class="name">Desk</td></tr>`) {
+		t.Errorf("streamed rows missing: %s", out.String())
+	}
+	// 10 elements flush 5 times, plus once at the end
+	if out.flushes != 6 {
+		t.Errorf("flushed %d times, want 6", out.flushes)
+	}
+
+	// Render options apply as they do to Render
+	for _, opts := range [][]RenderOption{
+		{WithCanonicalAttributes(), WithNonce("abc")},
+		{WithIndent("  ")},
+		{WithText()},
+		{WithXML()},
+	} {
+		var streamed strings.Builder
+		if err := RenderStream(page, &streamed, opts...); err != nil {
+			t.Fatal(err)
+		}
+		if want := RenderToString(page, opts...); streamed.String() != want {
+			t.Errorf("RenderStream with options = %q, want %q", streamed.String(), want)
+		}
+	}
+	var unminified strings.Builder
+	spaced := func(b *Builder) Node { return b.P("a  b") }
+	if err := RenderStream(spaced, &unminified, WithMinify()); err != nil || unminified.String() != "<p>a  b</p>" {
+		t.Errorf("RenderStream with WithMinify = %q, %v, want it unminified", unminified.String(), err)
+	}
+
+	// A write error stops the producer
+	built := 0
+	failing := func(b *Builder) Node {
+		return b.Ul(b.Stream(func(emit func(Node) error) error {
+			for i := 0; i < 100; i++ {
+				built++
+				if err := emit(b.Li("item")); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+	}
+	err := RenderStream(failing, &failingWriter{limit: 30})
+	if err == nil {
+		t.Fatal("RenderStream ignored the write error")
+	}
+	if built > 3 {
+		t.Errorf("built %d items after the writer failed", built)
+	}
+}
+
+func TestRenderIndented(t *testing.T) {
+	var sb strings.Builder
+	err := RenderIndented(func(b *Builder) Node {
+		return NewFragment(Raw("<!DOCTYPE html>"), b.Html(b.Body(
+			b.Div(Class("quote"),
+				b.H1("Quote ", b.Strong("#42")),
+				"Cover ", b.A(Href("/cover"), "details"),
+				NewFragment(b.Ul(b.Li("Fire"), b.Li("Theft"))),
+				b.Pre("line 1\n  line 2"),
+				b.Script(Raw("init();")),
+			),
+		)))
+	}, &sb, "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<!DOCTYPE html>
+<html>
+  <body>
+    <div class="quote">
+      <h1>Quote <strong>#42</strong></h1>
+      Cover <a href="/cover">details</a>
+      <ul>
+        <li>Fire</li>
+        <li>Theft</li>
+      </ul>
+      <pre>line 1
+  line 2</pre>
+      <script>init();</script>
+    </div>
+  </body>
+</html>
+`
+	if got := sb.String(); got != want {
+		t.Errorf("RenderIndented =\n%s\nwant\n%s", got, want)
+	}
+	if got := RenderToString(func(b *Builder) Node { return b.P("a") }, WithIndent("\t"), WithMinify()); got != "<p>a</p>\n" {
+		t.Errorf("WithIndent with WithMinify = %q", got)
+	}
+}
+
+func TestClasses(t *testing.T) {
+	active := true
+	html := RenderToString(func(b *Builder) Node {
+		return b.Button(Classes("tab  px-4 ", ClassIf(active, "active"), ClassIf(!active, "text-gray-500"), "", "px-4 font-bold"))
+	})
+	if want := `<button class="tab px-4 active font-bold"></button>`; html != want {
+		t.Errorf("Classes = %s, want %s", html, want)
+	}
+}
+
+func TestRenderMinified(t *testing.T) {
+	var sb strings.Builder
+	err := RenderMinified(func(b *Builder) Node {
+		return b.Div(
+			b.Style(Raw("\n  .card  { color: red; }\n")),
+			b.P("  Hello\n   ", b.Strong("world"), "  "),
+			Raw("<pre>\n  pre-formatted  </pre>"),
+			b.Pre("  keep   this "),
+			b.Script(Raw("if (a  <  b) {\n  go();\n}")),
+		)
+	}, &sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<div><style>\n  .card  { color: red; }\n</style><p>Hello <strong>world</strong></p>" +
+		"<pre>\n  pre-formatted  </pre><pre>  keep   this </pre><script>if (a  <  b) {\n  go();\n}</script></div>"
+	if got := sb.String(); got != want {
+		t.Errorf("RenderMinified =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestSafeURL(t *testing.T) {
+	cases := []struct {
+		url, want string
+	}{
+		{"/claims/42", "/claims/42"},
+		{"https://example.com/a:b", "https://example.com/a:b"},
+		{"mailto:ed@example.com", "mailto:ed@example.com"},
+		{"#top", "#top"},
+		{"javascript:alert(1)", "#"},
+		{"  JavaScript:alert(1)", "#"},
+		{"java\tscript:alert(1)", "#"},
+		{"vbscript:msgbox", "#"},
+		{"data:text/html;base64,PHNjcmlwdD4=", "#"},
+		{"data:image/png;base64,iVBORw0=", "#"},
+	}
+	for _, c := range cases {
+		if got := SafeURL(c.url); got != c.want {
+			t.Errorf("SafeURL(%q) = %q, want %q", c.url, got, c.want)
+		}
+	}
+
+	html := RenderToString(func(b *Builder) Node {
+		return b.Div(b.A(Href("javascript:steal()"), "x"), b.Img(Src("javascript:steal()")))
+	})
+	if !strings.Contains(html, `href="#"`) || !strings.Contains(html, `src=""`) || strings.Contains(html, "steal") {
+		t.Errorf("unsafe URLs rendered: %s", html)
+	}
+
+	AllowDataURIs(true)
+	defer AllowDataURIs(false)
+	if got := SafeURL("data:image/png;base64,iVBORw0="); got != "data:image/png;base64,iVBORw0=" {
+		t.Errorf("AllowDataURIs did not allow a PNG: %q", got)
+	}
+	if got := SafeURL("data:image/svg+xml,<svg onload=alert(1)>"); got != "#" {
+		t.Errorf("AllowDataURIs allowed SVG: %q", got)
+	}
+}
+
+func TestWithNonce(t *testing.T) {
+	dm := DarkModeTailwind()
+	page := func(b *Builder) Node {
+		return b.Div(
+			b.Script(Raw("init();")),
+			b.Script(Src("/app.js")),
+			b.Style(Raw("p { color: red; }")),
+			dm.Script(b),
+			dm.Toggle(b),
+			b.Span(b.Nonce()),
+		)
+	}
+	html := RenderToString(page, WithNonce("r4nd0m"))
+	if n := strings.Count(html, `nonce="r4nd0m"`); n != 3 {
+		t.Errorf("found %d nonces, want 3 (inline script, style, dark mode script): %s", n, html)
+	}
+	if !strings.Contains(html, `<script src="/app.js"></script>`) {
+		t.Errorf("external script got a nonce: %s", html)
+	}
+	if strings.Contains(html, "onclick") || !strings.Contains(html, `data-minty-dark-toggle="toggleDarkMode"`) {
+		t.Errorf("dark mode toggle should not use an inline handler under a nonce: %s", html)
+	}
+	if !strings.Contains(html, "<span>r4nd0m</span>") {
+		t.Errorf("b.Nonce() not set: %s", html)
+	}
+	if html := RenderToString(page); strings.Contains(html, "nonce=") || !strings.Contains(html, "onclick") {
+		t.Errorf("render without WithNonce changed: %s", html)
+	}
+
+	widgets := func(b *Builder) Node {
+		return b.Div(NewFragment(Each([]string{"gauge", "chart"}, func(name string) H {
+			return func(b *Builder) Node {
+				return b.Section(b.Style(Raw("."+name+" {}")), b.Script(Raw("init('"+name+"');")))
+			}
+		})...))
+	}
+	if n := strings.Count(RenderToString(widgets, WithNonce("r4nd0m")), `nonce="r4nd0m"`); n != 4 {
+		t.Errorf("found %d nonces in Each items, want 4", n)
+	}
+}
+
+func TestComment(t *testing.T) {
+	page := func(b *Builder) Node {
+		return b.Div(NewFragment(Comment("component: gauge-panel"), b.P("42%")), Comment("x --> <script>---"))
+	}
+	html := RenderToString(page)
+	want := `<div><!-- component: gauge-panel --><p>42%</p><!-- x - -> <script>- - - --></div>`
+	if html != want {
+		t.Errorf("Comment = %s, want %s", html, want)
+	}
+	var sb strings.Builder
+	if err := RenderMinified(page, &sb); err != nil || sb.String() != "<div><p>42%</p></div>" {
+		t.Errorf("RenderMinified kept comments: %s", sb.String())
+	}
+	if text := RenderToString(page, WithText()); strings.Contains(text, "component") {
+		t.Errorf("plain text contains the comment: %q", text)
+	}
+}
+
+func TestAttrs(t *testing.T) {
+	required := false
+	field := []Attribute{Type("text"), Name("policy"), nil}
+	html := RenderToString(func(b *Builder) Node {
+		return b.Div(
+			b.Input(Attrs(field...), AttrIf(required, Required()), AttrIf(true, nil)),
+			b.Span(AttrIf(false, Class("x")), "ok"),
+		)
+	})
+	for _, want := range []string{`type="text"`, `name="policy"`, "<span>ok</span>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("output missing %q: %s", want, html)
+		}
+	}
+	if strings.Contains(html, "required") || strings.Contains(html, `=""`) {
+		t.Errorf("a false AttrIf rendered something: %s", html)
+	}
+}
+
+func TestDataAttrs(t *testing.T) {
+	html := RenderToString(func(b *Builder) Node {
+		return b.Tr(DataAttrs(map[string]string{"type": "laptop", "status": "active", "bad key": "x", `x"y`: "z"}))
+	}, WithCanonicalAttributes())
+	if want := `<tr data-status="active" data-type="laptop"></tr>`; html != want {
+		t.Errorf("DataAttrs = %s, want %s", html, want)
+	}
+
+	StrictDataAttrs(true)
+	defer StrictDataAttrs(false)
+	defer func() {
+		if recover() == nil {
+			t.Error("StrictDataAttrs did not panic on an invalid key")
+		}
+	}()
+	DataAttrs(map[string]string{"Status": "active"})
+}
+
+func TestRenderToBytes(t *testing.T) {
+	html, err := RenderToBytes(func(b *Builder) Node { return b.P("Quote") })
+	if err != nil || string(html) != "<p>Quote</p>" {
+		t.Errorf("RenderToBytes = %q, %v", html, err)
+	}
+	// The result must not share the pooled buffer
+	other, _ := RenderToBytes(func(b *Builder) Node { return b.P("Other") })
+	if string(html) != "<p>Quote</p>" || string(other) != "<p>Other</p>" {
+		t.Errorf("results overwritten: %q, %q", html, other)
+	}
+
+	broken := func(b *Builder) Node { return b.Div(Picture(PictureOptions{})(b)) }
+	if _, err := RenderToBytes(broken); !errors.Is(err, ErrMissingAlt) {
+		t.Errorf("RenderToBytes error = %v, want ErrMissingAlt", err)
+	}
+	if got := RenderToString(broken); got != "" {
+		t.Errorf("RenderToString on error = %q, want empty", got)
+	}
+}
+
+func TestBuilderPool(t *testing.T) {
+	var kept *Builder
+	r := httptest.NewRequest("GET", "/gauges", nil)
+	page := func(b *Builder) Node {
+		kept = b
+		return b.P(b.UniqueID("g"), b.UniqueID("g"))
+	}
+	if err := RenderRequest(r, page, io.Discard, WithNonce("n")); err != nil {
+		t.Fatal(err)
+	}
+	if kept.Request() != nil || kept.Nonce() != "" || kept.ids.Load() != 0 {
+		t.Error("builder was not reset after the render")
+	}
+	for i := 0; i < 3; i++ {
+		if html := RenderToString(page); html != "<p>g-1g-2</p>" {
+			t.Fatalf("pooled builder leaked state: %s", html)
+		}
+	}
+	if html := RenderToString(gaugePanel); strings.Count(html, "<meter ") != 3 {
+		t.Errorf("benchmark page = %s", html)
+	}
+
+	paths := func(b *Builder) Node {
+		return b.Ul(NewFragment(Range(0, 2, func(int) H {
+			return func(b *Builder) Node { return b.Li(b.Request().URL.Path) }
+		})...))
+	}
+	var buf bytes.Buffer
+	if err := RenderRequest(r, paths, &buf); err != nil || buf.String() != "<ul><li>/gauges</li><li>/gauges</li></ul>" {
+		t.Errorf("request in Range items = %q, %v", buf.String(), err)
+	}
+}
+
+func TestHtmxMaps(t *testing.T) {
+	html := RenderToString(func(b *Builder) Node {
+		return b.Button(
+			HtmxValsMap(map[string]interface{}{"id": 7, "note": `a "b"`}),
+			HtmxHeadersMap(map[string]string{"X-CSRF-Token": "abc"}),
+			HtmxConfirm("Retire asset?"),
+			HtmxIndicator("#spinner"),
+			HtmxSync("this:drop"),
+		)
+	})
+	for _, want := range []string{
+		`hx-vals="{&#34;id&#34;:7,&#34;note&#34;:&#34;a \&#34;b\&#34;&#34;}"`,
+		`hx-headers="{&#34;X-CSRF-Token&#34;:&#34;abc&#34;}"`,
+		`hx-confirm="Retire asset?"`,
+		`hx-indicator="#spinner"`,
+		`hx-sync="this:drop"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("output missing %s in %s", want, html)
+		}
+	}
+}
+
+func TestOOB(t *testing.T) {
+	html := RenderToString(func(b *Builder) Node {
+		return NewFragment(
+			b.Div(ID("gauge"), HtmxTarget("#gauge"), HtmxSwap("outerHTML"), "72%"),
+			b.Span(ID("clock"), OOB(), "12:00"),
+			b.Ul(ID("log"), HxSwapOOB("beforeend:#log"), b.Li("tick")),
+			b.Nav(HtmxBoost(false)),
+		)
+	})
+	for _, want := range []string{
+		`hx-target="#gauge"`,
+		`hx-swap="outerHTML"`,
+		`hx-swap-oob="true"`,
+		`hx-swap-oob="beforeend:#log"`,
+		`hx-boost="false"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("output missing %s in %s", want, html)
+		}
+	}
+}
+
+func TestServerPushAttributes(t *testing.T) {
+	html := RenderToString(func(b *Builder) Node {
+		return b.Div(HtmxExt("sse", "ws"), SseConnect("/dashboard/events"),
+			b.Div(ID("gauge-cpu"), SseSwap("cpu"), "42%"),
+			b.Div(WsConnect("/chat"), b.Form(WsSend())),
+		)
+	})
+	for _, want := range []string{
+		`hx-ext="sse, ws"`,
+		`sse-connect="/dashboard/events"`,
+		`sse-swap="cpu"`,
+		`ws-connect="/chat"`,
+		`<form ws-send="ws-send">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("output missing %s in %s", want, html)
+		}
+	}
+}
+
+func TestCustomElements(t *testing.T) {
+	html, err := RenderToBytes(func(b *Builder) Node {
+		return b.Elem("asset-map", Attr("region", "emea"),
+			b.Void("gauge-tick", Attr("value", "75"), "ignored"),
+			b.P("Loading"),
+		)
+	})
+	if want := `<asset-map region="emea"><gauge-tick value="75" /><p>Loading</p></asset-map>`; err != nil || string(html) != want {
+		t.Errorf("custom elements = %s, %v; want %s", html, err, want)
+	}
+	for _, tag := range []string{"", "1x", "my widget", `x"y`, "a>b"} {
+		if _, err := RenderToBytes(func(b *Builder) Node { return b.Elem(tag) }); !errors.Is(err, ErrInvalidTagName) {
+			t.Errorf("Elem(%q) error = %v, want ErrInvalidTagName", tag, err)
+		}
+	}
+}
+
+func TestCompose(t *testing.T) {
+	card := func(slots Slots) H {
+		return Compose(func(b *Builder, s Slots) Node {
+			return b.Div(Class("card"),
+				b.Header(s.Or("header", func(b *Builder) Node { return Txt("Untitled") })(b)),
+				s.Get("body")(b),
+				If(s.Has("footer"), func(b *Builder) Node {
+					return b.Footer(s.Get("footer")(b))
+				})(b),
+			)
+		}, slots)
+	}
+
+	html := RenderToString(card(Slots{
+		"header": func(b *Builder) Node { return b.H2("Asset") },
+		"body":   func(b *Builder) Node { return b.P("Details") },
+		"footer": func(b *Builder) Node { return b.Button("Save") },
+	}))
+	want := `<div class="card"><header><h2>Asset</h2></header><p>Details</p><footer><button>Save</button></footer></div>`
+	if html != want {
+		t.Errorf("Compose with all slots = %s, want %s", html, want)
+	}
+
+	html = RenderToString(card(nil))
+	want = `<div class="card"><header>Untitled</header></div>`
+	if html != want {
+		t.Errorf("Compose with no slots = %s, want %s", html, want)
+	}
+
+	slots := Slots{"body": nil}
+	if slots.Has("body") || slots.Has("footer") {
+		t.Error("Has should be false for nil and absent slots")
+	}
+}
+
+func TestNewFragmentFlattens(t *testing.T) {
+	a, b := Txt("a"), B.Span("b")
+	nested := NewFragment(NewFragment(a, nil), b)
+	flat := NewFragment(a, b)
+
+	var nestedHTML, flatHTML strings.Builder
+	if err := nested.Render(&nestedHTML); err != nil {
+		t.Fatalf("nested fragment failed to render: %v", err)
+	}
+	flat.Render(&flatHTML)
+	if nestedHTML.String() != flatHTML.String() {
+		t.Errorf("nested fragment = %s, want %s", nestedHTML.String(), flatHTML.String())
+	}
+
+	children := nested.(*Fragment).Children
+	if len(children) != 2 || children[0] != a || children[1] != b {
+		t.Errorf("nested fragment children = %#v, want [a b]", children)
+	}
+	if got, want := countNodes(nested), countNodes(flat); got != want {
+		t.Errorf("nested fragment has %d nodes, want %d", got, want)
+	}
+
+	if html := RenderToString(func(b *Builder) Node {
+		return b.Div(NewFragment(nil, If(false, nil)(b), NewFragment()))
+	}); html != "<div></div>" {
+		t.Errorf("empty fragments = %s, want <div></div>", html)
+	}
+}
+
+func TestBarChart(t *testing.T) {
+	html := RenderToString(BarChart([]Series{
+		{Name: "2024", Values: []float64{40, 80}},
+		{Name: "2025", Values: []float64{60, 90}, Color: "#123456"},
+	}, ChartOptions{Title: "Sales", Labels: []string{"Q1", "Q2"}, YTicks: 4, ValueLabels: true}))
+
+	for _, want := range []string{
+		`viewBox="0 0 600 300"`,
+		`aria-label="Sales"`,
+		`minty-chart-grid`,
+		`minty-chart-legend`,
+		`fill="#123456"`,
+		`<title>Q2, 2025: 90</title>`,
+		`>100</text>`, // axis maximum rounded up from 90
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("BarChart output missing %q", want)
+		}
+	}
+
+	single := RenderToString(BarChart([]Series{{Values: []float64{1, 2}}}, ChartOptions{Stacked: true}))
+	if strings.Contains(single, "minty-chart-legend") || strings.Contains(single, "minty-chart-grid") {
+		t.Errorf("single series without YTicks should have no legend or axis: %s", single)
+	}
+}
+
+func TestTree(t *testing.T) {
+	type node struct {
+		name     string
+		children []node
+	}
+	roots := []node{
+		{name: "Electronics", children: []node{{name: "Laptops"}, {name: "Phones"}}},
+		{name: "Books"},
+	}
+	html := RenderToString(Tree(roots, TreeOptions[node]{
+		AriaLabel: "Categories",
+		Label: func(n node) H {
+			return func(b *Builder) Node { return Txt(n.name) }
+		},
+		Children: func(n node) []node { return n.children },
+		Expanded: func(n node) bool { return n.name == "Electronics" },
+	}))
+
+	for _, want := range []string{
+		`role="tree"`,
+		`aria-label="Categories"`,
+		`aria-expanded="true"`,
+		`role="group"`,
+		`aria-level="2"`,
+		`Laptops`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Tree output missing %q", want)
+		}
+	}
+	markup := html[:strings.Index(html, "<script>")]
+	if strings.Count(markup, `tabindex="0"`) != 1 {
+		t.Error("exactly one treeitem should be in the tab order")
+	}
+}
+
+func TestDebouncedTrigger(t *testing.T) {
+	cases := []struct {
+		event string
+		ms    int
+		want  string
+	}{
+		{"keyup", 300, "keyup changed delay:300ms"},
+		{"input, change", 250, "input changed delay:250ms, change delay:250ms"},
+		{"keyup delay:1s from:#search", 200, "keyup changed from:#search delay:200ms"},
+		{"click", 0, "click"},
+	}
+	for _, c := range cases {
+		if got := DebouncedTrigger(c.event, c.ms); got != c.want {
+			t.Errorf("DebouncedTrigger(%q, %d) = %q, want %q", c.event, c.ms, got, c.want)
+		}
+	}
+
+	html := RenderToString(LiveSearch("/search", "#results", "Search"))
+	if !strings.Contains(html, `hx-sync="this:replace"`) {
+		t.Errorf("LiveSearch should replace in-flight requests: %s", html)
+	}
+}
+
+func TestMinifyHTML(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"<div>\n  <p>  Hello\n  world  </p>\n</div>", "<div><p>Hello world</p></div>"},
+		{"<p><b>a</b> <i>b</i></p>", "<p><b>a</b> <i>b</i></p>"},
+		{"<span>a</span>\n\n<span>b</span>", "<span>a</span> <span>b</span>"},
+		{"<p>x<!-- note -->y</p>", "<p>xy</p>"},
+		{"<!--[if IE]><p>old</p><![endif]-->", "<!--[if IE]><p>old</p><![endif]-->"},
+		{"<pre>  keep\n   this </pre>", "<pre>  keep\n   this </pre>"},
+		{"<textarea>\n a  b</textarea>", "<textarea>\n a  b</textarea>"},
+		{"<script>if (a  <  b) {}</script>", "<script>if (a  <  b) {}</script>"},
+		{`<a title="x > y"  href="/">go</a>`, `<a title="x > y"  href="/">go</a>`},
+		{"<style>\n  a { color : red ; }\n</style>", "<style>\n  a { color : red ; }\n</style>"},
+		{"<p>1 < 2</p>", "<p>1 < 2</p>"},
+	}
+	for _, c := range cases {
+		if got := MinifyHTML(c.in); got != c.want {
+			t.Errorf("MinifyHTML(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+
+	page := func(b *Builder) Node {
+		return b.Div(b.P("Hello"), b.P("World"))
+	}
+	plain, minified := RenderToString(page), RenderToString(page, WithMinify())
+	if minified != MinifyHTML(plain) {
+		t.Errorf("WithMinify output %q differs from MinifyHTML %q", minified, MinifyHTML(plain))
+	}
+
+	style := "<style>\n  a :hover , b { color : red ; /* c */ }\n</style>"
+	if got, want := MinifyHTMLWithCSS(style), "<style>a :hover,b{color:red}</style>"; got != want {
+		t.Errorf("MinifyHTMLWithCSS(%q) = %q, want %q", style, got, want)
+	}
+	styled := func(b *Builder) Node { return b.Div(b.Style("\n  p { margin : 0 ; }\n"), b.P("x")) }
+	if got, want := RenderToString(styled, WithMinifyCSS()), "<div><style>p{margin:0}</style><p>x</p></div>"; got != want {
+		t.Errorf("WithMinifyCSS = %q, want %q", got, want)
+	}
+}
+
+func TestRenderFile(t *testing.T) {
+	dir := t.TempDir()
+	page := func(b *Builder) Node { return b.P("Hello") }
+
+	path := filepath.Join(dir, "nested", "index.html")
+	if err := RenderFile(page, path); err == nil {
+		t.Error("expected an error for a missing directory without WithMkdirAll")
+	}
+	if err := RenderFile(page, path, WithMkdirAll(0755), WithFileMode(0600)); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "<p>Hello</p>" {
+		t.Errorf("got %q, %v", data, err)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// A failed render leaves the previous file and no temporary files
+	failing := func(b *Builder) Node { return failingNode{} }
+	if err := RenderFile(failing, path); err == nil {
+		t.Error("expected the render error")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "<p>Hello</p>" {
+		t.Errorf("failed render replaced the file: %q", data)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("temporary file left behind: %v", entries)
+	}
+
+	gzPath := filepath.Join(dir, "index.html.gz")
+	if err := RenderFile(page, gzPath); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(gzPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := io.ReadAll(zr); string(data) != "<p>Hello</p>" {
+		t.Errorf("gzip content = %q", data)
+	}
+}
+
+// failingNode is a node whose rendering always fails
+type failingNode struct{}
+
+func (failingNode) Render(w io.Writer) error { return errors.New("render failed") }
+
+func TestSkeleton(t *testing.T) {
+	html := RenderToString(Skeleton(SkeletonOptions{Avatar: true, Lines: 2, Repeat: 3}))
+	if got := strings.Count(html, "minty-skeleton-avatar"); got != 3 {
+		t.Errorf("got %d avatars, want 3", got)
+	}
+	if got := strings.Count(html, "minty-skeleton-line\""); got != 6 {
+		t.Errorf("got %d lines, want 6", got)
+	}
+	for _, want := range []string{`role="status"`, `aria-busy="true"`, `aria-label="Loading…"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("skeleton missing %s", want)
+		}
+	}
+
+	block := RenderToString(Skeleton(SkeletonOptions{Block: "8rem", Lines: -1}))
+	if !strings.Contains(block, `style="height: 8rem"`) || strings.Contains(block, "minty-skeleton-line") {
+		t.Errorf("unexpected block skeleton: %s", block)
+	}
+
+	indicator := RenderToString(SkeletonIndicator("claims-loading", SkeletonOptions{}))
+	if !strings.Contains(indicator, `id="claims-loading"`) || !strings.Contains(indicator, "minty-skeleton-indicator") {
+		t.Errorf("unexpected indicator: %s", indicator)
+	}
+	css := RenderToString(SkeletonStyles())
+	if !strings.Contains(css, ".htmx-request.minty-skeleton-indicator") || !strings.Contains(css, "prefers-reduced-motion") {
+		t.Error("skeleton styles missing indicator or reduced-motion rules")
+	}
+}
+
+func TestUniqueIDPerRender(t *testing.T) {
+	field := func(b *Builder) Node { return b.Input(ID(b.UniqueID("field"))) }
+	page := func(b *Builder) Node { return b.Div(field(b), field(b)) }
+
+	for i := 0; i < 2; i++ {
+		html := RenderToString(page)
+		if !strings.Contains(html, `id="field-1"`) || !strings.Contains(html, `id="field-2"`) {
+			t.Errorf("render %d: got %s", i+1, html)
+		}
+	}
+}
+
+func TestControlHelpersUseRenderBuilder(t *testing.T) {
+	field := func(name string) H {
+		return func(b *Builder) Node { return b.Input(ID(b.UniqueID("field")), Name(name)) }
+	}
+	names := []string{"a", "b"}
+	page := func(b *Builder) Node {
+		return b.Form(
+			NewFragment(Each(names, field)...),
+			b.Fieldset(NewFragment(Range(0, 1, func(int) H { return field("c") })...)),
+			Map(names, field)(b),
+		)
+	}
+	fragment := func(b *Builder) Node {
+		return NewFragment(Each(names, func(name string) H {
+			return func(b *Builder) Node { return b.Div(NewFragment(Each([]string{name}, field)...)) }
+		})...)
+	}
+
+	for i := 0; i < 2; i++ {
+		html := RenderToString(func(b *Builder) Node { return b.Div(page(b), fragment(b)) })
+		for _, id := range []string{"field-1", "field-3", "field-5", "field-7"} {
+			if !strings.Contains(html, `id="`+id+`"`) {
+				t.Errorf("render %d missing %s: %s", i+1, id, html)
+			}
+		}
+		if strings.Contains(html, "field-8") {
+			t.Errorf("render %d handed out too many IDs: %s", i+1, html)
+		}
+		if html := RenderToString(fragment); !strings.Contains(html, `id="field-1"`) || !strings.Contains(html, `id="field-2"`) {
+			t.Errorf("render %d of a fragment: %s", i+1, html)
+		}
+	}
+
+	hash, _, _ := RenderIfChanged("", page, io.Discard)
+	if _, changed, _ := RenderIfChanged(hash, page, io.Discard); changed {
+		t.Error("RenderIfChanged reported a change for the same content")
+	}
+	if html := RenderToString(func(b *Builder) Node {
+		return b.Ul(NewFragment(Each([]int{1}, func(int) H { return func(*Builder) Node { return nil } })...))
+	}); html != "<ul></ul>" {
+		t.Errorf("nil template in Each = %s", html)
+	}
+}
+
+func TestSharedFragmentConcurrentRenders(t *testing.T) {
+	// A fragment built once and rendered by many requests
+	shared := NewFragment(Each([]string{"a", "b"}, func(name string) H {
+		return func(b *Builder) Node { return b.P(ID(b.UniqueID("x")), name) }
+	})...)
+	page := func(b *Builder) Node { return b.Div(b.Span(ID(b.UniqueID("x"))), shared) }
+	want := `<div><span id="x-1"></span><p id="x-2">a</p><p id="x-3">b</p></div>`
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if html := RenderToString(page, WithCanonicalAttributes()); html != want {
+					t.Errorf("shared fragment = %s, want %s", html, want)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if _, deferred := shared.(*Fragment).Children[0].(*deferredNode); !deferred {
+		t.Error("rendering changed the shared fragment")
+	}
+}
+
+func TestRenderRequest(t *testing.T) {
+	page := func(b *Builder) Node {
+		if b.IsHTMX() {
+			return b.Div(ID("results"), "partial for #"+b.HTMXTarget())
+		}
+		return b.Nav(AttrIf(!b.IsHTMX(), HtmxBoost(true)), HtmxTargetIf(b.IsHTMX(), "#main"), "full page")
+	}
+
+	var full bytes.Buffer
+	if err := RenderRequest(nil, page, &full); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(full.String(), `hx-boost="true"`) || strings.Contains(full.String(), "hx-target") {
+		t.Errorf("full render = %s", full.String())
+	}
+
+	r, _ := http.NewRequest("GET", "/claims", nil)
+	r.Header.Set("HX-Request", "true")
+	r.Header.Set("HX-Target", "results")
+	var partial bytes.Buffer
+	if err := RenderRequest(r, page, &partial); err != nil {
+		t.Fatal(err)
+	}
+	if got := partial.String(); got != `<div id="results">partial for #results</div>` {
+		t.Errorf("partial render = %s", got)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	type status string
+	html := RenderToString(Select(SelectOptions{
+		Label: "Status",
+		Name:  "status",
+		Value: "retired",
+		Options: append(EnumOptions([]status{"active", "retired"}, func(s status) string {
+			return strings.ToUpper(string(s))
+		}), SelectOption{Value: "lost", Text: "Lost", Disabled: true}),
+		Placeholder: "Select...",
+		Required:    true,
+		Error:       "Pick one",
+	}))
+	for _, want := range []string{
+		`<label for="status">Status</label>`,
+		`>RETIRED</option>`,
+		`aria-describedby="status-error"`,
+		`id="status-error"`,
+		`>Select...</option>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("select missing %q in %s", want, html)
+		}
+	}
+	if strings.Count(html, `selected="selected"`) != 1 {
+		t.Errorf("exactly one option should be selected: %s", html)
+	}
+	for _, option := range strings.Split(html, "<option")[1:] {
+		if strings.Contains(option, `value="retired"`) != strings.Contains(option, "selected") {
+			t.Errorf("only the current value should be selected: <option%s", option)
+		}
+	}
+
+	grouped := RenderToString(Select(SelectOptions{Name: "car", Options: []SelectOption{
+		{Value: "volvo", Text: "Volvo", Group: "Swedish"},
+		{Value: "audi", Text: "Audi", Group: "German"},
+		{Value: "saab", Text: "Saab", Group: "Swedish"},
+	}}))
+	if strings.Count(grouped, "<optgroup") != 2 {
+		t.Errorf("want 2 optgroups: %s", grouped)
+	}
+	swedish := grouped[strings.Index(grouped, `label="Swedish"`):strings.Index(grouped, `label="German"`)]
+	if !strings.Contains(swedish, "Volvo") || !strings.Contains(swedish, "Saab") {
+		t.Errorf("Swedish group should hold Volvo and Saab: %s", grouped)
+	}
+}
+
+func TestRenderContextUser(t *testing.T) {
+	page := func(b *Builder) Node {
+		return b.Div(
+			UserAvatar("")(b),
+			IfCan("claims.delete", func(b *Builder) Node { return b.Button("Delete") })(b),
+			b.Button(AttrIf(!b.Can("claims.approve"), Disabled()), "Approve"),
+		)
+	}
+
+	var anonymous bytes.Buffer
+	if err := RenderContext(context.Background(), page, &anonymous); err != nil {
+		t.Fatal(err)
+	}
+	if got := anonymous.String(); got != `<div><button disabled="disabled">Approve</button></div>` {
+		t.Errorf("anonymous render = %s", got)
+	}
+
+	user := User{ID: "u1", Name: "Ada Lovelace", Permissions: []string{"claims.approve"}}
+	ctx := WithUser(context.Background(), user)
+	if got, ok := UserFrom(ctx); !ok || got.ID != "u1" {
+		t.Fatalf("UserFrom = %+v, %v", got, ok)
+	}
+	r, _ := http.NewRequestWithContext(ctx, "GET", "/claims", nil)
+	var signedIn bytes.Buffer
+	if err := RenderRequest(r, page, &signedIn); err != nil {
+		t.Fatal(err)
+	}
+	got := signedIn.String()
+	if !strings.Contains(got, ">AL</span>") || strings.Contains(got, "Delete") || strings.Contains(got, "disabled") {
+		t.Errorf("signed-in render = %s", got)
+	}
+
+	if !(User{Permissions: []string{"*"}}).Can("anything") {
+		t.Error(`"*" should grant every permission`)
+	}
+}
+
+func TestAuthorizer(t *testing.T) {
+	page := Gate("assets.delete",
+		func(b *Builder) Node { return b.Button("Delete") },
+		func(b *Builder) Node { return b.Span("Read only") })
+	render := func(ctx context.Context) string {
+		var buf bytes.Buffer
+		if err := RenderContext(ctx, page, &buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+	ctx := WithUser(context.Background(), User{ID: "u1", Roles: []string{"admin"}})
+
+	if got := render(ctx); got != "<span>Read only</span>" {
+		t.Errorf("without authorizer = %s", got)
+	}
+
+	SetAuthorizer(AuthorizerFunc(func(ctx context.Context, u User, permission string) bool {
+		return u.HasRole("admin")
+	}))
+	defer SetAuthorizer(nil)
+	if got := render(ctx); got != "<button>Delete</button>" {
+		t.Errorf("with global authorizer = %s", got)
+	}
+
+	deny := AuthorizerFunc(func(context.Context, User, string) bool { return false })
+	if got := render(WithAuthorizer(ctx, deny)); got != "<span>Read only</span>" {
+		t.Errorf("context authorizer should take precedence, got %s", got)
+	}
+}
+
+func TestFooter(t *testing.T) {
+	html := RenderToString(Footer(FooterOptions{
+		Columns: []FooterColumn{
+			{Heading: "Product", Links: []NavLink{{URL: "/pricing", Text: "Pricing"}}},
+		},
+		Copyright: "Acme Inc.",
+		Since:     2019,
+		Year:      2025,
+		Legal:     []NavLink{{URL: "/privacy", Text: "Privacy"}},
+		Social: []SocialLink{{URL: "https://example.social/@acme", Label: "Mastodon",
+			Icon: func(b *Builder) Node { return b.Svg() }}},
+		BuiltWith: func(b *Builder) Node { return b.Text("Built with minty") },
+	}))
+	for _, want := range []string{
+		`<footer class="minty-footer">`,
+		`aria-label="Footer"`,
+		`>Product</h2>`,
+		`href="/pricing"`,
+		`© 2019–2025 Acme Inc.`,
+		`aria-label="Legal"`,
+		`aria-label="Mastodon"`,
+		`aria-hidden="true"`,
+		`Built with minty`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Footer output missing %q in %s", want, html)
+		}
+	}
+
+	bare := RenderToString(Footer(FooterOptions{Copyright: "Acme", Since: 2025, Year: 2025}))
+	if bare != `<footer class="minty-footer"><div class="minty-footer-bottom"><p class="minty-footer-copyright">© 2025 Acme</p></div></footer>` {
+		t.Errorf("bare footer = %s", bare)
+	}
+}
+
+func TestCanonicalAttributes(t *testing.T) {
+	page := func(b *Builder) Node {
+		return b.Div(Data("z", "1"), Disabled(), Class("card"), AriaLabel("Card"), ID("c1"), Required(),
+			b.Span(Title("t"), Class("x")))
+	}
+	want := `<div id="c1" class="card" aria-label="Card" data-z="1" disabled="disabled" required="required">` +
+		`<span class="x" title="t"></span></div>`
+	for i := 0; i < 5; i++ {
+		if got := RenderToString(page, WithCanonicalAttributes()); got != want {
+			t.Fatalf("canonical render = %s", got)
+		}
+	}
+
+	SetRenderObserver(func(Metrics) {})
+	defer SetRenderObserver(nil)
+	if got := RenderToString(page, WithCanonicalAttributes(), WithMinify()); got != want {
+		t.Errorf("canonical render with observer and minify = %s", got)
+	}
+}
+
+func TestLayoutPrimitives(t *testing.T) {
+	item := func(text string) H { return func(b *Builder) Node { return b.Span(text) } }
+
+	if got := RenderToString(Stack(2, item("a"), nil, item("b"))); got != `<div class="flex flex-col gap-2"><span>a</span><span>b</span></div>` {
+		t.Errorf("Stack = %s", got)
+	}
+	if got := RenderToString(Row(4, "", item("a"))); got != `<div class="flex flex-row items-center gap-4"><span>a</span></div>` {
+		t.Errorf("Row = %s", got)
+	}
+	if got := RenderToString(Grid(3, 9, item("a"))); got != `<div class="grid grid-cols-1 sm:grid-cols-2 lg:grid-cols-3 gap-12"><span>a</span></div>` {
+		t.Errorf("Grid = %s", got)
+	}
+
+	SetLayoutClasses(BootstrapLayout{})
+	defer SetLayoutClasses(nil)
+	if got := RenderToString(Grid(8, 4, item("a"), item("b"))); got != `<div class="row row-cols-1 row-cols-sm-2 row-cols-lg-6 g-3"><div class="col"><span>a</span></div><div class="col"><span>b</span></div></div>` {
+		t.Errorf("Bootstrap Grid = %s", got)
+	}
+	if got := RenderToString(Cluster(1, item("a"))); got != `<div class="d-flex flex-wrap align-items-center gap-1"><span>a</span></div>` {
+		t.Errorf("Bootstrap Cluster = %s", got)
+	}
+}
+
+func TestRenderIfChanged(t *testing.T) {
+	panel := func(count int) H {
+		return func(b *Builder) Node {
+			return b.Div(ID("stats"), Class("panel"), Data("count", fmt.Sprint(count)), Disabled(), fmt.Sprint(count))
+		}
+	}
+
+	var buf bytes.Buffer
+	hash, changed, err := RenderIfChanged("", panel(1), &buf)
+	if err != nil || !changed || hash == "" || !strings.Contains(buf.String(), ">1</div>") {
+		t.Fatalf("first render: hash=%q changed=%v err=%v out=%s", hash, changed, err, buf.String())
+	}
+	for i := 0; i < 5; i++ {
+		buf.Reset()
+		again, changed, err := RenderIfChanged(hash, panel(1), &buf)
+		if err != nil || changed || again != hash || buf.Len() != 0 {
+			t.Fatalf("unchanged render: hash=%q changed=%v err=%v out=%s", again, changed, err, buf.String())
+		}
+	}
+	if next, changed, _ := RenderIfChanged(hash, panel(2), &buf); !changed || next == hash {
+		t.Errorf("changed content not written")
+	}
+
+	rec := httptest.NewRecorder()
+	if err := ServeIfChanged(rec, httptest.NewRequest("GET", "/stats", nil), panel(1)); err != nil {
+		t.Fatal(err)
+	}
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag != `"`+hash+`"` || rec.Header().Get("Cache-Control") != "no-cache" {
+		t.Fatalf("first response: %d %v", rec.Code, rec.Header())
+	}
+	req := httptest.NewRequest("GET", "/stats", nil)
+	req.Header.Set("If-None-Match", `W/"other", `+etag)
+	rec = httptest.NewRecorder()
+	ServeIfChanged(rec, req, panel(1))
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("revalidation: %d %q", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	ServeIfChanged(rec, req, panel(3))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), ">3</div>") {
+		t.Errorf("changed content: %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+// gaugePanel is a small page for the render benchmarks.
+func gaugePanel(b *Builder) Node {
+	return b.Div(Class("gauges"),
+		NewFragment(Each([]int{12, 47, 88}, func(v int) H {
+			return func(b *Builder) Node {
+				return b.Div(Class("gauge"), b.Span(Class("value"), v), b.Meter(Value(fmt.Sprint(v))))
+			}
+		})...),
+	)
+}
+
+func BenchmarkRender(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Render(gaugePanel, io.Discard)
+	}
+}
+
+// BenchmarkRenderUnpooled renders with a new Builder each time, as Render
+// did before builders were pooled, for comparison with BenchmarkRender.
+func BenchmarkRenderUnpooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		renderWith(&Builder{}, gaugePanel, io.Discard)
+	}
+}
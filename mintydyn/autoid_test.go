@@ -0,0 +1,37 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestAutoID(t *testing.T) {
+	items := []map[string]interface{}{{"name": "Laptop"}, {"name": "Mouse"}}
+	filter := Dyn("").Data(items).TextFilter("name", "Name").Build()
+	page := func(b *mi.Builder) mi.Node {
+		return b.Div(filter(b), filter(b))
+	}
+
+	// A second render of the same page must produce the same ids
+	for render := 1; render <= 2; render++ {
+		html := mi.RenderToString(page)
+		for _, want := range []string{
+			`id="dyn-1"`, `id="dyn-1-config"`, "window.DynComponent_dyn_1 =",
+			`id="dyn-2"`, `id="dyn-2-config"`, "window.DynComponent_dyn_2 =",
+		} {
+			if !strings.Contains(html, want) {
+				t.Errorf("render %d missing %q", render, want)
+			}
+		}
+		if strings.Contains(html, `id=""`) || strings.Contains(html, "dyn-3") {
+			t.Errorf("render %d has an empty or extra id", render)
+		}
+	}
+
+	named := mi.RenderToString(Dyn("products").Data(items).TextFilter("name", "Name").Build())
+	if !strings.Contains(named, `id="products-config"`) || strings.Contains(named, "dyn-1") {
+		t.Error("explicit ids should be used as given")
+	}
+}
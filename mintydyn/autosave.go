@@ -0,0 +1,224 @@
+package mintydyn
+
+import (
+	"strconv"
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// AUTOSAVE
+// =============================================================================
+
+// AutoSaveOptions configures AutoSave.
+type AutoSaveOptions struct {
+	URL          string // Drafts are POSTed here; default the form's action
+	Delay        int    // Milliseconds without changes before saving (default 1500)
+	MaxWait      int    // Milliseconds a change may wait for the edited field to be left (default 10000)
+	VersionField string // Name of a form field holding the draft version, for conflict detection
+	Class        string // Extra classes for the status indicator
+	SavingText   string // Default "Saving…"
+	SavedText    string // Default "Saved"
+	ErrorText    string // Default "Not saved"
+	ConflictText string // Default "Changed elsewhere. Reload to see the latest version."
+}
+
+func (o AutoSaveOptions) withDefaults() AutoSaveOptions {
+	if o.Delay <= 0 {
+		o.Delay = 1500
+	}
+	if o.MaxWait <= 0 {
+		o.MaxWait = 10000
+	}
+	if o.SavingText == "" {
+		o.SavingText = "Saving…"
+	}
+	if o.SavedText == "" {
+		o.SavedText = "Saved"
+	}
+	if o.ErrorText == "" {
+		o.ErrorText = "Not saved"
+	}
+	if o.ConflictText == "" {
+		o.ConflictText = "Changed elsewhere. Reload to see the latest version."
+	}
+	return o
+}
+
+// AutoSave saves the form matching formSelector as a draft while it is
+// filled in, and renders the status indicator where it is placed, a live
+// region announcing "Saving…", "Saved" or "Not saved". Changes are saved
+// once the form has been quiet for Delay, posted URL-encoded with an
+// X-Autosave: true header; nothing is sent when the form is unchanged
+// since the last save. While the text field being typed in keeps focus
+// the save waits for it to be left, up to MaxWait, so a half-typed value
+// is not saved on every pause.
+//
+// With VersionField set, the handler answers a stale version with 409
+// Conflict, which stops autosaving and shows ConflictText, and answers a
+// successful save with the new version in an X-Autosave-Version header,
+// which is written back into the field.
+//
+// The form fires bubbling autosave:saved events with detail {version} and
+// autosave:error events with detail {status, conflict}; status is 0 when
+// the request failed to complete.
+//
+//	b.Form(mi.ID("claim-form"), mi.Action("/claims/submit"), mi.Method("post"),
+//	    b.Input(mi.Type("hidden"), mi.Name("version"), mi.Value(draft.Version)),
+//	    ...
+//	),
+//	mdy.AutoSave("#claim-form", mdy.AutoSaveOptions{
+//	    URL:          "/claims/draft",
+//	    VersionField: "version",
+//	})(b)
+func AutoSave(formSelector string, opts AutoSaveOptions) mi.H {
+	opts = opts.withDefaults()
+	return func(b *mi.Builder) mi.Node {
+		status := []interface{}{
+			mi.Class(strings.TrimSpace("dyn-autosave " + opts.Class)),
+			mi.Data("dyn-autosave", formSelector),
+			mi.Data("dyn-autosave-delay", strconv.Itoa(opts.Delay)),
+			mi.Data("dyn-autosave-max-wait", strconv.Itoa(opts.MaxWait)),
+			mi.Data("dyn-autosave-saving", opts.SavingText),
+			mi.Data("dyn-autosave-saved", opts.SavedText),
+			mi.Data("dyn-autosave-error", opts.ErrorText),
+			mi.Data("dyn-autosave-conflict", opts.ConflictText),
+			mi.Role("status"),
+			mi.Attr("aria-live", "polite"),
+		}
+		if opts.URL != "" {
+			status = append(status, mi.Data("dyn-autosave-url", opts.URL))
+		}
+		if opts.VersionField != "" {
+			status = append(status, mi.Data("dyn-autosave-version", opts.VersionField))
+		}
+		return mi.NewFragment(
+			b.Span(status...),
+			b.Script(mi.Raw(autoSaveJS)),
+		)
+	}
+}
+
+// autoSaveJS binds every status indicator on the page to its form, once
+// per indicator, on load and after htmx swaps.
+const autoSaveJS = `(function(){
+if (window.DynAutoSave) { window.DynAutoSave.bindAll(); return; }
+function serialize(form, versionField) {
+    var params = new URLSearchParams(new FormData(form));
+    if (versionField) params.delete(versionField);
+    return params.toString();
+}
+function isTextField(el) {
+    if (el.tagName === 'TEXTAREA' || el.isContentEditable) return true;
+    return el.tagName === 'INPUT' &&
+        /^(text|search|email|url|tel|password|number)$/.test(el.type || 'text');
+}
+function bind(status) {
+    if (status._dynAutoSave) return;
+    var form = document.querySelector(status.dataset.dynAutosave);
+    if (!form) return;
+    var d = status.dataset;
+    var delay = parseInt(d.dynAutosaveDelay, 10) || 1500;
+    var maxWait = parseInt(d.dynAutosaveMaxWait, 10) || 10000;
+    var versionField = d.dynAutosaveVersion || '';
+    var state = { saved: serialize(form, versionField), timer: null, since: 0, field: null, busy: false, again: false, stopped: false };
+    status._dynAutoSave = state;
+
+    function show(name, text) {
+        status.setAttribute('data-dyn-autosave-state', name);
+        status.textContent = text;
+    }
+    function fire(name, detail) {
+        form.dispatchEvent(new CustomEvent(name, { bubbles: true, detail: detail }));
+    }
+    function schedule(wait) {
+        if (state.stopped) return;
+        clearTimeout(state.timer);
+        if (!state.since) state.since = Date.now();
+        state.timer = setTimeout(attempt, wait);
+    }
+    function attempt() {
+        // Hold off while a field is mid-edit, unless the change has waited long enough
+        var waited = Date.now() - state.since;
+        var field = state.field;
+        if (field && field === document.activeElement && isTextField(field) && waited < maxWait) {
+            schedule(Math.min(delay, maxWait - waited));
+            return;
+        }
+        save();
+    }
+    function save() {
+        state.timer = null;
+        state.since = 0;
+        state.field = null;
+        if (state.stopped) return;
+        if (state.busy) { state.again = true; return; }
+        var body = serialize(form, versionField);
+        if (body === state.saved) return;
+        var data = new URLSearchParams(new FormData(form));
+        state.busy = true;
+        show('saving', d.dynAutosaveSaving);
+        fetch(d.dynAutosaveUrl || form.action, {
+            method: 'POST',
+            credentials: 'same-origin',
+            headers: { 'Content-Type': 'application/x-www-form-urlencoded', 'X-Autosave': 'true' },
+            body: data.toString()
+        }).then(function(res) {
+            if (res.status === 409 && versionField) {
+                state.stopped = true;
+                show('conflict', d.dynAutosaveConflict);
+                fire('autosave:error', { status: 409, conflict: true });
+                return;
+            }
+            if (!res.ok) {
+                show('error', d.dynAutosaveError);
+                fire('autosave:error', { status: res.status, conflict: false });
+                return;
+            }
+            state.saved = body;
+            var version = res.headers.get('X-Autosave-Version');
+            if (version !== null && versionField && form.elements[versionField]) {
+                form.elements[versionField].value = version;
+            }
+            show('saved', d.dynAutosaveSaved);
+            fire('autosave:saved', { version: version });
+        }, function() {
+            show('error', d.dynAutosaveError);
+            fire('autosave:error', { status: 0, conflict: false });
+        }).then(function() {
+            state.busy = false;
+            if (state.again) {
+                state.again = false;
+                schedule(0);
+            }
+        });
+    }
+
+    function changed(e) {
+        state.field = e.target;
+        schedule(delay);
+    }
+    form.addEventListener('input', changed);
+    form.addEventListener('change', changed);
+    form.addEventListener('focusout', function() {
+        // Leaving a field releases a save that was waiting for it
+        if (state.timer) schedule(0);
+    });
+    form.addEventListener('submit', function() {
+        clearTimeout(state.timer);
+        state.stopped = true;
+    });
+}
+window.DynAutoSave = {
+    bindAll: function() {
+        document.querySelectorAll('[data-dyn-autosave]').forEach(bind);
+    }
+};
+if (document.readyState === 'loading') {
+    document.addEventListener('DOMContentLoaded', window.DynAutoSave.bindAll);
+} else {
+    window.DynAutoSave.bindAll();
+}
+document.addEventListener('htmx:afterSwap', window.DynAutoSave.bindAll);
+})();`
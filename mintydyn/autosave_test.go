@@ -0,0 +1,40 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestAutoSave(t *testing.T) {
+	html := mi.RenderToString(AutoSave("#claim-form", AutoSaveOptions{
+		URL:          "/claims/draft",
+		VersionField: "version",
+	}))
+	markup := html[:strings.Index(html, "<script")]
+	for _, want := range []string{
+		`data-dyn-autosave="#claim-form"`,
+		`data-dyn-autosave-url="/claims/draft"`,
+		`data-dyn-autosave-version="version"`,
+		`data-dyn-autosave-delay="1500"`,
+		`data-dyn-autosave-saved="Saved"`,
+		`role="status"`,
+		`aria-live="polite"`,
+	} {
+		if !strings.Contains(markup, want) {
+			t.Errorf("AutoSave output missing %q in %s", want, markup)
+		}
+	}
+	if !strings.Contains(html, "window.DynAutoSave") {
+		t.Error("AutoSave script missing")
+	}
+
+	html = mi.RenderToString(AutoSave("#notes", AutoSaveOptions{Delay: 500}))
+	if strings.Contains(html, "data-dyn-autosave-url") || strings.Contains(html, "data-dyn-autosave-version") {
+		t.Errorf("unset URL and VersionField should be left out: %s", html)
+	}
+	if !strings.Contains(html, `data-dyn-autosave-delay="500"`) {
+		t.Errorf("Delay not applied: %s", html)
+	}
+}
@@ -2,6 +2,7 @@ package mintydyn
 
 import (
 	"reflect"
+	"time"
 
 	mi "github.com/ha1tch/minty"
 )
@@ -91,6 +92,14 @@ func (db *DynamicBuilder[S, D, R]) Minified() *DynamicBuilder[S, D, R] {
 	return db
 }
 
+// ScopedCSS namespaces the component's classes and injected CSS with its id,
+// so "dyn-state-trigger" becomes "c-<id>__state-trigger". Use this when
+// several components on one page need independent styling.
+func (db *DynamicBuilder[S, D, R]) ScopedCSS() *DynamicBuilder[S, D, R] {
+	db.options.ScopedCSS = true
+	return db
+}
+
 // =============================================================================
 // EXTERNAL SCRIPT METHODS
 // =============================================================================
@@ -129,6 +138,21 @@ func ScriptDefer() ScriptOption {
 	return func(s *ExternalScript) { s.Defer = true }
 }
 
+// Retry makes a failed load try again up to retries more times, waiting
+// backoff before the first retry and twice as long before each further one.
+// Every retry fires external:retry on the component with the script's src,
+// the attempt number and the delay. A required script that still fails
+// stops the component's initialization; an optional one is given up with a
+// console warning.
+//
+//	mdy.Required(), mdy.Retry(3, 500*time.Millisecond)
+func Retry(retries int, backoff time.Duration) ScriptOption {
+	return func(s *ExternalScript) {
+		s.Retries = retries
+		s.RetryDelay = int(backoff / time.Millisecond)
+	}
+}
+
 // OnLoad sets JS code to run when the script loads.
 func OnLoad(jsCode string) ScriptOption {
 	return func(s *ExternalScript) { s.OnLoad = jsCode }
@@ -171,6 +195,18 @@ func (db *DynamicBuilder[S, D, R]) OnState(stateID, jsCode string) *DynamicBuild
 	return db
 }
 
+// OnStateClass adds className to the elements matching targetSelector while
+// stateID is active and removes it when the state is left, e.g. to highlight
+// a related sidebar entry or dim content outside the component.
+func (db *DynamicBuilder[S, D, R]) OnStateClass(stateID, targetSelector, className string) *DynamicBuilder[S, D, R] {
+	db.options.StateClasses = append(db.options.StateClasses, StateClassToggle{
+		State:    stateID,
+		Selector: targetSelector,
+		Class:    className,
+	})
+	return db
+}
+
 // OnFilter sets the afterFilter hook.
 func (db *DynamicBuilder[S, D, R]) OnFilter(jsCode string) *DynamicBuilder[S, D, R] {
 	db.options.Hooks.AfterFilter = jsCode
@@ -188,14 +224,26 @@ func (db *DynamicBuilder[S, D, R]) OnDestroy(jsCode string) *DynamicBuilder[S, D
 // =============================================================================
 
 // Build generates the final component as a minty H function.
+//
+// An empty id is replaced at render time by one from b.UniqueID("dyn"), so
+// the same component can appear several times on a page without clashing
+// DOM ids or window.DynComponent_<id> globals. Generated ids are stable for
+// a given page layout but not across layouts: give components that other
+// code refers to by id an explicit one.
 func (db *DynamicBuilder[S, D, R]) Build() mi.H {
 	return func(b *mi.Builder) mi.Node {
-		pattern := db.detectPattern()
-		return db.generateComponent(b, pattern)
+		component := db
+		if db.id == "" {
+			named := *db
+			named.id = b.UniqueID("dyn")
+			component = &named
+		}
+		pattern := component.detectPattern()
+		return component.generateComponent(b, pattern)
 	}
 }
 
-// ID returns the component's ID.
+// ID returns the component's ID, which is empty for auto-named components.
 func (db *DynamicBuilder[S, D, R]) ID() string {
 	return db.id
 }
@@ -24,7 +24,7 @@ func (db *DynamicBuilder[S, D, R]) generateStatefulDataStructure(b *mi.Builder,
 	for _, state := range states {
 		panelClass := combineClasses(
 			theme.StateContentClass(),
-			"dyn-filterable-state",
+			db.scopeClasses("dyn-filterable-state"),
 		)
 		if state.Active {
 			panelClass = combineClasses(panelClass, theme.StateContentActiveClass())
@@ -32,26 +32,29 @@ func (db *DynamicBuilder[S, D, R]) generateStatefulDataStructure(b *mi.Builder,
 			panelClass = combineClasses(panelClass, theme.StateContentHiddenClass())
 		}
 
-		panel := b.Div(
-			mi.ID("state-"+state.ID),
+		panelAttrs := []interface{}{
+			mi.ID("state-" + state.ID),
 			mi.Class(panelClass),
 			mi.Data("state-id", state.ID),
+		}
+		panelAttrs = append(panelAttrs, tabPanelAttrs(state, len(states) > 1)...)
+		panel := b.Div(append(panelAttrs,
 			// Filters scoped to this state
 			b.Div(
-				mi.Class("dyn-state-filters"),
+				mi.Class(db.scopeClasses("dyn-state-filters")),
 				mi.Data("state-context", state.ID),
 			),
 			// Results scoped to this state
 			b.Div(
-				mi.Class("dyn-state-results"),
+				mi.Class(db.scopeClasses("dyn-state-results")),
 				mi.Data("state-context", state.ID),
 			),
-		)
+		)...)
 
 		stateContents = append(stateContents, panel)
 	}
 
-	containerAttrs := []interface{}{mi.Class("dyn-stateful-data-container")}
+	containerAttrs := []interface{}{mi.Class(db.scopeClasses("dyn-stateful-data-container"))}
 	containerAttrs = append(containerAttrs, stateContents...)
 	children = append(children, b.Div(containerAttrs...))
 
@@ -85,7 +88,7 @@ func (db *DynamicBuilder[S, D, R]) generateFilterableStatesStructure(b *mi.Build
 	// State-contextualized results
 	var stateResults []interface{}
 	for _, state := range states {
-		resultClass := "dyn-state-results"
+		resultClass := db.scopeClasses("dyn-state-results")
 		if state.Active {
 			resultClass = combineClasses(resultClass, theme.StateContentActiveClass())
 		} else {
@@ -106,14 +109,7 @@ func (db *DynamicBuilder[S, D, R]) generateFilterableStatesStructure(b *mi.Build
 	resultsContainer = append(resultsContainer, stateResults...)
 	children = append(children, b.Div(resultsContainer...))
 
-	// Pagination
-	opts := db.extractFilterOptions()
-	if opts.EnablePagination {
-		children = append(children, b.Div(
-			mi.ID(db.id+"-pagination"),
-			mi.Class(theme.PaginationClass()),
-		))
-	}
+	children = append(children, db.generatePaginationControls(b, theme)...)
 
 	return children
 }
@@ -141,9 +137,10 @@ func (db *DynamicBuilder[S, D, R]) generateDependentStatesStructure(b *mi.Builde
 // generateDependentStateNavigation creates navigation aware of dependency rules.
 func (db *DynamicBuilder[S, D, R]) generateDependentStateNavigation(b *mi.Builder, states []ComponentState, rules []DependencyRule, theme DynamicTheme) mi.Node {
 	var buttons []interface{}
+	focusable := focusableState(states)
 
 	for _, state := range states {
-		btnClass := combineClasses(theme.StateTriggerClass(), "dyn-dependent-trigger")
+		btnClass := combineClasses(theme.StateTriggerClass(), db.scopeClasses("dyn-dependent-trigger"))
 		if state.Active {
 			btnClass = combineClasses(btnClass, theme.StateTriggerActiveClass())
 		}
@@ -152,13 +149,12 @@ func (db *DynamicBuilder[S, D, R]) generateDependentStateNavigation(b *mi.Builde
 		}
 
 		btnAttrs := []interface{}{
+			mi.Type("button"),
 			mi.Class(btnClass),
 			mi.Data("state-target", state.ID),
 			mi.Data("client-action", "switch-state"),
-			mi.Attr("role", "tab"),
-			mi.Attr("aria-selected", boolStr(state.Active)),
-			mi.Attr("aria-controls", "state-"+state.ID),
 		}
+		btnAttrs = append(btnAttrs, tabAttrs(state, state.ID == focusable)...)
 
 		// Check if this state is controlled by rules
 		if isStateControlledByRules(state.ID, rules) {
@@ -174,7 +170,7 @@ func (db *DynamicBuilder[S, D, R]) generateDependentStateNavigation(b *mi.Builde
 	}
 
 	navAttrs := []interface{}{
-		mi.Class(combineClasses(theme.StateNavigationClass(), "dyn-dependent-navigation")),
+		mi.Class(combineClasses(theme.StateNavigationClass(), db.scopeClasses("dyn-dependent-navigation"))),
 		mi.Attr("role", "tablist"),
 	}
 	navAttrs = append(navAttrs, buttons...)
@@ -187,7 +183,7 @@ func (db *DynamicBuilder[S, D, R]) generateDependentStateContents(b *mi.Builder,
 	var panels []interface{}
 
 	for _, state := range states {
-		panelClass := combineClasses(theme.StateContentClass(), "dyn-dependent-state")
+		panelClass := combineClasses(theme.StateContentClass(), db.scopeClasses("dyn-dependent-state"))
 		if state.Active {
 			panelClass = combineClasses(panelClass, theme.StateContentActiveClass())
 		} else {
@@ -198,9 +194,8 @@ func (db *DynamicBuilder[S, D, R]) generateDependentStateContents(b *mi.Builder,
 			mi.ID("state-" + state.ID),
 			mi.Class(panelClass),
 			mi.Data("state-id", state.ID),
-			mi.Attr("role", "tabpanel"),
-			mi.Attr("aria-hidden", boolStr(!state.Active)),
 		}
+		panelAttrs = append(panelAttrs, tabPanelAttrs(state, true)...)
 
 		// Add rules that affect this state as metadata
 		affectingRules := findRulesAffectingTarget(rules, state.ID)
@@ -215,7 +210,7 @@ func (db *DynamicBuilder[S, D, R]) generateDependentStateContents(b *mi.Builder,
 		panels = append(panels, b.Div(panelAttrs...))
 	}
 
-	containerAttrs := []interface{}{mi.Class("dyn-dependent-states-container")}
+	containerAttrs := []interface{}{mi.Class(db.scopeClasses("dyn-dependent-states-container"))}
 	containerAttrs = append(containerAttrs, panels...)
 
 	return b.Div(containerAttrs...)
@@ -242,7 +237,7 @@ func (db *DynamicBuilder[S, D, R]) generateDependentDataStructure(b *mi.Builder,
 	// Results with dependency support
 	children = append(children, b.Div(
 		mi.ID(db.id+"-results"),
-		mi.Class(combineClasses(theme.ResultsClass(), "dyn-dependent-results")),
+		mi.Class(combineClasses(theme.ResultsClass(), db.scopeClasses("dyn-dependent-results"))),
 	))
 
 	return children
@@ -264,7 +259,7 @@ func (db *DynamicBuilder[S, D, R]) generateDependentFilterControls(b *mi.Builder
 
 		groupClass := theme.FilterGroupClass()
 		if len(affectingRules) > 0 {
-			groupClass = combineClasses(groupClass, "dyn-dependent-filter")
+			groupClass = combineClasses(groupClass, db.scopeClasses("dyn-dependent-filter"))
 		}
 
 		control := db.generateFilterControl(b, field, theme)
@@ -283,7 +278,7 @@ func (db *DynamicBuilder[S, D, R]) generateDependentFilterControls(b *mi.Builder
 
 	containerAttrs := []interface{}{
 		mi.ID(db.id + "-filters"),
-		mi.Class(combineClasses(theme.FilterControlsClass(), "dyn-dependent-filters")),
+		mi.Class(combineClasses(theme.FilterControlsClass(), db.scopeClasses("dyn-dependent-filters"))),
 	}
 	containerAttrs = append(containerAttrs, controls...)
 
@@ -309,33 +304,36 @@ func (db *DynamicBuilder[S, D, R]) generateCompleteStructure(b *mi.Builder, patt
 	// Each state has its own filters and results
 	var stateContents []interface{}
 	for _, state := range states {
-		panelClass := combineClasses(theme.StateContentClass(), "dyn-complete-state")
+		panelClass := combineClasses(theme.StateContentClass(), db.scopeClasses("dyn-complete-state"))
 		if state.Active {
 			panelClass = combineClasses(panelClass, theme.StateContentActiveClass())
 		} else {
 			panelClass = combineClasses(panelClass, theme.StateContentHiddenClass())
 		}
 
-		panel := b.Div(
-			mi.ID("state-"+state.ID),
+		panelAttrs := []interface{}{
+			mi.ID("state-" + state.ID),
 			mi.Class(panelClass),
 			mi.Data("state-id", state.ID),
+		}
+		panelAttrs = append(panelAttrs, tabPanelAttrs(state, len(states) > 1)...)
+		panel := b.Div(append(panelAttrs,
 			// Dependent filters within state context
 			b.Div(
-				mi.Class("dyn-state-filters dyn-dependent-filters"),
+				mi.Class(db.scopeClasses("dyn-state-filters dyn-dependent-filters")),
 				mi.Data("state-context", state.ID),
 			),
 			// Results with full feature support
 			b.Div(
-				mi.Class("dyn-state-results dyn-complete-results"),
+				mi.Class(db.scopeClasses("dyn-state-results dyn-complete-results")),
 				mi.Data("state-context", state.ID),
 			),
-		)
+		)...)
 
 		stateContents = append(stateContents, panel)
 	}
 
-	containerAttrs := []interface{}{mi.Class("dyn-complete-container")}
+	containerAttrs := []interface{}{mi.Class(db.scopeClasses("dyn-complete-container"))}
 	containerAttrs = append(containerAttrs, stateContents...)
 	children = append(children, b.Div(containerAttrs...))
 
@@ -0,0 +1,354 @@
+package mintydyn
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// COMMENT THREAD
+// =============================================================================
+
+// Comment is one comment of a CommentThread.
+type Comment struct {
+	ID        string
+	Author    mi.User // Name and avatar are shown; ID decides ownership
+	Body      string  // Plain text; line breaks are kept
+	CreatedAt time.Time
+	EditedAt  time.Time // Zero unless the comment was edited
+}
+
+// CommentThreadOptions configures a CommentThread.
+type CommentThreadOptions struct {
+	Class            string    // Extra classes for the thread
+	Label            string    // Heading, default "Comments"
+	PostURL          string    // New comments are posted here as "body" via htmx; empty hides the form
+	EditURL          string    // Edits are PUT here as "body"; "{id}" is replaced by the comment id
+	DeleteURL        string    // Deletes are sent here; "{id}" is replaced by the comment id
+	PostPermission   string    // Needed to see the form; empty lets anyone post
+	EditPermission   string    // Lets a user edit anyone's comments
+	DeletePermission string    // Lets a user delete anyone's comments
+	Placeholder      string    // Default "Add a comment…"
+	ErrorText        string    // Shown when posting fails, default "Your comment could not be posted. Try again."
+	Now              time.Time // Reference time for relative timestamps, default time.Now()
+}
+
+func (o CommentThreadOptions) withDefaults() CommentThreadOptions {
+	if o.Label == "" {
+		o.Label = "Comments"
+	}
+	if o.Placeholder == "" {
+		o.Placeholder = "Add a comment…"
+	}
+	if o.ErrorText == "" {
+		o.ErrorText = "Your comment could not be posted. Try again."
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	return o
+}
+
+// CommentThread renders a list of comments, oldest first, and a form that
+// posts new ones with htmx. A posted comment appears straight away, marked
+// as sending, with the current user as author; the PostURL handler answers
+// with the stored comment rendered by CommentItem, which replaces it. When
+// the post fails the pending comment is removed, the text is put back in
+// the form and an error is shown.
+//
+// The thread fires bubbling comment:posted events with detail {commentId}
+// and comment:failed events with detail {status, body} on its root.
+//
+// Edit and delete buttons appear on the current user's own comments and,
+// with EditPermission or DeletePermission as checked by b.Can, on everyone's.
+// Both requests answer with the comment's replacement: CommentItem after
+// an edit, nothing after a delete.
+//
+//	mdy.CommentThread("claim-42-notes", notes, mdy.CommentThreadOptions{
+//	    Label:            "Notes",
+//	    PostURL:          "/claims/42/notes",
+//	    EditURL:          "/claims/42/notes/{id}",
+//	    DeleteURL:        "/claims/42/notes/{id}",
+//	    DeletePermission: "claims.moderate",
+//	})
+func CommentThread(id string, comments []Comment, opts CommentThreadOptions) mi.H {
+	opts = opts.withDefaults()
+	return func(b *mi.Builder) mi.Node {
+		titleID := id + "-title"
+		list := []interface{}{mi.Class("dyn-comment-list"), mi.Data("dyn-comment-list", "")}
+		for _, comment := range comments {
+			list = append(list, CommentItem(id, comment, opts)(b))
+		}
+
+		thread := []interface{}{
+			mi.ID(id),
+			mi.Class(strings.TrimSpace("dyn-comments " + opts.Class)),
+			mi.Data("dyn-comments", ""),
+			mi.Attr("aria-labelledby", titleID),
+			b.H3(mi.ID(titleID), mi.Class("dyn-comments-title"), opts.Label),
+			b.Ol(list...),
+			b.P(mi.Class("dyn-comments-empty"), "No comments yet."),
+		}
+		if opts.PostURL != "" && (opts.PostPermission == "" || b.Can(opts.PostPermission)) {
+			thread = append(thread, commentForm(b, id, opts))
+		}
+		return mi.NewFragment(
+			b.Section(thread...),
+			b.Script(mi.Raw(commentsJS)),
+		)
+	}
+}
+
+// commentForm renders the new-comment form. The current user's name and
+// avatar are kept on it for the pending comment.
+func commentForm(b *mi.Builder, id string, opts CommentThreadOptions) mi.Node {
+	user, _ := b.User()
+	inputID := id + "-body"
+	return b.Form(
+		mi.Class("dyn-comment-form"),
+		mi.Data("dyn-comment-form", ""),
+		mi.Data("dyn-comment-author", user.Name),
+		mi.Data("dyn-comment-avatar", user.AvatarURL),
+		mi.Data("dyn-comment-initials", user.Initials()),
+		mi.HtmxPost(opts.PostURL),
+		mi.HtmxSwap("none"),
+		b.Label(mi.For(inputID), mi.Class("dyn-sr-only"), "Comment"),
+		b.Textarea(
+			mi.ID(inputID),
+			mi.Name("body"),
+			mi.Class("dyn-comment-input"),
+			mi.Rows(3),
+			mi.Required(),
+			mi.Placeholder(opts.Placeholder),
+		),
+		b.P(mi.Class("dyn-comment-error"), mi.Role("alert"), mi.Data("dyn-comment-error", ""), mi.Hidden(), opts.ErrorText),
+		b.Div(mi.Class("dyn-sr-only"), mi.Role("status"), mi.Data("dyn-comment-status", "")),
+		b.Button(mi.Type("submit"), mi.Class("dyn-comment-submit"), "Post comment"),
+	)
+}
+
+// CommentItem renders one comment of the thread with the given id, as
+// CommentThread does. Handlers render it in answer to a post or an edit:
+//
+//	mi.Render(mdy.CommentItem("claim-42-notes", note, opts), w)
+func CommentItem(threadID string, c Comment, opts CommentThreadOptions) mi.H {
+	opts = opts.withDefaults()
+	return func(b *mi.Builder) mi.Node {
+		itemID := threadID + "-comment-" + c.ID
+		user, signedIn := b.User()
+		own := signedIn && user.ID != "" && user.ID == c.Author.ID
+		canEdit := opts.EditURL != "" && (own || opts.EditPermission != "" && b.Can(opts.EditPermission))
+		canDelete := opts.DeleteURL != "" && (own || opts.DeletePermission != "" && b.Can(opts.DeletePermission))
+
+		meta := []interface{}{
+			mi.Class("dyn-comment-meta"),
+			b.Span(mi.Class("dyn-comment-author"), c.Author.Name),
+			" ",
+			b.Time(
+				mi.Attr("datetime", c.CreatedAt.Format(time.RFC3339)),
+				mi.Title(c.CreatedAt.Format("Jan 2, 2006 15:04")),
+				relativeTime(c.CreatedAt, opts.Now),
+			),
+		}
+		if !c.EditedAt.IsZero() {
+			meta = append(meta, " ", b.Span(mi.Class("dyn-comment-edited"), mi.Title(c.EditedAt.Format("Jan 2, 2006 15:04")), "(edited)"))
+		}
+
+		item := []interface{}{
+			mi.ID(itemID),
+			mi.Class("dyn-comment"),
+			mi.Data("dyn-comment", c.ID),
+			commentAvatar(b, c.Author),
+			b.Div(mi.Class("dyn-comment-main"),
+				b.Div(meta...),
+				b.P(mi.Class("dyn-comment-body"), c.Body),
+				commentActions(b, itemID, c, opts, canEdit, canDelete),
+			),
+		}
+		return b.Li(item...)
+	}
+}
+
+// commentActions renders the edit and delete buttons and the edit form of
+// a comment, or nil when the user may do neither.
+func commentActions(b *mi.Builder, itemID string, c Comment, opts CommentThreadOptions, canEdit, canDelete bool) mi.Node {
+	if !canEdit && !canDelete {
+		return nil
+	}
+	target := "#" + itemID
+	actions := []interface{}{mi.Class("dyn-comment-actions")}
+	var nodes []mi.Node
+	if canEdit {
+		formID := itemID + "-edit"
+		actions = append(actions, b.Button(
+			mi.Type("button"),
+			mi.Class("dyn-comment-action"),
+			mi.Data("dyn-comment-edit", formID),
+			mi.Attr("aria-expanded", "false"),
+			mi.Attr("aria-controls", formID),
+			"Edit",
+		))
+		nodes = append(nodes, b.Form(
+			mi.ID(formID),
+			mi.Class("dyn-comment-edit-form"),
+			mi.HtmxPut(commentURL(opts.EditURL, c.ID)),
+			mi.HtmxTarget(target),
+			mi.HtmxSwap("outerHTML"),
+			mi.Hidden(),
+			b.Label(mi.For(formID+"-body"), mi.Class("dyn-sr-only"), "Edit comment"),
+			b.Textarea(mi.ID(formID+"-body"), mi.Name("body"), mi.Class("dyn-comment-input"), mi.Rows(3), mi.Required(), c.Body),
+			b.Button(mi.Type("submit"), mi.Class("dyn-comment-submit"), "Save"),
+			b.Button(mi.Type("button"), mi.Class("dyn-comment-action"), mi.Data("dyn-comment-cancel", formID), "Cancel"),
+		))
+	}
+	if canDelete {
+		actions = append(actions, b.Button(
+			mi.Type("button"),
+			mi.Class("dyn-comment-action"),
+			mi.HtmxDelete(commentURL(opts.DeleteURL, c.ID)),
+			mi.HtmxTarget(target),
+			mi.HtmxSwap("outerHTML"),
+			mi.HtmxConfirm("Delete this comment?"),
+			"Delete",
+		))
+	}
+	return mi.NewFragment(append([]mi.Node{b.Div(actions...)}, nodes...)...)
+}
+
+// commentAvatar renders an author's picture, or their initials.
+func commentAvatar(b *mi.Builder, author mi.User) mi.Node {
+	if author.AvatarURL != "" {
+		return b.Img(mi.Src(author.AvatarURL), mi.Alt(""), mi.Class("dyn-comment-avatar"))
+	}
+	return b.Span(mi.Class("dyn-comment-avatar"), mi.Attr("aria-hidden", "true"), author.Initials())
+}
+
+// commentURL fills a comment id into a URL template.
+func commentURL(template, id string) string {
+	return strings.ReplaceAll(template, "{id}", url.PathEscape(id))
+}
+
+// relativeTime describes t relative to now, e.g. "5 minutes ago", falling
+// back to the date for anything older than a month.
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return "1 " + unit + " ago"
+		}
+		return fmt.Sprintf("%d %ss ago", n, unit)
+	}
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d/time.Minute), "minute")
+	case d < 24*time.Hour:
+		return plural(int(d/time.Hour), "hour")
+	case d < 30*24*time.Hour:
+		return plural(int(d/(24*time.Hour)), "day")
+	default:
+		return t.Format("Jan 2, 2006")
+	}
+}
+
+// commentsJS installs the delegated listeners once per page: optimistic
+// posting around the form's htmx request, and the edit toggle.
+const commentsJS = `(function(){
+if (window.DynComments) return;
+window.DynComments = true;
+function pendingComment(form, text) {
+    var item = document.createElement('li');
+    item.className = 'dyn-comment dyn-comment-pending';
+    item.setAttribute('aria-busy', 'true');
+    var avatar;
+    if (form.dataset.dynCommentAvatar) {
+        avatar = document.createElement('img');
+        avatar.src = form.dataset.dynCommentAvatar;
+        avatar.alt = '';
+    } else {
+        avatar = document.createElement('span');
+        avatar.setAttribute('aria-hidden', 'true');
+        avatar.textContent = form.dataset.dynCommentInitials || '';
+    }
+    avatar.className = 'dyn-comment-avatar';
+    var main = document.createElement('div');
+    main.className = 'dyn-comment-main';
+    var meta = document.createElement('div');
+    meta.className = 'dyn-comment-meta';
+    var author = document.createElement('span');
+    author.className = 'dyn-comment-author';
+    author.textContent = form.dataset.dynCommentAuthor || '';
+    meta.appendChild(author);
+    meta.appendChild(document.createTextNode(' Sending…'));
+    var body = document.createElement('p');
+    body.className = 'dyn-comment-body';
+    body.textContent = text;
+    main.appendChild(meta);
+    main.appendChild(body);
+    item.appendChild(avatar);
+    item.appendChild(main);
+    return item;
+}
+document.addEventListener('htmx:beforeRequest', function(e) {
+    var form = e.target;
+    if (!form.hasAttribute || !form.hasAttribute('data-dyn-comment-form')) return;
+    var input = form.querySelector('textarea');
+    var thread = form.closest('[data-dyn-comments]');
+    form.querySelector('[data-dyn-comment-error]').hidden = true;
+    form._dynPending = { text: input.value, item: pendingComment(form, input.value) };
+    thread.querySelector('[data-dyn-comment-list]').appendChild(form._dynPending.item);
+    input.value = '';
+});
+document.addEventListener('htmx:afterRequest', function(e) {
+    var form = e.target;
+    if (!form.hasAttribute || !form.hasAttribute('data-dyn-comment-form') || !form._dynPending) return;
+    var pending = form._dynPending;
+    form._dynPending = null;
+    var thread = form.closest('[data-dyn-comments]');
+    var xhr = e.detail.xhr;
+    if (e.detail.successful) {
+        var tpl = document.createElement('template');
+        tpl.innerHTML = xhr.responseText;
+        var item = tpl.content.querySelector('[data-dyn-comment]');
+        if (item) {
+            pending.item.replaceWith(item);
+            if (window.htmx) htmx.process(item);
+        } else {
+            pending.item.remove();
+        }
+        form.querySelector('[data-dyn-comment-status]').textContent = 'Comment posted';
+        thread.dispatchEvent(new CustomEvent('comment:posted', { bubbles: true, detail: {
+            commentId: item ? item.getAttribute('data-dyn-comment') : null
+        }}));
+        return;
+    }
+    // Roll back: drop the pending comment and give the text back
+    pending.item.remove();
+    var input = form.querySelector('textarea');
+    if (!input.value) input.value = pending.text;
+    form.querySelector('[data-dyn-comment-error]').hidden = false;
+    input.focus();
+    thread.dispatchEvent(new CustomEvent('comment:failed', { bubbles: true, detail: {
+        status: xhr ? xhr.status : 0,
+        body: pending.text
+    }}));
+});
+document.addEventListener('click', function(e) {
+    var edit = e.target.closest ? e.target.closest('[data-dyn-comment-edit], [data-dyn-comment-cancel]') : null;
+    if (!edit) return;
+    var formID = edit.getAttribute('data-dyn-comment-edit') || edit.getAttribute('data-dyn-comment-cancel');
+    var form = document.getElementById(formID);
+    var toggle = document.querySelector('[data-dyn-comment-edit="' + formID + '"]');
+    if (!form || !toggle) return;
+    var open = edit === toggle && form.hidden;
+    form.hidden = !open;
+    toggle.setAttribute('aria-expanded', open ? 'true' : 'false');
+    if (open) form.querySelector('textarea').focus();
+    else toggle.focus();
+});
+})();`
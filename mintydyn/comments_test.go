@@ -0,0 +1,84 @@
+package mintydyn
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestCommentThread(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	ada := mi.User{ID: "u1", Name: "Ada Lovelace"}
+	grace := mi.User{ID: "u2", Name: "Grace Hopper", AvatarURL: "/avatars/grace.png"}
+	comments := []Comment{
+		{ID: "c1", Author: ada, Body: "Photos attached", CreatedAt: now.Add(-3 * time.Hour)},
+		{ID: "c2", Author: grace, Body: "Adjuster assigned", CreatedAt: now.Add(-2 * 24 * time.Hour), EditedAt: now},
+	}
+	opts := CommentThreadOptions{
+		Label:     "Notes",
+		PostURL:   "/claims/42/notes",
+		EditURL:   "/claims/42/notes/{id}",
+		DeleteURL: "/claims/42/notes/{id}",
+		Now:       now,
+	}
+
+	render := func(user mi.User, opts CommentThreadOptions) string {
+		var sb strings.Builder
+		ctx := mi.WithUser(context.Background(), user)
+		if err := mi.RenderContext(ctx, CommentThread("notes", comments, opts), &sb); err != nil {
+			t.Fatal(err)
+		}
+		html := sb.String()
+		return html[:strings.Index(html, "<script")]
+	}
+
+	html := render(ada, opts)
+	for _, want := range []string{
+		`aria-labelledby="notes-title"`,
+		`>Notes</h3>`,
+		`data-dyn-comment="c1"`,
+		`>3 hours ago</time>`,
+		`>2 days ago</time>`,
+		`datetime="2025-03-10T09:00:00Z"`,
+		`>(edited)</span>`,
+		`>AL</span>`,
+		`src="/avatars/grace.png"`,
+		`hx-post="/claims/42/notes"`,
+		`data-dyn-comment-author="Ada Lovelace"`,
+		`hx-put="/claims/42/notes/c1"`,
+		`hx-delete="/claims/42/notes/c1"`,
+		`role="alert"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("CommentThread output missing %q in %s", want, html)
+		}
+	}
+	if strings.Contains(html, `/notes/c2"`) {
+		t.Error("a user may only edit and delete their own comments without permissions")
+	}
+
+	opts.DeletePermission = "claims.moderate"
+	moderator := render(mi.User{ID: "u3", Permissions: []string{"claims.moderate"}}, opts)
+	if !strings.Contains(moderator, `hx-delete="/claims/42/notes/c2"`) || strings.Contains(moderator, `hx-put=`) {
+		t.Errorf("DeletePermission should allow deleting, not editing: %s", moderator)
+	}
+
+	opts.PostPermission = "claims.comment"
+	if strings.Contains(render(grace, opts), "data-dyn-comment-form") {
+		t.Error("the form should need PostPermission")
+	}
+
+	for d, want := range map[time.Duration]string{
+		30 * time.Second:    "just now",
+		time.Minute:         "1 minute ago",
+		50 * time.Minute:    "50 minutes ago",
+		40 * 24 * time.Hour: "Jan 29, 2025",
+	} {
+		if got := relativeTime(now.Add(-d), now); got != want {
+			t.Errorf("relativeTime(-%v) = %q, want %q", d, got, want)
+		}
+	}
+}
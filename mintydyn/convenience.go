@@ -1,6 +1,8 @@
 package mintydyn
 
 import (
+	"errors"
+
 	mi "github.com/ha1tch/minty"
 )
 
@@ -130,7 +132,8 @@ func Dynamic(id string, states []ComponentState, data []map[string]interface{},
 // =============================================================================
 
 // Dyn starts a flexible builder chain.
-// Use when the convenience functions don't fit your needs.
+// Use when the convenience functions don't fit your needs. Pass an empty id
+// to have one generated per render (see DynamicBuilder.Build).
 //
 //	mdy.Dyn("complex").
 //	    States(myStates).
@@ -187,6 +190,38 @@ func (fb *FlexBuilder) ServerRenderedData(rowSelector, counterSelector string) *
 	return fb
 }
 
+// ServerFilter makes the server do the filtering: whenever the filters
+// change, after a short debounce, their state is POSTed to endpoint and the
+// results are replaced with the HTML it returns. The data items stay on the
+// server and are not sent to the page; the handler reads the state with
+// ParseFilterRequest and can filter with ApplyFilters. This is how the
+// server-filterable pattern, detected for more than 50 items, avoids
+// filtering in the browser.
+//
+//	mdy.Dyn("assets").
+//	    Data(assets).
+//	    TextFilter("name", "Name").
+//	    ServerFilter("/assets/filter").
+//	    Build()
+func (fb *FlexBuilder) ServerFilter(endpoint string) *FlexBuilder {
+	fb.filterOptions.Endpoint = endpoint
+	return fb
+}
+
+// Export adds an Export button that downloads the results matching the
+// filters as a CSV file named filename, with a column per schema field.
+//
+//	mdy.Dyn("claims").
+//	    Data(claims).
+//	    SelectFilter("status", "Status", statuses).
+//	    Export("claims.csv").
+//	    Build()
+func (fb *FlexBuilder) Export(filename string) *FlexBuilder {
+	fb.filterOptions.EnableExport = true
+	fb.filterOptions.ExportFilename = filename
+	return fb
+}
+
 // FilterField adds a filter field to the schema.
 func (fb *FlexBuilder) FilterField(field FilterableField) *FlexBuilder {
 	fb.filterSchema.Fields = append(fb.filterSchema.Fields, field)
@@ -282,6 +317,21 @@ func (fb *FlexBuilder) OnState(stateID, jsCode string) *FlexBuilder {
 	return fb
 }
 
+// OnStateClass toggles className on targetSelector while stateID is active.
+//
+//	mdy.Dyn("reader").
+//	    States(states).
+//	    OnStateClass("focus", "main > :not(#reader)", "is-dimmed").
+//	    Build()
+func (fb *FlexBuilder) OnStateClass(stateID, targetSelector, className string) *FlexBuilder {
+	fb.options.StateClasses = append(fb.options.StateClasses, StateClassToggle{
+		State:    stateID,
+		Selector: targetSelector,
+		Class:    className,
+	})
+	return fb
+}
+
 // OnFilter sets the afterFilter hook.
 func (fb *FlexBuilder) OnFilter(jsCode string) *FlexBuilder {
 	fb.options.Hooks.AfterFilter = jsCode
@@ -300,8 +350,61 @@ func (fb *FlexBuilder) Minified() *FlexBuilder {
 	return fb
 }
 
+// ScopedCSS namespaces classes and injected CSS with the component id.
+func (fb *FlexBuilder) ScopedCSS() *FlexBuilder {
+	fb.options.ScopedCSS = true
+	return fb
+}
+
 // Build creates the component.
 func (fb *FlexBuilder) Build() mi.H {
+	return fb.generic().Build()
+}
+
+// Validate checks the configuration like DynamicBuilder.Validate. States
+// that were set but are empty are reported too.
+func (fb *FlexBuilder) Validate(pageIDs ...string) error {
+	err := fb.generic().Validate(pageIDs...)
+	if !fb.emptyStates() {
+		return err
+	}
+	var cfg *ConfigError
+	if !errors.As(err, &cfg) {
+		pattern := fb.generic().detectPattern()
+		cfg = &ConfigError{ComponentID: fb.id, Pattern: pattern.PrimaryPattern}
+	}
+	cfg.Problems = append([]error{ErrEmptyStates}, cfg.Problems...)
+	return cfg
+}
+
+// BuildChecked validates the configuration like Validate and returns the
+// component only when it is sound.
+//
+//	component, err := mdy.Dyn("claims").Data(claims).Rules(rules).BuildChecked("sidebar")
+func (fb *FlexBuilder) BuildChecked(pageIDs ...string) (mi.H, error) {
+	if err := fb.Validate(pageIDs...); err != nil {
+		return nil, err
+	}
+	return fb.Build(), nil
+}
+
+// emptyStates reports whether states were set to an empty collection,
+// which generic drops.
+func (fb *FlexBuilder) emptyStates() bool {
+	switch s := fb.states.(type) {
+	case []ComponentState:
+		return len(s) == 0
+	case ComponentStateCollection:
+		return len(s.States) == 0
+	case map[string]ComponentState:
+		return len(s) == 0
+	default:
+		return false
+	}
+}
+
+// generic converts to the typed builder.
+func (fb *FlexBuilder) generic() *DynamicBuilder[[]ComponentState, FilterableDataset, []DependencyRule] {
 	// Convert to the appropriate generic builder based on what's provided
 	// This uses type assertions and falls back to sensible defaults
 
@@ -332,7 +435,7 @@ func (fb *FlexBuilder) Build() mi.H {
 	}
 
 	// Merge filterOptions from FlexBuilder
-	if fb.filterOptions.ServerRendered || fb.filterOptions.RowSelector != "" {
+	if fb.filterOptions.ServerRendered || fb.filterOptions.RowSelector != "" || fb.filterOptions.Endpoint != "" || fb.filterOptions.EnableExport {
 		data.Options = fb.filterOptions
 	}
 
@@ -357,7 +460,7 @@ func (fb *FlexBuilder) Build() mi.H {
 		builder = builder.WithStates(states)
 	}
 	// Include data if we have items, schema fields, or server-rendered mode configured
-	if len(data.Items) > 0 || len(data.Schema.Fields) > 0 || data.Options.ServerRendered {
+	if len(data.Items) > 0 || len(data.Schema.Fields) > 0 || data.Options.ServerRendered || data.Options.Endpoint != "" {
 		builder = builder.WithData(data)
 	}
 	if len(rules) > 0 {
@@ -370,7 +473,7 @@ func (fb *FlexBuilder) Build() mi.H {
 		builder = builder.WithTheme(fb.theme)
 	}
 
-	return builder.Build()
+	return builder
 }
 
 // =============================================================================
@@ -507,6 +610,17 @@ func BoolField(name, label string) FilterableField {
 	}
 }
 
+// DateRangeField creates a date range filter field, rendered as a DateRange.
+// Item values are ISO dates or timestamps (time.Time on the server) and are
+// compared by calendar day, inclusive.
+func DateRangeField(name, label string) FilterableField {
+	return FilterableField{
+		Name:  name,
+		Type:  "daterange",
+		Label: label,
+	}
+}
+
 // RangeField creates a range filter field.
 func RangeField(name, label string, min, max, step float64) FilterableField {
 	return FilterableField{
@@ -266,6 +266,1061 @@ func DefaultCSS() string {
 			BorderColor("#2563eb"),
 			Color("white"),
 		).
+		// Visually hidden live region for load announcements
+		Rule(".dyn-sr-only",
+			Position("absolute"),
+			Width("1px"),
+			Height("1px"),
+			Padding("0"),
+			Margin("-1px"),
+			Overflow("hidden"),
+			Prop("clip", "rect(0, 0, 0, 0)"),
+			Prop("white-space", "nowrap"),
+			Border("0"),
+		).
+		// Menu
+		Rule(".dyn-menu",
+			Position("relative"),
+			Display("inline-block"),
+		).
+		Rule(".dyn-menu-list",
+			Position("absolute"),
+			ZIndex("50"),
+			MinWidth("10rem"),
+			Margin("0.25rem 0 0"),
+			Padding("0.25rem 0"),
+			Prop("list-style", "none"),
+			Background("white"),
+			Border("1px solid #d1d5db"),
+			BorderRadius("0.375rem"),
+			BoxShadow("0 4px 12px rgba(0, 0, 0, 0.1)"),
+		).
+		Rule(".dyn-menu-list[data-align=\"end\"]",
+			Prop("right", "0"),
+		).
+		Rule(".dyn-menu-item",
+			Display("block"),
+			Width("100%"),
+			Padding("0.5rem 1rem"),
+			Border("0"),
+			Background("none"),
+			Color("inherit"),
+			FontSize("0.875rem"),
+			TextAlign("left"),
+			TextDecoration("none"),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-menu-item:hover, .dyn-menu-item:focus",
+			BackgroundColor("#f3f4f6"),
+			Prop("outline", "none"),
+		).
+		Rule(".dyn-menu-item[aria-disabled=\"true\"]",
+			Color("#9ca3af"),
+			Cursor("not-allowed"),
+		).
+		Rule(".dyn-menu-separator",
+			Height("1px"),
+			Margin("0.25rem 0"),
+			Background("#d1d5db"),
+		).
+		// Filter toolbar
+		Rule(".dyn-filter-toolbar",
+			Display("flex"),
+			FlexWrap("wrap"),
+			AlignItems("center"),
+			JustifyContent("space-between"),
+			Gap("0.5rem 1rem"),
+			MarginBottom("0.75rem"),
+			FontSize("0.875rem"),
+			Color("#6b7280"),
+		).
+		Rule(".dyn-filter-toolbar-status",
+			Display("flex"),
+			FlexWrap("wrap"),
+			Gap("0.5rem 1rem"),
+		).
+		Rule(".dyn-filter-toolbar-clear",
+			Padding("0.375rem 0.75rem"),
+			Border("1px solid #d1d5db"),
+			Background("white"),
+			Color("#374151"),
+			BorderRadius("0.25rem"),
+			FontSize("0.875rem"),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-filter-toolbar-clear:hover:not(:disabled)",
+			BackgroundColor("#f3f4f6"),
+		).
+		Rule(".dyn-filter-toolbar-clear:disabled",
+			Opacity("0.5"),
+			Cursor("not-allowed"),
+		).
+		// Facet sidebar
+		Rule(".dyn-facets",
+			Display("flex"),
+			FlexDirection("column"),
+			Gap("1.25rem"),
+			FontSize("0.875rem"),
+			Color("#374151"),
+		).
+		Rule(".dyn-facet",
+			Display("flex"),
+			FlexDirection("column"),
+			Gap("0.375rem"),
+			Margin("0"),
+			Padding("0"),
+			Border("0"),
+			MinWidth("0"),
+		).
+		Rule(".dyn-facet-title",
+			Padding("0"),
+			MarginBottom("0.25rem"),
+			FontWeight("600"),
+			Color("#111827"),
+		).
+		Rule(".dyn-facet-option",
+			Display("flex"),
+			AlignItems("center"),
+			Gap("0.5rem"),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-facet-value",
+			Prop("flex", "1"),
+			MinWidth("0"),
+		).
+		Rule(".dyn-facet-count",
+			Padding("0 0.375rem"),
+			BorderRadius("9999px"),
+			Background("#f3f4f6"),
+			Color("#6b7280"),
+			FontSize("0.75rem"),
+		).
+		Rule(".dyn-facet-option-empty",
+			Opacity("0.5"),
+		).
+		Rule(".dyn-facet-search",
+			Padding("0.375rem 0.5rem"),
+			Border("1px solid #d1d5db"),
+			BorderRadius("0.25rem"),
+		).
+		Rule(".dyn-facet-range",
+			Display("flex"),
+			FlexDirection("column"),
+			Gap("0.25rem"),
+		).
+		Rule(".dyn-facet-range-value",
+			Color("#6b7280"),
+		).
+		// Date range
+		Rule(".dyn-daterange",
+			Margin("0"),
+			Padding("0"),
+			Border("0"),
+			MinWidth("0"),
+		).
+		Rule(".dyn-daterange-legend",
+			Padding("0"),
+			MarginBottom("0.5rem"),
+			FontWeight("500"),
+		).
+		Rule(".dyn-daterange-presets, .dyn-daterange-fields",
+			Display("flex"),
+			FlexWrap("wrap"),
+			AlignItems("center"),
+			Gap("0.5rem"),
+		).
+		Rule(".dyn-daterange-presets",
+			MarginBottom("0.5rem"),
+		).
+		Rule(".dyn-daterange-preset",
+			Padding("0.25rem 0.75rem"),
+			Border("1px solid #d1d5db"),
+			Background("white"),
+			Color("inherit"),
+			BorderRadius("0.25rem"),
+			FontSize("0.875rem"),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-daterange-preset[aria-pressed=\"true\"]",
+			BackgroundColor("#2563eb"),
+			BorderColor("#2563eb"),
+			Color("white"),
+		).
+		Rule(".dyn-daterange-input",
+			Padding("0.375rem 0.5rem"),
+			Border("1px solid #d1d5db"),
+			BorderRadius("0.25rem"),
+			Background("white"),
+			Color("inherit"),
+			FontSize("0.875rem"),
+		).
+		Rule(".dyn-daterange-input[aria-invalid=\"true\"]",
+			BorderColor("#dc2626"),
+		).
+		Rule(".dyn-daterange-error",
+			Margin("0.25rem 0 0"),
+			Color("#dc2626"),
+			FontSize("0.875rem"),
+		).
+		// Sort controls
+		Rule(".dyn-sort-controls",
+			Display("flex"),
+			FlexWrap("wrap"),
+			AlignItems("center"),
+			Gap("0.5rem"),
+		).
+		Rule(".dyn-sort-button",
+			Padding("0.25rem 0.75rem"),
+			Border("1px solid #d1d5db"),
+			Background("white"),
+			Color("inherit"),
+			BorderRadius("0.25rem"),
+			FontSize("0.875rem"),
+			Cursor("pointer"),
+		).
+		Rule("[data-sort-field]",
+			Cursor("pointer"),
+			Prop("user-select", "none"),
+		).
+		Rule(".dyn-sort-asc, .dyn-sort-desc",
+			Color("#2563eb"),
+			FontWeight("600"),
+		).
+		Rule(".dyn-sort-asc::after",
+			Prop("content", `" \25B2"`),
+			FontSize("0.75em"),
+		).
+		Rule(".dyn-sort-desc::after",
+			Prop("content", `" \25BC"`),
+			FontSize("0.75em"),
+		).
+		// Export button
+		Rule(".dyn-export-button",
+			Padding("0.25rem 0.75rem"),
+			MarginBottom("0.5rem"),
+			Border("1px solid #d1d5db"),
+			Background("white"),
+			Color("inherit"),
+			BorderRadius("0.25rem"),
+			FontSize("0.875rem"),
+			Cursor("pointer"),
+		).
+		// Tooltip
+		Rule(".dyn-tooltip",
+			Padding("0.25rem 0.5rem"),
+			BorderRadius("0.25rem"),
+			BackgroundColor("#111827"),
+			Color("white"),
+			FontSize("0.75rem"),
+			ZIndex("1000"),
+			MaxWidth("20rem"),
+			Prop("pointer-events", "none"),
+		).
+		// Kanban board
+		Rule(".dyn-kanban",
+			Display("flex"),
+			Gap("0.75rem"),
+			Overflow("auto"),
+			AlignItems("flex-start"),
+		).
+		Rule(".dyn-kanban-column",
+			Prop("flex", "0 0 16rem"),
+			Padding("0.5rem"),
+			BorderRadius("0.375rem"),
+			Background("#f3f4f6"),
+			Border("1px solid #d1d5db"),
+		).
+		Rule(".dyn-kanban-column.dyn-kanban-over",
+			BorderColor("#2563eb"),
+		).
+		Rule(".dyn-kanban-column-title",
+			Display("flex"),
+			JustifyContent("space-between"),
+			Margin("0 0 0.5rem"),
+			FontSize("0.875rem"),
+			FontWeight("600"),
+			Color("#111827"),
+		).
+		Rule(".dyn-kanban-count",
+			Color("#6b7280"),
+			FontWeight("400"),
+		).
+		Rule(".dyn-kanban-cards",
+			Display("flex"),
+			FlexDirection("column"),
+			Gap("0.5rem"),
+			MinHeight("3rem"),
+			Margin("0"),
+			Padding("0"),
+			Prop("list-style", "none"),
+		).
+		Rule(".dyn-kanban-card",
+			Padding("0.5rem 0.75rem"),
+			BorderRadius("0.375rem"),
+			Background("white"),
+			Border("1px solid #d1d5db"),
+			Color("#111827"),
+			Cursor("grab"),
+		).
+		Rule(".dyn-kanban-card:focus-visible",
+			Prop("outline", "2px solid #2563eb"),
+			Prop("outline-offset", "2px"),
+		).
+		Rule(".dyn-kanban-card.dyn-kanban-grabbed, .dyn-kanban-card.dyn-kanban-dragging",
+			BorderColor("#2563eb"),
+			BoxShadow("0 4px 12px rgba(0, 0, 0, 0.15)"),
+			Opacity("0.85"),
+		).
+		Rule(".dyn-kanban-card-title",
+			FontWeight("500"),
+		).
+		Rule(".dyn-kanban-card-body",
+			MarginTop("0.25rem"),
+			FontSize("0.875rem"),
+			Color("#6b7280"),
+		).
+		// Comment thread
+		Rule(".dyn-comments-title",
+			Margin("0 0 0.75rem"),
+			FontSize("1rem"),
+			FontWeight("600"),
+			Color("#111827"),
+		).
+		Rule(".dyn-comment-list",
+			Display("flex"),
+			FlexDirection("column"),
+			Gap("1rem"),
+			Margin("0 0 1rem"),
+			Padding("0"),
+			Prop("list-style", "none"),
+		).
+		Rule(".dyn-comment-list:empty",
+			Display("none"),
+		).
+		Rule(".dyn-comment-list:not(:empty) ~ .dyn-comments-empty",
+			Display("none"),
+		).
+		Rule(".dyn-comments-empty",
+			Margin("0 0 1rem"),
+			Color("#6b7280"),
+			FontSize("0.875rem"),
+		).
+		Rule(".dyn-comment",
+			Display("flex"),
+			Gap("0.75rem"),
+		).
+		Rule(".dyn-comment-pending",
+			Opacity("0.6"),
+		).
+		Rule(".dyn-comment-avatar",
+			Display("flex"),
+			AlignItems("center"),
+			JustifyContent("center"),
+			Prop("flex", "0 0 2rem"),
+			Width("2rem"),
+			Height("2rem"),
+			BorderRadius("9999px"),
+			Background("#e5e7eb"),
+			Color("#374151"),
+			FontSize("0.75rem"),
+			FontWeight("600"),
+			Prop("object-fit", "cover"),
+		).
+		Rule(".dyn-comment-main",
+			Prop("flex", "1"),
+			MinWidth("0"),
+		).
+		Rule(".dyn-comment-meta",
+			FontSize("0.75rem"),
+			Color("#6b7280"),
+		).
+		Rule(".dyn-comment-author",
+			FontWeight("600"),
+			Color("#111827"),
+		).
+		Rule(".dyn-comment-body",
+			Margin("0.25rem 0 0"),
+			Prop("white-space", "pre-line"),
+			Prop("overflow-wrap", "anywhere"),
+		).
+		Rule(".dyn-comment-actions",
+			Display("flex"),
+			Gap("0.5rem"),
+			MarginTop("0.25rem"),
+		).
+		Rule(".dyn-comment-action",
+			Padding("0"),
+			Border("none"),
+			Background("none"),
+			Color("#2563eb"),
+			FontSize("0.75rem"),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-comment-form, .dyn-comment-edit-form",
+			Display("flex"),
+			FlexDirection("column"),
+			AlignItems("flex-start"),
+			Gap("0.5rem"),
+		).
+		Rule(".dyn-comment-edit-form",
+			MarginTop("0.5rem"),
+		).
+		Rule(".dyn-comment-edit-form[hidden]",
+			Display("none"),
+		).
+		Rule(".dyn-comment-input",
+			Width("100%"),
+			Padding("0.5rem"),
+			Border("1px solid #d1d5db"),
+			BorderRadius("0.25rem"),
+			FontFamily("inherit"),
+			FontSize("0.875rem"),
+		).
+		Rule(".dyn-comment-submit",
+			Padding("0.375rem 0.75rem"),
+			Border("1px solid #2563eb"),
+			BorderRadius("0.25rem"),
+			Background("#2563eb"),
+			Color("white"),
+			FontSize("0.875rem"),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-comment-error",
+			Margin("0"),
+			Color("#dc2626"),
+			FontSize("0.875rem"),
+		).
+		// Autosave indicator
+		Rule(".dyn-autosave",
+			FontSize("0.875rem"),
+			Color("#6b7280"),
+		).
+		Rule(".dyn-autosave[data-dyn-autosave-state=\"error\"], .dyn-autosave[data-dyn-autosave-state=\"conflict\"]",
+			Color("#dc2626"),
+		).
+		// Back to top and scroll progress
+		Rule(".dyn-scroll-top",
+			Position("fixed"),
+			Prop("right", "1.5rem"),
+			Prop("bottom", "1.5rem"),
+			Display("flex"),
+			AlignItems("center"),
+			JustifyContent("center"),
+			Width("2.75rem"),
+			Height("2.75rem"),
+			Border("1px solid #d1d5db"),
+			BorderRadius("9999px"),
+			Background("white"),
+			Color("#374151"),
+			FontSize("1.25rem"),
+			BoxShadow("0 4px 12px rgba(0, 0, 0, 0.15)"),
+			Cursor("pointer"),
+			ZIndex("900"),
+		).
+		Rule(".dyn-scroll-top[hidden]",
+			Display("none"),
+		).
+		Rule(".dyn-scroll-top:hover",
+			BackgroundColor("#f3f4f6"),
+		).
+		Rule(".dyn-scroll-top:focus-visible",
+			Prop("outline", "2px solid #2563eb"),
+			Prop("outline-offset", "2px"),
+		).
+		Rule(".dyn-scroll-progress",
+			Position("fixed"),
+			Prop("top", "0"),
+			Prop("left", "0"),
+			Width("100%"),
+			Height("3px"),
+			Background("#2563eb"),
+			Transform("scaleX(0)"),
+			Prop("transform-origin", "left"),
+			ZIndex("1001"),
+			Prop("pointer-events", "none"),
+		).
+		Render()
+}
+
+// CSSFromTokens returns CSS for dynamic components using the colors, radii
+// and spacing from the given design tokens. It covers the same selectors as
+// DefaultCSS.
+func CSSFromTokens(t mi.ThemeTokens) string {
+	c := t.Colors
+	return NewCSSBuilder().
+		Rule(".hidden",
+			Display("none !important"),
+		).
+		Rule(".dyn-component",
+			Position("relative"),
+			FontFamily(t.FontFamily),
+			Color(c.Text),
+		).
+		Rule(".dyn-state-navigation",
+			Display("flex"),
+			Gap(t.Space(1)),
+			BorderBottom("2px solid "+c.Border),
+			MarginBottom(t.Space(4)),
+		).
+		Rule(".dyn-state-trigger",
+			Padding(t.Space(3)+" "+t.Space(5)),
+			Border("none"),
+			Background("transparent"),
+			Cursor("pointer"),
+			FontSize("0.875rem"),
+			FontWeight("500"),
+			Color(c.Muted),
+			BorderBottom("2px solid transparent"),
+			MarginBottom("-2px"),
+			Transition("all 0.15s ease"),
+		).
+		Rule(".dyn-state-trigger:hover",
+			Color(c.Text),
+			BackgroundColor(c.Background),
+		).
+		Rule(".dyn-state-trigger.active",
+			Color(c.Primary),
+			BorderBottom("2px solid "+c.Primary),
+		).
+		Rule(".dyn-state-trigger.disabled",
+			Opacity("0.5"),
+			Cursor("not-allowed"),
+		).
+		Rule(".dyn-state-content",
+			Display("none"),
+		).
+		Rule(".dyn-state-content.active",
+			Display("block"),
+		).
+		Rule(".dyn-filter-controls",
+			Display("grid"),
+			GridTemplateColumns("repeat(auto-fit, minmax(200px, 1fr))"),
+			Gap(t.Space(4)),
+			MarginBottom(t.Space(4)),
+		).
+		Rule(".dyn-filter-group",
+			Display("flex"),
+			FlexDirection("column"),
+		).
+		Rule(".dyn-filter-label",
+			FontSize("0.875rem"),
+			FontWeight("500"),
+			Color(c.Text),
+			MarginBottom(t.Space(1)),
+		).
+		Rule(".dyn-filter-input, .dyn-filter-select",
+			Padding(t.Space(2)+" "+t.Space(3)),
+			Border("1px solid "+c.Border),
+			BorderRadius(t.Radius.Small),
+			FontSize("0.875rem"),
+			Transition("border-color 0.15s ease"),
+		).
+		Rule(".dyn-filter-input:focus, .dyn-filter-select:focus",
+			Prop("outline", "none"),
+			BorderColor(c.Primary),
+			BoxShadow("0 0 0 3px "+c.PrimarySoft),
+		).
+		Rule(".dyn-results",
+			MinHeight("100px"),
+		).
+		Rule(".dyn-no-results",
+			TextAlign("center"),
+			Padding(t.Space(6)),
+			Color(c.Muted),
+		).
+		Rule(".dyn-results-summary",
+			FontSize("0.875rem"),
+			Color(c.Muted),
+			MarginBottom(t.Space(2)),
+		).
+		Rule(".dyn-pagination",
+			Display("flex"),
+			JustifyContent("center"),
+			Gap(t.Space(1)),
+			MarginTop(t.Space(4)),
+		).
+		Rule(".dyn-page-btn",
+			Padding(t.Space(2)+" "+t.Space(3)),
+			Border("1px solid "+c.Border),
+			Background(c.Surface),
+			Cursor("pointer"),
+			BorderRadius(t.Radius.Small),
+			FontSize("0.875rem"),
+			Transition("all 0.15s ease"),
+		).
+		Rule(".dyn-page-btn:hover",
+			BackgroundColor(c.Background),
+		).
+		Rule(".dyn-page-btn.active",
+			BackgroundColor(c.Primary),
+			BorderColor(c.Primary),
+			Color(c.Surface),
+		).
+		Rule(".dyn-sr-only",
+			Position("absolute"),
+			Width("1px"),
+			Height("1px"),
+			Padding("0"),
+			Margin("-1px"),
+			Overflow("hidden"),
+			Prop("clip", "rect(0, 0, 0, 0)"),
+			Prop("white-space", "nowrap"),
+			Border("0"),
+		).
+		Rule(".dyn-menu",
+			Position("relative"),
+			Display("inline-block"),
+		).
+		Rule(".dyn-menu-list",
+			Position("absolute"),
+			ZIndex("50"),
+			MinWidth("10rem"),
+			Margin("0.25rem 0 0"),
+			Padding("0.25rem 0"),
+			Prop("list-style", "none"),
+			Background(c.Surface),
+			Border("1px solid "+c.Border),
+			BorderRadius(t.Radius.Medium),
+			BoxShadow("0 4px 12px rgba(0, 0, 0, 0.1)"),
+		).
+		Rule(".dyn-menu-list[data-align=\"end\"]",
+			Prop("right", "0"),
+		).
+		Rule(".dyn-menu-item",
+			Display("block"),
+			Width("100%"),
+			Padding(t.Space(2)+" "+t.Space(4)),
+			Border("0"),
+			Background("none"),
+			Color("inherit"),
+			FontSize("0.875rem"),
+			TextAlign("left"),
+			TextDecoration("none"),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-menu-item:hover, .dyn-menu-item:focus",
+			BackgroundColor(c.Background),
+			Prop("outline", "none"),
+		).
+		Rule(".dyn-menu-item[aria-disabled=\"true\"]",
+			Color(c.Muted),
+			Cursor("not-allowed"),
+		).
+		Rule(".dyn-menu-separator",
+			Height("1px"),
+			Margin("0.25rem 0"),
+			Background(c.Border),
+		).
+		Rule(".dyn-filter-toolbar",
+			Display("flex"),
+			FlexWrap("wrap"),
+			AlignItems("center"),
+			JustifyContent("space-between"),
+			Gap(t.Space(2)+" "+t.Space(4)),
+			MarginBottom(t.Space(3)),
+			FontSize("0.875rem"),
+			Color(c.Muted),
+		).
+		Rule(".dyn-filter-toolbar-status",
+			Display("flex"),
+			FlexWrap("wrap"),
+			Gap(t.Space(2)+" "+t.Space(4)),
+		).
+		Rule(".dyn-filter-toolbar-clear",
+			Padding(t.Space(1)+" "+t.Space(3)),
+			Border("1px solid "+c.Border),
+			Background(c.Surface),
+			Color(c.Text),
+			BorderRadius(t.Radius.Small),
+			FontSize("0.875rem"),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-filter-toolbar-clear:hover:not(:disabled)",
+			BackgroundColor(c.Background),
+		).
+		Rule(".dyn-filter-toolbar-clear:disabled",
+			Opacity("0.5"),
+			Cursor("not-allowed"),
+		).
+		Rule(".dyn-facets",
+			Display("flex"),
+			FlexDirection("column"),
+			Gap(t.Space(5)),
+			FontSize("0.875rem"),
+			Color(c.Text),
+		).
+		Rule(".dyn-facet",
+			Display("flex"),
+			FlexDirection("column"),
+			Gap(t.Space(1)),
+			Margin("0"),
+			Padding("0"),
+			Border("0"),
+			MinWidth("0"),
+		).
+		Rule(".dyn-facet-title",
+			Padding("0"),
+			MarginBottom(t.Space(1)),
+			FontWeight("600"),
+			Color(c.Text),
+		).
+		Rule(".dyn-facet-option",
+			Display("flex"),
+			AlignItems("center"),
+			Gap(t.Space(2)),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-facet-value",
+			Prop("flex", "1"),
+			MinWidth("0"),
+		).
+		Rule(".dyn-facet-count",
+			Padding("0 "+t.Space(1)),
+			BorderRadius("9999px"),
+			Background(c.Background),
+			Color(c.Muted),
+			FontSize("0.75rem"),
+		).
+		Rule(".dyn-facet-option-empty",
+			Opacity("0.5"),
+		).
+		Rule(".dyn-facet-search",
+			Padding(t.Space(1)+" "+t.Space(2)),
+			Border("1px solid "+c.Border),
+			BorderRadius(t.Radius.Small),
+			Background(c.Surface),
+			Color(c.Text),
+		).
+		Rule(".dyn-facet-range",
+			Display("flex"),
+			FlexDirection("column"),
+			Gap(t.Space(1)),
+		).
+		Rule(".dyn-facet-range-value",
+			Color(c.Muted),
+		).
+		Rule(".dyn-daterange",
+			Margin("0"),
+			Padding("0"),
+			Border("0"),
+			MinWidth("0"),
+		).
+		Rule(".dyn-daterange-legend",
+			Padding("0"),
+			MarginBottom(t.Space(2)),
+			FontWeight("500"),
+		).
+		Rule(".dyn-daterange-presets, .dyn-daterange-fields",
+			Display("flex"),
+			FlexWrap("wrap"),
+			AlignItems("center"),
+			Gap(t.Space(2)),
+		).
+		Rule(".dyn-daterange-presets",
+			MarginBottom(t.Space(2)),
+		).
+		Rule(".dyn-daterange-preset",
+			Padding(t.Space(1)+" "+t.Space(3)),
+			Border("1px solid "+c.Border),
+			Background(c.Surface),
+			Color("inherit"),
+			BorderRadius(t.Radius.Small),
+			FontSize("0.875rem"),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-daterange-preset[aria-pressed=\"true\"]",
+			BackgroundColor(c.Primary),
+			BorderColor(c.Primary),
+			Color(c.Surface),
+		).
+		Rule(".dyn-daterange-input",
+			Padding(t.Space(1)+" "+t.Space(2)),
+			Border("1px solid "+c.Border),
+			BorderRadius(t.Radius.Small),
+			Background(c.Surface),
+			Color(c.Text),
+			FontSize("0.875rem"),
+		).
+		Rule(".dyn-daterange-input[aria-invalid=\"true\"]",
+			BorderColor(c.Danger),
+		).
+		Rule(".dyn-daterange-error",
+			Margin("0.25rem 0 0"),
+			Color(c.Danger),
+			FontSize("0.875rem"),
+		).
+		Rule(".dyn-sort-controls",
+			Display("flex"),
+			FlexWrap("wrap"),
+			AlignItems("center"),
+			Gap(t.Space(2)),
+		).
+		Rule(".dyn-sort-button",
+			Padding(t.Space(1)+" "+t.Space(3)),
+			Border("1px solid "+c.Border),
+			Background(c.Surface),
+			Color("inherit"),
+			BorderRadius(t.Radius.Small),
+			FontSize("0.875rem"),
+			Cursor("pointer"),
+		).
+		Rule("[data-sort-field]",
+			Cursor("pointer"),
+			Prop("user-select", "none"),
+		).
+		Rule(".dyn-sort-asc, .dyn-sort-desc",
+			Color(c.Primary),
+			FontWeight("600"),
+		).
+		Rule(".dyn-sort-asc::after",
+			Prop("content", `" \25B2"`),
+			FontSize("0.75em"),
+		).
+		Rule(".dyn-sort-desc::after",
+			Prop("content", `" \25BC"`),
+			FontSize("0.75em"),
+		).
+		Rule(".dyn-export-button",
+			Padding(t.Space(1)+" "+t.Space(3)),
+			MarginBottom(t.Space(2)),
+			Border("1px solid "+c.Border),
+			Background(c.Surface),
+			Color("inherit"),
+			BorderRadius(t.Radius.Small),
+			FontSize("0.875rem"),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-tooltip",
+			Padding(t.Space(1)+" "+t.Space(2)),
+			BorderRadius(t.Radius.Small),
+			BackgroundColor(c.Text),
+			Color(c.Surface),
+			FontSize("0.75rem"),
+			ZIndex("1000"),
+			MaxWidth("20rem"),
+			Prop("pointer-events", "none"),
+		).
+		Rule(".dyn-kanban",
+			Display("flex"),
+			Gap(t.Space(3)),
+			Overflow("auto"),
+			AlignItems("flex-start"),
+		).
+		Rule(".dyn-kanban-column",
+			Prop("flex", "0 0 16rem"),
+			Padding(t.Space(2)),
+			BorderRadius(t.Radius.Medium),
+			Background(c.Background),
+			Border("1px solid "+c.Border),
+		).
+		Rule(".dyn-kanban-column.dyn-kanban-over",
+			BorderColor(c.Primary),
+		).
+		Rule(".dyn-kanban-column-title",
+			Display("flex"),
+			JustifyContent("space-between"),
+			Margin("0 0 0.5rem"),
+			FontSize("0.875rem"),
+			FontWeight("600"),
+			Color(c.Text),
+		).
+		Rule(".dyn-kanban-count",
+			Color(c.Muted),
+			FontWeight("400"),
+		).
+		Rule(".dyn-kanban-cards",
+			Display("flex"),
+			FlexDirection("column"),
+			Gap(t.Space(2)),
+			MinHeight("3rem"),
+			Margin("0"),
+			Padding("0"),
+			Prop("list-style", "none"),
+		).
+		Rule(".dyn-kanban-card",
+			Padding(t.Space(2)+" "+t.Space(3)),
+			BorderRadius(t.Radius.Medium),
+			Background(c.Surface),
+			Border("1px solid "+c.Border),
+			Color(c.Text),
+			Cursor("grab"),
+		).
+		Rule(".dyn-kanban-card:focus-visible",
+			Prop("outline", "2px solid "+c.Primary),
+			Prop("outline-offset", "2px"),
+		).
+		Rule(".dyn-kanban-card.dyn-kanban-grabbed, .dyn-kanban-card.dyn-kanban-dragging",
+			BorderColor(c.Primary),
+			BoxShadow("0 4px 12px rgba(0, 0, 0, 0.15)"),
+			Opacity("0.85"),
+		).
+		Rule(".dyn-kanban-card-title",
+			FontWeight("500"),
+		).
+		Rule(".dyn-kanban-card-body",
+			MarginTop("0.25rem"),
+			FontSize("0.875rem"),
+			Color(c.Muted),
+		).
+		Rule(".dyn-comments-title",
+			Margin("0 0 "+t.Space(3)),
+			FontSize("1rem"),
+			FontWeight("600"),
+			Color(c.Text),
+		).
+		Rule(".dyn-comment-list",
+			Display("flex"),
+			FlexDirection("column"),
+			Gap(t.Space(4)),
+			Margin("0 0 "+t.Space(4)),
+			Padding("0"),
+			Prop("list-style", "none"),
+		).
+		Rule(".dyn-comment-list:empty",
+			Display("none"),
+		).
+		Rule(".dyn-comment-list:not(:empty) ~ .dyn-comments-empty",
+			Display("none"),
+		).
+		Rule(".dyn-comments-empty",
+			Margin("0 0 "+t.Space(4)),
+			Color(c.Muted),
+			FontSize("0.875rem"),
+		).
+		Rule(".dyn-comment",
+			Display("flex"),
+			Gap(t.Space(3)),
+		).
+		Rule(".dyn-comment-pending",
+			Opacity("0.6"),
+		).
+		Rule(".dyn-comment-avatar",
+			Display("flex"),
+			AlignItems("center"),
+			JustifyContent("center"),
+			Prop("flex", "0 0 2rem"),
+			Width("2rem"),
+			Height("2rem"),
+			BorderRadius("9999px"),
+			Background(c.Border),
+			Color(c.Text),
+			FontSize("0.75rem"),
+			FontWeight("600"),
+			Prop("object-fit", "cover"),
+		).
+		Rule(".dyn-comment-main",
+			Prop("flex", "1"),
+			MinWidth("0"),
+		).
+		Rule(".dyn-comment-meta",
+			FontSize("0.75rem"),
+			Color(c.Muted),
+		).
+		Rule(".dyn-comment-author",
+			FontWeight("600"),
+			Color(c.Text),
+		).
+		Rule(".dyn-comment-body",
+			Margin(t.Space(1)+" 0 0"),
+			Prop("white-space", "pre-line"),
+			Prop("overflow-wrap", "anywhere"),
+		).
+		Rule(".dyn-comment-actions",
+			Display("flex"),
+			Gap(t.Space(2)),
+			MarginTop(t.Space(1)),
+		).
+		Rule(".dyn-comment-action",
+			Padding("0"),
+			Border("none"),
+			Background("none"),
+			Color(c.Primary),
+			FontSize("0.75rem"),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-comment-form, .dyn-comment-edit-form",
+			Display("flex"),
+			FlexDirection("column"),
+			AlignItems("flex-start"),
+			Gap(t.Space(2)),
+		).
+		Rule(".dyn-comment-edit-form",
+			MarginTop(t.Space(2)),
+		).
+		Rule(".dyn-comment-edit-form[hidden]",
+			Display("none"),
+		).
+		Rule(".dyn-comment-input",
+			Width("100%"),
+			Padding(t.Space(2)),
+			Border("1px solid "+c.Border),
+			BorderRadius(t.Radius.Small),
+			Background(c.Surface),
+			Color(c.Text),
+			FontFamily("inherit"),
+			FontSize("0.875rem"),
+		).
+		Rule(".dyn-comment-submit",
+			Padding(t.Space(1)+" "+t.Space(3)),
+			Border("1px solid "+c.Primary),
+			BorderRadius(t.Radius.Small),
+			Background(c.Primary),
+			Color("white"),
+			FontSize("0.875rem"),
+			Cursor("pointer"),
+		).
+		Rule(".dyn-comment-error",
+			Margin("0"),
+			Color(c.Danger),
+			FontSize("0.875rem"),
+		).
+		Rule(".dyn-autosave",
+			FontSize("0.875rem"),
+			Color(c.Muted),
+		).
+		Rule(".dyn-autosave[data-dyn-autosave-state=\"error\"], .dyn-autosave[data-dyn-autosave-state=\"conflict\"]",
+			Color(c.Danger),
+		).
+		Rule(".dyn-scroll-top",
+			Position("fixed"),
+			Prop("right", t.Space(4)),
+			Prop("bottom", t.Space(4)),
+			Display("flex"),
+			AlignItems("center"),
+			JustifyContent("center"),
+			Width("2.75rem"),
+			Height("2.75rem"),
+			Border("1px solid "+c.Border),
+			BorderRadius("9999px"),
+			Background(c.Surface),
+			Color(c.Text),
+			FontSize("1.25rem"),
+			BoxShadow("0 4px 12px rgba(0, 0, 0, 0.15)"),
+			Cursor("pointer"),
+			ZIndex("900"),
+		).
+		Rule(".dyn-scroll-top[hidden]",
+			Display("none"),
+		).
+		Rule(".dyn-scroll-top:hover",
+			BackgroundColor(c.Background),
+		).
+		Rule(".dyn-scroll-top:focus-visible",
+			Prop("outline", "2px solid "+c.Primary),
+			Prop("outline-offset", "2px"),
+		).
+		Rule(".dyn-scroll-progress",
+			Position("fixed"),
+			Prop("top", "0"),
+			Prop("left", "0"),
+			Width("100%"),
+			Height("3px"),
+			Background(c.Primary),
+			Transform("scaleX(0)"),
+			Prop("transform-origin", "left"),
+			ZIndex("1001"),
+			Prop("pointer-events", "none"),
+		).
 		Render()
 }
 
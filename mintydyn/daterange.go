@@ -0,0 +1,263 @@
+package mintydyn
+
+import (
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// DATE RANGE PICKER
+// =============================================================================
+
+// DateRangePreset names a quick-pick range. Presets are computed in the
+// browser from the visitor's local date.
+type DateRangePreset string
+
+const (
+	DateRangeToday      DateRangePreset = "today"
+	DateRangeLast7Days  DateRangePreset = "last7"
+	DateRangeLast30Days DateRangePreset = "last30"
+	DateRangeThisMonth  DateRangePreset = "month"
+)
+
+// DefaultDateRangePresets are shown when DateRangeOptions.Presets is nil.
+var DefaultDateRangePresets = []DateRangePreset{DateRangeToday, DateRangeLast7Days, DateRangeThisMonth}
+
+// Label returns the button text of a preset.
+func (p DateRangePreset) Label() string {
+	switch p {
+	case DateRangeToday:
+		return "Today"
+	case DateRangeLast7Days:
+		return "Last 7 days"
+	case DateRangeLast30Days:
+		return "Last 30 days"
+	case DateRangeThisMonth:
+		return "This month"
+	default:
+		return string(p)
+	}
+}
+
+// DateRangeOptions configures a DateRange. Dates are ISO "YYYY-MM-DD"
+// strings.
+type DateRangeOptions struct {
+	ID         string            // Fieldset id, prefix of the input ids; defaults to name
+	Class      string            // Extra classes for the fieldset
+	Label      string            // Legend, e.g. "Order date"
+	Start      string            // Initial start date
+	End        string            // Initial end date
+	Min        string            // Earliest selectable date
+	Max        string            // Latest selectable date
+	Presets    []DateRangePreset // Nil for DefaultDateRangePresets, empty for none
+	StartLabel string            // Default "From"
+	EndLabel   string            // Default "To"
+	ErrorText  string            // Default "Start date must be on or before the end date."
+}
+
+func (o DateRangeOptions) withDefaults(name string) DateRangeOptions {
+	if o.ID == "" {
+		o.ID = name
+	}
+	if o.Presets == nil {
+		o.Presets = DefaultDateRangePresets
+	}
+	if o.StartLabel == "" {
+		o.StartLabel = "From"
+	}
+	if o.EndLabel == "" {
+		o.EndLabel = "To"
+	}
+	if o.ErrorText == "" {
+		o.ErrorText = "Start date must be on or before the end date."
+	}
+	return o
+}
+
+// DateRange renders start and end date inputs with optional preset buttons.
+// The range is only committed while start ≤ end: the committed ISO values
+// are written into hidden "<name>_start" and "<name>_end" fields, and the
+// fieldset fires a bubbling change event whose detail holds {start, end}
+// (null for an open end). An invalid range is flagged with aria-invalid and
+// an alert, and the hidden fields keep the last valid range. The inputs'
+// own change events are not propagated, so hx-trigger="change" or an
+// addEventListener('change') on the fieldset or a surrounding form sees one
+// event per committed range.
+//
+//	mdy.DateRange("placed", mdy.DateRangeOptions{Label: "Order date", Max: today})
+//
+// Inside a DynamicBuilder's filters, use DateRangeField instead; it renders
+// this control and filters with the same semantics.
+func DateRange(name string, opts DateRangeOptions) mi.H {
+	return dateRange(name, opts)
+}
+
+// dateRange renders a DateRange with extra attributes on the fieldset.
+func dateRange(name string, opts DateRangeOptions, attrs ...mi.Attribute) mi.H {
+	opts = opts.withDefaults(name)
+	return func(b *mi.Builder) mi.Node {
+		invalid := opts.Start != "" && opts.End != "" && opts.Start > opts.End
+		errorID := opts.ID + "-error"
+
+		dateInput := func(part, value string) mi.Node {
+			args := []mi.Attribute{
+				mi.Type("date"),
+				mi.ID(opts.ID + "-" + part),
+				mi.Class("dyn-daterange-input"),
+				mi.Data("dyn-daterange-part", part),
+				mi.Value(value),
+				mi.Attr("aria-describedby", errorID),
+			}
+			if opts.Min != "" {
+				args = append(args, mi.Attr("min", opts.Min))
+			}
+			if opts.Max != "" {
+				args = append(args, mi.Attr("max", opts.Max))
+			}
+			if invalid && part == "end" {
+				args = append(args, mi.Attr("aria-invalid", "true"))
+			}
+			return b.Input(args...)
+		}
+		hiddenInput := func(part, value string) mi.Node {
+			if invalid {
+				value = ""
+			}
+			return b.Input(
+				mi.Type("hidden"),
+				mi.Name(name+"_"+part),
+				mi.Value(value),
+				mi.Data("dyn-daterange-value", part),
+			)
+		}
+
+		fieldset := []interface{}{
+			mi.ID(opts.ID),
+			mi.Class(strings.TrimSpace("dyn-daterange " + opts.Class)),
+			mi.Data("dyn-daterange", ""),
+		}
+		for _, attr := range attrs {
+			fieldset = append(fieldset, attr)
+		}
+		if opts.Label != "" {
+			fieldset = append(fieldset, b.Legend(mi.Class("dyn-daterange-legend"), opts.Label))
+		}
+		if len(opts.Presets) > 0 {
+			presets := []interface{}{
+				mi.Class("dyn-daterange-presets"),
+				mi.Role("group"),
+				mi.AriaLabel("Presets"),
+			}
+			for _, preset := range opts.Presets {
+				presets = append(presets, b.Button(
+					mi.Type("button"),
+					mi.Class("dyn-daterange-preset"),
+					mi.Data("dyn-daterange-preset", string(preset)),
+					mi.Attr("aria-pressed", "false"),
+					preset.Label(),
+				))
+			}
+			fieldset = append(fieldset, b.Div(presets...))
+		}
+
+		errorArgs := []interface{}{
+			mi.ID(errorID),
+			mi.Class("dyn-daterange-error"),
+			mi.Role("alert"),
+			opts.ErrorText,
+		}
+		if !invalid {
+			errorArgs = append(errorArgs, mi.Hidden())
+		}
+
+		fieldset = append(fieldset,
+			b.Div(mi.Class("dyn-daterange-fields"),
+				b.Label(mi.For(opts.ID+"-start"), opts.StartLabel),
+				dateInput("start", opts.Start),
+				b.Label(mi.For(opts.ID+"-end"), opts.EndLabel),
+				dateInput("end", opts.End),
+			),
+			hiddenInput("start", opts.Start),
+			hiddenInput("end", opts.End),
+			b.P(errorArgs...),
+		)
+
+		return mi.NewFragment(
+			b.Fieldset(fieldset...),
+			b.Script(mi.Raw(dateRangeJS)),
+		)
+	}
+}
+
+// dateRangeJS installs delegated listeners once per page.
+const dateRangeJS = `(function(){
+if (window.DynDateRange) return;
+window.DynDateRange = true;
+function iso(d) {
+    var m = d.getMonth() + 1, day = d.getDate();
+    return d.getFullYear() + '-' + (m < 10 ? '0' : '') + m + '-' + (day < 10 ? '0' : '') + day;
+}
+function presetRange(name) {
+    var today = new Date();
+    today.setHours(0, 0, 0, 0);
+    var start = new Date(today);
+    switch (name) {
+        case 'today': return [today, today];
+        case 'last7': start.setDate(start.getDate() - 6); return [start, today];
+        case 'last30': start.setDate(start.getDate() - 29); return [start, today];
+        case 'month':
+            return [new Date(today.getFullYear(), today.getMonth(), 1),
+                    new Date(today.getFullYear(), today.getMonth() + 1, 0)];
+        default: return null;
+    }
+}
+function field(root, attr, part) {
+    return root.querySelector('[data-dyn-daterange-' + attr + '="' + part + '"]');
+}
+// commit validates the range and, when it is valid and has changed, copies
+// it into the hidden fields and fires the fieldset's change event.
+function commit(root) {
+    var start = field(root, 'part', 'start'), end = field(root, 'part', 'end');
+    var error = document.getElementById(root.id + '-error');
+    var invalid = !!(start.value && end.value && start.value > end.value);
+    end.setCustomValidity(invalid && error ? error.textContent : '');
+    if (invalid) end.setAttribute('aria-invalid', 'true');
+    else end.removeAttribute('aria-invalid');
+    if (error) error.hidden = !invalid;
+    if (invalid) return;
+    var hiddenStart = field(root, 'value', 'start'), hiddenEnd = field(root, 'value', 'end');
+    if (hiddenStart.value === start.value && hiddenEnd.value === end.value) return;
+    hiddenStart.value = start.value;
+    hiddenEnd.value = end.value;
+    root.dispatchEvent(new CustomEvent('change', {
+        bubbles: true,
+        detail: { start: start.value || null, end: end.value || null }
+    }));
+}
+function press(root, btn) {
+    root.querySelectorAll('[data-dyn-daterange-preset]').forEach(function(b) {
+        b.setAttribute('aria-pressed', b === btn ? 'true' : 'false');
+    });
+}
+// Capture the inputs' own change events so listeners only see committed ranges
+document.addEventListener('change', function(e) {
+    if (!e.target.hasAttribute || !e.target.hasAttribute('data-dyn-daterange-part')) return;
+    e.stopPropagation();
+    var root = e.target.closest('[data-dyn-daterange]');
+    if (!root) return;
+    press(root, null);
+    commit(root);
+}, true);
+document.addEventListener('click', function(e) {
+    var btn = e.target.closest ? e.target.closest('[data-dyn-daterange-preset]') : null;
+    if (!btn) return;
+    var root = btn.closest('[data-dyn-daterange]');
+    var range = presetRange(btn.getAttribute('data-dyn-daterange-preset'));
+    if (!root || !range) return;
+    field(root, 'part', 'start').value = iso(range[0]);
+    field(root, 'part', 'end').value = iso(range[1]);
+    press(root, btn);
+    commit(root);
+});
+})();`
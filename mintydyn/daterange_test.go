@@ -0,0 +1,100 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestDateRange(t *testing.T) {
+	html := mi.RenderToString(DateRange("placed", DateRangeOptions{
+		Label: "Order date",
+		Start: "2024-03-01",
+		End:   "2024-03-31",
+		Max:   "2024-12-31",
+	}))
+	markup := html[:strings.Index(html, "<script")]
+	for _, want := range []string{
+		`<legend`,
+		`type="date"`,
+		`id="placed-start"`,
+		`for="placed-end"`,
+		`max="2024-12-31"`,
+		`name="placed_start"`,
+		`name="placed_end"`,
+		`value="2024-03-31"`,
+		`aria-describedby="placed-error"`,
+		`data-dyn-daterange-preset="last7"`,
+		`>Last 7 days</button>`,
+		`role="alert"`,
+	} {
+		if !strings.Contains(markup, want) {
+			t.Errorf("DateRange output missing %q in %s", want, markup)
+		}
+	}
+	if strings.Contains(markup, `aria-invalid`) || !strings.Contains(markup, `hidden`) {
+		t.Errorf("a valid range should render no error: %s", markup)
+	}
+
+	invalid := mi.RenderToString(DateRange("placed", DateRangeOptions{
+		Start:   "2024-04-01",
+		End:     "2024-03-01",
+		Presets: []DateRangePreset{},
+	}))
+	invalid = invalid[:strings.Index(invalid, "<script")]
+	if !strings.Contains(invalid, `aria-invalid="true"`) {
+		t.Errorf("an inverted range should be flagged: %s", invalid)
+	}
+	for _, input := range strings.Split(invalid, "<input")[1:] {
+		if strings.Contains(input, `type="hidden"`) && !strings.Contains(input, `value=""`) {
+			t.Errorf("hidden field should be empty for an invalid range: <input%s", input)
+		}
+	}
+	if strings.Contains(invalid, "data-dyn-daterange-preset") {
+		t.Errorf("an empty Presets slice should render no presets: %s", invalid)
+	}
+}
+
+func TestApplyFiltersDateRange(t *testing.T) {
+	items := []map[string]interface{}{
+		{"id": 1, "placed": "2024-03-01"},
+		{"id": 2, "placed": "2024-03-15T18:30:00Z"},
+		{"id": 3, "placed": time.Date(2024, 3, 31, 23, 0, 0, 0, time.UTC)},
+		{"id": 4, "placed": "2024-04-02"},
+		{"id": 5},
+	}
+	schema := FilterSchema{Fields: []FilterableField{DateRangeField("placed", "Placed")}}
+
+	ids := func(state map[string]interface{}) []int {
+		var out []int
+		for _, item := range ApplyFilters(items, schema, state) {
+			out = append(out, item["id"].(int))
+		}
+		return out
+	}
+	tests := []struct {
+		desc string
+		rng  map[string]interface{}
+		want []int
+	}{
+		{"empty range is inactive", map[string]interface{}{"min": nil, "max": ""}, []int{1, 2, 3, 4, 5}},
+		{"bounds are inclusive days", map[string]interface{}{"min": "2024-03-01", "max": "2024-03-31"}, []int{1, 2, 3}},
+		{"open end", map[string]interface{}{"min": "2024-03-15"}, []int{2, 3, 4}},
+		{"open start", map[string]interface{}{"max": "2024-03-14"}, []int{1}},
+	}
+	for _, tt := range tests {
+		got := ids(map[string]interface{}{"placed": tt.rng})
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.desc, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.desc, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -41,6 +41,11 @@ Filterable data:
 	    },
 	})
 
+Fields with Sortable set get sort buttons, and any element with a
+data-sort-field attribute, such as a table header, sorts by that field when
+clicked. Shift-click adds further sort keys. EnableExport adds a button
+that downloads the filtered results as CSV.
+
 Form with dependencies:
 
 	form := mdy.Form("insurance", []mdy.DependencyRule{
@@ -126,7 +131,8 @@ Available patterns:
   - pre-rendered-states: All tab content rendered upfront
   - dynamic-states: Tab content loaded on demand
   - client-filterable: Data filtered in browser
-  - server-filterable: Data filtered via server requests
+  - server-filterable: Data filtered via server requests, with ServerFilter
+    (without an endpoint it is filtered in the browser)
   - dependency-only: Just form field dependencies
   - stateful-data: Tabs with data context per tab
   - filterable-states: Filtering with state context
@@ -0,0 +1,39 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestExport(t *testing.T) {
+	data := []map[string]interface{}{{"claim": "C-1", "status": "open"}, {"claim": "C-2", "status": "closed"}}
+	html := mi.RenderToString(Dyn("claims").
+		Data(data).
+		SelectFilter("status", "Status", []string{"open", "closed"}).
+		Export("claims.csv").
+		Build())
+	for _, want := range []string{
+		`id="claims-export"`,
+		`aria-controls="claims-results"`,
+		`"enableExport":true`,
+		`"exportFilename":"claims.csv"`,
+		`exportCSV()`,
+		`data:exported`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("export output missing %q", want)
+		}
+	}
+
+	rows := mi.RenderToString(FilterWithOptions("rows", nil, FilterSchema{Fields: []FilterableField{TextField("claim", "Claim")}},
+		FilterOptions{ServerRendered: true, RowSelector: ".claim-row", EnableExport: true}))
+	if !strings.Contains(rows, `id="rows-export"`) {
+		t.Error("server-rendered output missing the export button")
+	}
+
+	if plain := mi.RenderToString(Dyn("claims").Data(data).TextFilter("claim", "Claim").Build()); strings.Contains(plain, `id="claims-export"`) {
+		t.Error("export button rendered without EnableExport")
+	}
+}
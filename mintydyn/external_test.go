@@ -0,0 +1,28 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestExternalScriptRetry(t *testing.T) {
+	states := []ComponentState{ActiveState("map", "Map", "map")}
+	html := mi.RenderToString(Dyn("location").States(states).
+		ExternalScript("https://maps.example.com/api.js", Required(), Retry(3, 250*time.Millisecond)).
+		ExternalScript("https://cdn.example.com/chart.js").
+		Build())
+
+	for _, want := range []string{
+		`"src":"https://maps.example.com/api.js","required":true,"retries":3,"retryDelay":250}`,
+		`"src":"https://cdn.example.com/chart.js"}`,
+		`this.trigger('external:retry'`,
+		`loadScriptOnce(script)`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("component output missing %q", want)
+		}
+	}
+}
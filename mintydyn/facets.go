@@ -0,0 +1,456 @@
+package mintydyn
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// FACET SIDEBAR
+// =============================================================================
+
+// FacetSidebarOptions configures a FacetSidebar.
+type FacetSidebarOptions struct {
+	Class  string   // Extra classes for the sidebar
+	Label  string   // Accessible name of the sidebar, default "Filters"
+	Fields []string // Fields to show, in this order; default every schema field
+}
+
+// facetOptionLimit is the most distinct values an inferred string field may
+// have to become a checkbox facet rather than a text search.
+const facetOptionLimit = 12
+
+// FacetSchema returns the schema of a dataset, or one inferred from its
+// items when the schema has no fields: booleans become boolean filters,
+// numbers become range filters spanning the data, strings with a handful of
+// repeated values become multiselect filters and other strings text
+// filters. Pass the result to the filterable component as well as to
+// FacetSidebar so both agree on the fields:
+//
+//	schema := mdy.FacetSchema(dataset)
+//	mdy.Filter("products", dataset.Items, schema)
+func FacetSchema(dataset FilterableDataset) FilterSchema {
+	if len(dataset.Schema.Fields) > 0 {
+		return dataset.Schema
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	for _, item := range dataset.Items {
+		for name := range item {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	var schema FilterSchema
+	for _, name := range names {
+		field := FilterableField{Name: name, Label: name, Type: "text"}
+		kind := ""
+		for _, item := range dataset.Items {
+			value := item[name]
+			if value == nil {
+				continue
+			}
+			k := "text"
+			switch value.(type) {
+			case bool:
+				k = "boolean"
+			case string:
+				k = "string"
+			default:
+				if _, ok := toFloat(value); ok {
+					k = "range"
+				}
+			}
+			if kind != "" && kind != k {
+				kind = "text"
+				break
+			}
+			kind = k
+		}
+		switch kind {
+		case "boolean":
+			field.Type = "boolean"
+		case "range":
+			if min, max, ok := facetBounds(dataset.Items, name); ok {
+				field.Type = "range"
+				field.Range = &RangeInfo{Min: min, Max: max}
+			}
+		case "string":
+			values := facetValues(dataset.Items, name)
+			if len(values) <= facetOptionLimit && len(values) < len(dataset.Items) {
+				field.Type = "multiselect"
+				field.Options = values
+			} else {
+				field.Searchable = true
+			}
+		}
+		schema.Fields = append(schema.Fields, field)
+	}
+	return schema
+}
+
+// FacetSidebar renders a sidebar of facet controls for the filterable
+// component with the given id, generated from the dataset's schema (see
+// FacetSchema): checkbox groups for multiselect fields, radio groups for
+// select fields, a checkbox for boolean fields, min and max sliders for
+// range fields, a date range for daterange fields and a search box for text
+// fields. Options missing from the schema are taken from the data.
+//
+// Every checkbox and radio shows how many items it would match given the
+// other active filters, and the counts follow the component's DataManager
+// live as any facet or filter changes; options with no matches are dimmed.
+// The component's schema must contain the sidebar's fields. The sidebar can
+// sit anywhere on the page, before or after the component.
+//
+//	mdy.FacetSidebar("products", dataset, mdy.FacetSidebarOptions{
+//	    Fields: []string{"category", "price", "inStock"},
+//	})
+//
+// Select and multiselect values are compared as strings, as with the
+// generated filter controls.
+func FacetSidebar(componentID string, dataset FilterableDataset, opts FacetSidebarOptions) mi.H {
+	if opts.Label == "" {
+		opts.Label = "Filters"
+	}
+	fields := FacetSchema(dataset).Fields
+	if opts.Fields != nil {
+		byName := make(map[string]FilterableField, len(fields))
+		for _, field := range fields {
+			byName[field.Name] = field
+		}
+		fields = nil
+		for _, name := range opts.Fields {
+			if field, ok := byName[name]; ok {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	return func(b *mi.Builder) mi.Node {
+		sidebar := []interface{}{
+			mi.Class(strings.TrimSpace("dyn-facets " + opts.Class)),
+			mi.AriaLabel(opts.Label),
+			mi.Data("dyn-facets", componentID),
+			mi.Data("dyn-component", "DynComponent_"+sanitizeID(componentID)),
+		}
+		for _, field := range fields {
+			sidebar = append(sidebar, facetControl(b, componentID, field, dataset.Items))
+		}
+		return mi.NewFragment(
+			b.Aside(sidebar...),
+			b.Script(mi.Raw(facetsJS)),
+		)
+	}
+}
+
+// facetControl renders the facet for one field, or nil when there is
+// nothing to filter by.
+func facetControl(b *mi.Builder, componentID string, field FilterableField, items []map[string]interface{}) mi.Node {
+	label := field.Label
+	if label == "" {
+		label = field.Name
+	}
+	id := componentID + "-facet-" + field.Name
+	facet := []interface{}{
+		mi.Class("dyn-facet"),
+		mi.Data("dyn-facet", field.Name),
+		mi.Data("dyn-facet-type", field.Type),
+	}
+
+	switch field.Type {
+	case "select", "multiselect":
+		options := field.Options
+		if len(options) == 0 {
+			options = facetValues(items, field.Name)
+		}
+		if len(options) == 0 {
+			return nil
+		}
+		facet = append(facet, b.Legend(mi.Class("dyn-facet-title"), label))
+		inputType := "checkbox"
+		if field.Type == "select" {
+			inputType = "radio"
+			facet = append(facet, facetOption(b, inputType, id, "", "All", len(items), items, true))
+		}
+		for _, option := range options {
+			facet = append(facet, facetOption(b, inputType, id, option, option, facetCount(items, field.Name, option), items, false))
+		}
+		return b.Fieldset(facet...)
+
+	case "boolean":
+		return b.Div(append(facet,
+			facetOption(b, "checkbox", id, "true", label, facetCount(items, field.Name, true), items, false),
+		)...)
+
+	case "range":
+		var min, max float64
+		ok := field.Range != nil && field.Range.Min < field.Range.Max
+		if ok {
+			min, max = field.Range.Min, field.Range.Max
+		} else if min, max, ok = facetBounds(items, field.Name); !ok {
+			return nil
+		}
+		step := "any"
+		if field.Range != nil && field.Range.Step > 0 {
+			step = floatStr(field.Range.Step)
+		}
+		bound := func(part, name string, value float64) mi.Node {
+			return b.Input(
+				mi.Type("range"),
+				mi.ID(id+"-"+part),
+				mi.Attr("min", floatStr(min)),
+				mi.Attr("max", floatStr(max)),
+				mi.Attr("step", step),
+				mi.Value(floatStr(value)),
+				mi.AriaLabel(name+" "+label),
+				mi.Data("dyn-facet-input", ""),
+				mi.Data("dyn-facet-bound", part),
+			)
+		}
+		return b.Fieldset(append(facet,
+			b.Legend(mi.Class("dyn-facet-title"), label),
+			b.Div(mi.Class("dyn-facet-range"),
+				bound("min", "Minimum", min),
+				bound("max", "Maximum", max),
+			),
+			b.Output(
+				mi.Class("dyn-facet-range-value"),
+				mi.For(id+"-min "+id+"-max"),
+				mi.Data("dyn-facet-output", ""),
+				floatStr(min)+" – "+floatStr(max),
+			),
+		)...)
+
+	case "daterange":
+		// The fieldset's legend labels the control
+		return b.Div(append(facet,
+			dateRange(field.Name, DateRangeOptions{ID: id, Label: label})(b),
+		)...)
+
+	default:
+		return b.Div(append(facet,
+			b.Label(mi.Class("dyn-facet-title"), mi.For(id), label),
+			b.Input(
+				mi.Type("search"),
+				mi.ID(id),
+				mi.Class("dyn-facet-search"),
+				mi.Placeholder("Search "+label+"..."),
+				mi.Attr("autocomplete", "off"),
+				mi.Data("dyn-facet-input", ""),
+			),
+		)...)
+	}
+}
+
+// facetOption renders one checkbox or radio of a facet with its count. The
+// option is only marked empty when the items are known, since server-rendered
+// components may leave them out.
+func facetOption(b *mi.Builder, inputType, name, value, text string, count int, items []map[string]interface{}, checked bool) mi.Node {
+	class := "dyn-facet-option"
+	if count == 0 && len(items) > 0 {
+		class += " dyn-facet-option-empty"
+	}
+	countKey := value
+	if inputType == "radio" && value == "" {
+		countKey = "*"
+	}
+	input := []mi.Attribute{
+		mi.Type(inputType),
+		mi.Value(value),
+		mi.Data("dyn-facet-input", ""),
+	}
+	if inputType == "radio" {
+		input = append(input, mi.Name(name))
+	}
+	if checked {
+		input = append(input, mi.Checked())
+	}
+	return b.Label(
+		mi.Class(class),
+		b.Input(input...),
+		b.Span(mi.Class("dyn-facet-value"), text),
+		b.Span(mi.Class("dyn-facet-count"), mi.Data("dyn-facet-count", countKey), strconv.Itoa(count)),
+	)
+}
+
+// facetCount counts the items whose field equals value, as the DataManager
+// would compare it.
+func facetCount(items []map[string]interface{}, name string, value interface{}) int {
+	count := 0
+	for _, item := range items {
+		if jsStrictEqual(item[name], value) {
+			count++
+		}
+	}
+	return count
+}
+
+// facetValues returns the distinct string values of a field, sorted.
+func facetValues(items []map[string]interface{}, name string) []string {
+	seen := map[string]bool{}
+	var values []string
+	for _, item := range items {
+		if s, ok := item[name].(string); ok && s != "" && !seen[s] {
+			seen[s] = true
+			values = append(values, s)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// facetBounds returns the smallest and largest numeric values of a field,
+// and false when it has fewer than two different values.
+func facetBounds(items []map[string]interface{}, name string) (min, max float64, ok bool) {
+	found := false
+	for _, item := range items {
+		f, isNum := toFloat(item[name])
+		if !isNum {
+			continue
+		}
+		if !found || f < min {
+			min = f
+		}
+		if !found || f > max {
+			max = f
+		}
+		found = true
+	}
+	return min, max, found && min < max
+}
+
+// facetsJS installs the event listeners once per page and brings every
+// sidebar up to date with its component, for sidebars rendered after the
+// component became ready. A facet's counts apply every active filter except
+// its own, using the DataManager's matching so they agree with the results.
+const facetsJS = `(function(){
+if (!window.DynFacets) {
+    var dataManager = function(sidebar) {
+        var comp = sidebar && window[sidebar.getAttribute('data-dyn-component')];
+        return comp && comp.managers ? comp.managers.data : null;
+    };
+    var matches = function(data, item, field, filter) {
+        if (!data.serverRendered) return data.matchesFilter(item, field, filter);
+        var attr = field.replace(/([A-Z])/g, '-$1').toLowerCase();
+        return data.valueMatchesFilter(item.dataset[field] || item.dataset[attr] || '', filter);
+    };
+    var count = function(data, facet) {
+        var field = facet.getAttribute('data-dyn-facet');
+        var counts = facet.querySelectorAll('[data-dyn-facet-count]');
+        if (!counts.length || !data.filters.has(field)) return;
+        var others = [];
+        data.filters.forEach(function(filter, name) {
+            if (filter.active && name !== field) others.push([name, filter]);
+        });
+        var items = data.getAllData().filter(function(item) {
+            return others.every(function(f) { return matches(data, item, f[0], f[1]); });
+        });
+        var boolean = facet.getAttribute('data-dyn-facet-type') === 'boolean';
+        counts.forEach(function(el) {
+            var value = el.getAttribute('data-dyn-facet-count');
+            var probe = boolean ? { type: 'boolean', value: true } : { type: 'multiselect', value: [value] };
+            var n = value === '*' ? items.length : items.filter(function(item) {
+                return matches(data, item, field, probe);
+            }).length;
+            el.textContent = n;
+            var option = el.closest('.dyn-facet-option');
+            if (option) option.classList.toggle('dyn-facet-option-empty', n === 0);
+        });
+    };
+    var update = function(sidebar) {
+        var data = dataManager(sidebar);
+        if (!data) return;
+        sidebar.querySelectorAll('[data-dyn-facet]').forEach(function(facet) { count(data, facet); });
+    };
+    var showRange = function(facet) {
+        var min = facet.querySelector('[data-dyn-facet-bound="min"]');
+        var max = facet.querySelector('[data-dyn-facet-bound="max"]');
+        var output = facet.querySelector('[data-dyn-facet-output]');
+        if (output) output.textContent = min.value + ' – ' + max.value;
+        return [min, max];
+    };
+    var value = function(facet) {
+        var inputs = Array.prototype.slice.call(facet.querySelectorAll('[data-dyn-facet-input]'));
+        switch (facet.getAttribute('data-dyn-facet-type')) {
+            case 'multiselect':
+                return inputs.filter(function(i) { return i.checked; }).map(function(i) { return i.value; });
+            case 'select':
+                var checked = inputs.filter(function(i) { return i.checked; })[0];
+                return checked ? checked.value : '';
+            case 'boolean':
+                return inputs[0].checked;
+            case 'range':
+                var bounds = showRange(facet), min = Number(bounds[0].value), max = Number(bounds[1].value);
+                return { min: min > Number(bounds[0].min) ? min : null, max: max < Number(bounds[1].max) ? max : null };
+            default:
+                return inputs[0].value;
+        }
+    };
+    var apply = function(facet, v) {
+        var data = dataManager(facet.closest('[data-dyn-facets]'));
+        if (data) data.updateFilter(facet.getAttribute('data-dyn-facet'), v);
+    };
+    var reset = function(sidebar) {
+        sidebar.querySelectorAll('[data-dyn-facet] input').forEach(function(input) {
+            var bound = input.getAttribute('data-dyn-facet-bound');
+            if (input.type === 'checkbox') input.checked = false;
+            else if (input.type === 'radio') input.checked = input.value === '';
+            else if (bound) input.value = bound === 'min' ? input.min : input.max;
+            else input.value = '';
+            input.removeAttribute('aria-invalid');
+        });
+        sidebar.querySelectorAll('[data-dyn-facet] [aria-pressed]').forEach(function(btn) { btn.setAttribute('aria-pressed', 'false'); });
+        sidebar.querySelectorAll('[data-dyn-facet] [role="alert"]').forEach(function(error) { error.hidden = true; });
+        sidebar.querySelectorAll('[data-dyn-facet-type="range"]').forEach(showRange);
+    };
+    var timers = new WeakMap();
+    window.DynFacets = { update: update };
+    ['dyn:component:ready', 'dyn:data:filtered', 'dyn:filters:cleared'].forEach(function(type) {
+        document.addEventListener(type, function(e) {
+            var comp = e.detail && e.detail.component;
+            if (!comp) return;
+            document.querySelectorAll('[data-dyn-facets]').forEach(function(sidebar) {
+                if (sidebar.getAttribute('data-dyn-facets') !== comp.id) return;
+                if (type === 'dyn:filters:cleared') reset(sidebar);
+                update(sidebar);
+            });
+        });
+    });
+    document.addEventListener('input', function(e) {
+        var facet = e.target.closest ? e.target.closest('[data-dyn-facet]') : null;
+        if (!facet || !e.target.hasAttribute('data-dyn-facet-input')) return;
+        var type = facet.getAttribute('data-dyn-facet-type');
+        if (type === 'range') {
+            // Keep the bounds from crossing while dragging
+            var bounds = showRange(facet);
+            if (Number(bounds[0].value) > Number(bounds[1].value)) {
+                if (e.target === bounds[0]) bounds[1].value = bounds[0].value;
+                else bounds[0].value = bounds[1].value;
+                showRange(facet);
+            }
+        } else if (type === 'text') {
+            clearTimeout(timers.get(facet));
+            timers.set(facet, setTimeout(function() { apply(facet, value(facet)); }, 300));
+        }
+    });
+    document.addEventListener('change', function(e) {
+        var facet = e.target.closest ? e.target.closest('[data-dyn-facet]') : null;
+        if (!facet) return;
+        if (e.target.hasAttribute('data-dyn-daterange')) {
+            apply(facet, { min: e.detail ? e.detail.start : null, max: e.detail ? e.detail.end : null });
+        } else if (e.target.hasAttribute('data-dyn-facet-input')) {
+            clearTimeout(timers.get(facet));
+            apply(facet, value(facet));
+        }
+    });
+}
+document.querySelectorAll('[data-dyn-facets]').forEach(window.DynFacets.update);
+})();`
@@ -0,0 +1,71 @@
+package mintydyn
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestFacetSidebar(t *testing.T) {
+	dataset := FilterableDataset{Items: []map[string]interface{}{
+		{"name": "Kettle", "category": "kitchen", "price": 25.0, "inStock": true},
+		{"name": "Toaster", "category": "kitchen", "price": 40.0, "inStock": false},
+		{"name": "Lamp", "category": "lighting", "price": 15.0, "inStock": true},
+	}}
+
+	schema := FacetSchema(dataset)
+	types := map[string]string{}
+	for _, field := range schema.Fields {
+		types[field.Name] = field.Type
+	}
+	want := map[string]string{"name": "text", "category": "multiselect", "price": "range", "inStock": "boolean"}
+	for name, typ := range want {
+		if types[name] != typ {
+			t.Errorf("inferred %s as %q, want %q", name, types[name], typ)
+		}
+	}
+
+	html := mi.RenderToString(FacetSidebar("products", dataset, FacetSidebarOptions{}))
+	markup := html[:strings.Index(html, "<script")]
+	for _, want := range []string{
+		`data-dyn-facets="products"`,
+		`data-dyn-component="DynComponent_products"`,
+		`aria-label="Filters"`,
+		`data-dyn-facet-type="multiselect"`,
+		`<span class="dyn-facet-value">kitchen</span>`,
+		`type="search"`,
+		`data-dyn-facet-bound="max"`,
+		`15 – 40</output>`,
+	} {
+		if !strings.Contains(markup, want) {
+			t.Errorf("FacetSidebar output missing %q in %s", want, markup)
+		}
+	}
+	for value, count := range map[string]string{"kitchen": "2", "lighting": "1", "true": "2"} {
+		if !regexp.MustCompile(`data-dyn-facet-count="` + value + `"[^>]*>` + count + `<`).MatchString(markup) {
+			t.Errorf("count for %s is not %s in %s", value, count, markup)
+		}
+	}
+
+	// Listed fields only, in order; schema options with no items are dimmed
+	dataset.Schema = FilterSchema{Fields: []FilterableField{
+		SelectField("category", "Category", []string{"kitchen", "garden"}),
+		TextField("name", "Name"),
+	}}
+	html = mi.RenderToString(FacetSidebar("products", dataset, FacetSidebarOptions{Fields: []string{"category"}}))
+	markup = html[:strings.Index(html, "<script")]
+	for _, want := range []string{
+		`type="radio"`,
+		`data-dyn-facet-count="*"`,
+		`dyn-facet-option dyn-facet-option-empty`,
+	} {
+		if !strings.Contains(markup, want) {
+			t.Errorf("select facet missing %q in %s", want, markup)
+		}
+	}
+	if strings.Contains(markup, `type="search"`) {
+		t.Errorf("Fields did not limit the facets: %s", markup)
+	}
+}
@@ -0,0 +1,342 @@
+package mintydyn
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// SERVER-SIDE FILTERING (mirrors the generated DataManager JavaScript)
+// =============================================================================
+
+// ApplyFilters filters items in Go with the same semantics as the client-side
+// DataManager, so server-filterable components return exactly what the
+// browser would show. state maps field names to filter values as the client
+// sends them:
+//
+//	text:        string, case-insensitive substring match
+//	boolean:     bool, only true filters (item value must be exactly true)
+//	range:       map with "min"/"max" keys, inclusive; nil means unbounded
+//	daterange:   map with "min"/"max" ISO dates, compared by day, inclusive;
+//	             nil or "" means unbounded, items without a date never match
+//	select:      value compared with strict equality; "" means all
+//	multiselect: []interface{} or []string of allowed values; empty means all
+//
+// Fields missing from the schema or from state, and inactive values (empty
+// text, false, empty range, empty selection), do not filter.
+func ApplyFilters(items []map[string]interface{}, schema FilterSchema, state map[string]interface{}) []map[string]interface{} {
+	active := make([]FilterableField, 0, len(schema.Fields))
+	for _, field := range schema.Fields {
+		if value, ok := state[field.Name]; ok && isFilterValueActive(field.Type, value) {
+			active = append(active, field)
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		matches := true
+		for _, field := range active {
+			if !matchesFilter(item[field.Name], field.Type, state[field.Name]) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// ResultCountHeader is the response header a ServerFilter endpoint can set
+// to the number of matching items, shown in the component's summary.
+const ResultCountHeader = "X-Result-Count"
+
+// maxFilterRequestSize limits the body ParseFilterRequest reads.
+const maxFilterRequestSize = 1 << 20
+
+// FilterRequest is what a ServerFilter component POSTs: the active filters
+// and, once the user has sorted, the sort keys in order.
+type FilterRequest struct {
+	Filters map[string]interface{} `json:"filters"`
+	Sort    []SortKey              `json:"sort,omitempty"`
+}
+
+// ParseFilterRequest reads the filter state a ServerFilter component POSTs,
+// {"filters": {...}} with only the active filters, and checks it against
+// schema with ValidateFilterState. The state is ready for ApplyFilters:
+//
+//	func filterAssets(w http.ResponseWriter, r *http.Request) {
+//	    state, err := mdy.ParseFilterRequest(r, assetSchema)
+//	    if err != nil {
+//	        http.Error(w, err.Error(), http.StatusBadRequest)
+//	        return
+//	    }
+//	    matches := mdy.ApplyFilters(assets, assetSchema, state)
+//	    w.Header().Set(mdy.ResultCountHeader, strconv.Itoa(len(matches)))
+//	    mi.Render(assetRows(matches), w)
+//	}
+//
+// Use ReadFilterRequest for sortable components.
+func ParseFilterRequest(r *http.Request, schema FilterSchema) (map[string]interface{}, error) {
+	req, err := ReadFilterRequest(r, schema)
+	if err != nil {
+		return nil, err
+	}
+	return req.Filters, nil
+}
+
+// ReadFilterRequest is ParseFilterRequest including the sort keys, which
+// must name schema fields. They are ready for SortItems.
+func ReadFilterRequest(r *http.Request, schema FilterSchema) (FilterRequest, error) {
+	var req FilterRequest
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxFilterRequestSize+1))
+	if err != nil {
+		return FilterRequest{}, err
+	}
+	if len(body) > maxFilterRequestSize {
+		return FilterRequest{}, fmt.Errorf("%w: request body too large", ErrInvalidFilterValue)
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return FilterRequest{}, fmt.Errorf("%w: %v", ErrInvalidFilterValue, err)
+	}
+	if req.Filters == nil {
+		req.Filters = map[string]interface{}{}
+	}
+	if err := ValidateFilterState(schema, req.Filters); err != nil {
+		return FilterRequest{}, err
+	}
+	if err := validateSortKeys(schema, req.Sort); err != nil {
+		return FilterRequest{}, err
+	}
+	return req, nil
+}
+
+// isFilterValueActive mirrors DataManager.isFilterValueActive.
+func isFilterValueActive(filterType string, value interface{}) bool {
+	switch filterType {
+	case "text":
+		s, ok := value.(string)
+		return ok && s != ""
+	case "boolean":
+		b, ok := value.(bool)
+		return ok && b
+	case "range":
+		m, ok := value.(map[string]interface{})
+		return ok && (m["min"] != nil || m["max"] != nil)
+	case "daterange":
+		m, ok := value.(map[string]interface{})
+		return ok && (jsTruthy(m["min"]) || jsTruthy(m["max"]))
+	case "multiselect":
+		return len(toSlice(value)) > 0
+	case "select":
+		return jsTruthy(value)
+	default:
+		return value != nil
+	}
+}
+
+// matchesFilter mirrors DataManager.matchesFilter.
+func matchesFilter(itemValue interface{}, filterType string, filterValue interface{}) bool {
+	switch filterType {
+	case "text":
+		s := ""
+		if jsTruthy(itemValue) {
+			s = jsString(itemValue)
+		}
+		return strings.Contains(strings.ToLower(s), strings.ToLower(jsString(filterValue)))
+	case "boolean":
+		return jsStrictEqual(itemValue, filterValue)
+	case "range":
+		m, _ := filterValue.(map[string]interface{})
+		num := jsNumber(itemValue)
+		min, max := math.Inf(-1), math.Inf(1)
+		if m["min"] != nil {
+			min = jsNumber(m["min"])
+		}
+		if m["max"] != nil {
+			max = jsNumber(m["max"])
+		}
+		return num >= min && num <= max
+	case "daterange":
+		m, _ := filterValue.(map[string]interface{})
+		day := isoDay(itemValue)
+		if day == "" {
+			return false
+		}
+		if jsTruthy(m["min"]) && day < isoDay(m["min"]) {
+			return false
+		}
+		return !jsTruthy(m["max"]) || day <= isoDay(m["max"])
+	case "multiselect":
+		for _, v := range toSlice(filterValue) {
+			if jsStrictEqual(itemValue, v) {
+				return true
+			}
+		}
+		return false
+	default:
+		return jsStrictEqual(itemValue, filterValue)
+	}
+}
+
+// isoDay returns the "YYYY-MM-DD" prefix of an ISO date or timestamp, as
+// String(value).slice(0, 10) would in the DataManager. Times are formatted
+// the way encoding/json sends them.
+func isoDay(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		s = v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		s = v.Format(time.RFC3339)
+	default:
+		s = jsString(v)
+	}
+	if len(s) > 10 {
+		s = s[:10]
+	}
+	return s
+}
+
+// toSlice converts a multiselect value to []interface{}.
+func toSlice(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case []string:
+		out := make([]interface{}, len(v))
+		for i, s := range v {
+			out[i] = s
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// jsTruthy reports whether a value is truthy in JavaScript.
+func jsTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	default:
+		if f, ok := toFloat(value); ok {
+			return f != 0 && !math.IsNaN(f)
+		}
+		return true
+	}
+}
+
+// jsString converts a value the way JavaScript's String() would for scalars.
+func jsString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		if f, ok := toFloat(value); ok {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+// jsNumber converts a value the way JavaScript's Number() would for scalars.
+func jsNumber(value interface{}) float64 {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			return 0
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return math.NaN()
+		}
+		return f
+	default:
+		if f, ok := toFloat(value); ok {
+			return f
+		}
+		return math.NaN()
+	}
+}
+
+// jsStrictEqual mirrors JavaScript's === for scalar values. All Go numeric
+// types compare as numbers, since JSON has a single number type.
+func jsStrictEqual(a, b interface{}) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	switch va := a.(type) {
+	case nil:
+		return b == nil
+	case string:
+		vb, ok := b.(string)
+		return ok && va == vb
+	case bool:
+		vb, ok := b.(bool)
+		return ok && va == vb
+	default:
+		return false
+	}
+}
+
+// toFloat converts Go numeric types to float64.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
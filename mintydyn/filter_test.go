@@ -0,0 +1,152 @@
+package mintydyn
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestApplyFilters(t *testing.T) {
+	items := []map[string]interface{}{
+		{"name": "Laptop Pro", "price": 1200, "inStock": true, "category": "hardware"},
+		{"name": "laptop stand", "price": 45.5, "inStock": false, "category": "accessories"},
+		{"name": "Editor", "price": 100, "inStock": true, "category": "software"},
+		{"name": nil, "price": "300", "inStock": "true", "category": "hardware"},
+	}
+	schema := FilterSchema{Fields: []FilterableField{
+		TextField("name", "Name"),
+		RangeField("price", "Price", 0, 2000, 1),
+		BoolField("inStock", "In stock"),
+		SelectField("category", "Category", nil),
+		MultiSelectField("categories", "Categories", nil),
+	}}
+
+	names := func(result []map[string]interface{}) []interface{} {
+		out := make([]interface{}, len(result))
+		for i, r := range result {
+			out[i] = r["name"]
+		}
+		return out
+	}
+
+	tests := []struct {
+		desc  string
+		state map[string]interface{}
+		want  []interface{}
+	}{
+		{"no filters", nil, []interface{}{"Laptop Pro", "laptop stand", "Editor", nil}},
+		{"text is case-insensitive", map[string]interface{}{"name": "LAPTOP"}, []interface{}{"Laptop Pro", "laptop stand"}},
+		{"empty text is inactive", map[string]interface{}{"name": ""}, []interface{}{"Laptop Pro", "laptop stand", "Editor", nil}},
+		{"range is inclusive", map[string]interface{}{"price": map[string]interface{}{"min": 100, "max": 1200}}, []interface{}{"Laptop Pro", "Editor", nil}},
+		{"range open max", map[string]interface{}{"price": map[string]interface{}{"min": 301.0, "max": nil}}, []interface{}{"Laptop Pro"}},
+		{"empty range is inactive", map[string]interface{}{"price": map[string]interface{}{"min": nil, "max": nil}}, []interface{}{"Laptop Pro", "laptop stand", "Editor", nil}},
+		{"boolean is strict", map[string]interface{}{"inStock": true}, []interface{}{"Laptop Pro", "Editor"}},
+		{"false boolean is inactive", map[string]interface{}{"inStock": false}, []interface{}{"Laptop Pro", "laptop stand", "Editor", nil}},
+		{"select", map[string]interface{}{"category": "hardware"}, []interface{}{"Laptop Pro", nil}},
+		{"empty select is inactive", map[string]interface{}{"category": ""}, []interface{}{"Laptop Pro", "laptop stand", "Editor", nil}},
+		{"empty multiselect matches all", map[string]interface{}{"categories": []interface{}{}}, []interface{}{"Laptop Pro", "laptop stand", "Editor", nil}},
+		{"combined filters", map[string]interface{}{"name": "laptop", "inStock": true}, []interface{}{"Laptop Pro"}},
+		{"unknown field ignored", map[string]interface{}{"color": "red"}, []interface{}{"Laptop Pro", "laptop stand", "Editor", nil}},
+	}
+
+	for _, tt := range tests {
+		got := names(ApplyFilters(items, schema, tt.state))
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.desc, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.desc, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestApplyFiltersMultiselect(t *testing.T) {
+	items := []map[string]interface{}{
+		{"category": "hardware"},
+		{"category": "software"},
+		{"category": "services"},
+	}
+	schema := FilterSchema{Fields: []FilterableField{{Name: "category", Type: "multiselect"}}}
+
+	got := ApplyFilters(items, schema, map[string]interface{}{"category": []string{"hardware", "services"}})
+	if len(got) != 2 || got[0]["category"] != "hardware" || got[1]["category"] != "services" {
+		t.Errorf("multiselect = %v", got)
+	}
+}
+
+func TestFilterLoadMode(t *testing.T) {
+	data := []map[string]interface{}{{"name": "a"}, {"name": "b"}, {"name": "c"}}
+	schema := FilterSchema{Fields: []FilterableField{TextField("name", "Name")}}
+
+	html := mi.RenderToString(FilterWithOptions("claims", data, schema, FilterOptions{
+		ItemsPerPage: 2,
+		LoadMode:     LoadModeInfinite,
+	}))
+	for _, want := range []string{
+		`id="claims-pagination"`,
+		`id="claims-status"`,
+		`role="status"`,
+		`"loadMode":"infinite"`,
+		`IntersectionObserver`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("infinite output missing %q", want)
+		}
+	}
+
+	plain := mi.RenderToString(FilterWithOptions("claims", data, schema, FilterOptions{ItemsPerPage: 2}))
+	if strings.Contains(plain, `id="claims-pagination"`) || strings.Contains(plain, `id="claims-status"`) {
+		t.Error("load controls rendered without pagination or a load mode")
+	}
+}
+
+func TestServerFilter(t *testing.T) {
+	data := make([]map[string]interface{}, 60)
+	for i := range data {
+		data[i] = map[string]interface{}{"name": fmt.Sprintf("asset-%d", i)}
+	}
+
+	html := mi.RenderToString(Dyn("assets").Data(data).TextFilter("name", "Name").ServerFilter("/assets/filter").Build())
+	for _, want := range []string{
+		`data-pattern="server-filterable"`,
+		`"endpoint":"/assets/filter"`,
+		`"data":[]`,
+		`id="assets-results"`,
+		`X-Result-Count`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("ServerFilter output missing %q", want)
+		}
+	}
+	if strings.Contains(html, "asset-59") {
+		t.Error("ServerFilter should keep the items on the server")
+	}
+
+	schema := FilterSchema{Fields: []FilterableField{TextField("name", "Name"), RangeField("price", "Price", 0, 100, 1)}}
+	req := httptest.NewRequest("POST", "/assets/filter", strings.NewReader(`{"filters":{"name":"asset-17","price":{"min":10,"max":null}}}`))
+	state, err := ParseFilterRequest(req, schema)
+	if err != nil {
+		t.Fatalf("ParseFilterRequest: %v", err)
+	}
+	if got := ApplyFilters(data, FilterSchema{Fields: schema.Fields[:1]}, state); len(got) != 1 || got[0]["name"] != "asset-17" {
+		t.Errorf("ApplyFilters with the parsed state = %v", got)
+	}
+
+	for _, body := range []string{`{"filters":{"colour":"red"}}`, `{"filters":{"price":{"min":500}}}`, `not json`} {
+		req := httptest.NewRequest("POST", "/assets/filter", strings.NewReader(body))
+		if _, err := ParseFilterRequest(req, schema); err == nil {
+			t.Errorf("ParseFilterRequest(%s) should fail", body)
+		}
+	}
+	req = httptest.NewRequest("POST", "/assets/filter", strings.NewReader(`{}`))
+	if state, err := ParseFilterRequest(req, schema); err != nil || len(state) != 0 {
+		t.Errorf("ParseFilterRequest({}) = %v, %v, want an empty state", state, err)
+	}
+}
@@ -0,0 +1,103 @@
+package mintydyn
+
+import (
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// FILTER TOOLBAR
+// =============================================================================
+
+// FilterToolbarOptions configures a FilterToolbar.
+type FilterToolbarOptions struct {
+	Class       string // Extra classes for the toolbar
+	ClearLabel  string // Default "Clear all filters"
+	HideSummary bool   // Leave out the "Showing N of M" summary
+}
+
+// FilterToolbar renders a count of the active filters, a "Showing N of M"
+// summary and a button that clears every filter of the filterable
+// component with the given id. The counts follow the component's
+// DataManager live, and the button is disabled while no filter is active.
+// The toolbar can sit anywhere on the page, before or after the component.
+//
+//	mdy.FilterToolbar("claims", mdy.FilterToolbarOptions{})
+func FilterToolbar(componentID string, opts FilterToolbarOptions) mi.H {
+	if opts.ClearLabel == "" {
+		opts.ClearLabel = "Clear all filters"
+	}
+	return func(b *mi.Builder) mi.Node {
+		status := []interface{}{
+			mi.Class("dyn-filter-toolbar-status"),
+			mi.Role("status"),
+			b.Span(mi.Class("dyn-filter-toolbar-count"), mi.Data("dyn-toolbar-count", ""), "No filters active"),
+		}
+		if !opts.HideSummary {
+			status = append(status, b.Span(mi.Class("dyn-filter-toolbar-results"), mi.Data("dyn-toolbar-results", "")))
+		}
+
+		return mi.NewFragment(
+			b.Div(
+				mi.Class(strings.TrimSpace("dyn-filter-toolbar "+opts.Class)),
+				mi.Data("dyn-filter-toolbar", componentID),
+				mi.Data("dyn-component", "DynComponent_"+sanitizeID(componentID)),
+				b.Div(status...),
+				b.Button(
+					mi.Type("button"),
+					mi.Class("dyn-filter-toolbar-clear"),
+					mi.Data("dyn-toolbar-clear", ""),
+					mi.Attr("aria-controls", componentID),
+					mi.Disabled(),
+					opts.ClearLabel,
+				),
+			),
+			b.Script(mi.Raw(filterToolbarJS)),
+		)
+	}
+}
+
+// filterToolbarJS installs the event listeners once per page and brings
+// every toolbar up to date with its component, for toolbars rendered after
+// the component became ready.
+const filterToolbarJS = `(function(){
+if (!window.DynFilterToolbar) {
+    var dataManager = function(toolbar) {
+        var comp = window[toolbar.getAttribute('data-dyn-component')];
+        return comp && comp.managers ? comp.managers.data : null;
+    };
+    var update = function(toolbar) {
+        var data = dataManager(toolbar);
+        if (!data) return;
+        var active = data.activeFilterCount();
+        var count = toolbar.querySelector('[data-dyn-toolbar-count]');
+        count.textContent = active === 0 ? 'No filters active' :
+            active + (active === 1 ? ' filter' : ' filters') + ' active';
+        var results = toolbar.querySelector('[data-dyn-toolbar-results]');
+        if (results) {
+            var total = data.getAllData().length;
+            var visible = data.getVisibleCount();
+            results.textContent = 'Showing ' + (visible == null ? total : visible) + ' of ' + total;
+        }
+        toolbar.querySelector('[data-dyn-toolbar-clear]').disabled = active === 0;
+    };
+    window.DynFilterToolbar = { update: update };
+    ['dyn:component:ready', 'dyn:data:filtered', 'dyn:filters:cleared'].forEach(function(type) {
+        document.addEventListener(type, function(e) {
+            var comp = e.detail && e.detail.component;
+            if (!comp) return;
+            document.querySelectorAll('[data-dyn-filter-toolbar]').forEach(function(toolbar) {
+                if (toolbar.getAttribute('data-dyn-filter-toolbar') === comp.id) update(toolbar);
+            });
+        });
+    });
+    document.addEventListener('click', function(e) {
+        var btn = e.target.closest ? e.target.closest('[data-dyn-toolbar-clear]') : null;
+        if (!btn) return;
+        var data = dataManager(btn.closest('[data-dyn-filter-toolbar]'));
+        if (data) data.clearFilters();
+    });
+}
+document.querySelectorAll('[data-dyn-filter-toolbar]').forEach(window.DynFilterToolbar.update);
+})();`
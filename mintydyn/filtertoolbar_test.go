@@ -0,0 +1,39 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestFilterToolbar(t *testing.T) {
+	html := mi.RenderToString(FilterToolbar("claim-list", FilterToolbarOptions{}))
+	markup := html[:strings.Index(html, "<script")]
+	for _, want := range []string{
+		`data-dyn-filter-toolbar="claim-list"`,
+		`data-dyn-component="DynComponent_claim_list"`,
+		`role="status"`,
+		`data-dyn-toolbar-results`,
+		`aria-controls="claim-list"`,
+		`disabled="disabled"`,
+		`>Clear all filters</button>`,
+	} {
+		if !strings.Contains(markup, want) {
+			t.Errorf("FilterToolbar output missing %q in %s", want, markup)
+		}
+	}
+
+	bare := mi.RenderToString(FilterToolbar("claims", FilterToolbarOptions{HideSummary: true, ClearLabel: "Reset"}))
+	if strings.Contains(bare, `data-dyn-toolbar-results=`) || !strings.Contains(bare, ">Reset</button>") {
+		t.Errorf("HideSummary/ClearLabel not applied: %s", bare)
+	}
+
+	data := []map[string]interface{}{{"name": "a"}}
+	component := mi.RenderToString(Filter("claims", data, FilterSchema{Fields: []FilterableField{TextField("name", "Name")}}))
+	for _, want := range []string{"activeFilterCount()", "trigger('filters:cleared'"} {
+		if !strings.Contains(component, want) {
+			t.Errorf("DataManager missing %q", want)
+		}
+	}
+}
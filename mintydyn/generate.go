@@ -75,7 +75,10 @@ func (db *DynamicBuilder[S, D, R]) generateConfigScript(b *mi.Builder, pattern D
 		"contentHidden":          theme.StateContentHiddenClass(),
 		"paginationButton":       theme.PaginationButtonClass(),
 		"paginationButtonActive": theme.PaginationButtonActiveClass(),
+		"sortAsc":                sortClasses(theme).asc,
+		"sortDesc":               sortClasses(theme).desc,
 	}
+	config["classPrefix"] = db.classPrefix()
 
 	// Add data based on what's provided
 	if pattern.HasStates {
@@ -95,9 +98,14 @@ func (db *DynamicBuilder[S, D, R]) generateConfigScript(b *mi.Builder, pattern D
 	}
 
 	if pattern.HasData {
-		config["data"] = db.extractData()
+		filterOptions := db.extractFilterOptions()
+		if filterOptions.Endpoint != "" {
+			config["data"] = []map[string]interface{}{} // Filtered on the server
+		} else {
+			config["data"] = db.extractData()
+		}
 		config["schema"] = db.extractFilterSchema()
-		config["filterOptions"] = db.extractFilterOptions()
+		config["filterOptions"] = filterOptions
 	}
 
 	if pattern.HasRules {
@@ -159,7 +167,7 @@ func (db *DynamicBuilder[S, D, R]) generatePatternStructure(b *mi.Builder, patte
 		return db.generateCompleteStructure(b, pattern)
 
 	default:
-		return []mi.Node{b.Div(mi.Class("dyn-empty"), "Empty dynamic component")}
+		return []mi.Node{b.Div(mi.Class(db.scopeClasses("dyn-empty")), "Empty dynamic component")}
 	}
 }
 
@@ -171,7 +179,7 @@ func (db *DynamicBuilder[S, D, R]) generatePatternStructure(b *mi.Builder, patte
 func (db *DynamicBuilder[S, D, R]) generateStatesStructure(b *mi.Builder, pattern DetectedPattern) []mi.Node {
 	states := db.extractStates()
 	if len(states) == 0 {
-		return []mi.Node{b.Div(mi.Class("dyn-empty"), "No states provided")}
+		return []mi.Node{b.Div(mi.Class(db.scopeClasses("dyn-empty")), "No states provided")}
 	}
 
 	theme := db.getTheme()
@@ -191,6 +199,7 @@ func (db *DynamicBuilder[S, D, R]) generateStatesStructure(b *mi.Builder, patter
 // generateStateNavigation creates the tab bar.
 func (db *DynamicBuilder[S, D, R]) generateStateNavigation(b *mi.Builder, states []ComponentState, theme DynamicTheme) mi.Node {
 	var buttons []interface{}
+	focusable := focusableState(states)
 
 	for _, state := range states {
 		btnClass := theme.StateTriggerClass()
@@ -206,10 +215,8 @@ func (db *DynamicBuilder[S, D, R]) generateStateNavigation(b *mi.Builder, states
 			mi.Class(btnClass),
 			mi.Data("state-target", state.ID),
 			mi.Data("client-action", "switch-state"),
-			mi.Attr("role", "tab"),
-			mi.Attr("aria-selected", boolStr(state.Active)),
-			mi.Attr("aria-controls", "state-"+state.ID),
 		}
+		btnAttrs = append(btnAttrs, tabAttrs(state, state.ID == focusable)...)
 
 		if state.Disabled {
 			btnAttrs = append(btnAttrs, mi.Disabled())
@@ -239,6 +246,51 @@ func (db *DynamicBuilder[S, D, R]) generateStateNavigation(b *mi.Builder, states
 	return b.Div(navAttrs...)
 }
 
+// focusableState returns the id of the state whose tab is in the tab order,
+// the active one or else the first, as the StatesManager starts on it.
+func focusableState(states []ComponentState) string {
+	for _, state := range states {
+		if state.Active {
+			return state.ID
+		}
+	}
+	if len(states) > 0 {
+		return states[0].ID
+	}
+	return ""
+}
+
+// tabAttrs returns the ARIA attributes of the tab switching to state. Only
+// the focusable tab is in the tab order; the arrow keys move between the
+// others, and the StatesManager moves tabindex 0 along with the active tab.
+func tabAttrs(state ComponentState, focusable bool) []interface{} {
+	tabIndex := -1
+	if focusable {
+		tabIndex = 0
+	}
+	return []interface{}{
+		mi.ID("state-" + state.ID + "-tab"),
+		mi.Role("tab"),
+		mi.Attr("aria-selected", boolStr(state.Active)),
+		mi.Attr("aria-controls", "state-"+state.ID),
+		mi.TabIndex(tabIndex),
+	}
+}
+
+// tabPanelAttrs returns the ARIA attributes of state's panel, labelled by
+// its tab when there is a tab bar.
+func tabPanelAttrs(state ComponentState, hasTab bool) []interface{} {
+	attrs := []interface{}{
+		mi.Role("tabpanel"),
+		mi.Attr("aria-hidden", boolStr(!state.Active)),
+		mi.TabIndex(0),
+	}
+	if hasTab {
+		attrs = append(attrs, mi.Attr("aria-labelledby", "state-"+state.ID+"-tab"))
+	}
+	return attrs
+}
+
 // generateStateContents creates the content panels.
 func (db *DynamicBuilder[S, D, R]) generateStateContents(b *mi.Builder, states []ComponentState, theme DynamicTheme) mi.Node {
 	var panels []interface{}
@@ -255,9 +307,8 @@ func (db *DynamicBuilder[S, D, R]) generateStateContents(b *mi.Builder, states [
 			mi.ID("state-" + state.ID),
 			mi.Class(panelClass),
 			mi.Data("state-id", state.ID),
-			mi.Attr("role", "tabpanel"),
-			mi.Attr("aria-hidden", boolStr(!state.Active)),
 		}
+		panelAttrs = append(panelAttrs, tabPanelAttrs(state, len(states) > 1)...)
 
 		// Add condition as data attribute if present
 		if state.Condition != nil {
@@ -314,22 +365,53 @@ func (db *DynamicBuilder[S, D, R]) generateFilterableStructure(b *mi.Builder, pa
 		mi.Class(theme.ResultsSummaryClass()),
 	))
 
+	// Export button, downloading the filtered results as CSV
+	if db.extractFilterOptions().EnableExport {
+		children = append(children, b.Button(
+			mi.Type("button"),
+			mi.ID(db.id+"-export"),
+			mi.Class(db.scopeClasses("dyn-export-button")),
+			mi.Attr("aria-controls", db.id+"-results"),
+			"Export",
+		))
+	}
+
 	// Results container
 	children = append(children, b.Div(
 		mi.ID(db.id+"-results"),
 		mi.Class(theme.ResultsClass()),
 	))
 
-	// Pagination
+	children = append(children, db.generatePaginationControls(b, theme)...)
+
+	// Item template, cloned and bound per result by the DataManager
+	if view := db.extractFilterOptions().ItemView; view != nil {
+		children = append(children, b.Template(mi.ID(db.id+"-item-template"), view(b)))
+	}
+
+	return children
+}
+
+// generatePaginationControls creates the pagination container, which holds
+// page buttons or the load-more controls depending on the load mode, and a
+// live region announcing results appended by the incremental modes.
+func (db *DynamicBuilder[S, D, R]) generatePaginationControls(b *mi.Builder, theme DynamicTheme) []mi.Node {
+	var nodes []mi.Node
 	opts := db.extractFilterOptions()
-	if opts.EnablePagination {
-		children = append(children, b.Div(
+	if opts.EnablePagination || opts.LoadMode != "" {
+		nodes = append(nodes, b.Div(
 			mi.ID(db.id+"-pagination"),
 			mi.Class(theme.PaginationClass()),
 		))
 	}
-
-	return children
+	if opts.LoadMode == LoadModeInfinite || opts.LoadMode == LoadModeLoadMore {
+		nodes = append(nodes, b.Div(
+			mi.ID(db.id+"-status"),
+			mi.Role("status"),
+			mi.Class(db.scopeClasses("dyn-sr-only")),
+		))
+	}
+	return nodes
 }
 
 // generateFilterControls creates the filter input fields.
@@ -350,6 +432,9 @@ func (db *DynamicBuilder[S, D, R]) generateFilterControls(b *mi.Builder, theme D
 		mi.Class(theme.FilterControlsClass()),
 	}
 	containerAttrs = append(containerAttrs, controls...)
+	if sortControls := db.generateSortControls(b, schema); sortControls != nil {
+		containerAttrs = append(containerAttrs, sortControls)
+	}
 
 	return b.Div(containerAttrs...)
 }
@@ -386,7 +471,7 @@ func (db *DynamicBuilder[S, D, R]) generateFilterControl(b *mi.Builder, field Fi
 		var checkboxes []interface{}
 		for _, opt := range field.Options {
 			checkboxes = append(checkboxes, b.Label(
-				mi.Class("dyn-checkbox-label"),
+				mi.Class(db.scopeClasses("dyn-checkbox-label")),
 				b.Input(
 					mi.Type("checkbox"),
 					mi.Value(opt),
@@ -397,7 +482,7 @@ func (db *DynamicBuilder[S, D, R]) generateFilterControl(b *mi.Builder, field Fi
 				" "+opt,
 			))
 		}
-		control = b.Div(append([]interface{}{mi.Class("dyn-multiselect-control")}, checkboxes...)...)
+		control = b.Div(append([]interface{}{mi.Class(db.scopeClasses("dyn-multiselect-control"))}, checkboxes...)...)
 
 	case "boolean":
 		control = b.Input(
@@ -411,7 +496,7 @@ func (db *DynamicBuilder[S, D, R]) generateFilterControl(b *mi.Builder, field Fi
 	case "range":
 		if field.Range != nil {
 			control = b.Div(
-				mi.Class("dyn-range-control"),
+				mi.Class(db.scopeClasses("dyn-range-control")),
 				b.Input(
 					mi.Type("range"),
 					mi.ID(db.id+"-filter-"+field.Name+"-min"),
@@ -437,6 +522,16 @@ func (db *DynamicBuilder[S, D, R]) generateFilterControl(b *mi.Builder, field Fi
 			control = mi.Txt("Range configuration missing")
 		}
 
+	case "daterange":
+		// The fieldset's legend labels the control
+		return b.Div(
+			mi.Class(theme.FilterGroupClass()),
+			dateRange(field.Name, DateRangeOptions{
+				ID:    db.id + "-filter-" + field.Name,
+				Label: field.Label,
+			}, mi.Data("filter-field", field.Name), mi.Data("filter-type", "daterange"))(b),
+		)
+
 	default:
 		control = mi.Txt("Unknown filter type: " + field.Type)
 	}
@@ -499,7 +594,7 @@ func (db *DynamicBuilder[S, D, R]) generateDependencyStructure(b *mi.Builder, pa
 	return []mi.Node{
 		b.Div(
 			mi.ID(db.id+"-dependency-status"),
-			mi.Class("dyn-dependency-status "+db.getTheme().HiddenClass()),
+			mi.Class(combineClasses(db.scopeClasses("dyn-dependency-status"), db.getTheme().HiddenClass())),
 		),
 	}
 }
@@ -98,6 +98,11 @@ class DynamicComponent_%s {
         return configScript ? JSON.parse(configScript.textContent) : {};
     }
     
+    // Class name for a component-generated class (namespaced when scoped)
+    cls(name) {
+        return (this.config.classPrefix || 'dyn-') + name;
+    }
+    
     // Async initialization that waits for external scripts
     async initWithDependencies() {
         try {
@@ -148,7 +153,21 @@ class DynamicComponent_%s {
         }));
     }
     
+    // loadScript loads a script, retrying failed loads with exponential
+    // backoff as the script's retries and retryDelay allow
     loadScript(script) {
+        const retries = script.retries || 0;
+        const delay = script.retryDelay || 500;
+        const attempt = (n) => this.loadScriptOnce(script).catch(error => {
+            if (n >= retries) throw error;
+            const wait = delay * Math.pow(2, n);
+            this.trigger('external:retry', { src: script.src, attempt: n + 1, retries, delay: wait, error });
+            return new Promise(resolve => setTimeout(resolve, wait)).then(() => attempt(n + 1));
+        });
+        return attempt(0);
+    }
+    
+    loadScriptOnce(script) {
         return new Promise((resolve, reject) => {
             // Check if already loaded
             if (document.querySelector('script[src="' + script.src + '"]')) {
@@ -170,7 +189,11 @@ class DynamicComponent_%s {
                 }
                 resolve();
             };
-            el.onerror = () => reject(new Error('Failed to load: ' + script.src));
+            el.onerror = () => {
+                // Remove the failed tag so a retry is not taken for loaded
+                el.remove();
+                reject(new Error('Failed to load: ' + script.src));
+            };
             
             document.head.appendChild(el);
         });
@@ -296,6 +319,12 @@ class DynamicComponent_%s {
     }
     
     getInputValue(element) {
+        // A DateRange fieldset reports its committed range
+        if (element.dataset && element.dataset.filterType === 'daterange') {
+            const start = element.querySelector('[data-dyn-daterange-value="start"]');
+            const end = element.querySelector('[data-dyn-daterange-value="end"]');
+            return { min: (start && start.value) || null, max: (end && end.value) || null };
+        }
         switch (element.type) {
             case 'checkbox':
             case 'radio':
@@ -397,6 +426,7 @@ class StatesManager_%s {
         this.component = component;
         this.states = component.config.states || [];
         this.themeClasses = component.config.themeClasses || {};
+        this.stateClasses = (component.config.options || {}).stateClasses || [];
         this.currentState = null;
         this.stateElements = new Map();
         this.triggers = new Map();
@@ -408,6 +438,7 @@ class StatesManager_%s {
         this.findStateElements();
         this.findStateTriggers();
         this.setInitialState();
+        this.bindKeyboard();
     }
     
     findStateElements() {
@@ -427,6 +458,34 @@ class StatesManager_%s {
         });
     }
     
+    // Keyboard navigation for the tab bar: Left and Right move to the
+    // previous and next enabled tab, wrapping around, and Home and End to the
+    // first and last. The focused tab is activated, and only the active tab
+    // is in the tab order (roving tabindex), so Tab moves on to its panel.
+    bindKeyboard() {
+        this.component.container.addEventListener('keydown', (event) => {
+            const trigger = event.target.closest('[role="tab"][data-state-target]');
+            if (!trigger) return;
+            const tabs = this.states
+                .filter(state => !state.disabled)
+                .map(state => this.triggers.get(state.id))
+                .filter(tab => tab);
+            const index = tabs.indexOf(trigger);
+            if (index < 0) return;
+            let next;
+            switch (event.key) {
+                case 'ArrowRight': next = tabs[(index + 1) %% tabs.length]; break;
+                case 'ArrowLeft': next = tabs[(index - 1 + tabs.length) %% tabs.length]; break;
+                case 'Home': next = tabs[0]; break;
+                case 'End': next = tabs[tabs.length - 1]; break;
+                default: return;
+            }
+            event.preventDefault();
+            next.focus();
+            this.component.switchToState(next.dataset.stateTarget);
+        });
+    }
+    
     setInitialState() {
         const activeState = this.states.find(state => state.active);
         if (activeState) {
@@ -490,7 +549,10 @@ class StatesManager_%s {
             // Add active classes to trigger
             this.addClasses(trigger, this.themeClasses.triggerActive);
             trigger.setAttribute('aria-selected', 'true');
+            trigger.tabIndex = 0;
         }
+        
+        this.toggleStateClasses(stateId, true);
     }
     
     hideState(stateId) {
@@ -508,7 +570,21 @@ class StatesManager_%s {
             // Remove active classes from trigger
             this.removeClasses(trigger, this.themeClasses.triggerActive);
             trigger.setAttribute('aria-selected', 'false');
+            trigger.tabIndex = -1;
         }
+        
+        this.toggleStateClasses(stateId, false);
+    }
+    
+    // Apply or revert the OnStateClass toggles registered for a state
+    toggleStateClasses(stateId, active) {
+        this.stateClasses.forEach(toggle => {
+            if (toggle.state !== stateId) return;
+            document.querySelectorAll(toggle.selector).forEach(el => {
+                if (active) this.addClasses(el, toggle.class);
+                else this.removeClasses(el, toggle.class);
+            });
+        });
     }
     
     // Helper to add multiple classes (space-separated string)
@@ -570,10 +646,12 @@ class DataManager_%s {
         this.filters = new Map();
         this.currentPage = 1;
         this.itemsPerPage = this.filterOptions.itemsPerPage || 10;
+        this.loadMode = this.resolveLoadMode();
+        this.observer = null;
         
         // Server-rendered mode uses pre-rendered DOM elements
         this.serverRendered = this.filterOptions.serverRendered || false;
-        this.rowSelector = this.filterOptions.rowSelector || '.dyn-data-row';
+        this.rowSelector = this.filterOptions.rowSelector || '.' + component.cls('data-row');
         this.counterSelector = this.filterOptions.counterSelector || '';
         
         if (this.serverRendered) {
@@ -586,12 +664,35 @@ class DataManager_%s {
             this.rows = null;
         }
         
+        // With an endpoint the server does the filtering: the active filters
+        // are POSTed to it and the results replaced with the HTML it returns
+        this.endpoint = this.filterOptions.endpoint || '';
+        this.fetchTimer = null;
+        this.fetchController = null;
+        
+        // Sort keys in order, each { field, dir }; see bindSortEvents
+        this.sortKeys = [];
+        
         this.init();
     }
     
+    // resolveLoadMode picks pages, infinite or loadmore. Infinite scrolling
+    // falls back to pages where IntersectionObserver is unavailable.
+    resolveLoadMode() {
+        let mode = this.filterOptions.loadMode || (this.filterOptions.enablePagination ? 'pages' : '');
+        if (mode === 'infinite' && !('IntersectionObserver' in window)) mode = 'pages';
+        return mode;
+    }
+    
+    isIncremental() {
+        return this.loadMode === 'infinite' || this.loadMode === 'loadmore';
+    }
+    
     init() {
         this.setupFilters();
-        if (this.serverRendered) {
+        if (this.endpoint) {
+            this.fetchResults();
+        } else if (this.serverRendered) {
             this.applyServerFilters();
         } else {
             this.renderResults();
@@ -616,6 +717,7 @@ class DataManager_%s {
             case 'text': return '';
             case 'boolean': return false;
             case 'range': return { min: null, max: null };
+            case 'daterange': return { min: null, max: null };
             case 'multiselect': return [];
             case 'select': return '';
             default: return null;
@@ -626,6 +728,11 @@ class DataManager_%s {
         this.component.on('filter:change', (event) => {
             this.updateFilter(event.detail.field, event.detail.value);
         });
+        this.bindSortEvents();
+        const exportButton = document.getElementById(this.component.id + '-export');
+        if (exportButton) {
+            exportButton.addEventListener('click', () => this.exportCSV());
+        }
     }
     
     updateFilter(field, value, notify = true) {
@@ -634,6 +741,11 @@ class DataManager_%s {
             filter.value = value;
             filter.active = this.isFilterValueActive(filter.type, value);
             
+            if (this.endpoint) {
+                // data:filtered fires when the new results have arrived
+                this.scheduleFetch();
+                return;
+            }
             if (this.serverRendered) {
                 this.applyServerFilters();
             } else {
@@ -653,6 +765,58 @@ class DataManager_%s {
         }
     }
     
+    // scheduleFetch requests new results once the filters have been left
+    // alone for the debounce delay
+    scheduleFetch() {
+        clearTimeout(this.fetchTimer);
+        this.fetchTimer = setTimeout(() => this.fetchResults(), this.filterOptions.debounce || 300);
+    }
+    
+    // fetchResults POSTs the active filters and the sort keys to the endpoint
+    // as {"filters": {...}, "sort": [...]} and swaps the results container's content with the
+    // HTML response. A newer request aborts the one in flight, so older
+    // results never overwrite newer ones. The X-Result-Count response header,
+    // when present, updates the summary and the resultCount of data:filtered.
+    fetchResults() {
+        clearTimeout(this.fetchTimer);
+        const resultsContainer = document.getElementById(this.component.id + '-results');
+        if (!resultsContainer) return;
+        if (this.fetchController) this.fetchController.abort();
+        const controller = this.fetchController = new AbortController();
+        const filters = {};
+        this.filters.forEach((filter, field) => {
+            if (filter.active) filters[field] = filter.value;
+        });
+        resultsContainer.setAttribute('aria-busy', 'true');
+        fetch(this.endpoint, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json', 'X-Dyn-Filter': this.component.id },
+            body: JSON.stringify({ filters: filters, sort: this.sortKeys }),
+            signal: controller.signal
+        }).then(response => {
+            if (!response.ok) throw new Error('Filter request failed: HTTP ' + response.status);
+            const count = response.headers.get('X-Result-Count');
+            return response.text().then(html => ({ html: html, count: count === null ? null : Number(count) }));
+        }).then(result => {
+            resultsContainer.innerHTML = result.html;
+            if (window.htmx) window.htmx.process(resultsContainer);
+            this.visibleCount = result.count;
+            const summaryContainer = document.getElementById(this.component.id + '-summary');
+            if (summaryContainer && result.count !== null) {
+                summaryContainer.textContent = result.count + ' results';
+            }
+            if (result.count !== null) this.updateCounter(result.count);
+            this.component.trigger('data:filtered', { filters: filters, resultCount: result.count });
+        }).catch(error => {
+            if (error.name === 'AbortError') return;
+            this.component.trigger('data:error', { error: error.message });
+        }).finally(() => {
+            if (this.fetchController !== controller) return;
+            this.fetchController = null;
+            resultsContainer.removeAttribute('aria-busy');
+        });
+    }
+    
     // Server-rendered filtering: show/hide existing DOM elements
     applyServerFilters() {
         if (!this.rows) return;
@@ -672,17 +836,20 @@ class DataManager_%s {
         for (const [field, filter] of this.filters.entries()) {
             if (!filter.active) continue;
             
-            // Get value from data attribute (data-fieldname or data-field-name)
-            const attrName = field.replace(/([A-Z])/g, '-$1').toLowerCase();
-            const rowValue = row.dataset[field] || row.dataset[attrName] || '';
-            
-            if (!this.valueMatchesFilter(rowValue, filter)) {
+            if (!this.valueMatchesFilter(this.rowValue(row, field), filter)) {
                 return false;
             }
         }
         return true;
     }
     
+    // rowValue reads a field from a row's data attribute (data-fieldname or
+    // data-field-name)
+    rowValue(row, field) {
+        const attrName = field.replace(/([A-Z])/g, '-$1').toLowerCase();
+        return row.dataset[field] || row.dataset[attrName] || '';
+    }
+    
     valueMatchesFilter(rowValue, filter) {
         switch (filter.type) {
             case 'text':
@@ -698,11 +865,21 @@ class DataManager_%s {
                 const min = filter.value.min != null ? Number(filter.value.min) : -Infinity;
                 const max = filter.value.max != null ? Number(filter.value.max) : Infinity;
                 return num >= min && num <= max;
+            case 'daterange':
+                return this.dateInRange(rowValue, filter.value);
             default:
                 return rowValue === filter.value;
         }
     }
     
+    // dateInRange compares ISO dates or timestamps by day, inclusive
+    dateInRange(value, range) {
+        const day = String(value == null ? '' : value).slice(0, 10);
+        if (!day) return false;
+        if (range.min && day < String(range.min).slice(0, 10)) return false;
+        return !range.max || day <= String(range.max).slice(0, 10);
+    }
+    
     updateCounter(count) {
         if (this.counterSelector) {
             const counter = document.querySelector(this.counterSelector);
@@ -717,6 +894,7 @@ class DataManager_%s {
             case 'text': return value && value.length > 0;
             case 'boolean': return value === true;
             case 'range': return value.min != null || value.max != null;
+            case 'daterange': return !!(value && (value.min || value.max));
             case 'multiselect': return Array.isArray(value) && value.length > 0;
             case 'select': return value && value !== '';
             default: return value != null;
@@ -731,6 +909,134 @@ class DataManager_%s {
                 return this.matchesFilter(item, field, filter);
             });
         });
+        this.filteredData = this.sortItems(this.filteredData, (item, field) => item[field]);
+    }
+    
+    // Sorting: clicking an element with data-sort-field, such as the
+    // generated sort buttons or a table header, sorts by that field, and
+    // clicking it again reverses the order. Shift-click adds the field as a
+    // further key, ordering rows the earlier keys leave tied. Elements
+    // outside the component need data-sort-target set to its id.
+    bindSortEvents() {
+        document.addEventListener('click', (event) => {
+            const control = event.target.closest('[data-sort-field]');
+            if (!control || !this.ownsSortControl(control)) return;
+            event.preventDefault();
+            this.toggleSort(control.dataset.sortField, event.shiftKey);
+        });
+    }
+    
+    ownsSortControl(el) {
+        if (el.dataset.sortTarget) return el.dataset.sortTarget === this.component.id;
+        return this.component.container.contains(el);
+    }
+    
+    toggleSort(field, extend) {
+        const index = this.sortKeys.findIndex(key => key.field === field);
+        if (extend) {
+            if (index >= 0) {
+                const key = this.sortKeys[index];
+                key.dir = key.dir === 'asc' ? 'desc' : 'asc';
+            } else {
+                this.sortKeys.push({ field: field, dir: 'asc' });
+            }
+        } else {
+            const dir = index === 0 && this.sortKeys[0].dir === 'asc' ? 'desc' : 'asc';
+            this.sortKeys = [{ field: field, dir: dir }];
+        }
+        this.applySort();
+    }
+    
+    // applySort reorders the results: the endpoint sorts them on the server,
+    // server-rendered rows are moved in the DOM, and client data is sorted
+    // and shown from the first page
+    applySort() {
+        this.updateSortIndicators();
+        if (this.endpoint) {
+            this.fetchResults();
+        } else if (this.serverRendered) {
+            this.sortRows();
+        } else {
+            this.filteredData = this.sortItems(this.filteredData, (item, field) => item[field]);
+            this.currentPage = 1;
+            this.renderResults();
+        }
+        this.component.trigger('data:sorted', { sort: this.sortKeys.map(key => ({ field: key.field, dir: key.dir })) });
+    }
+    
+    // updateSortIndicators marks the sort controls with the theme's
+    // direction classes and aria-sort (table headers) or aria-pressed, and
+    // the component with data-sort and data-dir
+    updateSortIndicators() {
+        const themeClasses = this.component.config.themeClasses || {};
+        const ascClass = themeClasses.sortAsc || this.component.cls('sort-asc');
+        const descClass = themeClasses.sortDesc || this.component.cls('sort-desc');
+        const split = classes => classes.split(' ').filter(c => c);
+        this.component.container.dataset.sort = this.sortKeys.map(key => key.field).join(',');
+        this.component.container.dataset.dir = this.sortKeys.map(key => key.dir).join(',');
+        document.querySelectorAll('[data-sort-field]').forEach(el => {
+            if (!this.ownsSortControl(el)) return;
+            const key = this.sortKeys.find(k => k.field === el.dataset.sortField);
+            el.classList.remove(...split(ascClass), ...split(descClass));
+            if (key) el.classList.add(...split(key.dir === 'asc' ? ascClass : descClass));
+            if (el.tagName === 'TH' || el.getAttribute('role') === 'columnheader') {
+                el.setAttribute('aria-sort', key ? (key.dir === 'asc' ? 'ascending' : 'descending') : 'none');
+            } else {
+                el.setAttribute('aria-pressed', key ? 'true' : 'false');
+            }
+        });
+    }
+    
+    // sortRows moves server-rendered rows into sorted order within their
+    // parents, hidden ones included
+    sortRows() {
+        if (!this.rows) return;
+        this.rows = this.sortItems(Array.from(this.rows), (row, field) => this.rowValue(row, field));
+        this.rows.forEach(row => row.parentNode.appendChild(row));
+    }
+    
+    // sortItems returns items stably sorted by the sort keys, using the
+    // field types in the schema
+    sortItems(items, valueOf) {
+        if (this.sortKeys.length === 0) return items;
+        const types = {};
+        (this.schema.fields || []).forEach(field => { types[field.name] = field.type; });
+        return items
+            .map((item, index) => ({ item: item, index: index }))
+            .sort((a, b) => {
+                for (const key of this.sortKeys) {
+                    const order = this.compareValues(valueOf(a.item, key.field), valueOf(b.item, key.field), types[key.field], key.dir === 'desc');
+                    if (order !== 0) return order;
+                }
+                return a.index - b.index;
+            })
+            .map(entry => entry.item);
+    }
+    
+    // compareValues orders two values of a field: range fields as numbers,
+    // boolean fields false before true, others as text ignoring case. Empty
+    // values come last in either direction.
+    compareValues(a, b, type, desc) {
+        const x = this.sortValue(a, type);
+        const y = this.sortValue(b, type);
+        if (x === null || y === null) {
+            return x === y ? 0 : (x === null ? 1 : -1);
+        }
+        const order = x < y ? -1 : (x > y ? 1 : 0);
+        return desc ? -order : order;
+    }
+    
+    sortValue(value, type) {
+        if (value == null || value === '') return null;
+        switch (type) {
+            case 'range':
+                const num = Number(value);
+                return isNaN(num) ? null : num;
+            case 'boolean':
+                return value === true || value === 'true' || value === '1' ? 1 : 0;
+            default:
+                return String(value).toLowerCase();
+        }
     }
     
     matchesFilter(item, field, filter) {
@@ -746,6 +1052,8 @@ class DataManager_%s {
                 const min = filter.value.min != null ? Number(filter.value.min) : -Infinity;
                 const max = filter.value.max != null ? Number(filter.value.max) : Infinity;
                 return num >= min && num <= max;
+            case 'daterange':
+                return this.dateInRange(itemValue, filter.value);
             case 'multiselect':
                 return filter.value.includes(itemValue);
             case 'select':
@@ -768,25 +1076,162 @@ class DataManager_%s {
         
         // Empty state
         if (this.filteredData.length === 0) {
-            resultsContainer.innerHTML = '<div class="dyn-no-results text-gray-500 dark:text-gray-400 text-center py-8">No results found</div>';
+            resultsContainer.innerHTML = '<div class="' + this.component.cls('no-results') + ' text-gray-500 dark:text-gray-400 text-center py-8">No results found</div>';
             return;
         }
         
-        // Paginate if needed
+        // Paginate if needed; incremental modes show every page loaded so far
         let displayData = this.filteredData;
-        if (this.filterOptions.enablePagination) {
+        if (this.loadMode === 'pages') {
             const start = (this.currentPage - 1) * this.itemsPerPage;
             const end = start + this.itemsPerPage;
             displayData = this.filteredData.slice(start, end);
+        } else if (this.isIncremental()) {
+            displayData = this.filteredData.slice(0, this.currentPage * this.itemsPerPage);
         }
         
         // Render items - uses template from server or default
-        resultsContainer.innerHTML = displayData.map(item => this.renderItem(item)).join('');
+        resultsContainer.replaceChildren(this.renderItems(displayData));
         
         // Update pagination
-        if (this.filterOptions.enablePagination) {
+        if (this.loadMode === 'pages') {
             this.renderPagination();
+        } else if (this.isIncremental()) {
+            this.renderLoadMore();
+        }
+    }
+    
+    // renderLoadMore renders the "Load more" button and, in infinite mode, a
+    // sentinel that loads the next page when it scrolls into view. The button
+    // stays in infinite mode too, so keyboard users can reach every result.
+    renderLoadMore() {
+        const container = document.getElementById(this.component.id + '-pagination');
+        if (this.observer) {
+            this.observer.disconnect();
+            this.observer = null;
+        }
+        if (!container) return;
+        
+        const total = this.filteredData.length;
+        const shown = Math.min(this.currentPage * this.itemsPerPage, total);
+        if (shown >= total) {
+            container.innerHTML = '';
+            return;
         }
+        
+        const themeClasses = this.component.config.themeClasses || {};
+        const btnClass = themeClasses.paginationButton || this.component.cls('page-btn');
+        const sentinelClass = this.component.cls('load-sentinel');
+        let html = '';
+        if (this.loadMode === 'infinite') {
+            html += '<div class="' + sentinelClass + '" aria-hidden="true"></div>';
+        }
+        html += '<button type="button" class="' + btnClass + '" data-load-more>Load more (' + shown + ' of ' + total + ')</button>';
+        container.innerHTML = html;
+        
+        container.querySelector('button[data-load-more]').addEventListener('click', () => this.loadNextPage(true));
+        if (this.loadMode === 'infinite') {
+            this.observer = new IntersectionObserver(entries => {
+                if (entries.some(entry => entry.isIntersecting)) this.loadNextPage(false);
+            }, { rootMargin: '200px' });
+            this.observer.observe(container.querySelector('.' + sentinelClass));
+        }
+    }
+    
+    // loadNextPage appends the next page of filtered results without
+    // re-rendering the ones already shown, and announces how many arrived.
+    // When the user asked for it (the button), focus moves to the first new
+    // result so keyboard users continue from where the list grew.
+    loadNextPage(moveFocus) {
+        const resultsContainer = document.getElementById(this.component.id + '-results');
+        const start = this.currentPage * this.itemsPerPage;
+        if (!resultsContainer || start >= this.filteredData.length) return;
+        
+        const next = this.filteredData.slice(start, start + this.itemsPerPage);
+        this.currentPage++;
+        
+        const content = this.renderItems(next);
+        const first = content.firstElementChild;
+        resultsContainer.appendChild(content);
+        
+        const shown = Math.min(this.currentPage * this.itemsPerPage, this.filteredData.length);
+        this.announce(next.length + ' more results loaded, showing ' + shown + ' of ' + this.filteredData.length);
+        if (moveFocus && first) {
+            if (!first.hasAttribute('tabindex')) first.setAttribute('tabindex', '-1');
+            first.focus();
+        }
+        
+        this.renderLoadMore();
+        this.component.trigger('data:loaded', {
+            page: this.currentPage,
+            added: next.length,
+            shown: shown,
+            total: this.filteredData.length
+        });
+    }
+    
+    announce(message) {
+        const status = document.getElementById(this.component.id + '-status');
+        if (status) status.textContent = message;
+    }
+    
+    // renderItems returns a fragment with one rendered element per item,
+    // cloned from the component's <template> when the server provided one
+    renderItems(items) {
+        const template = document.getElementById(this.component.id + '-item-template');
+        if (template && this.component.container.dataset.viewMode !== 'json') {
+            const fragment = document.createDocumentFragment();
+            items.forEach(item => fragment.appendChild(this.bindTemplate(template.content.cloneNode(true), item)));
+            return fragment;
+        }
+        const holder = document.createElement('template');
+        holder.innerHTML = items.map(item => this.renderItem(item)).join('');
+        return holder.content;
+    }
+    
+    // bindTemplate fills a cloned template with item's values. Elements
+    // marked data-each repeat once per entry of an array field and bind
+    // relative to that entry; data-bind sets text and data-bind-<attr> sets
+    // an attribute, both from dotted paths ("customer.name", or "." for the
+    // entry itself). Values are assigned as text and attribute values, never
+    // parsed as HTML.
+    bindTemplate(root, item) {
+        root.querySelectorAll('[data-each]').forEach(el => {
+            // Nested lists are bound by their parent's copies
+            if (el.parentElement && el.parentElement.closest('[data-each]')) return;
+            const list = this.lookupPath(item, el.getAttribute('data-each'));
+            el.removeAttribute('data-each');
+            (Array.isArray(list) ? list : []).forEach(entry => {
+                el.parentNode.insertBefore(this.bindTemplate(el.cloneNode(true), entry), el);
+            });
+            el.remove();
+        });
+        const elements = Array.from(root.querySelectorAll('*'));
+        if (root.nodeType === Node.ELEMENT_NODE) elements.unshift(root);
+        elements.forEach(el => {
+            Array.from(el.attributes).forEach(attr => {
+                if (attr.name === 'data-bind') {
+                    const value = this.lookupPath(item, attr.value);
+                    el.textContent = value == null ? '' : String(value);
+                } else if (attr.name.startsWith('data-bind-')) {
+                    const name = attr.name.slice(10);
+                    const value = this.lookupPath(item, attr.value);
+                    if (value == null || value === false) {
+                        el.removeAttribute(name);
+                    } else if (/^(href|src|action|formaction)$/.test(name) && /^\s*javascript:/i.test(String(value))) {
+                        el.removeAttribute(name);
+                    } else {
+                        el.setAttribute(name, value === true ? '' : String(value));
+                    }
+                }
+            });
+        });
+        return root;
+    }
+    
+    lookupPath(item, path) {
+        if (path === '.' || path === '') return item;
+        return path.split('.').reduce((value, key) => value == null ? undefined : value[key], item);
     }
     
     renderItem(item) {
@@ -804,7 +1249,7 @@ class DataManager_%s {
             return result;
         }
         // Default rendering - JSON dump
-        return '<div class="dyn-result-item">' + JSON.stringify(item) + '</div>';
+        return '<div class="' + this.component.cls('result-item') + '">' + JSON.stringify(item) + '</div>';
     }
     
     renderPagination() {
@@ -813,7 +1258,7 @@ class DataManager_%s {
         
         const totalPages = Math.ceil(this.filteredData.length / this.itemsPerPage);
         const themeClasses = this.component.config.themeClasses || {};
-        const btnClass = themeClasses.paginationButton || 'dyn-page-btn';
+        const btnClass = themeClasses.paginationButton || this.component.cls('page-btn');
         const activeClass = themeClasses.paginationButtonActive || 'active';
         let html = '';
         
@@ -833,6 +1278,64 @@ class DataManager_%s {
         });
     }
     
+    // exportCSV downloads the results matching the filters, on every page,
+    // in their current order: client data, the visible server-rendered rows,
+    // or the rows an endpoint returned, read from their data attributes.
+    // Columns follow the schema's field order.
+    exportCSV() {
+        let items, valueOf;
+        if (this.endpoint) {
+            const resultsContainer = document.getElementById(this.component.id + '-results');
+            items = resultsContainer ? Array.from(resultsContainer.querySelectorAll(this.rowSelector)) : [];
+            valueOf = (row, field) => this.rowValue(row, field);
+        } else if (this.serverRendered) {
+            items = this.getData();
+            valueOf = (row, field) => this.rowValue(row, field);
+        } else {
+            items = this.filteredData;
+            valueOf = (item, field) => item[field];
+        }
+        
+        let columns = (this.schema.fields || []).map(field => ({ name: field.name, label: field.label || field.name }));
+        if (columns.length === 0 && items.length > 0) {
+            const first = (this.endpoint || this.serverRendered) ? items[0].dataset : items[0];
+            columns = Object.keys(first).map(name => ({ name: name, label: name }));
+        }
+        
+        const lines = [columns.map(column => this.csvCell(column.label)).join(',')];
+        items.forEach(item => {
+            lines.push(columns.map(column => this.csvCell(valueOf(item, column.name))).join(','));
+        });
+        const filename = this.filterOptions.exportFilename || 'export.csv';
+        // The byte order mark makes spreadsheet apps read the file as UTF-8
+        const blob = new Blob(['\uFEFF' + lines.join('\r\n') + '\r\n'], { type: 'text/csv;charset=utf-8' });
+        const url = URL.createObjectURL(blob);
+        const link = document.createElement('a');
+        link.href = url;
+        link.download = filename;
+        link.style.display = 'none';
+        document.body.appendChild(link);
+        link.click();
+        link.remove();
+        setTimeout(() => URL.revokeObjectURL(url), 0);
+        this.component.trigger('data:exported', { filename: filename, rowCount: items.length });
+    }
+    
+    // csvCell quotes a value when it holds a quote, comma or line break,
+    // doubling its quotes. Text that a spreadsheet would run as a formula
+    // is prefixed with an apostrophe.
+    csvCell(value) {
+        if (value == null) return '';
+        let text = Array.isArray(value) ? value.join(', ') : String(value);
+        if (/^[=+\-@\t\r]/.test(text) && isNaN(Number(text))) {
+            text = "'" + text;
+        }
+        if (/[",\r\n]/.test(text)) {
+            return '"' + text.replace(/"/g, '""') + '"';
+        }
+        return text;
+    }
+    
     getData() {
         if (this.serverRendered) {
             return Array.from(this.rows).filter(r => r.style.display !== 'none');
@@ -848,28 +1351,70 @@ class DataManager_%s {
     }
     
     getVisibleCount() {
-        if (this.serverRendered) {
+        if (this.serverRendered || this.endpoint) {
             return this.visibleCount;
         }
         return this.filteredData.length;
     }
     
+    activeFilterCount() {
+        let count = 0;
+        this.filters.forEach(filter => {
+            if (filter.active) count++;
+        });
+        return count;
+    }
+    
     clearFilters() {
         this.filters.forEach((filter, field) => {
             filter.active = false;
             filter.value = this.getDefaultFilterValue(filter.type);
         });
-        if (this.serverRendered) {
+        this.resetFilterControls();
+        if (this.endpoint) {
+            this.fetchResults();
+        } else if (this.serverRendered) {
             this.applyServerFilters();
         } else {
             this.applyFilters();
             this.currentPage = 1;
             this.renderResults();
         }
+        this.component.trigger('filters:cleared', { resultCount: this.getVisibleCount() });
+    }
+    
+    // resetFilterControls returns the generated filter inputs to their
+    // cleared state without firing change events
+    resetFilterControls() {
+        this.component.container.querySelectorAll('[data-filter-field]').forEach(el => {
+            switch (el.dataset.filterType) {
+                case 'boolean':
+                case 'multiselect':
+                    el.checked = false;
+                    break;
+                case 'range-min':
+                    el.value = el.min;
+                    break;
+                case 'range-max':
+                    el.value = el.max;
+                    break;
+                case 'daterange':
+                    el.querySelectorAll('input').forEach(input => {
+                        input.value = '';
+                        if (input.setCustomValidity) input.setCustomValidity('');
+                        input.removeAttribute('aria-invalid');
+                    });
+                    el.querySelectorAll('[aria-pressed]').forEach(btn => btn.setAttribute('aria-pressed', 'false'));
+                    el.querySelectorAll('[role="alert"]').forEach(error => { error.hidden = true; });
+                    break;
+                default:
+                    el.value = '';
+            }
+        });
     }
     
     setData(newData) {
-        if (this.serverRendered) {
+        if (this.serverRendered || this.endpoint) {
             console.warn('setData not supported in server-rendered mode');
             return;
         }
@@ -0,0 +1,266 @@
+package mintydyn
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// FILTER STATE SCHEMA AND VALIDATION
+// =============================================================================
+
+// jsonSchemaDialect is the JSON Schema version JSONSchema emits.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// isoDatePattern matches an empty string or a YYYY-MM-DD date.
+const isoDatePattern = `^$|^[0-9]{4}-[0-9]{2}-[0-9]{2}$`
+
+// JSONSchema returns a JSON Schema (draft 2020-12) describing the filter
+// state a component built from s sends, field by field, with the value
+// shapes listed on ApplyFilters. Unknown fields are not allowed; fields of
+// an unknown type accept any value. Use it to document the contract or to
+// validate filter state outside Go; ValidateFilterState applies the same
+// rules in Go.
+func (s FilterSchema) JSONSchema() ([]byte, error) {
+	properties := make(map[string]interface{}, len(s.Fields))
+	for _, field := range s.Fields {
+		if field.Name == "" {
+			return nil, fmt.Errorf("mintydyn: filter field without a name (%q)", field.Label)
+		}
+		if _, dup := properties[field.Name]; dup {
+			return nil, fmt.Errorf("mintydyn: duplicate filter field %q", field.Name)
+		}
+		property := fieldJSONSchema(field)
+		if field.Label != "" {
+			property["title"] = field.Label
+		}
+		if field.DefaultValue != nil {
+			property["default"] = field.DefaultValue
+		}
+		properties[field.Name] = property
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"$schema":              jsonSchemaDialect,
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}, "", "  ")
+}
+
+// fieldJSONSchema describes the value shape of one filter field.
+func fieldJSONSchema(field FilterableField) map[string]interface{} {
+	switch field.Type {
+	case "text":
+		return map[string]interface{}{"type": "string"}
+	case "boolean":
+		return map[string]interface{}{"type": "boolean"}
+	case "select":
+		if len(field.Options) == 0 {
+			return map[string]interface{}{"type": []string{"string", "number", "boolean"}}
+		}
+		return map[string]interface{}{"enum": append([]string{""}, field.Options...)}
+	case "multiselect":
+		items := map[string]interface{}{"type": []string{"string", "number", "boolean"}}
+		if len(field.Options) > 0 {
+			items = map[string]interface{}{"enum": field.Options}
+		}
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": items}
+	case "range":
+		bound := map[string]interface{}{"type": []string{"number", "null"}}
+		if field.Range != nil {
+			bound["minimum"] = field.Range.Min
+			bound["maximum"] = field.Range.Max
+		}
+		return boundsJSONSchema(bound)
+	case "daterange":
+		return boundsJSONSchema(map[string]interface{}{
+			"type":    []string{"string", "null"},
+			"pattern": isoDatePattern,
+		})
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// boundsJSONSchema describes a {"min", "max"} object with the given bound.
+func boundsJSONSchema(bound map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           map[string]interface{}{"min": bound, "max": bound},
+		"additionalProperties": false,
+	}
+}
+
+// Filter state problems reported by ValidateFilterState. Each is wrapped
+// with the field and value, so test with errors.Is.
+var (
+	ErrUnknownFilterField    = errors.New("unknown filter field")
+	ErrInvalidFilterValue    = errors.New("invalid filter value")
+	ErrFilterValueOutOfRange = errors.New("filter value out of range")
+)
+
+// ValidateFilterState checks filter state posted by a client against
+// schema: every field must be in the schema and every value must have the
+// shape ApplyFilters expects, with select and multiselect values among the
+// field's options, range bounds within its Range, dates as YYYY-MM-DD, and
+// min no greater than max. State decoded from JSON and Go values (ints,
+// []string, time.Time) are both accepted. The result is nil or every
+// problem joined with errors.Join.
+//
+//	if err := mdy.ValidateFilterState(schema, state); err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	    return
+//	}
+func ValidateFilterState(schema FilterSchema, state map[string]interface{}) error {
+	fields := make(map[string]FilterableField, len(schema.Fields))
+	for _, field := range schema.Fields {
+		fields[field.Name] = field
+	}
+
+	var problems []error
+	for name, value := range state {
+		field, ok := fields[name]
+		if !ok {
+			problems = append(problems, fmt.Errorf("%w: %q", ErrUnknownFilterField, name))
+			continue
+		}
+		if err := validateFilterValue(field, value); err != nil {
+			problems = append(problems, fmt.Errorf("field %q: %w", name, err))
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// validateFilterValue checks one value against its field.
+func validateFilterValue(field FilterableField, value interface{}) error {
+	invalid := func(want string) error {
+		return fmt.Errorf("%w: %s %v (%T), want %s", ErrInvalidFilterValue, field.Type, value, value, want)
+	}
+
+	switch field.Type {
+	case "text":
+		if _, ok := value.(string); !ok {
+			return invalid("a string")
+		}
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return invalid("a boolean")
+		}
+
+	case "select":
+		if s, ok := value.(string); ok && s == "" {
+			return nil
+		}
+		if !isFilterScalar(value) {
+			return invalid("a string, number or boolean")
+		}
+		if len(field.Options) > 0 && !isFilterOption(field, value) {
+			return fmt.Errorf("%w: %v is not an option", ErrInvalidFilterValue, value)
+		}
+
+	case "multiselect":
+		switch value.(type) {
+		case nil, []interface{}, []string:
+		default:
+			return invalid("an array")
+		}
+		for _, v := range toSlice(value) {
+			if !isFilterScalar(v) {
+				return invalid("an array of strings, numbers or booleans")
+			}
+			if len(field.Options) > 0 && !isFilterOption(field, v) {
+				return fmt.Errorf("%w: %v is not an option", ErrInvalidFilterValue, v)
+			}
+		}
+
+	case "range":
+		bounds, err := filterBounds(value)
+		if err != nil {
+			return invalid(err.Error())
+		}
+		var numbers [2]float64
+		for i, bound := range bounds {
+			if bound == nil {
+				continue
+			}
+			n, ok := toFloat(bound)
+			if !ok {
+				return invalid("numeric min and max")
+			}
+			if field.Range != nil && (n < field.Range.Min || n > field.Range.Max) {
+				return fmt.Errorf("%w: %v outside %v to %v", ErrFilterValueOutOfRange, n, field.Range.Min, field.Range.Max)
+			}
+			numbers[i] = n
+		}
+		if bounds[0] != nil && bounds[1] != nil && numbers[0] > numbers[1] {
+			return fmt.Errorf("%w: min %v above max %v", ErrFilterValueOutOfRange, numbers[0], numbers[1])
+		}
+
+	case "daterange":
+		bounds, err := filterBounds(value)
+		if err != nil {
+			return invalid(err.Error())
+		}
+		var days [2]string
+		for i, bound := range bounds {
+			switch v := bound.(type) {
+			case nil, time.Time, *time.Time:
+			case string:
+				if v == "" {
+					break
+				}
+				if _, err := time.Parse("2006-01-02", v); err != nil {
+					return invalid("dates as YYYY-MM-DD")
+				}
+			default:
+				return invalid("dates as YYYY-MM-DD")
+			}
+			days[i] = isoDay(bound)
+		}
+		if days[0] != "" && days[1] != "" && days[0] > days[1] {
+			return fmt.Errorf("%w: start %s after end %s", ErrFilterValueOutOfRange, days[0], days[1])
+		}
+	}
+	return nil
+}
+
+// filterBounds returns the min and max of a range or date range value.
+func filterBounds(value interface{}) ([2]interface{}, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return [2]interface{}{}, errors.New("an object with min and max")
+	}
+	for key := range m {
+		if key != "min" && key != "max" {
+			return [2]interface{}{}, fmt.Errorf("only min and max, not %q", key)
+		}
+	}
+	return [2]interface{}{m["min"], m["max"]}, nil
+}
+
+// isFilterScalar reports whether value is a string, bool or number.
+func isFilterScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, bool:
+		return true
+	}
+	_, ok := toFloat(value)
+	return ok
+}
+
+// isFilterOption reports whether value is one of the field's options.
+func isFilterOption(field FilterableField, value interface{}) bool {
+	text, ok := value.(string)
+	if !ok {
+		return false
+	}
+	for _, option := range field.Options {
+		if option == text {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package mintydyn
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFilterSchemaJSONSchema(t *testing.T) {
+	schema := FilterSchema{Fields: []FilterableField{
+		TextField("name", "Name"),
+		SelectField("status", "Status", []string{"open", "closed"}),
+		MultiSelectField("tags", "Tags", []string{"a", "b"}),
+		BoolField("urgent", "Urgent"),
+		RangeField("amount", "Amount", 0, 1000, 10),
+		DateRangeField("filed", "Filed"),
+	}}
+	out, err := schema.JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Schema     string                            `json:"$schema"`
+		Additional bool                              `json:"additionalProperties"`
+		Properties map[string]map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if doc.Schema != jsonSchemaDialect || doc.Additional || len(doc.Properties) != 6 {
+		t.Fatalf("unexpected schema: %s", out)
+	}
+	if doc.Properties["name"]["type"] != "string" || doc.Properties["name"]["title"] != "Name" {
+		t.Errorf("text field: %v", doc.Properties["name"])
+	}
+	if enum, _ := doc.Properties["status"]["enum"].([]interface{}); len(enum) != 3 || enum[0] != "" {
+		t.Errorf("select field: %v", doc.Properties["status"])
+	}
+	if !strings.Contains(string(out), `"maximum": 1000`) || !strings.Contains(string(out), `"pattern"`) {
+		t.Errorf("range or daterange bounds missing: %s", out)
+	}
+
+	dup := FilterSchema{Fields: []FilterableField{TextField("a", "A"), TextField("a", "A")}}
+	if _, err := dup.JSONSchema(); err == nil {
+		t.Error("duplicate field accepted")
+	}
+}
+
+func TestValidateFilterState(t *testing.T) {
+	schema := FilterSchema{Fields: []FilterableField{
+		TextField("name", "Name"),
+		SelectField("status", "Status", []string{"open", "closed"}),
+		MultiSelectField("tags", "Tags", []string{"a", "b"}),
+		BoolField("urgent", "Urgent"),
+		RangeField("amount", "Amount", 0, 1000, 10),
+		DateRangeField("filed", "Filed"),
+	}}
+
+	valid := map[string]interface{}{
+		"name":   "smith",
+		"status": "",
+		"tags":   []interface{}{"a"},
+		"urgent": true,
+		"amount": map[string]interface{}{"min": float64(10), "max": nil},
+		"filed":  map[string]interface{}{"min": "2024-01-01", "max": ""},
+	}
+	if err := ValidateFilterState(schema, valid); err != nil {
+		t.Errorf("valid state rejected: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		state map[string]interface{}
+		want  error
+	}{
+		{"unknown field", map[string]interface{}{"owner": "x"}, ErrUnknownFilterField},
+		{"text type", map[string]interface{}{"name": 3}, ErrInvalidFilterValue},
+		{"select option", map[string]interface{}{"status": "pending"}, ErrInvalidFilterValue},
+		{"multiselect option", map[string]interface{}{"tags": []string{"c"}}, ErrInvalidFilterValue},
+		{"boolean type", map[string]interface{}{"urgent": "yes"}, ErrInvalidFilterValue},
+		{"range bound", map[string]interface{}{"amount": map[string]interface{}{"max": 5000}}, ErrFilterValueOutOfRange},
+		{"range order", map[string]interface{}{"amount": map[string]interface{}{"min": 500, "max": 100}}, ErrFilterValueOutOfRange},
+		{"range key", map[string]interface{}{"amount": map[string]interface{}{"from": 1}}, ErrInvalidFilterValue},
+		{"date format", map[string]interface{}{"filed": map[string]interface{}{"min": "01/02/2024"}}, ErrInvalidFilterValue},
+		{"date order", map[string]interface{}{"filed": map[string]interface{}{"min": "2024-02-01", "max": "2024-01-01"}}, ErrFilterValueOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateFilterState(schema, tt.state); !errors.Is(err, tt.want) {
+				t.Errorf("ValidateFilterState() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package mintydyn
+
+import (
+	"strconv"
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// KANBAN BOARD
+// =============================================================================
+
+// KanbanCard is one card on a Kanban board.
+type KanbanCard struct {
+	ID    string // Sent as cardId in kanban:move events
+	Title string
+	Body  mi.H   // Optional content below the title, e.g. amount and assignee
+	Class string // Extra classes, e.g. a priority color
+}
+
+// KanbanColumn is one column of a Kanban board, typically one status.
+type KanbanColumn struct {
+	ID      string   // Sent as from/to in kanban:move events, e.g. "shipped"
+	Title   string
+	Cards   []KanbanCard
+	Accepts []string // IDs of columns whose cards may move here; empty accepts all
+}
+
+// KanbanOptions configures a Kanban board.
+type KanbanOptions struct {
+	Class   string         // Extra classes for the board
+	Label   string         // Accessible name of the board, default "Board"
+	MoveURL string         // Posts card, from, to and index here on every move via htmx
+	Attrs   []mi.Attribute // Extra attributes for the board, e.g. hx-target
+}
+
+// KanbanMoveVals is the hx-vals value that sends a kanban:move event's
+// details as the card, from, to and index parameters. MoveURL uses it; set
+// it yourself to post moves with other htmx attributes.
+const KanbanMoveVals = `js:{card: event.detail.cardId, from: event.detail.from, to: event.detail.to, index: event.detail.index}`
+
+// Kanban renders a board of columns whose cards move between columns by
+// drag and drop or from the keyboard: Space or Enter picks up the focused
+// card, the arrow keys move it between and within columns, Space or Enter
+// drops it and Escape puts it back. Moves are announced to screen readers.
+//
+// Each move fires a bubbling kanban:move CustomEvent on the board with
+// detail {cardId, from, to, index}. Set MoveURL to post moves with htmx:
+//
+//	mdy.Kanban("claims", columns, mdy.KanbanOptions{MoveURL: "/claims/move"})
+//
+// Columns listing Accepts refuse cards from other columns, which mirrors a
+// status machine such as the domains' order transitions.
+func Kanban(id string, columns []KanbanColumn, opts KanbanOptions) mi.H {
+	if opts.Label == "" {
+		opts.Label = "Board"
+	}
+	return func(b *mi.Builder) mi.Node {
+		helpID := id + "-help"
+		board := []interface{}{
+			mi.ID(id),
+			mi.Class(strings.TrimSpace("dyn-kanban " + opts.Class)),
+			mi.Data("dyn-kanban", ""),
+			mi.Role("region"),
+			mi.AriaLabel(opts.Label),
+		}
+		if opts.MoveURL != "" {
+			board = append(board,
+				mi.HtmxPost(opts.MoveURL),
+				mi.HtmxTrigger("kanban:move"),
+				mi.HtmxVals(KanbanMoveVals),
+				mi.HtmxSwap("none"),
+			)
+		}
+		for _, attr := range opts.Attrs {
+			board = append(board, attr)
+		}
+
+		for _, column := range columns {
+			titleID := id + "-" + column.ID + "-title"
+			cards := []interface{}{mi.Class("dyn-kanban-cards"), mi.Role("list")}
+			for _, card := range column.Cards {
+				content := []interface{}{
+					mi.Class(strings.TrimSpace("dyn-kanban-card " + card.Class)),
+					mi.Data("dyn-kanban-card", card.ID),
+					mi.Role("listitem"),
+					mi.Attr("draggable", "true"),
+					mi.Attr("tabindex", "0"),
+					mi.Attr("aria-describedby", helpID),
+					b.Div(mi.Class("dyn-kanban-card-title"), card.Title),
+				}
+				if card.Body != nil {
+					content = append(content, b.Div(mi.Class("dyn-kanban-card-body"), card.Body(b)))
+				}
+				cards = append(cards, b.Li(content...))
+			}
+
+			section := []interface{}{
+				mi.Class("dyn-kanban-column"),
+				mi.Data("dyn-kanban-column", column.ID),
+				mi.Attr("aria-labelledby", titleID),
+			}
+			if len(column.Accepts) > 0 {
+				section = append(section, mi.Data("dyn-kanban-accepts", strings.Join(column.Accepts, " ")))
+			}
+			section = append(section,
+				b.H3(mi.ID(titleID), mi.Class("dyn-kanban-column-title"),
+					b.Span(mi.Data("dyn-kanban-name", ""), column.Title), " ",
+					b.Span(mi.Class("dyn-kanban-count"), mi.Data("dyn-kanban-count", ""),
+						strconv.Itoa(len(column.Cards))),
+				),
+				b.Ul(cards...),
+			)
+			board = append(board, b.Section(section...))
+		}
+
+		board = append(board,
+			b.P(mi.ID(helpID), mi.Class("dyn-sr-only"),
+				"Press Space to pick up the card, arrow keys to move it, Space to drop it and Escape to cancel."),
+			b.Div(mi.Class("dyn-sr-only"), mi.Data("dyn-kanban-live", ""), mi.Attr("aria-live", "assertive")),
+		)
+
+		return mi.NewFragment(
+			b.Div(board...),
+			b.Script(mi.Raw(kanbanJS)),
+		)
+	}
+}
+
+// kanbanJS installs delegated drag-and-drop and keyboard listeners once per
+// page.
+const kanbanJS = `(function(){
+if (window.DynKanban) return;
+window.DynKanban = true;
+function columnOf(card) { return card.closest('[data-dyn-kanban-column]'); }
+function columnName(column) {
+    var name = column.querySelector('[data-dyn-kanban-name]');
+    return name ? name.textContent : column.getAttribute('data-dyn-kanban-column');
+}
+function accepts(column, from) {
+    var list = column.getAttribute('data-dyn-kanban-accepts');
+    return column === from || !list || list.split(' ').indexOf(from.getAttribute('data-dyn-kanban-column')) >= 0;
+}
+function cardsOf(column) {
+    return Array.prototype.slice.call(column.querySelectorAll('[data-dyn-kanban-card]'));
+}
+// place inserts card into column before the given card, or last.
+function place(card, column, before) {
+    column.querySelector('.dyn-kanban-cards').insertBefore(card, before || null);
+}
+function counts(board) {
+    board.querySelectorAll('[data-dyn-kanban-column]').forEach(function(column) {
+        var count = column.querySelector('[data-dyn-kanban-count]');
+        if (count) count.textContent = String(cardsOf(column).length);
+    });
+}
+function announce(board, text) {
+    var live = board.querySelector('[data-dyn-kanban-live]');
+    if (live) live.textContent = text;
+}
+// moved reports a finished move when the card's column or position changed.
+function moved(card, fromColumn, fromIndex) {
+    var board = card.closest('[data-dyn-kanban]');
+    var column = columnOf(card);
+    var index = cardsOf(column).indexOf(card);
+    counts(board);
+    if (column === fromColumn && index === fromIndex) return;
+    board.dispatchEvent(new CustomEvent('kanban:move', { bubbles: true, detail: {
+        cardId: card.getAttribute('data-dyn-kanban-card'),
+        from: fromColumn.getAttribute('data-dyn-kanban-column'),
+        to: column.getAttribute('data-dyn-kanban-column'),
+        index: index
+    }}));
+}
+
+// Keyboard: pick up, move, drop, cancel
+var grabbed = null;
+function release(restore) {
+    var g = grabbed;
+    grabbed = null;
+    g.card.classList.remove('dyn-kanban-grabbed');
+    if (restore) {
+        var board = g.card.closest('[data-dyn-kanban]');
+        place(g.card, g.column, cardsOf(g.column).filter(function(c) { return c !== g.card; })[g.index]);
+        counts(board);
+    }
+    return g;
+}
+document.addEventListener('keydown', function(e) {
+    var card = e.target.matches && e.target.matches('[data-dyn-kanban-card]') ? e.target : null;
+    if (!card) return;
+    var board = card.closest('[data-dyn-kanban]');
+    if (grabbed && grabbed.card !== card) release(true);
+    var column = columnOf(card);
+    if (e.key === ' ' || e.key === 'Enter') {
+        e.preventDefault();
+        if (!grabbed) {
+            grabbed = { card: card, column: column, index: cardsOf(column).indexOf(card) };
+            card.classList.add('dyn-kanban-grabbed');
+            announce(board, 'Picked up ' + card.textContent.trim() + ' in ' + columnName(column) + '.');
+            return;
+        }
+        var g = release(false);
+        announce(board, 'Dropped in ' + columnName(column) + ', position ' + (cardsOf(column).indexOf(card) + 1) + '.');
+        moved(card, g.column, g.index);
+        return;
+    }
+    if (!grabbed) return;
+    if (e.key === 'Escape') {
+        e.preventDefault();
+        release(true);
+        card.focus();
+        announce(board, 'Move cancelled.');
+        return;
+    }
+    var cards = cardsOf(column);
+    var index = cards.indexOf(card);
+    if (e.key === 'ArrowUp' || e.key === 'ArrowDown') {
+        e.preventDefault();
+        if (e.key === 'ArrowUp' && index > 0) place(card, column, cards[index - 1]);
+        else if (e.key === 'ArrowDown' && index < cards.length - 1) place(card, column, cards[index + 2]);
+        else return;
+    } else if (e.key === 'ArrowLeft' || e.key === 'ArrowRight') {
+        e.preventDefault();
+        var columns = Array.prototype.slice.call(board.querySelectorAll('[data-dyn-kanban-column]'));
+        var step = e.key === 'ArrowLeft' ? -1 : 1;
+        var next = null;
+        for (var i = columns.indexOf(column) + step; i >= 0 && i < columns.length; i += step) {
+            if (accepts(columns[i], grabbed.column)) { next = columns[i]; break; }
+        }
+        if (!next) {
+            announce(board, 'Cannot move further.');
+            return;
+        }
+        place(card, next, cardsOf(next)[index]);
+    } else {
+        return;
+    }
+    // Moving a focused element can blur it
+    card.focus();
+    counts(board);
+    column = columnOf(card);
+    announce(board, columnName(column) + ', position ' + (cardsOf(column).indexOf(card) + 1) + '.');
+});
+document.addEventListener('focusout', function(e) {
+    if (!grabbed || e.target !== grabbed.card) return;
+    // Cancel the move once focus has really left the card
+    setTimeout(function() {
+        if (grabbed && document.activeElement !== grabbed.card) release(true);
+    }, 0);
+});
+
+// Pointer: HTML5 drag and drop
+var dragging = null;
+document.addEventListener('dragstart', function(e) {
+    var card = e.target.closest ? e.target.closest('[data-dyn-kanban-card]') : null;
+    if (!card) return;
+    var column = columnOf(card);
+    dragging = { card: card, column: column, index: cardsOf(column).indexOf(card) };
+    e.dataTransfer.effectAllowed = 'move';
+    e.dataTransfer.setData('text/plain', card.getAttribute('data-dyn-kanban-card'));
+    card.classList.add('dyn-kanban-dragging');
+});
+document.addEventListener('dragover', function(e) {
+    if (!dragging) return;
+    var column = e.target.closest ? e.target.closest('[data-dyn-kanban-column]') : null;
+    if (!column || column.closest('[data-dyn-kanban]') !== dragging.card.closest('[data-dyn-kanban]')) return;
+    if (!accepts(column, dragging.column)) return;
+    e.preventDefault();
+    e.dataTransfer.dropEffect = 'move';
+    var before = null;
+    cardsOf(column).some(function(other) {
+        if (other === dragging.card) return false;
+        var box = other.getBoundingClientRect();
+        if (e.clientY < box.top + box.height / 2) { before = other; return true; }
+        return false;
+    });
+    place(dragging.card, column, before);
+    document.querySelectorAll('.dyn-kanban-over').forEach(function(c) { if (c !== column) c.classList.remove('dyn-kanban-over'); });
+    column.classList.add('dyn-kanban-over');
+});
+document.addEventListener('drop', function(e) {
+    if (!dragging) return;
+    e.preventDefault();
+    var d = dragging;
+    dragging = null;
+    d.card.classList.remove('dyn-kanban-dragging');
+    document.querySelectorAll('.dyn-kanban-over').forEach(function(c) { c.classList.remove('dyn-kanban-over'); });
+    moved(d.card, d.column, d.index);
+});
+document.addEventListener('dragend', function() {
+    if (!dragging) return;
+    // Dropped outside a column: put the card back
+    var d = dragging;
+    dragging = null;
+    d.card.classList.remove('dyn-kanban-dragging');
+    document.querySelectorAll('.dyn-kanban-over').forEach(function(c) { c.classList.remove('dyn-kanban-over'); });
+    place(d.card, d.column, cardsOf(d.column).filter(function(c) { return c !== d.card; })[d.index]);
+    counts(d.card.closest('[data-dyn-kanban]'));
+});
+})();`
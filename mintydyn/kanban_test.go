@@ -0,0 +1,52 @@
+package mintydyn
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestKanban(t *testing.T) {
+	columns := []KanbanColumn{
+		{ID: "pending", Title: "Pending", Cards: []KanbanCard{
+			{ID: "ord-1", Title: "Order 1", Body: func(b *mi.Builder) mi.Node { return b.Span("$10.00") }},
+			{ID: "ord-2", Title: "Order 2"},
+		}},
+		{ID: "shipped", Title: "Shipped", Accepts: []string{"pending"}},
+	}
+	html := mi.RenderToString(Kanban("orders", columns, KanbanOptions{MoveURL: "/orders/move"}))
+	markup := html[:strings.Index(html, "<script")]
+	for _, want := range []string{
+		`id="orders"`,
+		`aria-label="Board"`,
+		`hx-post="/orders/move"`,
+		`hx-trigger="kanban:move"`,
+		`data-dyn-kanban-column="pending"`,
+		`data-dyn-kanban-accepts="pending"`,
+		`aria-labelledby="orders-shipped-title"`,
+		`data-dyn-kanban-card="ord-1"`,
+		`draggable="true"`,
+		`tabindex="0"`,
+		`aria-describedby="orders-help"`,
+		`<span>$10.00</span>`,
+		`aria-live="assertive"`,
+	} {
+		if !strings.Contains(markup, want) {
+			t.Errorf("Kanban output missing %q in %s", want, markup)
+		}
+	}
+	counts := regexp.MustCompile(`<span[^>]*data-dyn-kanban-count=""[^>]*>(\d+)</span>`).FindAllStringSubmatch(markup, -1)
+	if len(counts) != 2 || counts[0][1] != "2" || counts[1][1] != "0" {
+		t.Errorf("column counts missing: %s", markup)
+	}
+	if !strings.Contains(html, "new CustomEvent('kanban:move'") {
+		t.Error("Kanban script does not fire kanban:move")
+	}
+
+	plain := mi.RenderToString(Kanban("b", nil, KanbanOptions{Label: "Claims"}))
+	if strings.Contains(plain, "hx-post") || !strings.Contains(plain, `aria-label="Claims"`) {
+		t.Errorf("options not applied: %s", plain)
+	}
+}
@@ -0,0 +1,155 @@
+package mintydyn
+
+import (
+	"strconv"
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// INPUT MASKING
+// =============================================================================
+
+// Common mask patterns. In a pattern, # matches a digit, A a letter and * a
+// letter or digit; anything else is a literal inserted as the user types.
+const (
+	MaskPhoneUS  = "(###) ###-####"
+	MaskCard     = "#### #### #### ####"
+	MaskDate     = "##/##/####"
+	MaskZipPlus4 = "#####-####"
+	MaskCurrency = "currency" // Groups thousands and allows two decimals, e.g. 12,345.67
+)
+
+// Mask renders a text input that formats what the user types with pattern,
+// plus a hidden input named name that carries the clean value (the typed
+// letters and digits, or a plain decimal for MaskCurrency) for submission.
+// The visible input has id name and no name, so only the clean value posts.
+// Extra attributes apply to the visible input; a Value is formatted on load.
+//
+//	b.Label(mi.For("phone"), "Phone"),
+//	mdy.Mask("phone", mdy.MaskPhoneUS, mi.Placeholder("(555) 123-4567")),
+//
+// Backspace and Delete skip over inserted separators, so editing behaves as
+// if only the significant characters were there.
+func Mask(name, pattern string, attrs ...mi.Attribute) mi.H {
+	return func(b *mi.Builder) mi.Node {
+		valueID := name + "-value"
+		inputMode := "text"
+		if pattern == MaskCurrency {
+			inputMode = "decimal"
+		} else if !strings.ContainsAny(pattern, "A*") {
+			inputMode = "numeric"
+		}
+
+		args := []mi.Attribute{
+			mi.Type("text"),
+			mi.ID(name),
+			mi.Attr("inputmode", inputMode),
+			mi.Attr("autocomplete", "off"),
+			mi.Data("dyn-mask", pattern),
+			mi.Data("dyn-mask-target", valueID),
+		}
+		if pattern != MaskCurrency {
+			args = append(args, mi.Attr("maxlength", strconv.Itoa(len([]rune(pattern)))))
+		}
+		args = append(args, attrs...)
+
+		return mi.NewFragment(
+			b.Input(args...),
+			b.Input(mi.Type("hidden"), mi.ID(valueID), mi.Name(name)),
+			b.Script(mi.Raw(maskJS)),
+		)
+	}
+}
+
+// maskJS installs delegated listeners once per page and formats any masked
+// inputs rendered so far.
+const maskJS = `(function(){
+if (!window.DynMask) {
+    var SLOTS = { '#': /[0-9]/, 'A': /[A-Za-z]/, '*': /[A-Za-z0-9]/ };
+    function isCurrency(el) { return el.getAttribute('data-dyn-mask') === 'currency'; }
+    function significant(el, ch) { return isCurrency(el) ? /[0-9.]/.test(ch) : /[A-Za-z0-9]/.test(ch); }
+    function rawOf(el, value) {
+        var raw = '';
+        for (var i = 0; i < value.length; i++) if (significant(el, value[i])) raw += value[i];
+        if (isCurrency(el)) {
+            var dot = raw.indexOf('.');
+            if (dot >= 0) raw = raw.slice(0, dot + 1) + raw.slice(dot + 1).replace(/\./g, '').slice(0, 2);
+        }
+        return raw;
+    }
+    function format(el, raw) {
+        if (isCurrency(el)) {
+            var parts = raw.split('.');
+            var whole = parts[0].replace(/^0+(?=\d)/, '').replace(/\B(?=(\d{3})+(?!\d))/g, ',');
+            if (parts.length > 1) return (whole || '0') + '.' + parts[1];
+            return whole;
+        }
+        var pattern = el.getAttribute('data-dyn-mask'), out = '', r = 0;
+        for (var p = 0; p < pattern.length && r < raw.length; p++) {
+            var slot = SLOTS[pattern[p]];
+            if (!slot) { out += pattern[p]; continue; }
+            while (r < raw.length && !slot.test(raw[r])) r++;
+            if (r < raw.length) out += raw[r++];
+        }
+        return out;
+    }
+    function clean(el, raw) {
+        if (!isCurrency(el)) return raw;
+        if (raw === '' || raw === '.') return '';
+        return raw.replace(/^0+(?=\d)/, '').replace(/\.$/, '');
+    }
+    // apply reformats the input, keeping the caret after the same number of
+    // significant characters it followed before.
+    function apply(el, raw, caretRaw) {
+        var display = format(el, raw);
+        el.value = display;
+        var target = document.getElementById(el.getAttribute('data-dyn-mask-target'));
+        if (target) target.value = clean(el, rawOf(el, display));
+        if (caretRaw === undefined || document.activeElement !== el) return;
+        var pos = 0, seen = 0;
+        while (pos < display.length && seen < caretRaw) {
+            if (significant(el, display[pos])) seen++;
+            pos++;
+        }
+        el.setSelectionRange(pos, pos);
+    }
+    function countBefore(el, value, pos) {
+        var n = 0;
+        for (var i = 0; i < pos; i++) if (significant(el, value[i])) n++;
+        return n;
+    }
+    window.DynMask = {
+        init: function() {
+            document.querySelectorAll('[data-dyn-mask]:not([data-dyn-masked])').forEach(function(el) {
+                el.setAttribute('data-dyn-masked', '');
+                apply(el, rawOf(el, el.value));
+            });
+        }
+    };
+    document.addEventListener('input', function(e) {
+        var el = e.target;
+        if (!el.hasAttribute || !el.hasAttribute('data-dyn-mask')) return;
+        apply(el, rawOf(el, el.value), countBefore(el, el.value, el.selectionStart));
+    });
+    document.addEventListener('keydown', function(e) {
+        var el = e.target;
+        if (!el.hasAttribute || !el.hasAttribute('data-dyn-mask')) return;
+        if ((e.key !== 'Backspace' && e.key !== 'Delete') || el.selectionStart !== el.selectionEnd) return;
+        var value = el.value, pos = el.selectionStart;
+        var neighbour = e.key === 'Backspace' ? value[pos - 1] : value[pos];
+        if (neighbour === undefined || significant(el, neighbour)) return;
+        // The caret is next to a separator: remove the nearest significant
+        // character in that direction instead of the separator itself.
+        var before = countBefore(el, value, pos);
+        var raw = rawOf(el, value);
+        var index = e.key === 'Backspace' ? before - 1 : before;
+        if (index < 0 || index >= raw.length) return;
+        e.preventDefault();
+        apply(el, raw.slice(0, index) + raw.slice(index + 1), index);
+    });
+    document.addEventListener('DOMContentLoaded', function() { window.DynMask.init(); });
+}
+window.DynMask.init();
+})();`
@@ -0,0 +1,34 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestMask(t *testing.T) {
+	html := mi.RenderToString(Mask("phone", MaskPhoneUS, mi.Class("input")))
+	for _, want := range []string{
+		`id="phone"`,
+		`data-dyn-mask="(###) ###-####"`,
+		`data-dyn-mask-target="phone-value"`,
+		`inputmode="numeric"`,
+		`maxlength="14"`,
+		`class="input"`,
+		`id="phone-value"`,
+		`type="hidden"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Mask output missing %q in %s", want, html)
+		}
+	}
+	if strings.Count(html, `name="phone"`) != 1 {
+		t.Error("only the hidden clean value should be submitted")
+	}
+
+	currency := mi.RenderToString(Mask("premium", MaskCurrency))
+	if !strings.Contains(currency, `inputmode="decimal"`) || strings.Contains(currency, "maxlength") {
+		t.Errorf("currency mask should be decimal and unbounded: %s", currency)
+	}
+}
@@ -0,0 +1,192 @@
+package mintydyn
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// MENU BUTTON
+// =============================================================================
+
+// MenuItem is one entry of a Menu. Set Href to navigate or Hook to run
+// JavaScript; a Separator item renders a divider and ignores the rest.
+type MenuItem struct {
+	Label     string
+	Href      string
+	Hook      string // JavaScript run with context = {event, item}, like other hooks
+	Disabled  bool   // Shown and focusable but not activatable
+	Separator bool
+}
+
+// MenuOptions configures a Menu.
+type MenuOptions struct {
+	ID    string // Menu element id; derived from the item labels when empty
+	Class string // Extra classes for the wrapper
+	Align string // "start" (default) or "end" to align the menu's right edge with the button
+}
+
+// Menu renders a menu button that opens a list of actions, following the
+// WAI-ARIA menu button pattern: the button carries aria-haspopup and
+// aria-expanded, Enter, Space and the arrow keys open the menu, Up, Down,
+// Home and End move between items, and Escape, Tab or a click outside close
+// it, returning focus to the button where appropriate.
+//
+//	actions := func(b *mi.Builder) mi.Node { return mi.Txt("Actions") }
+//	mdy.Menu(actions, []mdy.MenuItem{
+//	    {Label: "Edit", Href: "/assets/42/edit"},
+//	    {Label: "Duplicate", Hook: "duplicateAsset(42)"},
+//	    {Separator: true},
+//	    {Label: "Delete", Hook: "confirmDelete(42)", Disabled: !canDelete},
+//	}, mdy.MenuOptions{Align: "end"})
+func Menu(label mi.H, items []MenuItem, opts MenuOptions) mi.H {
+	return func(b *mi.Builder) mi.Node {
+		id := opts.ID
+		if id == "" {
+			h := fnv.New32a()
+			for _, item := range items {
+				h.Write([]byte(item.Label + "\x00"))
+			}
+			id = fmt.Sprintf("dyn-menu-%x", h.Sum32())
+		}
+		buttonID := id + "-button"
+
+		list := []interface{}{
+			mi.ID(id),
+			mi.Role("menu"),
+			mi.Class("dyn-menu-list"),
+			mi.AriaLabelledby(buttonID),
+			mi.Hidden(),
+		}
+		if opts.Align == "end" {
+			list = append(list, mi.Data("align", "end"))
+		}
+		for _, item := range items {
+			if item.Separator {
+				list = append(list, b.Li(mi.Role("separator"), mi.Class("dyn-menu-separator")))
+				continue
+			}
+			list = append(list, b.Li(mi.Role("none"), menuItem(b, item)))
+		}
+
+		return mi.NewFragment(
+			b.Div(
+				mi.Class(strings.TrimSpace("dyn-menu "+opts.Class)),
+				mi.Data("dyn-menu", ""),
+				b.Button(
+					mi.Type("button"),
+					mi.ID(buttonID),
+					mi.Class("dyn-menu-button"),
+					mi.Attr("aria-haspopup", "menu"),
+					mi.Attr("aria-expanded", "false"),
+					mi.Attr("aria-controls", id),
+					label(b),
+				),
+				b.Ul(list...),
+			),
+			b.Script(mi.Raw(menuJS)),
+		)
+	}
+}
+
+// menuItem renders a link for enabled Href items and a button otherwise, so
+// disabled links cannot be followed.
+func menuItem(b *mi.Builder, item MenuItem) mi.Node {
+	args := []interface{}{
+		mi.Role("menuitem"),
+		mi.Class("dyn-menu-item"),
+		mi.TabIndex(-1),
+	}
+	if item.Disabled {
+		args = append(args, mi.Attr("aria-disabled", "true"))
+	} else if item.Hook != "" {
+		args = append(args, mi.Data("dyn-menu-hook", item.Hook))
+	}
+	if item.Href != "" && !item.Disabled {
+		return b.A(append(args, mi.Href(item.Href), item.Label)...)
+	}
+	return b.Button(append(args, mi.Type("button"), item.Label)...)
+}
+
+// menuJS installs delegated listeners once per page.
+const menuJS = `(function(){
+if (window.DynMenu) return;
+function parts(el) {
+    var root = el.closest('[data-dyn-menu]');
+    return root ? { root: root, button: root.querySelector('.dyn-menu-button'), list: root.querySelector('[role="menu"]') } : null;
+}
+function items(list) { return Array.prototype.slice.call(list.querySelectorAll('[role="menuitem"]')); }
+function open(p, focus) {
+    closeAll(p.root);
+    p.list.hidden = false;
+    p.button.setAttribute('aria-expanded', 'true');
+    var all = items(p.list);
+    if (focus === 'first' && all.length) all[0].focus();
+    if (focus === 'last' && all.length) all[all.length - 1].focus();
+}
+function close(p, restoreFocus) {
+    if (p.list.hidden) return;
+    p.list.hidden = true;
+    p.button.setAttribute('aria-expanded', 'false');
+    if (restoreFocus) p.button.focus();
+}
+function closeAll(except) {
+    document.querySelectorAll('[data-dyn-menu]').forEach(function(root) {
+        if (root !== except) close(parts(root), false);
+    });
+}
+window.DynMenu = { open: function(el) { var p = parts(el); if (p) open(p, 'first'); }, close: function(el) { var p = parts(el); if (p) close(p, true); } };
+document.addEventListener('click', function(e) {
+    if (!e.target.closest) return;
+    var button = e.target.closest('.dyn-menu-button');
+    if (button) {
+        var p = parts(button);
+        if (p.list.hidden) open(p, 'first');
+        else close(p, false);
+        return;
+    }
+    var item = e.target.closest('[data-dyn-menu] [role="menuitem"]');
+    if (!item) {
+        closeAll(null);
+        return;
+    }
+    if (item.getAttribute('aria-disabled') === 'true') {
+        e.preventDefault();
+        return;
+    }
+    var hook = item.getAttribute('data-dyn-menu-hook');
+    if (hook) {
+        try { new Function('context', hook)({ event: e, item: item }); }
+        catch (err) { console.error('Menu hook error:', err); }
+    }
+    close(parts(item), !item.getAttribute('href'));
+});
+document.addEventListener('keydown', function(e) {
+    if (!e.target.closest) return;
+    var button = e.target.closest('.dyn-menu-button');
+    if (button) {
+        var p = parts(button);
+        if (e.key === 'ArrowDown' || e.key === 'Enter' || e.key === ' ') { e.preventDefault(); open(p, 'first'); }
+        else if (e.key === 'ArrowUp') { e.preventDefault(); open(p, 'last'); }
+        else if (e.key === 'Escape') close(p, true);
+        return;
+    }
+    var item = e.target.closest('[data-dyn-menu] [role="menuitem"]');
+    if (!item) return;
+    var p = parts(item), all = items(p.list), index = all.indexOf(item);
+    switch (e.key) {
+    case 'ArrowDown': all[(index + 1) % all.length].focus(); break;
+    case 'ArrowUp': all[(index - 1 + all.length) % all.length].focus(); break;
+    case 'Home': all[0].focus(); break;
+    case 'End': all[all.length - 1].focus(); break;
+    case 'Escape': close(p, true); break;
+    case 'Tab': close(p, false); return;
+    case ' ': item.click(); break;
+    default: return;
+    }
+    e.preventDefault();
+});
+})();`
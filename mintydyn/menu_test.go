@@ -0,0 +1,42 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestMenu(t *testing.T) {
+	html := mi.RenderToString(Menu(func(b *mi.Builder) mi.Node { return mi.Txt("Actions") }, []MenuItem{
+		{Label: "Edit", Href: "/assets/42/edit"},
+		{Label: "Duplicate", Hook: "duplicate(42)"},
+		{Separator: true},
+		{Label: "Delete", Href: "/assets/42/delete", Disabled: true},
+	}, MenuOptions{ID: "asset-menu", Align: "end"}))
+	markup := html[:strings.Index(html, "<script")]
+
+	for _, want := range []string{
+		`id="asset-menu-button"`,
+		`aria-haspopup="menu"`,
+		`aria-expanded="false"`,
+		`aria-controls="asset-menu"`,
+		`role="menu"`,
+		`aria-labelledby="asset-menu-button"`,
+		`data-align="end"`,
+		`href="/assets/42/edit"`,
+		`data-dyn-menu-hook="duplicate(42)"`,
+		`role="separator"`,
+		`aria-disabled="true"`,
+	} {
+		if !strings.Contains(markup, want) {
+			t.Errorf("Menu output missing %q in %s", want, markup)
+		}
+	}
+	if strings.Count(markup, `role="menuitem"`) != 3 {
+		t.Errorf("want 3 menu items: %s", markup)
+	}
+	if strings.Contains(markup, "/assets/42/delete") {
+		t.Error("disabled items should not render a followable link")
+	}
+}
@@ -16,6 +16,8 @@ package mintydyn
 
 import (
 	"encoding/json"
+
+	mi "github.com/ha1tch/minty"
 )
 
 // =============================================================================
@@ -141,6 +143,7 @@ type FilterableField struct {
 	Options      []string    `json:"options,omitempty"`      // For select/multiselect
 	Range        *RangeInfo  `json:"range,omitempty"`        // For range type
 	Searchable   bool        `json:"searchable,omitempty"`
+	Sortable     bool        `json:"sortable,omitempty"`     // Adds a sort button; see FlexBuilder.Sortable
 	DefaultValue interface{} `json:"defaultValue,omitempty"`
 }
 
@@ -161,9 +164,23 @@ type FilterOptions struct {
 	ServerRendered   bool   `json:"serverRendered"` // Data is pre-rendered in HTML, just show/hide
 	RowSelector      string `json:"rowSelector"`    // CSS selector for data rows (e.g., ".asset-row")
 	CounterSelector  string `json:"counterSelector"` // CSS selector for count display (e.g., "#asset-count")
-	ItemTemplate     string `json:"itemTemplate,omitempty"` // JS template for rendering items (uses ${field} syntax)
+	ItemTemplate     string `json:"itemTemplate,omitempty"` // JS template for rendering items (uses ${field} syntax); prefer ItemView
+	ItemView         mi.H   `json:"-"`                      // Item markup with Bind placeholders, rendered into a <template>
+	LoadMode         string `json:"loadMode,omitempty"`     // LoadModePages, LoadModeInfinite or LoadModeLoadMore
+	Endpoint         string `json:"endpoint,omitempty"`     // Filter on the server: POST the filter state here, see ServerFilter
+	Debounce         int    `json:"debounce,omitempty"`     // Milliseconds to wait for more filter changes before a request; default 300
+	EnableExport     bool   `json:"enableExport,omitempty"`   // Render an Export button downloading the filtered results as CSV
+	ExportFilename   string `json:"exportFilename,omitempty"` // Name of the downloaded file; default "export.csv"
 }
 
+// Load modes for FilterOptions.LoadMode. Each shows ItemsPerPage results at a
+// time; an empty mode means LoadModePages when EnablePagination is set.
+const (
+	LoadModePages    = "pages"    // Numbered page buttons
+	LoadModeInfinite = "infinite" // Append the next page when the end of the list scrolls into view
+	LoadModeLoadMore = "loadmore" // Append the next page from a "Load more" button
+)
+
 // =============================================================================
 // COMPONENT OPTIONS
 // =============================================================================
@@ -182,6 +199,9 @@ type DynamicOptions struct {
 	// JavaScript output
 	MinifyJS bool `json:"minifyJs,omitempty"` // Minify generated JavaScript
 
+	// CSS scoping
+	ScopedCSS bool `json:"scopedCss,omitempty"` // Namespace dyn-* classes per component (c-<id>__*)
+
 	// Custom attributes for container
 	CustomAttributes map[string]string `json:"customAttributes,omitempty"`
 
@@ -192,17 +212,22 @@ type DynamicOptions struct {
 	// Lifecycle hooks
 	Hooks ComponentHooks `json:"hooks,omitempty"`
 
+	// Classes toggled on arbitrary elements while a state is active
+	StateClasses []StateClassToggle `json:"stateClasses,omitempty"`
+
 	// General metadata
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // ExternalScript defines an external JavaScript dependency.
 type ExternalScript struct {
-	Src      string `json:"src"`
-	Async    bool   `json:"async,omitempty"`
-	Defer    bool   `json:"defer,omitempty"`
-	OnLoad   string `json:"onLoad,omitempty"`   // JS code to run when loaded
-	Required bool   `json:"required,omitempty"` // Block component init until loaded
+	Src        string `json:"src"`
+	Async      bool   `json:"async,omitempty"`
+	Defer      bool   `json:"defer,omitempty"`
+	OnLoad     string `json:"onLoad,omitempty"`     // JS code to run when loaded
+	Required   bool   `json:"required,omitempty"`   // Block component init until loaded
+	Retries    int    `json:"retries,omitempty"`    // Further attempts after a failed load
+	RetryDelay int    `json:"retryDelay,omitempty"` // Milliseconds before the first retry, doubled for each further one; default 500
 }
 
 // ComponentHooks provides lifecycle callbacks.
@@ -217,6 +242,14 @@ type ComponentHooks struct {
 	StateHooks        map[string]string `json:"stateHooks,omitempty"` // Per-state callbacks: stateID -> JS code
 }
 
+// StateClassToggle adds Class to every element matching Selector, anywhere
+// in the document, while State is active, and removes it when State is left.
+type StateClassToggle struct {
+	State    string `json:"state"`
+	Selector string `json:"selector"`
+	Class    string `json:"class"` // Space-separated classes allowed
+}
+
 // =============================================================================
 // PATTERN DETECTION
 // =============================================================================
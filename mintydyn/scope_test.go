@@ -0,0 +1,43 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestScopedCSS(t *testing.T) {
+	states := []ComponentState{
+		ActiveState("one", "One", "first"),
+		NewState("two", "Two", "second"),
+	}
+
+	html := mi.RenderToString(Dyn("orders").States(states).ScopedCSS().Build())
+
+	for _, want := range []string{
+		`c-orders__state-trigger`,
+		`c-orders__state-navigation`,
+		`.c-orders__state-trigger {`,
+		`"classPrefix":"c-orders__"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("scoped output missing %q", want)
+		}
+	}
+	if strings.Contains(html, `class="dyn-`) || strings.Contains(html, ".dyn-state") {
+		t.Error("scoped output still contains unscoped dyn-* classes")
+	}
+
+	plain := mi.RenderToString(Dyn("orders").States(states).Build())
+	if strings.Contains(plain, "c-orders__") {
+		t.Error("unscoped output should not contain namespaced classes")
+	}
+}
+
+func TestScopeClassList(t *testing.T) {
+	got := scopeClassList("nav-link dyn-state-trigger active", "c-x__")
+	if want := "nav-link c-x__state-trigger active"; got != want {
+		t.Errorf("scopeClassList = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package mintydyn
+
+import (
+	"strconv"
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// BACK TO TOP AND SCROLL PROGRESS
+// =============================================================================
+
+// ScrollTopOptions configures a ScrollTop button.
+type ScrollTopOptions struct {
+	Class     string // Extra classes for the button
+	Label     string // Accessible name, default "Back to top"
+	Threshold int    // Pixels scrolled before the button appears (default 400)
+	Progress  bool   // Also render a reading-progress bar at the top of the page
+	FocusID   string // Element focused after scrolling up, e.g. "main"; default none
+}
+
+// ScrollTop renders a "back to top" button that appears once the page has
+// scrolled past Threshold and scrolls smoothly to the top, instantly for
+// users who prefer reduced motion. The button stays hidden, and out of the
+// tab order, until it is needed; place it at the end of the page so it
+// comes last in focus order. With FocusID set, focus moves to that element
+// once the page is back at the top, so keyboard users continue from there.
+//
+//	mdy.ScrollTop(mdy.ScrollTopOptions{Progress: true, FocusID: "main"})
+func ScrollTop(opts ScrollTopOptions) mi.H {
+	if opts.Label == "" {
+		opts.Label = "Back to top"
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = 400
+	}
+	return func(b *mi.Builder) mi.Node {
+		button := []interface{}{
+			mi.Type("button"),
+			mi.Class(strings.TrimSpace("dyn-scroll-top " + opts.Class)),
+			mi.Data("dyn-scroll-top", strconv.Itoa(opts.Threshold)),
+			mi.AriaLabel(opts.Label),
+			mi.Title(opts.Label),
+			mi.Hidden(),
+		}
+		if opts.FocusID != "" {
+			button = append(button, mi.Data("dyn-scroll-focus", opts.FocusID))
+		}
+		button = append(button, b.Span(mi.Attr("aria-hidden", "true"), "↑"))
+
+		var progress mi.Node = mi.NewFragment()
+		if opts.Progress {
+			progress = b.Div(
+				mi.Class("dyn-scroll-progress"),
+				mi.Data("dyn-scroll-progress", ""),
+				mi.Attr("aria-hidden", "true"),
+			)
+		}
+
+		return mi.NewFragment(
+			progress,
+			b.Button(button...),
+			b.Script(mi.Raw(scrollTopJS)),
+		)
+	}
+}
+
+// scrollTopJS installs one throttled scroll listener per page that updates
+// every ScrollTop button and progress bar.
+const scrollTopJS = `(function(){
+if (window.DynScrollTop) return;
+window.DynScrollTop = true;
+var reduceMotion = window.matchMedia('(prefers-reduced-motion: reduce)');
+var pending = false;
+function update() {
+    pending = false;
+    var root = document.documentElement;
+    var y = window.scrollY || root.scrollTop;
+    var max = root.scrollHeight - root.clientHeight;
+    document.querySelectorAll('[data-dyn-scroll-top]').forEach(function(btn) {
+        var show = y > Number(btn.getAttribute('data-dyn-scroll-top'));
+        if (btn.hidden === show) btn.hidden = !show;
+    });
+    document.querySelectorAll('[data-dyn-scroll-progress]').forEach(function(bar) {
+        bar.style.transform = 'scaleX(' + (max > 0 ? Math.min(1, y / max) : 0) + ')';
+    });
+}
+function schedule() {
+    if (pending) return;
+    pending = true;
+    window.requestAnimationFrame(update);
+}
+window.addEventListener('scroll', schedule, { passive: true });
+window.addEventListener('resize', schedule);
+document.addEventListener('click', function(e) {
+    var btn = e.target.closest ? e.target.closest('[data-dyn-scroll-top]') : null;
+    if (!btn) return;
+    window.scrollTo({ top: 0, behavior: reduceMotion.matches ? 'auto' : 'smooth' });
+    var target = document.getElementById(btn.getAttribute('data-dyn-scroll-focus') || '');
+    if (target) {
+        if (!target.hasAttribute('tabindex') && target.tabIndex < 0) target.setAttribute('tabindex', '-1');
+        target.focus({ preventScroll: true });
+    }
+});
+if (document.readyState === 'loading') document.addEventListener('DOMContentLoaded', update);
+else update();
+})();`
@@ -0,0 +1,38 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestScrollTop(t *testing.T) {
+	html := mi.RenderToString(ScrollTop(ScrollTopOptions{Progress: true, FocusID: "main"}))
+	markup := html[:strings.Index(html, "<script")]
+	for _, want := range []string{
+		`<button `,
+		`type="button"`,
+		`data-dyn-scroll-top="400"`,
+		`aria-label="Back to top"`,
+		`hidden="hidden"`,
+		`data-dyn-scroll-focus="main"`,
+		`data-dyn-scroll-progress`,
+	} {
+		if !strings.Contains(markup, want) {
+			t.Errorf("ScrollTop output missing %q in %s", want, markup)
+		}
+	}
+	if strings.Index(markup, "dyn-scroll-progress") > strings.Index(markup, "<button") {
+		t.Errorf("progress bar should precede the button: %s", markup)
+	}
+	if !strings.Contains(html, "prefers-reduced-motion") {
+		t.Error("ScrollTop script ignores reduced motion")
+	}
+
+	plain := mi.RenderToString(ScrollTop(ScrollTopOptions{Threshold: 800, Label: "Top"}))
+	if strings.Contains(plain, `data-dyn-scroll-progress=`) || !strings.Contains(plain, `data-dyn-scroll-top="800"`) ||
+		!strings.Contains(plain, `aria-label="Top"`) {
+		t.Errorf("options not applied: %s", plain)
+	}
+}
@@ -0,0 +1,119 @@
+package mintydyn
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// =============================================================================
+// SERVER-SIDE SEARCH INDEX
+// =============================================================================
+
+// SearchIndex is an inverted index over the text of selected fields of a
+// dataset. Build it once when the data changes and query it per request,
+// instead of scanning every field of every row on each search.
+type SearchIndex struct {
+	size     int
+	terms    []string                // Sorted distinct terms, for prefix lookup
+	postings map[string][]searchPost // Term -> items containing it, by position
+}
+
+// searchPost records how often a term occurs in one item.
+type searchPost struct {
+	item  int
+	count int
+}
+
+// BuildSearchIndex indexes the given fields of items. Field values are
+// converted to text as the client does, lowercased and split into words on
+// anything that is not a letter or digit; nil values are skipped.
+//
+//	index := mdy.BuildSearchIndex(claims, []string{"claimant", "policy", "notes"})
+//	for _, i := range index.Search(r.URL.Query().Get("q")) {
+//	    results = append(results, claims[i])
+//	}
+func BuildSearchIndex(items []map[string]interface{}, fields []string) *SearchIndex {
+	idx := &SearchIndex{size: len(items), postings: make(map[string][]searchPost)}
+	for i, item := range items {
+		counts := make(map[string]int)
+		for _, field := range fields {
+			value, ok := item[field]
+			if !ok || value == nil {
+				continue
+			}
+			for _, term := range searchTerms(jsString(value)) {
+				counts[term]++
+			}
+		}
+		for term, count := range counts {
+			idx.postings[term] = append(idx.postings[term], searchPost{item: i, count: count})
+		}
+	}
+
+	idx.terms = make([]string, 0, len(idx.postings))
+	for term := range idx.postings {
+		idx.terms = append(idx.terms, term)
+	}
+	sort.Strings(idx.terms)
+	return idx
+}
+
+// Search returns the positions of the items matching every word of query,
+// where a query word matches any indexed word it is a prefix of, so partial
+// input such as "lap" finds "laptop". Results are ranked by how many times
+// the query words occur, most first, then by position. An empty query
+// returns every position in order.
+func (idx *SearchIndex) Search(query string) []int {
+	words := searchTerms(query)
+	if len(words) == 0 {
+		all := make([]int, idx.size)
+		for i := range all {
+			all[i] = i
+		}
+		return all
+	}
+
+	var scores map[int]int
+	for _, word := range words {
+		matched := make(map[int]int)
+		for i := sort.SearchStrings(idx.terms, word); i < len(idx.terms) && strings.HasPrefix(idx.terms[i], word); i++ {
+			for _, post := range idx.postings[idx.terms[i]] {
+				matched[post.item] += post.count
+			}
+		}
+		if scores == nil {
+			scores = matched
+		} else {
+			for item, score := range scores {
+				if extra, ok := matched[item]; ok {
+					scores[item] = score + extra
+				} else {
+					delete(scores, item)
+				}
+			}
+		}
+		if len(scores) == 0 {
+			return nil
+		}
+	}
+
+	result := make([]int, 0, len(scores))
+	for item := range scores {
+		result = append(result, item)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if scores[result[a]] != scores[result[b]] {
+			return scores[result[a]] > scores[result[b]]
+		}
+		return result[a] < result[b]
+	})
+	return result
+}
+
+// searchTerms lowercases s and splits it into words.
+func searchTerms(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,35 @@
+package mintydyn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchIndex(t *testing.T) {
+	items := []map[string]interface{}{
+		{"name": "Laptop Pro", "notes": "fast laptop"},
+		{"name": "Laptop stand", "notes": nil},
+		{"name": "Editor", "notes": "for laptop users", "sku": 1200},
+		{"name": "Desk lamp", "notes": "LED"},
+	}
+	idx := BuildSearchIndex(items, []string{"name", "notes", "sku"})
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"laptop", []int{0, 1, 2}},
+		{"LAP", []int{0, 1, 2}},
+		{"laptop stand", []int{1}},
+		{"pro fast", []int{0}},
+		{"1200", []int{2}},
+		{"null", nil},
+		{"chair", nil},
+		{"  ", []int{0, 1, 2, 3}},
+	}
+	for _, tt := range tests {
+		if got := idx.Search(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package mintydyn
+
+import (
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// KEYBOARD SHORTCUTS
+// =============================================================================
+
+// Shortcut binds a key combination to a single action. Exactly one of Focus,
+// Click, Href or Hook should be set; the first non-empty one in that order wins.
+type Shortcut struct {
+	Keys        string // Key combination, e.g. "n", "/", "g i", "ctrl+k", "shift+?"
+	Description string // Shown in the "?" overlay
+	Focus       string // CSS selector of an element to focus
+	Click       string // CSS selector of an element to click
+	Href        string // URL to navigate to
+	Hook        string // JavaScript run with context = {event, shortcut}, like other hooks
+	InInputs    bool   // Also fire while focus is in a text field
+}
+
+// reservedShortcuts are combinations the browser or assistive technology
+// needs. Bindings for them are dropped rather than overriding the browser.
+var reservedShortcuts = map[string]bool{
+	"tab": true, "shift+tab": true, "escape": true, "f1": true, "f5": true,
+	"f6": true, "f11": true, "f12": true, "ctrl+f": true, "ctrl+l": true,
+	"ctrl+n": true, "ctrl+p": true, "ctrl+r": true, "ctrl+t": true,
+	"ctrl+w": true, "ctrl+shift+t": true, "ctrl+tab": true, "alt+left": true,
+	"alt+right": true, "alt+f4": true,
+}
+
+// normalizeShortcut lower-cases a combination and orders the modifiers of
+// each step (ctrl, alt, shift, meta) so equivalent spellings compare equal.
+// "cmd" is accepted for meta, and shift is dropped for symbol keys such as
+// "?" since the symbol already implies it.
+func normalizeShortcut(keys string) string {
+	var steps []string
+	for _, step := range strings.Fields(strings.ToLower(keys)) {
+		parts := strings.Split(step, "+")
+		key := parts[len(parts)-1]
+		mods := map[string]bool{}
+		for _, p := range parts[:len(parts)-1] {
+			if p == "cmd" {
+				p = "meta"
+			}
+			mods[p] = true
+		}
+		if len(key) == 1 && (key[0] < 'a' || key[0] > 'z') {
+			mods["shift"] = false
+		}
+		var out []string
+		for _, m := range []string{"ctrl", "alt", "shift", "meta"} {
+			if mods[m] {
+				out = append(out, m)
+			}
+		}
+		steps = append(steps, strings.Join(append(out, key), "+"))
+	}
+	return strings.Join(steps, " ")
+}
+
+// Shortcuts renders a page-wide keyboard shortcut registry. Render it once
+// per page, typically at the end of the body:
+//
+//	mdy.Shortcuts([]mdy.Shortcut{
+//	    {Keys: "n", Description: "New asset", Href: "/assets/new"},
+//	    {Keys: "/", Description: "Search", Focus: "#search"},
+//	    {Keys: "ctrl+s", Description: "Save", Click: "#save", InInputs: true},
+//	})
+//
+// Pressing "?" opens an overlay listing the active shortcuts, unless "?" is
+// itself bound. Keys typed into text fields are left alone unless the binding
+// sets InInputs, unmatched keys are never intercepted, and combinations the
+// browser needs (Tab, Escape, ctrl+w, ctrl+l, ...) are ignored.
+func Shortcuts(bindings []Shortcut) mi.H {
+	return func(b *mi.Builder) mi.Node {
+		type binding struct {
+			Keys     string `json:"keys"`
+			Action   string `json:"action"`
+			Target   string `json:"target"`
+			InInputs bool   `json:"inInputs,omitempty"`
+		}
+
+		var active []binding
+		var items []interface{}
+		helpBound := false
+		for _, s := range bindings {
+			keys := normalizeShortcut(s.Keys)
+			if keys == "" || reservedShortcuts[keys] {
+				continue
+			}
+			bind := binding{Keys: keys, InInputs: s.InInputs}
+			switch {
+			case s.Focus != "":
+				bind.Action, bind.Target = "focus", s.Focus
+			case s.Click != "":
+				bind.Action, bind.Target = "click", s.Click
+			case s.Href != "":
+				bind.Action, bind.Target = "navigate", s.Href
+			case s.Hook != "":
+				bind.Action, bind.Target = "hook", s.Hook
+			default:
+				continue
+			}
+			if keys == "?" {
+				helpBound = true
+			}
+			active = append(active, bind)
+
+			description := s.Description
+			if description == "" {
+				description = s.Keys
+			}
+			items = append(items, b.Dt(shortcutKeys(b, s.Keys)), b.Dd(description))
+		}
+
+		config := map[string]interface{}{"bindings": active, "help": !helpBound}
+		nodes := []mi.Node{
+			b.Script(mi.Type("application/json"), mi.ID("dyn-shortcuts-config"), mi.Raw(MustJSON(config))),
+		}
+		if !helpBound {
+			nodes = append(nodes, b.Dialog(
+				mi.ID("dyn-shortcuts-help"),
+				mi.Class("dyn-shortcuts-help"),
+				mi.AriaLabelledby("dyn-shortcuts-title"),
+				b.H2(mi.ID("dyn-shortcuts-title"), "Keyboard shortcuts"),
+				b.Dl(items...),
+				b.Form(mi.Method("dialog"), b.Button(mi.Type("submit"), "Close")),
+			))
+		}
+		nodes = append(nodes, b.Script(mi.Raw(shortcutsJS)))
+		return mi.NewFragment(nodes...)
+	}
+}
+
+// shortcutKeys renders a combination as <kbd> elements, one per key.
+func shortcutKeys(b *mi.Builder, keys string) mi.Node {
+	var parts []interface{}
+	for _, seq := range strings.Fields(keys) {
+		if len(parts) > 0 {
+			parts = append(parts, " then ")
+		}
+		parts = append(parts, b.Kbd(seq))
+	}
+	return b.Span(parts...)
+}
+
+// shortcutsJS installs a single keydown listener per page. Sequences such as
+// "g i" match when their keys are pressed within a second of each other.
+const shortcutsJS = `(function(){
+if (window.DynShortcuts) return;
+var el = document.getElementById('dyn-shortcuts-config');
+if (!el) return;
+var config = JSON.parse(el.textContent);
+window.DynShortcuts = { bindings: config.bindings || [] };
+var pending = '', timer = null;
+function isTextField(t) {
+    if (!t || !t.tagName) return false;
+    if (t.isContentEditable) return true;
+    var tag = t.tagName.toLowerCase();
+    if (tag === 'textarea' || tag === 'select') return true;
+    if (tag !== 'input') return false;
+    var type = (t.getAttribute('type') || 'text').toLowerCase();
+    return ['button','checkbox','radio','submit','reset','file','image','range','color'].indexOf(type) < 0;
+}
+function comboOf(e) {
+    var key = e.key.length === 1 ? e.key.toLowerCase() : e.key.toLowerCase().replace('arrow', '');
+    var mods = [];
+    if (e.ctrlKey) mods.push('ctrl');
+    if (e.altKey) mods.push('alt');
+    // Shift is implied by symbols such as "?", so it only counts for letters and named keys
+    if (e.shiftKey && (e.key.length > 1 || /[a-z]/i.test(e.key))) mods.push('shift');
+    if (e.metaKey) mods.push('meta');
+    mods.push(key === ' ' ? 'space' : key);
+    return mods.join('+');
+}
+function run(binding, e) {
+    var target;
+    switch (binding.action) {
+    case 'focus':
+        target = document.querySelector(binding.target);
+        if (target) { target.focus(); if (target.select) target.select(); }
+        break;
+    case 'click':
+        target = document.querySelector(binding.target);
+        if (target) target.click();
+        break;
+    case 'navigate':
+        window.location.href = binding.target;
+        break;
+    case 'hook':
+        try { new Function('context', binding.target)({ event: e, shortcut: binding }); }
+        catch (err) { console.error('Shortcut hook error:', err); }
+        break;
+    }
+}
+document.addEventListener('keydown', function(e) {
+    if (e.defaultPrevented || e.isComposing || e.repeat) return;
+    if (e.key === 'Shift' || e.key === 'Control' || e.key === 'Alt' || e.key === 'Meta') return;
+    var inText = isTextField(e.target);
+    var combo = comboOf(e);
+    var seq = pending ? pending + ' ' + combo : combo;
+    var prefix = false, match = null;
+    window.DynShortcuts.bindings.forEach(function(b) {
+        if (inText && !b.inInputs) return;
+        if (b.keys === seq) match = b;
+        else if (b.keys.indexOf(seq + ' ') === 0) prefix = true;
+    });
+    clearTimeout(timer);
+    if (match) {
+        pending = '';
+        e.preventDefault();
+        run(match, e);
+        return;
+    }
+    if (prefix) {
+        pending = seq;
+        timer = setTimeout(function() { pending = ''; }, 1000);
+        return;
+    }
+    pending = '';
+    if (config.help && combo === '?' && !inText) {
+        var help = document.getElementById('dyn-shortcuts-help');
+        if (help && help.showModal && !help.open) { e.preventDefault(); help.showModal(); }
+    }
+});
+})();`
@@ -0,0 +1,51 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestNormalizeShortcut(t *testing.T) {
+	cases := map[string]string{
+		"N":            "n",
+		"Shift+Ctrl+K": "ctrl+shift+k",
+		"cmd+k":        "meta+k",
+		"shift+?":      "?",
+		"g  i":         "g i",
+	}
+	for in, want := range cases {
+		if got := normalizeShortcut(in); got != want {
+			t.Errorf("normalizeShortcut(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestShortcuts(t *testing.T) {
+	html := mi.RenderToString(Shortcuts([]Shortcut{
+		{Keys: "n", Description: "New asset", Href: "/assets/new"},
+		{Keys: "/", Description: "Search", Focus: "#search"},
+		{Keys: "ctrl+w", Description: "Close", Click: "#close"},
+	}))
+
+	for _, want := range []string{
+		`"keys":"n","action":"navigate","target":"/assets/new"`,
+		`"keys":"/","action":"focus","target":"#search"`,
+		`id="dyn-shortcuts-help"`,
+		`<kbd>n</kbd>`,
+		`New asset`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Shortcuts output missing %q", want)
+		}
+	}
+	if strings.Contains(html, "ctrl+w") {
+		t.Error("reserved browser shortcut should be dropped")
+	}
+
+	custom := mi.RenderToString(Shortcuts([]Shortcut{{Keys: "?", Hook: "openHelp()"}}))
+	if strings.Contains(custom, `id="dyn-shortcuts-help"`) {
+		t.Error("help overlay should be omitted when ? is bound")
+	}
+}
@@ -0,0 +1,229 @@
+package mintydyn
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// COLUMN SORTING (mirrors the generated DataManager JavaScript)
+// =============================================================================
+
+// Sort directions for SortKey.Dir.
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// SortKey is one key of a multi-key sort, as the DataManager sends it to a
+// ServerFilter endpoint.
+type SortKey struct {
+	Field string `json:"field"`
+	Dir   string `json:"dir"` // SortAsc or SortDesc
+}
+
+// SortTheme is implemented by themes that style the sort indicators. It is
+// optional so existing DynamicTheme implementations keep working.
+type SortTheme interface {
+	SortAscClass() string  // default: "dyn-sort-asc"
+	SortDescClass() string // default: "dyn-sort-desc"
+}
+
+func (t *DefaultTheme) SortAscClass() string           { return "dyn-sort-asc" }
+func (t *DefaultTheme) SortDescClass() string          { return "dyn-sort-desc" }
+func (t *BootstrapDynamicTheme) SortAscClass() string  { return "dyn-sort-asc active" }
+func (t *BootstrapDynamicTheme) SortDescClass() string { return "dyn-sort-desc active" }
+func (t *TailwindDynamicTheme) SortAscClass() string {
+	return "dyn-sort-asc text-blue-600 font-semibold"
+}
+func (t *TailwindDynamicTheme) SortDescClass() string {
+	return "dyn-sort-desc text-blue-600 font-semibold"
+}
+func (t *TailwindDarkTheme) SortAscClass() string {
+	return "dyn-sort-asc text-blue-600 dark:text-blue-400 font-semibold"
+}
+func (t *TailwindDarkTheme) SortDescClass() string {
+	return "dyn-sort-desc text-blue-600 dark:text-blue-400 font-semibold"
+}
+
+func (t *scopedTheme) SortAscClass() string  { return t.s(sortClasses(t.inner).asc) }
+func (t *scopedTheme) SortDescClass() string { return t.s(sortClasses(t.inner).desc) }
+
+// sortIndicators holds the classes marking the sorted direction.
+type sortIndicators struct {
+	asc, desc string
+}
+
+// sortClasses returns theme's sort indicator classes, or the defaults.
+func sortClasses(theme DynamicTheme) sortIndicators {
+	classes := sortIndicators{asc: "dyn-sort-asc", desc: "dyn-sort-desc"}
+	if st, ok := theme.(SortTheme); ok {
+		classes.asc = getClass(st.SortAscClass(), classes.asc)
+		classes.desc = getClass(st.SortDescClass(), classes.desc)
+	}
+	return classes
+}
+
+// Sortable marks the named filter fields sortable, adding a sort button for
+// each above the results.
+//
+//	mdy.Dyn("assets").
+//	    Data(assets).
+//	    TextFilter("name", "Name").
+//	    FilterField(mdy.FilterableField{Name: "price", Type: "range", Label: "Price"}).
+//	    Sortable("name", "price").
+//	    Build()
+func (fb *FlexBuilder) Sortable(names ...string) *FlexBuilder {
+	for i := range fb.filterSchema.Fields {
+		for _, name := range names {
+			if fb.filterSchema.Fields[i].Name == name {
+				fb.filterSchema.Fields[i].Sortable = true
+			}
+		}
+	}
+	return fb
+}
+
+// generateSortControls creates a sort button for each sortable field, or
+// returns nil when there are none. Any other element with data-sort-field,
+// such as a table header, sorts the same way when clicked; outside the
+// component it also needs data-sort-target set to the component id.
+func (db *DynamicBuilder[S, D, R]) generateSortControls(b *mi.Builder, schema FilterSchema) mi.Node {
+	var buttons []interface{}
+	for _, field := range schema.Fields {
+		if !field.Sortable {
+			continue
+		}
+		label := field.Label
+		if label == "" {
+			label = field.Name
+		}
+		buttons = append(buttons, b.Button(
+			mi.Type("button"),
+			mi.Class(db.scopeClasses("dyn-sort-button")),
+			mi.Data("sort-field", field.Name),
+			mi.Attr("aria-pressed", "false"),
+			label,
+		))
+	}
+	if len(buttons) == 0 {
+		return nil
+	}
+	return b.Div(append([]interface{}{
+		mi.ID(db.id + "-sort"),
+		mi.Class(db.scopeClasses("dyn-sort-controls")),
+		mi.Role("group"),
+		mi.AriaLabel("Sort by"),
+	}, buttons...)...)
+}
+
+// SortItems sorts items in Go the way the client-side DataManager does, so
+// a ServerFilter endpoint returns them in the order the browser would. The
+// sort is stable and applies keys in turn, each breaking ties left by the
+// one before. Range fields compare as numbers and boolean fields false
+// before true; all others compare as text, ignoring case. Missing and empty
+// values, and non-numbers in range fields, sort last in either direction.
+// Keys naming fields missing from schema sort as text.
+//
+//	req, err := mdy.ReadFilterRequest(r, assetSchema)
+//	...
+//	matches := mdy.SortItems(mdy.ApplyFilters(assets, assetSchema, req.Filters), assetSchema, req.Sort)
+func SortItems(items []map[string]interface{}, schema FilterSchema, keys []SortKey) []map[string]interface{} {
+	result := append([]map[string]interface{}(nil), items...)
+	if len(keys) == 0 {
+		return result
+	}
+	types := make(map[string]string, len(schema.Fields))
+	for _, field := range schema.Fields {
+		types[field.Name] = field.Type
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		for _, key := range keys {
+			if order := compareSortValues(result[i][key.Field], result[j][key.Field], types[key.Field], key.Dir == SortDesc); order != 0 {
+				return order < 0
+			}
+		}
+		return false
+	})
+	return result
+}
+
+// compareSortValues mirrors DataManager.compareValues: -1, 0 or 1 as a sorts
+// before, with or after b, in descending order when desc is set. Empty
+// values come last either way.
+func compareSortValues(a, b interface{}, fieldType string, desc bool) int {
+	x, xok := sortValue(a, fieldType)
+	y, yok := sortValue(b, fieldType)
+	switch {
+	case !xok && !yok:
+		return 0
+	case !xok:
+		return 1
+	case !yok:
+		return -1
+	}
+	order := 0
+	if fx, ok := x.(float64); ok {
+		fy := y.(float64)
+		switch {
+		case fx < fy:
+			order = -1
+		case fx > fy:
+			order = 1
+		}
+	} else {
+		order = strings.Compare(x.(string), y.(string))
+	}
+	if desc {
+		return -order
+	}
+	return order
+}
+
+// sortValue mirrors DataManager.sortValue: the comparable form of a value,
+// a float64 for range and boolean fields and a lower-case string otherwise,
+// or false when it is empty.
+func sortValue(value interface{}, fieldType string) (interface{}, bool) {
+	if value == nil || value == "" {
+		return nil, false
+	}
+	switch fieldType {
+	case "range":
+		f := jsNumber(value)
+		if math.IsNaN(f) {
+			return nil, false
+		}
+		return f, true
+	case "boolean":
+		if value == true || value == "true" || value == "1" {
+			return 1.0, true
+		}
+		return 0.0, true
+	default:
+		return strings.ToLower(jsString(value)), true
+	}
+}
+
+// validateSortKeys checks that the keys name schema fields and directions.
+func validateSortKeys(schema FilterSchema, keys []SortKey) error {
+	for _, key := range keys {
+		known := false
+		for _, field := range schema.Fields {
+			if field.Name == key.Field {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%w: sort by %q", ErrUnknownFilterField, key.Field)
+		}
+		if key.Dir != SortAsc && key.Dir != SortDesc {
+			return fmt.Errorf("%w: sort direction %q, want %q or %q", ErrInvalidFilterValue, key.Dir, SortAsc, SortDesc)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package mintydyn
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestSortItems(t *testing.T) {
+	items := []map[string]interface{}{
+		{"name": "banana", "price": 10, "inStock": true},
+		{"name": "Apple", "price": "9", "inStock": false},
+		{"name": "cherry", "price": 100},
+		{"name": "", "price": 10, "inStock": "true"},
+		{"name": "apple", "price": "n/a", "inStock": true},
+	}
+	schema := FilterSchema{Fields: []FilterableField{
+		TextField("name", "Name"),
+		RangeField("price", "Price", 0, 100, 1),
+		BoolField("inStock", "In stock"),
+	}}
+	order := func(result []map[string]interface{}) []int {
+		out := make([]int, len(result))
+		for i, r := range result {
+			for j, item := range items {
+				if reflect.ValueOf(r).Pointer() == reflect.ValueOf(item).Pointer() {
+					out[i] = j
+				}
+			}
+		}
+		return out
+	}
+
+	tests := []struct {
+		desc string
+		keys []SortKey
+		want []int
+	}{
+		{"no keys", nil, []int{0, 1, 2, 3, 4}},
+		{"text ignores case, stable, empty last", []SortKey{{"name", SortAsc}}, []int{1, 4, 0, 2, 3}},
+		{"text descending, empty still last", []SortKey{{"name", SortDesc}}, []int{2, 0, 1, 4, 3}},
+		{"numbers not text", []SortKey{{"price", SortAsc}}, []int{1, 0, 3, 2, 4}},
+		{"numbers descending", []SortKey{{"price", SortDesc}}, []int{2, 0, 3, 1, 4}},
+		{"second key breaks ties", []SortKey{{"price", SortAsc}, {"name", SortDesc}}, []int{1, 0, 3, 2, 4}},
+		{"booleans", []SortKey{{"inStock", SortAsc}, {"name", SortAsc}}, []int{1, 4, 0, 3, 2}},
+	}
+	for _, tt := range tests {
+		if got := order(SortItems(items, schema, tt.keys)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: SortItems order = %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+	if items[0]["name"] != "banana" {
+		t.Error("SortItems should not reorder its argument")
+	}
+}
+
+func TestSortControls(t *testing.T) {
+	data := []map[string]interface{}{{"name": "a", "price": 1}, {"name": "b", "price": 2}}
+	html := mi.RenderToString(Dyn("parts").
+		Data(data).
+		FilterField(TextField("name", "Name")).
+		FilterField(RangeField("price", "Price", 0, 10, 1)).
+		FilterField(BoolField("inStock", "In stock")).
+		Sortable("name", "price").
+		Theme(NewBootstrapDynamicTheme()).
+		Build())
+	for _, want := range []string{
+		`id="parts-sort"`,
+		`data-sort-field="name"`,
+		`data-sort-field="price"`,
+		`"sortable":true`,
+		`"sortAsc":"dyn-sort-asc active"`,
+		`bindSortEvents()`,
+		`sort: this.sortKeys`,
+		`data:sorted`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("sortable output missing %q", want)
+		}
+	}
+	if strings.Contains(html, `data-sort-field="inStock"`) {
+		t.Error("sort button rendered for a field not marked sortable")
+	}
+	if plain := mi.RenderToString(Dyn("parts").Data(data).TextFilter("name", "Name").Build()); strings.Contains(plain, `id="parts-sort"`) {
+		t.Error("sort controls rendered without sortable fields")
+	}
+	if !strings.Contains(DefaultCSS(), ".dyn-sort-asc::after") || !strings.Contains(CSSFromTokens(mi.DefaultThemeTokens()), ".dyn-sort-desc::after") {
+		t.Error("CSS missing the sort indicators")
+	}
+}
+
+func TestReadFilterRequestSort(t *testing.T) {
+	schema := FilterSchema{Fields: []FilterableField{TextField("name", "Name"), RangeField("price", "Price", 0, 100, 1)}}
+	req := httptest.NewRequest("POST", "/parts/filter", strings.NewReader(`{"filters":{"name":"a"},"sort":[{"field":"price","dir":"desc"},{"field":"name","dir":"asc"}]}`))
+	got, err := ReadFilterRequest(req, schema)
+	if err != nil {
+		t.Fatalf("ReadFilterRequest: %v", err)
+	}
+	if want := []SortKey{{"price", SortDesc}, {"name", SortAsc}}; !reflect.DeepEqual(got.Sort, want) || got.Filters["name"] != "a" {
+		t.Errorf("ReadFilterRequest = %+v", got)
+	}
+
+	for body, want := range map[string]error{
+		`{"sort":[{"field":"colour","dir":"asc"}]}`: ErrUnknownFilterField,
+		`{"sort":[{"field":"name","dir":"up"}]}`:    ErrInvalidFilterValue,
+	} {
+		req := httptest.NewRequest("POST", "/parts/filter", strings.NewReader(body))
+		if _, err := ReadFilterRequest(req, schema); !errors.Is(err, want) {
+			t.Errorf("ReadFilterRequest(%s) = %v, want %v", body, err, want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestOnStateClass(t *testing.T) {
+	states := []ComponentState{
+		ActiveState("browse", "Browse", "all content"),
+		NewState("focus", "Focus mode", "just the article"),
+	}
+
+	html := mi.RenderToString(Dyn("reader").
+		States(states).
+		OnStateClass("focus", ".sidebar, .comments", "is-dimmed").
+		OnStateClass("focus", `a[href="#article"]`, "is-current").
+		Build())
+
+	for _, want := range []string{
+		`"stateClasses":[`,
+		`{"state":"focus","selector":".sidebar, .comments","class":"is-dimmed"}`,
+		`toggleStateClasses(stateId, true)`,
+		`toggleStateClasses(stateId, false)`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+
+	plain := mi.RenderToString(Dyn("reader").States(states).Build())
+	if strings.Contains(plain, `"stateClasses"`) {
+		t.Error("stateClasses should be omitted when no toggles are registered")
+	}
+}
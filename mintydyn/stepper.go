@@ -0,0 +1,193 @@
+package mintydyn
+
+import (
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// NUMBER STEPPER
+// =============================================================================
+
+// StepperOptions configures a NumberStepper.
+type StepperOptions struct {
+	ID            string         // Input id; defaults to name
+	Class         string         // Extra classes for the wrapper
+	Label         string         // Accessible name of the input, e.g. "Quantity"
+	Value         float64        // Initial value, clamped to the bounds
+	Min           float64        // Lower bound (default 0)
+	Max           float64        // Upper bound; 0 means no maximum
+	Step          float64        // Increment (default 1)
+	DecreaseLabel string         // Default "Decrease"
+	IncreaseLabel string         // Default "Increase"
+	Attrs         []mi.Attribute // Extra attributes for the input, e.g. htmx triggers
+}
+
+func (o StepperOptions) withDefaults(name string) StepperOptions {
+	if o.ID == "" {
+		o.ID = name
+	}
+	if o.Step <= 0 {
+		o.Step = 1
+	}
+	if o.DecreaseLabel == "" {
+		o.DecreaseLabel = "Decrease"
+	}
+	if o.IncreaseLabel == "" {
+		o.IncreaseLabel = "Increase"
+	}
+	if o.Value < o.Min {
+		o.Value = o.Min
+	}
+	if o.Max > 0 && o.Value > o.Max {
+		o.Value = o.Max
+	}
+	return o
+}
+
+// NumberStepper renders a number input flanked by decrease and increase
+// buttons. The buttons respect Min, Max and Step, are disabled at the bounds,
+// and repeat while held down.
+//
+//	mdy.NumberStepper("qty", mdy.StepperOptions{Label: "Quantity", Value: 1, Min: 1, Max: 10})
+//
+// Every step fires the input's native input event, and a change event once
+// the button is released, so cart handlers and hx-trigger="change" see
+// button presses exactly like typed edits.
+func NumberStepper(name string, opts StepperOptions) mi.H {
+	opts = opts.withDefaults(name)
+	return func(b *mi.Builder) mi.Node {
+		input := []mi.Attribute{
+			mi.Type("number"),
+			mi.ID(opts.ID),
+			mi.Name(name),
+			mi.Value(floatStr(opts.Value)),
+			mi.Attr("min", floatStr(opts.Min)),
+			mi.Attr("step", floatStr(opts.Step)),
+			mi.Attr("inputmode", "decimal"),
+			mi.Class("dyn-stepper-input"),
+		}
+		if opts.Max > 0 {
+			input = append(input, mi.Attr("max", floatStr(opts.Max)))
+		}
+		if opts.Label != "" {
+			input = append(input, mi.AriaLabel(opts.Label))
+		}
+		input = append(input, opts.Attrs...)
+
+		button := func(dir, label, text string, disabled bool) mi.Node {
+			args := []interface{}{
+				mi.Type("button"),
+				mi.Class("dyn-stepper-btn"),
+				mi.Data("dyn-stepper-dir", dir),
+				mi.AriaLabel(label),
+				mi.Attr("aria-controls", opts.ID),
+			}
+			if disabled {
+				args = append(args, mi.Disabled())
+			}
+			return b.Button(append(args, text)...)
+		}
+
+		return mi.NewFragment(
+			b.Div(
+				mi.Class(strings.TrimSpace("dyn-stepper "+opts.Class)),
+				mi.Data("dyn-stepper", ""),
+				mi.Role("group"),
+				button("-1", opts.DecreaseLabel, "−", opts.Value <= opts.Min),
+				b.Input(input...),
+				button("1", opts.IncreaseLabel, "+", opts.Max > 0 && opts.Value >= opts.Max),
+			),
+			b.Script(mi.Raw(stepperJS)),
+		)
+	}
+}
+
+// stepperJS installs delegated listeners once per page.
+const stepperJS = `(function(){
+if (window.DynStepper) return;
+window.DynStepper = true;
+function parts(btn) {
+    var group = btn.closest('[data-dyn-stepper]');
+    return group ? { group: group, input: group.querySelector('input') } : null;
+}
+function num(input, attr, fallback) {
+    var v = parseFloat(input.getAttribute(attr));
+    return isNaN(v) ? fallback : v;
+}
+function sync(group) {
+    var input = group.querySelector('input');
+    var value = parseFloat(input.value);
+    var min = num(input, 'min', -Infinity), max = num(input, 'max', Infinity);
+    group.querySelectorAll('[data-dyn-stepper-dir]').forEach(function(btn) {
+        btn.disabled = input.disabled || (btn.getAttribute('data-dyn-stepper-dir') < 0 ? value <= min : value >= max);
+    });
+}
+// step moves the value one step, snapped to the step's precision so 0.1
+// steps never drift, and reports whether the value changed.
+function step(btn) {
+    var p = parts(btn);
+    if (!p || p.input.disabled || p.input.readOnly) return false;
+    var input = p.input;
+    var size = num(input, 'step', 1);
+    var decimals = (String(size).split('.')[1] || '').length;
+    var min = num(input, 'min', -Infinity), max = num(input, 'max', Infinity);
+    var current = parseFloat(input.value);
+    if (isNaN(current)) current = isFinite(min) ? min : 0;
+    var next = current + size * Number(btn.getAttribute('data-dyn-stepper-dir'));
+    next = Math.min(max, Math.max(min, Number(next.toFixed(decimals))));
+    if (next === current) return false;
+    input.value = String(next);
+    input.dispatchEvent(new Event('input', { bubbles: true }));
+    sync(p.group);
+    return true;
+}
+function commit(btn) {
+    var p = parts(btn);
+    if (p) p.input.dispatchEvent(new Event('change', { bubbles: true }));
+}
+var timer = null, repeater = null, repeated = false;
+function stop() {
+    clearTimeout(timer);
+    clearInterval(repeater);
+    timer = repeater = null;
+}
+document.addEventListener('pointerdown', function(e) {
+    var btn = e.target.closest ? e.target.closest('[data-dyn-stepper-dir]') : null;
+    if (!btn || btn.disabled || e.button !== 0) return;
+    repeated = false;
+    timer = setTimeout(function() {
+        repeater = setInterval(function() {
+            if (step(btn)) {
+                repeated = true;
+                return;
+            }
+            // Reached a bound: the button is now disabled and gets no click
+            stop();
+            if (repeated) {
+                repeated = false;
+                commit(btn);
+            }
+        }, 80);
+    }, 400);
+});
+['pointerup', 'pointercancel', 'pointerleave'].forEach(function(type) {
+    document.addEventListener(type, stop, true);
+});
+document.addEventListener('click', function(e) {
+    var btn = e.target.closest ? e.target.closest('[data-dyn-stepper-dir]') : null;
+    if (!btn) return;
+    // A long press already stepped; the click that ends it only commits
+    if (repeated) {
+        repeated = false;
+        commit(btn);
+        return;
+    }
+    if (step(btn)) commit(btn);
+});
+document.addEventListener('input', function(e) {
+    var group = e.target.closest ? e.target.closest('[data-dyn-stepper]') : null;
+    if (group && e.target.tagName === 'INPUT') sync(group);
+});
+})();`
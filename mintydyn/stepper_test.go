@@ -0,0 +1,36 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestNumberStepper(t *testing.T) {
+	html := mi.RenderToString(NumberStepper("qty", StepperOptions{Label: "Quantity", Value: 1, Min: 1, Max: 5}))
+	markup := html[:strings.Index(html, "<script")]
+	for _, want := range []string{
+		`type="number"`,
+		`name="qty"`,
+		`value="1"`,
+		`min="1"`,
+		`max="5"`,
+		`aria-label="Quantity"`,
+		`aria-label="Decrease"`,
+		`aria-label="Increase"`,
+		`aria-controls="qty"`,
+	} {
+		if !strings.Contains(markup, want) {
+			t.Errorf("NumberStepper output missing %q in %s", want, markup)
+		}
+	}
+	if strings.Count(markup, `disabled="disabled"`) != 1 {
+		t.Errorf("only the decrease button should be disabled at the minimum: %s", markup)
+	}
+
+	clamped := mi.RenderToString(NumberStepper("qty", StepperOptions{Value: 9, Max: 5, Step: 0.5}))
+	if !strings.Contains(clamped, `value="5"`) || !strings.Contains(clamped, `step="0.5"`) {
+		t.Errorf("value should clamp to max and keep the step: %s", clamped)
+	}
+}
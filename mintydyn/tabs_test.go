@@ -0,0 +1,62 @@
+package mintydyn
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestTabsARIA(t *testing.T) {
+	states := []ComponentState{
+		{ID: "general", Label: "General", Content: "General settings"},
+		{ID: "billing", Label: "Billing", Active: true, Content: "Billing settings"},
+		{ID: "legacy", Label: "Legacy", Disabled: true, Content: "Old settings"},
+	}
+	// tag returns the start tag with the given id, whose attributes render
+	// in no particular order
+	tag := func(html, id string) string {
+		return regexp.MustCompile(`<[a-z]+ [^>]*\bid="` + id + `"[^>]*>`).FindString(html)
+	}
+	hasAttrs := func(html, id string, attrs ...string) {
+		t.Helper()
+		el := tag(html, id)
+		for _, attr := range attrs {
+			if !strings.Contains(el, attr) {
+				t.Errorf("#%s missing %s: %s", id, attr, el)
+			}
+		}
+	}
+
+	html := mi.RenderToString(Tabs("settings", states))
+	hasAttrs(html, "state-billing-tab", `role="tab"`, `aria-selected="true"`, `aria-controls="state-billing"`, `tabindex="0"`)
+	hasAttrs(html, "state-general-tab", `role="tab"`, `aria-selected="false"`, `aria-controls="state-general"`, `tabindex="-1"`)
+	hasAttrs(html, "state-billing", `role="tabpanel"`, `aria-hidden="false"`, `tabindex="0"`, `aria-labelledby="state-billing-tab"`)
+	hasAttrs(html, "state-general", `aria-hidden="true"`, `aria-labelledby="state-general-tab"`)
+	for _, want := range []string{
+		`role="tablist"`,
+		`bindKeyboard()`,
+		`case 'ArrowRight':`,
+		`case 'Home':`,
+		`trigger.tabIndex = -1`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("tabs output missing %q", want)
+		}
+	}
+
+	// Without an active state the first tab is the one in the tab order
+	states[1].Active = false
+	html = mi.RenderToString(Tabs("settings", states))
+	hasAttrs(html, "state-general-tab", `tabindex="0"`)
+
+	rules := []DependencyRule{ShowWhen("plan", "equals", "pro", "billing")}
+	html = mi.RenderToString(TabsWithRules("wizard", states, rules))
+	hasAttrs(html, "state-billing-tab", `role="tab"`, `tabindex="-1"`, `type="button"`)
+	hasAttrs(html, "state-billing", `role="tabpanel"`, `aria-labelledby="state-billing-tab"`)
+
+	if single := mi.RenderToString(Tabs("one", states[:1])); strings.Contains(single, "aria-labelledby") {
+		t.Error("a lone panel without a tab bar should not be labelled by a tab")
+	}
+}
@@ -0,0 +1,45 @@
+package mintydyn
+
+import (
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// ITEM TEMPLATES
+// =============================================================================
+
+// FilterOptions.ItemView is rendered once into a <template> element; the
+// DataManager clones it for every result and fills in the placeholders
+// below. Values are set as text and attribute values, so item data can
+// never inject markup.
+//
+//	mdy.FilterOptions{
+//	    ItemView: func(b *mi.Builder) mi.Node {
+//	        return b.Article(mi.Class("claim"),
+//	            b.A(mdy.BindAttr("href", "url"), b.H3(mdy.Bind("title"))),
+//	            b.P(mdy.Bind("customer.name")),
+//	            b.Ul(b.Li(mdy.BindEach("tags"), mdy.Bind("."))),
+//	        )
+//	    },
+//	}
+//
+// Paths are dotted field names; "." is the item itself, for lists of
+// scalars.
+
+// Bind fills the element's text with the value at path.
+func Bind(path string) mi.Attribute {
+	return mi.Data("bind", path)
+}
+
+// BindAttr sets the attribute to the value at path. A null or false value
+// removes the attribute, true sets it empty, and javascript: URLs are
+// dropped from href, src, action and formaction.
+func BindAttr(attr, path string) mi.Attribute {
+	return mi.Data("bind-"+attr, path)
+}
+
+// BindEach repeats the element once per entry of the array at path. Bind
+// and BindAttr inside the element are relative to the entry.
+func BindEach(path string) mi.Attribute {
+	return mi.Data("each", path)
+}
@@ -0,0 +1,41 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestFilterItemView(t *testing.T) {
+	data := []map[string]interface{}{{"title": "<b>Claim</b>", "tags": []string{"a"}}}
+	schema := FilterSchema{Fields: []FilterableField{TextField("title", "Title")}}
+
+	html := mi.RenderToString(FilterWithOptions("claims", data, schema, FilterOptions{
+		ItemView: func(b *mi.Builder) mi.Node {
+			return b.Article(
+				b.A(BindAttr("href", "url"), Bind("title")),
+				b.Span(BindEach("tags"), Bind(".")),
+			)
+		},
+	}))
+	for _, want := range []string{
+		`<template id="claims-item-template"><article>`,
+		`data-bind-href="url"`,
+		`data-bind="title"`,
+		`data-each="tags"`,
+		`bindTemplate(`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+	if strings.Contains(html, `"ItemView"`) {
+		t.Error("ItemView should not be serialized into the config")
+	}
+
+	plain := mi.RenderToString(FilterWithOptions("claims", data, schema, FilterOptions{}))
+	if strings.Contains(plain, `id="claims-item-template"`) {
+		t.Error("template rendered without an ItemView")
+	}
+}
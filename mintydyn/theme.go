@@ -1,5 +1,11 @@
 package mintydyn
 
+import (
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
 // =============================================================================
 // DYNAMIC THEME INTERFACE
 // =============================================================================
@@ -206,16 +212,138 @@ func (t *TailwindDarkTheme) HiddenClass() string                 { return "hidde
 func (t *TailwindDarkTheme) DisabledClass() string               { return "opacity-50 cursor-not-allowed" }
 func (t *TailwindDarkTheme) InjectCSS() string                   { return "" }
 
+// =============================================================================
+// TOKEN THEME (shared design tokens)
+// =============================================================================
+
+// TokenTheme uses the default semantic class names and injects CSS generated
+// from mi.ThemeTokens, so dynamic components share the page's palette.
+type TokenTheme struct {
+	DefaultTheme
+	tokens mi.ThemeTokens
+}
+
+// NewThemeFromTokens creates a theme whose styling is derived from tokens.
+func NewThemeFromTokens(tokens mi.ThemeTokens) DynamicTheme {
+	return &TokenTheme{tokens: tokens}
+}
+
+// Tokens returns the design tokens the theme was built from.
+func (t *TokenTheme) Tokens() mi.ThemeTokens { return t.tokens }
+
+func (t *TokenTheme) InjectCSS() string { return CSSFromTokens(t.tokens) }
+
 // =============================================================================
 // THEME HELPER FUNCTIONS
 // =============================================================================
 
 // getTheme returns the theme or default if nil.
+// With ScopedCSS enabled the theme is wrapped so its classes are namespaced.
 func (db *DynamicBuilder[S, D, R]) getTheme() DynamicTheme {
-	if db.theme != nil {
-		return db.theme
+	theme := db.theme
+	if theme == nil {
+		theme = NewDefaultTheme()
+	}
+	if db.options.ScopedCSS {
+		return &scopedTheme{inner: theme, prefix: scopePrefix(db.id)}
+	}
+	return theme
+}
+
+// classPrefix returns the prefix replacing "dyn-" in generated classes.
+func (db *DynamicBuilder[S, D, R]) classPrefix() string {
+	if db.options.ScopedCSS {
+		return scopePrefix(db.id)
+	}
+	return "dyn-"
+}
+
+// scopeClasses namespaces the dyn-* classes in a class list when ScopedCSS is on.
+func (db *DynamicBuilder[S, D, R]) scopeClasses(classes string) string {
+	if !db.options.ScopedCSS {
+		return classes
+	}
+	return scopeClassList(classes, scopePrefix(db.id))
+}
+
+// =============================================================================
+// SCOPED THEME
+// =============================================================================
+
+// scopePrefix returns the per-component class namespace for an id.
+func scopePrefix(id string) string {
+	return "c-" + id + "__"
+}
+
+// scopeClassList replaces the "dyn-" prefix of each class in a
+// space-separated list. Framework classes are left untouched.
+func scopeClassList(classes, prefix string) string {
+	if classes == "" {
+		return ""
+	}
+	fields := strings.Fields(classes)
+	for i, c := range fields {
+		if strings.HasPrefix(c, "dyn-") {
+			fields[i] = prefix + strings.TrimPrefix(c, "dyn-")
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// scopeCSS rewrites ".dyn-" class selectors in a stylesheet to the namespace.
+func scopeCSS(css, prefix string) string {
+	return strings.ReplaceAll(css, ".dyn-", "."+prefix)
+}
+
+// scopedTheme wraps a theme and namespaces its dyn-* classes and CSS.
+type scopedTheme struct {
+	inner  DynamicTheme
+	prefix string
+}
+
+func (t *scopedTheme) s(classes string) string { return scopeClassList(classes, t.prefix) }
+
+func (t *scopedTheme) ComponentClass() string { return t.s(t.inner.ComponentClass()) }
+func (t *scopedTheme) ComponentPatternClass(p string) string {
+	return t.s(t.inner.ComponentPatternClass(p))
+}
+func (t *scopedTheme) StateNavigationClass() string      { return t.s(t.inner.StateNavigationClass()) }
+func (t *scopedTheme) StateTriggerClass() string         { return t.s(t.inner.StateTriggerClass()) }
+func (t *scopedTheme) StateTriggerActiveClass() string   { return t.s(t.inner.StateTriggerActiveClass()) }
+func (t *scopedTheme) StateTriggerDisabledClass() string { return t.s(t.inner.StateTriggerDisabledClass()) }
+func (t *scopedTheme) StateContentClass() string         { return t.s(t.inner.StateContentClass()) }
+func (t *scopedTheme) StateContentActiveClass() string   { return t.s(t.inner.StateContentActiveClass()) }
+func (t *scopedTheme) StateContentHiddenClass() string   { return t.s(t.inner.StateContentHiddenClass()) }
+func (t *scopedTheme) StateContainerClass() string       { return t.s(t.inner.StateContainerClass()) }
+func (t *scopedTheme) FilterControlsClass() string       { return t.s(t.inner.FilterControlsClass()) }
+func (t *scopedTheme) FilterGroupClass() string          { return t.s(t.inner.FilterGroupClass()) }
+func (t *scopedTheme) FilterLabelClass() string          { return t.s(t.inner.FilterLabelClass()) }
+func (t *scopedTheme) FilterInputClass() string          { return t.s(t.inner.FilterInputClass()) }
+func (t *scopedTheme) FilterSelectClass() string         { return t.s(t.inner.FilterSelectClass()) }
+func (t *scopedTheme) FilterCheckboxClass() string       { return t.s(t.inner.FilterCheckboxClass()) }
+func (t *scopedTheme) FilterRangeClass() string          { return t.s(t.inner.FilterRangeClass()) }
+func (t *scopedTheme) ResultsClass() string              { return t.s(t.inner.ResultsClass()) }
+func (t *scopedTheme) ResultsEmptyClass() string         { return t.s(t.inner.ResultsEmptyClass()) }
+func (t *scopedTheme) ResultsSummaryClass() string       { return t.s(t.inner.ResultsSummaryClass()) }
+func (t *scopedTheme) PaginationClass() string           { return t.s(t.inner.PaginationClass()) }
+func (t *scopedTheme) PaginationButtonClass() string     { return t.s(t.inner.PaginationButtonClass()) }
+func (t *scopedTheme) PaginationButtonActiveClass() string {
+	return t.s(t.inner.PaginationButtonActiveClass())
+}
+func (t *scopedTheme) HiddenClass() string   { return t.s(t.inner.HiddenClass()) }
+func (t *scopedTheme) DisabledClass() string { return t.s(t.inner.DisabledClass()) }
+
+// InjectCSS scopes the wrapped theme's CSS. The default theme injects no CSS,
+// and the global DefaultCSS cannot match namespaced classes, so a scoped copy
+// of DefaultCSS is injected in its place.
+func (t *scopedTheme) InjectCSS() string {
+	css := t.inner.InjectCSS()
+	if css == "" {
+		if _, ok := t.inner.(*DefaultTheme); ok {
+			css = DefaultCSS()
+		}
 	}
-	return NewDefaultTheme()
+	return scopeCSS(css, t.prefix)
 }
 
 // getClass returns the theme class or fallback if empty.
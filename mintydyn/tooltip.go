@@ -0,0 +1,149 @@
+package mintydyn
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// ACCESSIBLE TOOLTIP
+// =============================================================================
+
+// TooltipOptions configures a Tooltip.
+type TooltipOptions struct {
+	ID        string       // Tooltip element id; derived from the text when empty
+	Placement string       // top (default), bottom, left, right; flipped when off-screen
+	Theme     DynamicTheme // Optional; themes implementing TooltipTheme style the tooltip
+}
+
+// TooltipTheme is implemented by themes that provide tooltip styling.
+// It is optional so existing DynamicTheme implementations keep working.
+type TooltipTheme interface {
+	TooltipClass() string // default: "dyn-tooltip"
+}
+
+func (t *DefaultTheme) TooltipClass() string          { return "dyn-tooltip" }
+func (t *BootstrapDynamicTheme) TooltipClass() string { return "badge text-bg-dark dyn-tooltip" }
+func (t *TailwindDynamicTheme) TooltipClass() string {
+	return "dyn-tooltip z-50 px-2 py-1 text-xs text-white bg-gray-900 rounded shadow"
+}
+func (t *TailwindDarkTheme) TooltipClass() string {
+	return "dyn-tooltip z-50 px-2 py-1 text-xs text-white dark:text-gray-900 bg-gray-900 dark:bg-gray-100 rounded shadow"
+}
+
+// Tooltip renders trigger with an accessible tooltip instead of a native
+// title attribute. The tooltip is linked with aria-describedby, shown on
+// hover and keyboard focus, hidden with Escape, and positioned next to the
+// trigger, flipping to the opposite side when it would leave the viewport.
+//
+//	mdy.Tooltip(func(b *mi.Builder) mi.Node {
+//	    return b.Button(mi.Class("icon-btn"), "✎")
+//	}, "Edit invoice", mdy.TooltipOptions{Placement: "bottom"})
+func Tooltip(trigger mi.H, text string, opts TooltipOptions) mi.H {
+	return func(b *mi.Builder) mi.Node {
+		id := opts.ID
+		if id == "" {
+			h := fnv.New32a()
+			h.Write([]byte(text))
+			id = fmt.Sprintf("dyn-tooltip-%x", h.Sum32())
+		}
+		placement := opts.Placement
+		switch placement {
+		case "top", "bottom", "left", "right":
+		default:
+			placement = "top"
+		}
+
+		class := "dyn-tooltip"
+		if tt, ok := opts.Theme.(TooltipTheme); ok {
+			class = getClass(tt.TooltipClass(), class)
+		}
+
+		// Attach to the trigger element directly when possible, otherwise
+		// wrap it in a focusable span so keyboard users can reach it.
+		node := trigger(b)
+		if el, ok := node.(*mi.Element); ok {
+			if existing := el.Attributes["aria-describedby"]; existing != "" {
+				el.Attributes["aria-describedby"] = existing + " " + id
+			} else {
+				el.Attributes["aria-describedby"] = id
+			}
+			el.Attributes["data-dyn-tooltip"] = id
+		} else {
+			node = b.Span(
+				mi.Class("dyn-tooltip-trigger"),
+				mi.TabIndex(0),
+				mi.AriaDescribedby(id),
+				mi.Data("dyn-tooltip", id),
+				node,
+			)
+		}
+
+		return mi.NewFragment(
+			node,
+			b.Span(
+				mi.ID(id),
+				mi.Role("tooltip"),
+				mi.Class(class),
+				mi.Data("placement", placement),
+				mi.Hidden(),
+				text,
+			),
+			b.Script(mi.Raw(tooltipJS)),
+		)
+	}
+}
+
+// tooltipJS installs one set of delegated listeners per page, however many
+// tooltips are rendered.
+const tooltipJS = `(function(){
+if (window.DynTooltips) return;
+window.DynTooltips = { current: null };
+var GAP = 6;
+function tipFor(t) { return document.getElementById(t.getAttribute('data-dyn-tooltip')); }
+function place(t, tip) {
+    var r = t.getBoundingClientRect();
+    tip.style.position = 'fixed';
+    var w = tip.offsetWidth, h = tip.offsetHeight;
+    var p = tip.getAttribute('data-placement') || 'top';
+    if (p === 'top' && r.top - h - GAP < 0) p = 'bottom';
+    else if (p === 'bottom' && r.bottom + h + GAP > window.innerHeight) p = 'top';
+    else if (p === 'left' && r.left - w - GAP < 0) p = 'right';
+    else if (p === 'right' && r.right + w + GAP > window.innerWidth) p = 'left';
+    var top, left;
+    if (p === 'top' || p === 'bottom') {
+        top = p === 'top' ? r.top - h - GAP : r.bottom + GAP;
+        left = r.left + (r.width - w) / 2;
+    } else {
+        top = r.top + (r.height - h) / 2;
+        left = p === 'left' ? r.left - w - GAP : r.right + GAP;
+    }
+    left = Math.max(GAP, Math.min(left, window.innerWidth - w - GAP));
+    tip.style.top = Math.round(top) + 'px';
+    tip.style.left = Math.round(left) + 'px';
+    tip.setAttribute('data-placement-actual', p);
+}
+function show(t) {
+    var tip = tipFor(t);
+    if (!tip) return;
+    if (window.DynTooltips.current && window.DynTooltips.current !== t) hide(window.DynTooltips.current);
+    tip.hidden = false;
+    place(t, tip);
+    window.DynTooltips.current = t;
+}
+function hide(t) {
+    var tip = tipFor(t);
+    if (tip) tip.hidden = true;
+    if (window.DynTooltips.current === t) window.DynTooltips.current = null;
+}
+function triggerOf(e) { return e.target.closest ? e.target.closest('[data-dyn-tooltip]') : null; }
+document.addEventListener('mouseover', function(e) { var t = triggerOf(e); if (t) show(t); });
+document.addEventListener('mouseout', function(e) { var t = triggerOf(e); if (t && !t.contains(e.relatedTarget)) hide(t); });
+document.addEventListener('focusin', function(e) { var t = triggerOf(e); if (t) show(t); });
+document.addEventListener('focusout', function(e) { var t = triggerOf(e); if (t) hide(t); });
+document.addEventListener('keydown', function(e) {
+    if (e.key === 'Escape' && window.DynTooltips.current) hide(window.DynTooltips.current);
+});
+})();`
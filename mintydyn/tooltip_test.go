@@ -0,0 +1,34 @@
+package mintydyn
+
+import (
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestTooltip(t *testing.T) {
+	button := func(b *mi.Builder) mi.Node { return b.Button(mi.Class("icon"), "x") }
+	html := mi.RenderToString(Tooltip(button, "Delete item", TooltipOptions{ID: "del-tip", Placement: "left"}))
+
+	for _, want := range []string{
+		`aria-describedby="del-tip"`,
+		`data-dyn-tooltip="del-tip"`,
+		`role="tooltip"`,
+		`data-placement="left"`,
+		`Escape`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Tooltip output missing %q", want)
+		}
+	}
+	if strings.Contains(html, "title=") {
+		t.Error("Tooltip should not use the native title attribute")
+	}
+
+	text := func(b *mi.Builder) mi.Node { return mi.Txt("?") }
+	wrapped := mi.RenderToString(Tooltip(text, "Help", TooltipOptions{Placement: "diagonal"}))
+	if !strings.Contains(wrapped, `tabindex="0"`) || !strings.Contains(wrapped, `data-placement="top"`) {
+		t.Errorf("non-element trigger should be wrapped and placement defaulted: %s", wrapped)
+	}
+}
@@ -0,0 +1,177 @@
+package mintydyn
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	mi "github.com/ha1tch/minty"
+)
+
+// =============================================================================
+// CONFIGURATION CHECKS
+// =============================================================================
+
+// Configuration problems reported by Validate and BuildChecked. Each is
+// wrapped with the specifics, so test with errors.Is.
+var (
+	ErrEmptyStates        = errors.New("states provided but empty")
+	ErrDuplicateState     = errors.New("duplicate state id")
+	ErrMissingStateID     = errors.New("state without an id")
+	ErrDataWithoutSchema  = errors.New("data too large for an inferred schema")
+	ErrMissingRowSelector = errors.New("server-rendered data without a row selector")
+	ErrInvalidRule        = errors.New("invalid rule")
+	ErrUnknownRuleTrigger = errors.New("rule trigger not found")
+	ErrUnknownRuleTarget  = errors.New("rule target not found")
+)
+
+// ConfigError lists every configuration problem found in a component.
+type ConfigError struct {
+	ComponentID string
+	Pattern     string  // The detected pattern, e.g. PatternServerFilterable
+	Problems    []error // Each wraps one of the Err* values
+}
+
+func (e *ConfigError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		messages[i] = p.Error()
+	}
+	return fmt.Sprintf("mintydyn: component %q (%s): %s", e.ComponentID, e.Pattern, strings.Join(messages, "; "))
+}
+
+// Unwrap returns the problems, so errors.Is matches any of them.
+func (e *ConfigError) Unwrap() []error {
+	return e.Problems
+}
+
+var validRuleActions = map[string]bool{
+	"show": true, "hide": true, "enable": true, "disable": true,
+	"addClass": true, "removeClass": true, "setValue": true, "setText": true,
+	"setHTML": true, "focus": true, "blur": true,
+}
+
+var validRuleConditions = map[string]bool{
+	"equals": true, "notEquals": true, "contains": true, "greaterThan": true,
+	"lessThan": true, "checked": true, "unchecked": true, "empty": true, "notEmpty": true,
+}
+
+var (
+	idAttrPattern      = regexp.MustCompile(`\sid="([^"]*)"`)
+	triggerAttrPattern = regexp.MustCompile(`\sdata-dependency-trigger="([^"]*)"`)
+)
+
+// Validate checks the component's configuration for mistakes that would
+// otherwise show up as a silently broken page: empty or duplicate states,
+// more than 50 data items without a filter schema (the schema is only
+// inferred for client-side data), server-rendered data without a row
+// selector, and rules with unknown actions or conditions, triggers that no
+// element in the component declares, or targets that no element has.
+//
+// Rule targets are looked up among the ids in the component's own markup
+// and pageIDs, the ids of elements elsewhere on the page that rules may
+// change. The result is nil or a *ConfigError.
+func (db *DynamicBuilder[S, D, R]) Validate(pageIDs ...string) error {
+	pattern := db.detectPattern()
+	var problems []error
+
+	if pattern.HasStates {
+		states := db.extractStates()
+		if len(states) == 0 {
+			problems = append(problems, ErrEmptyStates)
+		}
+		seen := make(map[string]bool)
+		for i, state := range states {
+			switch {
+			case state.ID == "":
+				problems = append(problems, fmt.Errorf("%w: state %d (%q)", ErrMissingStateID, i, state.Label))
+			case seen[state.ID]:
+				problems = append(problems, fmt.Errorf("%w: %q", ErrDuplicateState, state.ID))
+			}
+			seen[state.ID] = true
+		}
+	}
+
+	if pattern.HasData {
+		opts := db.extractFilterOptions()
+		if pattern.DataSize > 50 && len(db.extractFilterSchema().Fields) == 0 {
+			problems = append(problems, fmt.Errorf("%w: %d items, add filter fields", ErrDataWithoutSchema, pattern.DataSize))
+		}
+		if opts.ServerRendered && opts.RowSelector == "" {
+			problems = append(problems, ErrMissingRowSelector)
+		}
+	}
+
+	if pattern.HasRules {
+		problems = append(problems, db.validateRules(pageIDs)...)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ConfigError{ComponentID: db.id, Pattern: pattern.PrimaryPattern, Problems: problems}
+}
+
+// validateRules checks each rule against the component's rendered markup.
+func (db *DynamicBuilder[S, D, R]) validateRules(pageIDs []string) []error {
+	markup := mi.RenderToString(db.Build())
+	ids := make(map[string]bool)
+	for _, id := range pageIDs {
+		ids[id] = true
+	}
+	for _, m := range idAttrPattern.FindAllStringSubmatch(markup, -1) {
+		ids[html.UnescapeString(m[1])] = true
+	}
+	triggers := make(map[string]bool)
+	for _, m := range triggerAttrPattern.FindAllStringSubmatch(markup, -1) {
+		triggers[html.UnescapeString(m[1])] = true
+	}
+
+	var problems []error
+	for i, rule := range db.extractRules() {
+		name := rule.ID
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		trigger := rule.Trigger.ComponentID
+		switch {
+		case trigger == "":
+			problems = append(problems, fmt.Errorf("%w: rule %s has no trigger", ErrInvalidRule, name))
+		case !triggers[trigger]:
+			problems = append(problems, fmt.Errorf("%w: rule %s triggers on %q, but no element has data-dependency-trigger=%q",
+				ErrUnknownRuleTrigger, name, trigger, trigger))
+		}
+		if c := rule.Trigger.Condition; c != "" && !validRuleConditions[c] {
+			problems = append(problems, fmt.Errorf("%w: rule %s has unknown condition %q", ErrInvalidRule, name, c))
+		}
+		if len(rule.Actions) == 0 {
+			problems = append(problems, fmt.Errorf("%w: rule %s has no actions", ErrInvalidRule, name))
+		}
+		for _, action := range rule.Actions {
+			if !validRuleActions[action.Action] {
+				problems = append(problems, fmt.Errorf("%w: rule %s has unknown action %q", ErrInvalidRule, name, action.Action))
+			}
+			if !ids[action.TargetID] {
+				problems = append(problems, fmt.Errorf("%w: rule %s targets %q", ErrUnknownRuleTarget, name, action.TargetID))
+			}
+		}
+	}
+	return problems
+}
+
+// BuildChecked validates the configuration like Validate and returns the
+// component only when it is sound, so mistakes fail at startup or in tests
+// instead of rendering a broken page.
+//
+//	component, err := mdy.New[...]("claims").WithData(dataset).BuildChecked()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (db *DynamicBuilder[S, D, R]) BuildChecked(pageIDs ...string) (mi.H, error) {
+	if err := db.Validate(pageIDs...); err != nil {
+		return nil, err
+	}
+	return db.Build(), nil
+}
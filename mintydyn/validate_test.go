@@ -0,0 +1,65 @@
+package mintydyn
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	mi "github.com/ha1tch/minty"
+)
+
+func TestBuildChecked(t *testing.T) {
+	form := func(b *mi.Builder) mi.Node {
+		return b.Div(
+			b.Select(mi.ID("coverage"), mi.Data("dependency-trigger", "coverage")),
+			b.Div(mi.ID("auto-fields")),
+		)
+	}
+	states := []ComponentState{{ID: "form", Label: "Form", Content: mi.H(form), Active: true}}
+	rule := func(trigger, target string) DependencyRule {
+		return DependencyRule{
+			ID:      "show-" + target,
+			Trigger: TriggerCondition{ComponentID: trigger, Condition: "equals", Value: "auto"},
+			Actions: []DependencyAction{{TargetID: target, Action: "show"}},
+		}
+	}
+
+	h, err := Dyn("quote").States(states).Rules([]DependencyRule{rule("coverage", "auto-fields")}).BuildChecked()
+	if err != nil || h == nil {
+		t.Fatalf("valid component: %v", err)
+	}
+	if _, err := Dyn("quote").States(states).Rules([]DependencyRule{rule("coverage", "sidebar")}).BuildChecked("sidebar"); err != nil {
+		t.Errorf("page ids should satisfy rule targets: %v", err)
+	}
+
+	_, err = Dyn("quote").States(states).Rules([]DependencyRule{rule("plan", "missing")}).BuildChecked()
+	var cfg *ConfigError
+	if !errors.As(err, &cfg) || cfg.ComponentID != "quote" {
+		t.Fatalf("err = %v, want *ConfigError", err)
+	}
+	if !errors.Is(err, ErrUnknownRuleTrigger) || !errors.Is(err, ErrUnknownRuleTarget) {
+		t.Errorf("err = %v, want unknown trigger and target", err)
+	}
+	if !strings.Contains(err.Error(), `"missing"`) {
+		t.Errorf("error should name the target: %v", err)
+	}
+
+	if _, err := Dyn("tabs").States([]ComponentState{}).BuildChecked(); !errors.Is(err, ErrEmptyStates) {
+		t.Errorf("empty states err = %v", err)
+	}
+	dup := []ComponentState{{ID: "a", Label: "A"}, {ID: "a", Label: "Again"}}
+	if _, err := Dyn("tabs").States(dup).BuildChecked(); !errors.Is(err, ErrDuplicateState) {
+		t.Errorf("duplicate states err = %v", err)
+	}
+
+	items := make([]map[string]interface{}, 60)
+	for i := range items {
+		items[i] = map[string]interface{}{"n": i}
+	}
+	if _, err := Dyn("list").Data(items).BuildChecked(); !errors.Is(err, ErrDataWithoutSchema) {
+		t.Errorf("large data without schema err = %v", err)
+	}
+	if _, err := Dyn("list").Data(items).TextFilter("n", "N").BuildChecked(); err != nil {
+		t.Errorf("large data with schema: %v", err)
+	}
+}
@@ -136,14 +136,7 @@ func CardLayout(title string, content ...mi.H) mi.H {
 // Each applies a function to each item in a slice and returns HTML nodes
 // The returned nodes can be spread directly into NewFragment
 func Each[T any](items []T, fn func(T) mi.H) []mi.Node {
-	if len(items) == 0 {
-		return []mi.Node{}
-	}
-	nodes := make([]mi.Node, len(items))
-	for i, item := range items {
-		nodes[i] = fn(item)(mi.B)
-	}
-	return nodes
+	return mi.Each(items, fn)
 }
 
 // EachH applies a function to each item and returns H functions (lazy evaluation)
@@ -0,0 +1,281 @@
+// Package mintyhook delivers domain events as webhooks: an HTTP sender with
+// signing and retries, signature verification for receivers, and a queue
+// that delivers in the background. The domain services take a Dispatcher;
+// the event envelope is mintytypes.WebhookEvent.
+//
+//	import mh "github.com/ha1tch/minty/mintyhook"
+package mintyhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+// Dispatcher delivers domain events, e.g. "order.shipped" or
+// "invoice.paid", to integrators. Domain services call it after a change
+// has been made; payload is the changed entity.
+type Dispatcher interface {
+	Dispatch(event string, payload interface{}) error
+}
+
+// DispatcherFunc adapts a function to the Dispatcher interface.
+type DispatcherFunc func(event string, payload interface{}) error
+
+// Dispatch calls f(event, payload).
+func (f DispatcherFunc) Dispatch(event string, payload interface{}) error {
+	return f(event, payload)
+}
+
+// envelope returns payload as an envelope, wrapping it unless it already
+// is one.
+func envelope(event string, payload interface{}) mt.WebhookEvent {
+	if e, ok := payload.(mt.WebhookEvent); ok {
+		return e
+	}
+	return mt.NewWebhookEvent(event, payload)
+}
+
+// Retry configures redelivery after network errors, 429 and 5xx
+// responses. Other responses are not retried.
+type Retry struct {
+	Attempts   int           // Total attempts, default 3
+	Backoff    time.Duration // Wait before the second attempt, doubled after each, default 1s
+	MaxBackoff time.Duration // Upper bound on the wait, default 30s
+}
+
+func (r Retry) withDefaults() Retry {
+	if r.Attempts <= 0 {
+		r.Attempts = 3
+	}
+	if r.Backoff <= 0 {
+		r.Backoff = time.Second
+	}
+	if r.MaxBackoff <= 0 {
+		r.MaxBackoff = 30 * time.Second
+	}
+	return r
+}
+
+// Webhook request headers.
+const (
+	EventHeader     = "Minty-Event"
+	IDHeader        = "Minty-Event-Id"
+	SignatureHeader = "Minty-Signature"
+)
+
+// Endpoint is a Dispatcher that POSTs each event as JSON to URL. With a
+// Secret, every request is signed; receivers check the signature with
+// Verify. Dispatch blocks while retrying, so wrap it in a Queue to keep
+// slow receivers out of the request path.
+type Endpoint struct {
+	URL    string
+	Secret string       // HMAC-SHA256 signing secret; empty sends unsigned requests
+	Client *http.Client // Default: a client with a 10s timeout
+	Retry  Retry
+}
+
+var defaultClient = &http.Client{Timeout: 10 * time.Second}
+
+// Dispatch delivers the event, retrying as configured. The error describes
+// the last failed attempt.
+func (e *Endpoint) Dispatch(event string, payload interface{}) error {
+	env := envelope(event, payload)
+	body, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", env.Type, err)
+	}
+	client := e.Client
+	if client == nil {
+		client = defaultClient
+	}
+	retry := e.Retry.withDefaults()
+	wait := retry.Backoff
+
+	for attempt := 1; ; attempt++ {
+		retryable, err := e.post(client, env, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt == retry.Attempts {
+			return fmt.Errorf("webhook %s %s: attempt %d: %w", env.Type, env.ID, attempt, err)
+		}
+		time.Sleep(wait)
+		if wait *= 2; wait > retry.MaxBackoff {
+			wait = retry.MaxBackoff
+		}
+	}
+}
+
+// post makes one delivery attempt and reports whether a failure is worth
+// retrying.
+func (e *Endpoint) post(client *http.Client, env mt.WebhookEvent, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, e.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, env.Type)
+	req.Header.Set(IDHeader, env.ID)
+	if e.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(e.Secret, time.Now(), body))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("receiver responded %s", resp.Status)
+}
+
+// Sign returns the signature header value for body sent at t:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">".
+// Including the time lets receivers reject replayed requests.
+func Sign(secret string, t time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + timestamp + ",v1=" + mac(secret, timestamp, body)
+}
+
+func mac(secret, timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ErrSignature is returned by Verify for a missing, malformed, wrong or
+// expired signature.
+var ErrSignature = errors.New("invalid webhook signature")
+
+// Verify checks the Minty-Signature header of a received webhook against
+// body, the raw request body. Signatures older than tolerance are rejected;
+// zero skips the age check.
+//
+//	body, _ := io.ReadAll(r.Body)
+//	err := mh.Verify(secret, r.Header.Get(mh.SignatureHeader), body, 5*time.Minute)
+func Verify(secret, header string, body []byte, tolerance time.Duration) error {
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || signature == "" {
+		return fmt.Errorf("%w: malformed header", ErrSignature)
+	}
+	if !hmac.Equal([]byte(signature), []byte(mac(secret, timestamp, body))) {
+		return ErrSignature
+	}
+	if age := time.Since(time.Unix(seconds, 0)); tolerance > 0 && (age > tolerance || age < -tolerance) {
+		return fmt.Errorf("%w: signed %s ago", ErrSignature, age.Round(time.Second))
+	}
+	return nil
+}
+
+// Errors returned by Queue.Dispatch.
+var (
+	ErrQueueFull   = errors.New("webhook queue full")
+	ErrQueueClosed = errors.New("webhook queue closed")
+)
+
+// Queue is a Dispatcher that delivers events in the background, so domain
+// operations do not wait for receivers. Each event's envelope and data are
+// captured when it is queued.
+type Queue struct {
+	dispatcher Dispatcher
+	onError    func(event mt.WebhookEvent, err error)
+	events     chan mt.WebhookEvent
+	mu         sync.RWMutex
+	closed     bool
+	wg         sync.WaitGroup
+}
+
+// NewQueue starts workers goroutines delivering through dispatcher, holding
+// up to size undelivered events. onError, if not nil, is called with each
+// event that could not be delivered.
+//
+//	queue := mh.NewQueue(&mh.Endpoint{URL: url, Secret: secret}, 100, 2,
+//	    func(e mt.WebhookEvent, err error) { log.Print(err) })
+//	defer queue.Close()
+//	service.SetWebhookDispatcher(queue)
+func NewQueue(dispatcher Dispatcher, size, workers int, onError func(event mt.WebhookEvent, err error)) *Queue {
+	if workers < 1 {
+		workers = 1
+	}
+	q := &Queue{
+		dispatcher: dispatcher,
+		onError:    onError,
+		events:     make(chan mt.WebhookEvent, size),
+	}
+	q.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+	return q
+}
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+	for event := range q.events {
+		if err := q.dispatcher.Dispatch(event.Type, event); err != nil && q.onError != nil {
+			q.onError(event, err)
+		}
+	}
+}
+
+// Dispatch queues the event without waiting. It fails when the queue is
+// full or closed.
+func (q *Queue) Dispatch(event string, payload interface{}) error {
+	env := envelope(event, payload)
+	data, err := json.Marshal(env.Data)
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", event, err)
+	}
+	env.Data = json.RawMessage(data)
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	select {
+	case q.events <- env:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops accepting events and waits until the queued ones have been
+// delivered or have failed.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.events)
+	}
+	q.mu.Unlock()
+	q.wg.Wait()
+}
@@ -0,0 +1,96 @@
+package mintyhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mt "github.com/ha1tch/minty/mintytypes"
+)
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	now := time.Now()
+	tests := []struct {
+		desc   string
+		header string
+		body   []byte
+		ok     bool
+	}{
+		{"valid", Sign("secret", now, body), body, true},
+		{"wrong secret", Sign("other", now, body), body, false},
+		{"changed body", Sign("secret", now, body), []byte(`{"id":"evt_2"}`), false},
+		{"expired", Sign("secret", now.Add(-time.Hour), body), body, false},
+		{"malformed", "v1=abc", body, false},
+		{"missing", "", body, false},
+	}
+	for _, tt := range tests {
+		err := Verify("secret", tt.header, tt.body, 5*time.Minute)
+		if (err == nil) != tt.ok || err != nil && !errors.Is(err, ErrSignature) {
+			t.Errorf("%s: Verify = %v", tt.desc, err)
+		}
+	}
+	if err := Verify("secret", Sign("secret", now.Add(-time.Hour), body), body, 0); err != nil {
+		t.Errorf("Verify without a tolerance = %v, want no age check", err)
+	}
+}
+
+func TestEndpointRetry(t *testing.T) {
+	tests := []struct {
+		status   int
+		attempts int32
+	}{
+		{http.StatusNoContent, 1},
+		{http.StatusBadRequest, 1},
+		{http.StatusTooManyRequests, 3},
+		{http.StatusBadGateway, 3},
+	}
+	for _, tt := range tests {
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(tt.status)
+		}))
+		endpoint := &Endpoint{URL: server.URL, Retry: Retry{Backoff: time.Millisecond}}
+		err := endpoint.Dispatch("order.created", map[string]string{"id": "ord_1"})
+		server.Close()
+		if (err == nil) != (tt.status < 300) || attempts != tt.attempts {
+			t.Errorf("status %d: Dispatch = %v after %d attempts, want %d", tt.status, err, attempts, tt.attempts)
+		}
+	}
+}
+
+func TestQueueFull(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	var delivered []string
+	queue := NewQueue(DispatcherFunc(func(event string, payload interface{}) error {
+		started <- struct{}{}
+		<-release
+		delivered = append(delivered, payload.(mt.WebhookEvent).Type)
+		return nil
+	}), 1, 1, nil)
+
+	// The worker holds the first event, the queue the second
+	if err := queue.Dispatch("a", nil); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	if err := queue.Dispatch("b", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.Dispatch("c", nil); err != ErrQueueFull {
+		t.Errorf("Dispatch to a full queue = %v, want ErrQueueFull", err)
+	}
+	close(release)
+	<-started
+	queue.Close()
+	if len(delivered) != 2 || delivered[0] != "a" || delivered[1] != "b" {
+		t.Errorf("delivered %v, want [a b]", delivered)
+	}
+	if err := queue.Dispatch("d", nil); err != ErrQueueClosed {
+		t.Errorf("Dispatch after Close = %v, want ErrQueueClosed", err)
+	}
+}
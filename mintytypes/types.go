@@ -4,9 +4,21 @@
 package mintytypes
 
 import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 // =====================================================
@@ -14,37 +26,226 @@ import (
 // =====================================================
 
 // Money represents monetary values with precision.
-// Uses int64 cents for exact arithmetic, no floating point errors.
+// Uses int64 minor units for exact arithmetic, no floating point errors.
 type Money struct {
-	Amount   int64  `json:"amount"`   // Amount in smallest currency unit (cents for USD)
+	Amount   int64  `json:"amount"`   // Amount in minor units (cents for USD, yen for JPY, fils for BHD)
 	Currency string `json:"currency"` // ISO 4217 currency code
 }
 
+// currencyDecimals lists the currencies whose minor unit is not a hundredth
+// of the major unit. See CurrencyDecimals.
+var (
+	currencyDecimalsMu sync.RWMutex
+	currencyDecimals   = map[string]int{
+		"JPY": 0, "KRW": 0, "VND": 0, "CLP": 0, "ISK": 0, "PYG": 0, "UGX": 0, "XAF": 0, "XOF": 0,
+		"BHD": 3, "KWD": 3, "OMR": 3, "JOD": 3, "TND": 3, "LYD": 3, "IQD": 3,
+	}
+)
+
+// CurrencyDecimals returns the number of decimal places of a currency's
+// minor unit: 0 for JPY, 3 for BHD and 2 for currencies not in the table.
+func CurrencyDecimals(code string) int {
+	currencyDecimalsMu.RLock()
+	defer currencyDecimalsMu.RUnlock()
+	if d, ok := currencyDecimals[strings.ToUpper(code)]; ok {
+		return d
+	}
+	return 2
+}
+
+// maxCurrencyDecimals is the most decimal places a minor unit may have, as
+// an int64 amount holds 18 digits.
+const maxCurrencyDecimals = 18
+
+// SetCurrencyDecimals sets the decimal places of a currency's minor unit,
+// overriding the built-in table. Set it at startup, before amounts in that
+// currency are created or formatted. Decimals outside 0 to 18 are an error
+// and leave the table unchanged.
+func SetCurrencyDecimals(code string, decimals int) error {
+	if decimals < 0 || decimals > maxCurrencyDecimals {
+		return fmt.Errorf("invalid decimals %d for currency %s: want 0 to %d", decimals, code, maxCurrencyDecimals)
+	}
+	currencyDecimalsMu.Lock()
+	defer currencyDecimalsMu.Unlock()
+	currencyDecimals[strings.ToUpper(code)] = decimals
+	return nil
+}
+
 // MajorUnit returns the major currency unit as float64.
 func (m Money) MajorUnit() float64 {
-	return float64(m.Amount) / 100.0
+	return float64(m.Amount) / math.Pow10(CurrencyDecimals(m.Currency))
+}
+
+// currencySymbols holds the symbols Format puts before the amount.
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CAD": "CA$",
+	"AUD": "AU$",
 }
 
-// Format returns formatted money string based on currency.
+// Format returns the amount with its currency symbol, or followed by its
+// code, with thousands separators and the currency's decimal places:
+// "$1,299.00", "¥1,000", "12.345 BHD". It is FormatLocale for en-US.
 func (m Money) Format() string {
-	switch strings.ToUpper(m.Currency) {
-	case "USD":
-		return fmt.Sprintf("$%.2f", m.MajorUnit())
-	case "EUR":
-		return fmt.Sprintf("€%.2f", m.MajorUnit())
-	case "GBP":
-		return fmt.Sprintf("£%.2f", m.MajorUnit())
-	case "JPY":
-		return fmt.Sprintf("¥%.0f", m.MajorUnit()*100) // JPY doesn't use cents
-	case "CAD":
-		return fmt.Sprintf("CA$%.2f", m.MajorUnit())
-	case "AUD":
-		return fmt.Sprintf("AU$%.2f", m.MajorUnit())
+	return m.FormatLocale("en-US")
+}
+
+// moneyLocale describes how a locale writes amounts of money.
+type moneyLocale struct {
+	group, decimal string // Thousands and decimal separators
+	symbolAfter    bool   // Symbol after the number, as in "5,00 €"
+	symbolSpace    string // Between the number and a symbol, "" for none
+}
+
+// moneyLocales lists the locales FormatLocale supports. The non-breaking
+// spaces keep an amount on one line.
+var moneyLocales = map[string]moneyLocale{
+	"en-US": {group: ",", decimal: "."},
+	"en-GB": {group: ",", decimal: "."},
+	"en-CA": {group: ",", decimal: "."},
+	"en-AU": {group: ",", decimal: "."},
+	"ja-JP": {group: ",", decimal: "."},
+	"de-DE": {group: ".", decimal: ",", symbolAfter: true, symbolSpace: "\u00a0"},
+	"de-AT": {group: "\u00a0", decimal: ",", symbolSpace: "\u00a0"},
+	"de-CH": {group: "’", decimal: ".", symbolSpace: "\u00a0"},
+	"fr-FR": {group: "\u202f", decimal: ",", symbolAfter: true, symbolSpace: "\u00a0"},
+	"fr-CA": {group: "\u00a0", decimal: ",", symbolAfter: true, symbolSpace: "\u00a0"},
+	"es-ES": {group: ".", decimal: ",", symbolAfter: true, symbolSpace: "\u00a0"},
+	"it-IT": {group: ".", decimal: ",", symbolAfter: true, symbolSpace: "\u00a0"},
+	"pt-PT": {group: "\u00a0", decimal: ",", symbolAfter: true, symbolSpace: "\u00a0"},
+	"pt-BR": {group: ".", decimal: ",", symbolSpace: "\u00a0"},
+	"nl-NL": {group: ".", decimal: ",", symbolSpace: "\u00a0"},
+	"sv-SE": {group: "\u00a0", decimal: ",", symbolAfter: true, symbolSpace: "\u00a0"},
+	"nb-NO": {group: "\u00a0", decimal: ",", symbolAfter: true, symbolSpace: "\u00a0"},
+	"da-DK": {group: ".", decimal: ",", symbolAfter: true, symbolSpace: "\u00a0"},
+	"pl-PL": {group: "\u00a0", decimal: ",", symbolAfter: true, symbolSpace: "\u00a0"},
+}
+
+// moneyLanguages picks a locale for a bare language tag, or for a region
+// not in moneyLocales.
+var moneyLanguages = map[string]string{
+	"en": "en-US", "ja": "ja-JP", "de": "de-DE", "fr": "fr-FR", "es": "es-ES",
+	"it": "it-IT", "pt": "pt-PT", "nl": "nl-NL", "sv": "sv-SE", "nb": "nb-NO",
+	"no": "nb-NO", "da": "da-DK", "pl": "pl-PL",
+}
+
+// FormatLocale returns the amount formatted for a locale such as "de-DE",
+// with that locale's separators and symbol placement: "1.299,00 €" for
+// de-DE and "$1,299.00" for en-US. The spaces are non-breaking. A locale
+// that is not in the table falls back to another region of its language,
+// then to en-US. Currencies without a known symbol are followed by their
+// code, as in Format.
+func (m Money) FormatLocale(locale string) string {
+	loc, ok := moneyLocales[locale]
+	if !ok {
+		language, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+		if loc, ok = moneyLocales[moneyLanguages[strings.ToLower(language)]]; !ok {
+			loc = moneyLocales["en-US"]
+		}
+	}
+
+	code := strings.ToUpper(m.Currency)
+	number := formatMinorUnits(m.Amount, CurrencyDecimals(code), loc.group, loc.decimal)
+	sign := ""
+	if m.Amount < 0 {
+		sign = "-"
+	}
+	symbol, ok := currencySymbols[code]
+	switch {
+	case !ok:
+		return sign + number + " " + m.Currency
+	case loc.symbolAfter:
+		return sign + number + loc.symbolSpace + symbol
 	default:
-		return fmt.Sprintf("%.2f %s", m.MajorUnit(), m.Currency)
+		return sign + symbol + loc.symbolSpace + number
 	}
 }
 
+// formatMinorUnits renders the absolute value of amount with the given
+// thousands and decimal separators and number of decimal places.
+func formatMinorUnits(amount int64, decimals int, group, decimal string) string {
+	abs := uint64(amount)
+	if amount < 0 {
+		abs = -abs
+	}
+	digits := strconv.FormatUint(abs, 10)
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	whole, fraction := digits[:len(digits)-decimals], digits[len(digits)-decimals:]
+
+	var grouped strings.Builder
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(group)
+		}
+		grouped.WriteRune(r)
+	}
+	if decimals > 0 {
+		grouped.WriteString(decimal + fraction)
+	}
+	return grouped.String()
+}
+
+// moneyJSON is the JSON form of Money.
+type moneyJSON struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+// MarshalJSON encodes the amount as a decimal string in major units, with
+// the currency's decimal places, so clients need not know the minor unit:
+// {"amount": "12.99", "currency": "USD"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	amount := formatMinorUnits(m.Amount, CurrencyDecimals(m.Currency), "", ".")
+	if m.Amount < 0 {
+		amount = "-" + amount
+	}
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{amount, m.Currency})
+}
+
+// UnmarshalJSON decodes the form MarshalJSON writes without loss, as the
+// decimal is parsed exactly into minor units. An amount with more decimal
+// places than the currency has is an error. A plain number is read as
+// minor units, the form Money was encoded in before it had MarshalJSON.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var decimal string
+	if err := json.Unmarshal(raw.Amount, &decimal); err != nil {
+		var minor int64
+		if len(raw.Amount) > 0 {
+			if err := json.Unmarshal(raw.Amount, &minor); err != nil {
+				return fmt.Errorf("invalid money amount %s", raw.Amount)
+			}
+		}
+		*m = Money{Amount: minor, Currency: raw.Currency}
+		return nil
+	}
+
+	digits := strings.TrimPrefix(decimal, "-")
+	if strings.Contains(digits, ",") {
+		return fmt.Errorf("invalid money amount %q: unexpected character ','", decimal)
+	}
+	amount, err := parseMinorUnits(digits, CurrencyDecimals(raw.Currency))
+	if err != nil {
+		return fmt.Errorf("invalid money amount %q: %v", decimal, err)
+	}
+	if digits != decimal {
+		amount = -amount
+	}
+	*m = Money{Amount: amount, Currency: raw.Currency}
+	return nil
+}
+
 // Add adds another Money value (must be same currency).
 func (m Money) Add(other Money) (Money, error) {
 	if m.Currency != other.Currency {
@@ -61,6 +262,85 @@ func (m Money) Subtract(other Money) (Money, error) {
 	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
 }
 
+// Sub is Subtract.
+func (m Money) Sub(other Money) (Money, error) {
+	return m.Subtract(other)
+}
+
+// Mul multiplies the amount by a whole factor, such as a quantity.
+func (m Money) Mul(factor int64) Money {
+	return Money{Amount: m.Amount * factor, Currency: m.Currency}
+}
+
+// RoundingMode selects how MulFloat and Convert round a result that falls
+// between two minor units.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds halves away from zero: 2.5 to 3, -2.5 to -3.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds halves to the even neighbour, banker's rounding:
+	// 2.5 to 2, 3.5 to 4. It avoids the upward drift of RoundHalfUp when
+	// many rounded amounts are summed.
+	RoundHalfEven
+)
+
+// MulFloat multiplies the amount by f, such as a tax rate or a discount,
+// rounding to a whole minor unit with mode.
+func (m Money) MulFloat(f float64, mode RoundingMode) Money {
+	return Money{Amount: roundMinorUnits(float64(m.Amount)*f, mode), Currency: m.Currency}
+}
+
+// roundMinorUnits rounds a fractional minor-unit amount with mode.
+func roundMinorUnits(amount float64, mode RoundingMode) int64 {
+	if mode == RoundHalfEven {
+		return int64(math.RoundToEven(amount))
+	}
+	return int64(math.Round(amount))
+}
+
+// Allocate splits the amount into parts proportional to ratios, e.g. a
+// coupon across cart items in proportion to their totals. Minor units left
+// over from rounding down go one at a time to the earliest parts, so the
+// parts always sum to m exactly: $1.00 allocated 1:1:1 is $0.34, $0.33 and
+// $0.33. Ratios must not be negative and must not all be zero.
+func (m Money) Allocate(ratios []int) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, errors.New("cannot allocate money without ratios")
+	}
+	var total int64
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("cannot allocate money by negative ratio %d", ratio)
+		}
+		total += int64(ratio)
+	}
+	if total == 0 {
+		return nil, errors.New("cannot allocate money by ratios that sum to zero")
+	}
+
+	parts := make([]Money, len(ratios))
+	remainder := m.Amount
+	for i, ratio := range ratios {
+		share := m.Amount / total * int64(ratio)
+		share += m.Amount % total * int64(ratio) / total
+		parts[i] = Money{Amount: share, Currency: m.Currency}
+		remainder -= share
+	}
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i++ {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].Amount += step
+		remainder -= step
+	}
+	return parts, nil
+}
+
 // IsZero returns true if the amount is zero.
 func (m Money) IsZero() bool {
 	return m.Amount == 0
@@ -76,10 +356,269 @@ func (m Money) IsNegative() bool {
 	return m.Amount < 0
 }
 
-// NewMoney creates a new Money value from a major unit amount.
+// Cmp compares two Money values of the same currency, returning -1, 0 or 1.
+// It returns an error on currency mismatch instead of comparing raw amounts.
+func (m Money) Cmp(other Money) (int, error) {
+	if m.Currency != other.Currency {
+		return 0, fmt.Errorf("cannot compare different currencies: %s and %s", m.Currency, other.Currency)
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// LessThan reports whether m is less than other (must be same currency).
+func (m Money) LessThan(other Money) (bool, error) {
+	c, err := m.Cmp(other)
+	return err == nil && c < 0, err
+}
+
+// GreaterThan reports whether m is greater than other (must be same currency).
+func (m Money) GreaterThan(other Money) (bool, error) {
+	c, err := m.Cmp(other)
+	return err == nil && c > 0, err
+}
+
+// Equal reports whether m equals other (must be same currency). Like
+// LessThan and GreaterThan it reports false on a currency mismatch.
+func (m Money) Equal(other Money) (bool, error) {
+	c, err := m.Cmp(other)
+	return err == nil && c == 0, err
+}
+
+// Clamp limits m to the range [min, max]. Bounds in a different currency
+// than m are ignored.
+func (m Money) Clamp(min, max Money) Money {
+	if c, err := m.Cmp(min); err == nil && c < 0 {
+		return min
+	}
+	if c, err := m.Cmp(max); err == nil && c > 0 {
+		return max
+	}
+	return m
+}
+
+// CheckCredit reports whether a new charge fits within a credit limit given
+// the balance already outstanding. All three values must share a currency.
+// The error names the remaining credit when the charge is rejected.
+func CheckCredit(limit, outstanding, charge Money) error {
+	if limit.Currency != outstanding.Currency || limit.Currency != charge.Currency {
+		return fmt.Errorf("credit check currency mismatch: limit %s, outstanding %s, charge %s",
+			limit.Currency, outstanding.Currency, charge.Currency)
+	}
+	remaining := Money{Amount: limit.Amount - outstanding.Amount, Currency: limit.Currency}
+	if charge.Amount > remaining.Amount {
+		if remaining.Amount < 0 {
+			remaining.Amount = 0
+		}
+		return fmt.Errorf("charge of %s exceeds credit limit of %s: %s outstanding, %s remaining",
+			charge.Format(), limit.Format(), outstanding.Format(), remaining.Format())
+	}
+	return nil
+}
+
+// ExchangeRateSource supplies conversion rates between currencies, e.g.
+// from a rates service or a database table of daily rates. Rate returns how
+// many units of to one unit of from buys, e.g. Rate("USD", "EUR") = 0.92.
+type ExchangeRateSource interface {
+	Rate(from, to string) (float64, error)
+}
+
+// ErrNoExchangeRate is returned when no rate is known for a currency pair.
+var ErrNoExchangeRate = errors.New("no exchange rate")
+
+// StaticRates is a fixed table of exchange rates keyed by "FROM/TO", e.g.
+// StaticRates{"USD/EUR": 0.92}. The inverse pair is derived when only one
+// direction is listed. It suits tests and reports with pinned rates.
+type StaticRates map[string]float64
+
+// Rate implements ExchangeRateSource.
+func (r StaticRates) Rate(from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return 1, nil
+	}
+	if rate, ok := r[from+"/"+to]; ok && rate > 0 {
+		return rate, nil
+	}
+	if rate, ok := r[to+"/"+from]; ok && rate > 0 {
+		return 1 / rate, nil
+	}
+	return 0, fmt.Errorf("%w: %s to %s", ErrNoExchangeRate, from, to)
+}
+
+// Convert returns m expressed in currency to, using the rate from src and
+// rounding the converted amount to a whole minor unit with mode. Money
+// arithmetic never converts implicitly; call Convert first to combine
+// amounts in different currencies.
+func (m Money) Convert(to string, src ExchangeRateSource, mode RoundingMode) (Money, error) {
+	to = strings.ToUpper(to)
+	if strings.ToUpper(m.Currency) == to {
+		return Money{Amount: m.Amount, Currency: to}, nil
+	}
+	rate, err := src.Rate(m.Currency, to)
+	if err != nil {
+		return Money{}, err
+	}
+	if rate <= 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return Money{}, fmt.Errorf("invalid exchange rate %v for %s to %s", rate, m.Currency, to)
+	}
+	// Rates are per major unit, so scale for currencies with different minor units
+	scale := math.Pow10(CurrencyDecimals(to) - CurrencyDecimals(m.Currency))
+	return Money{Amount: roundMinorUnits(float64(m.Amount)*rate*scale, mode), Currency: to}, nil
+}
+
+// moneySymbols maps currency symbols to codes, longest first so "CA$" wins
+// over "$".
+var moneySymbols = []struct{ symbol, currency string }{
+	{"CA$", CurrencyCAD},
+	{"AU$", CurrencyAUD},
+	{"US$", CurrencyUSD},
+	{"$", CurrencyUSD},
+	{"€", CurrencyEUR},
+	{"£", CurrencyGBP},
+	{"¥", CurrencyJPY},
+}
+
+// dollarCurrencies are the currencies a bare "$" may stand for.
+var dollarCurrencies = map[string]bool{
+	CurrencyUSD: true, CurrencyCAD: true, CurrencyAUD: true, "NZD": true, "HKD": true, "SGD": true,
+}
+
+// ParseMoney parses a human-entered amount such as "$1,299.00", "24.99 USD",
+// "-5" or "(12.50)" into Money. A symbol or ISO code in the string sets the
+// currency, otherwise defaultCurrency is used; a bare "$" means the dollar
+// currency given by the code or default, or USD. Commas are accepted only as thousands
+// separators, and input that could be read more than one way, like
+// "12,50" or "€5 USD", is rejected rather than guessed.
+func ParseMoney(s, defaultCurrency string) (Money, error) {
+	text := strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")") {
+		negative = true
+		text = strings.TrimSpace(text[1 : len(text)-1])
+	}
+	if strings.HasPrefix(text, "-") {
+		negative = !negative
+		text = strings.TrimSpace(text[1:])
+	}
+
+	currency := ""
+	if fields := strings.Fields(text); len(fields) == 2 {
+		for i, field := range fields {
+			if isCurrencyCode(field) {
+				currency = strings.ToUpper(field)
+				text = fields[1-i]
+				break
+			}
+		}
+	}
+	for _, sym := range moneySymbols {
+		if strings.HasPrefix(text, sym.symbol) || strings.HasSuffix(text, sym.symbol) {
+			symbolCurrency := sym.currency
+			if sym.symbol == "$" {
+				// A bare dollar sign takes its meaning from the code or the default
+				if def := strings.ToUpper(defaultCurrency); dollarCurrencies[currency] {
+					symbolCurrency = currency
+				} else if dollarCurrencies[def] {
+					symbolCurrency = def
+				}
+			}
+			if currency != "" && currency != symbolCurrency {
+				return Money{}, fmt.Errorf("ambiguous money %q: %s conflicts with %s", s, sym.symbol, currency)
+			}
+			if currency == "" {
+				currency = symbolCurrency
+			}
+			text = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(text, sym.symbol), sym.symbol))
+			break
+		}
+	}
+	if strings.HasPrefix(text, "-") && !negative {
+		negative = true
+		text = text[1:]
+	}
+	if currency == "" {
+		currency = strings.ToUpper(defaultCurrency)
+	}
+
+	amount, err := parseMinorUnits(text, CurrencyDecimals(currency))
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid money %q: %v", s, err)
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// isCurrencyCode reports whether s looks like an ISO 4217 code.
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseMinorUnits parses a plain decimal with optional thousands separators
+// into minor units with the given decimal places, without going through
+// floating point.
+func parseMinorUnits(text string, decimals int) (int64, error) {
+	whole, fraction := text, ""
+	if dot := strings.IndexByte(text, '.'); dot >= 0 {
+		whole, fraction = text[:dot], text[dot+1:]
+	}
+	if whole == "" && fraction == "" {
+		return 0, errors.New("no digits")
+	}
+	if strings.Contains(whole, ",") {
+		groups := strings.Split(whole, ",")
+		if len(groups[0]) == 0 || len(groups[0]) > 3 {
+			return 0, errors.New("misplaced thousands separator")
+		}
+		for _, group := range groups[1:] {
+			if len(group) != 3 {
+				return 0, errors.New("misplaced thousands separator")
+			}
+		}
+		whole = strings.Join(groups, "")
+	}
+	if len(fraction) > decimals {
+		return 0, fmt.Errorf("more than %d decimal places", decimals)
+	}
+	for _, part := range []string{whole, fraction} {
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return 0, fmt.Errorf("unexpected character %q", r)
+			}
+		}
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	units, err := strconv.ParseInt(whole+fraction+strings.Repeat("0", decimals-len(fraction)), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return units, nil
+}
+
+// NewMoney creates a new Money value from a major unit amount, rounded to
+// the currency's minor unit: NewMoney(1000, "JPY") is 1,000 yen and
+// NewMoney(9.99, "USD") is 999 cents.
 func NewMoney(majorUnit float64, currency string) Money {
 	return Money{
-		Amount:   int64(majorUnit * 100), // Convert to cents
+		Amount:   int64(math.Round(majorUnit * math.Pow10(CurrencyDecimals(currency)))),
 		Currency: strings.ToUpper(currency),
 	}
 }
@@ -216,6 +755,7 @@ func (s BaseStatus) GetDescription() string { return s.Description }
 type ValidationError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"` // Source line or record number, for imported data
 }
 
 // ValidationErrors is a collection of validation errors.
@@ -242,6 +782,43 @@ func (v ValidationErrors) GetFieldErrors(field string) []string {
 	return errors
 }
 
+// ForField returns the error messages for field, in the order they were
+// added, or nil when it has none. It is GetFieldErrors.
+func (v ValidationErrors) ForField(field string) []string {
+	return v.GetFieldErrors(field)
+}
+
+// First returns the first error message for field, for forms that show one
+// message per field.
+func (v ValidationErrors) First(field string) (string, bool) {
+	for _, err := range v {
+		if err.Field == field {
+			return err.Message, true
+		}
+	}
+	return "", false
+}
+
+// ToMap groups the error messages by field, in the order they were added.
+// Messages of errors with a Line are prefixed with it, as in Error.
+func (v ValidationErrors) ToMap() map[string][]string {
+	fields := make(map[string][]string)
+	for _, err := range v {
+		message := err.Message
+		if err.Line > 0 {
+			message = fmt.Sprintf("line %d: %s", err.Line, err.Message)
+		}
+		fields[err.Field] = append(fields[err.Field], message)
+	}
+	return fields
+}
+
+// MarshalJSON encodes the errors as ToMap does, for API responses:
+// {"email": ["Email must be a valid email address"], "sku": [...]}.
+func (v ValidationErrors) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.ToMap())
+}
+
 // Error implements the error interface.
 func (v ValidationErrors) Error() string {
 	if len(v) == 0 {
@@ -249,11 +826,25 @@ func (v ValidationErrors) Error() string {
 	}
 	var messages []string
 	for _, err := range v {
+		if err.Line > 0 {
+			messages = append(messages, fmt.Sprintf("line %d: %s: %s", err.Line, err.Field, err.Message))
+			continue
+		}
 		messages = append(messages, fmt.Sprintf("%s: %s", err.Field, err.Message))
 	}
 	return strings.Join(messages, "; ")
 }
 
+// AtLine returns the errors with Line set to line where it is not set yet.
+func (v ValidationErrors) AtLine(line int) ValidationErrors {
+	for i := range v {
+		if v[i].Line == 0 {
+			v[i].Line = line
+		}
+	}
+	return v
+}
+
 // =====================================================
 // VALIDATION FUNCTIONS
 // =====================================================
@@ -276,6 +867,106 @@ func ValidateEmail(field, email, fieldName string, errors *ValidationErrors) {
 	}
 }
 
+// ValidatePhone validates a phone number in E.164 form, "+14155550123",
+// or a common US form such as "(415) 555-0123", "415-555-0123" or
+// "1 415 555 0123". Spaces, dashes, dots and parentheses are allowed
+// between digits.
+func ValidatePhone(field, phone, fieldName string, errors *ValidationErrors) {
+	phone = strings.TrimSpace(phone)
+	if phone == "" {
+		return // Use ValidateRequired for empty check
+	}
+	if !isPhoneNumber(phone) {
+		errors.Add(field, fmt.Sprintf("%s must be a valid phone number, e.g. +14155550123 or (415) 555-0123", fieldName))
+	}
+}
+
+// isPhoneNumber reports whether phone is an E.164 or US phone number.
+func isPhoneNumber(phone string) bool {
+	international := strings.HasPrefix(phone, "+")
+	var digits []byte
+	for _, c := range []byte(strings.TrimPrefix(phone, "+")) {
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c == ' ' || c == '-' || c == '.' || c == '(' || c == ')':
+		default:
+			return false
+		}
+	}
+	if international {
+		// E.164: a country code that does not start with 0, up to 15 digits
+		return len(digits) >= 8 && len(digits) <= 15 && digits[0] != '0'
+	}
+	if len(digits) == 11 && digits[0] == '1' {
+		digits = digits[1:]
+	}
+	// NANP: area code and exchange both start with 2-9
+	return len(digits) == 10 && digits[0] >= '2' && digits[3] >= '2'
+}
+
+// ValidateURL validates an absolute http or https URL with a host.
+func ValidateURL(field, value, fieldName string, errors *ValidationErrors) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return // Use ValidateRequired for empty check
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errors.Add(field, fmt.Sprintf("%s must be a valid http or https URL", fieldName))
+	}
+}
+
+// validateRegexCache holds the patterns ValidateRegex has compiled.
+var validateRegexCache sync.Map
+
+// ValidateRegex validates that value matches pattern, a regular expression
+// in Go syntax. Anchor the pattern with ^ and $ to match the whole value.
+// Compiled patterns are cached; an invalid pattern panics, as it is a
+// programming error.
+//
+//	mt.ValidateRegex("sku", product.SKU, "SKU", `^[A-Z]{3}-\d{4}$`, &errs)
+func ValidateRegex(field, value, fieldName, pattern string, errors *ValidationErrors) {
+	if strings.TrimSpace(value) == "" {
+		return // Use ValidateRequired for empty check
+	}
+	re, ok := validateRegexCache.Load(pattern)
+	if !ok {
+		re, _ = validateRegexCache.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	}
+	if !re.(*regexp.Regexp).MatchString(value) {
+		errors.Add(field, fmt.Sprintf("%s has an invalid format", fieldName))
+	}
+}
+
+// ValidateRange validates that value lies between min and max, inclusive,
+// e.g. "Weight must be between 0.1 and 1000".
+func ValidateRange(field string, value, min, max float64, fieldName string, errors *ValidationErrors) {
+	if math.IsNaN(value) || value < min || value > max {
+		errors.Add(field, fmt.Sprintf("%s must be between %s and %s", fieldName,
+			strconv.FormatFloat(min, 'f', -1, 64), strconv.FormatFloat(max, 'f', -1, 64)))
+	}
+}
+
+// ValidateLength validates that value has between min and max characters,
+// inclusive, counting runes rather than bytes. A max of zero or less leaves
+// the length unbounded above. Like the other string validators it accepts an
+// empty value; use ValidateRequired for that.
+func ValidateLength(field, value string, min, max int, fieldName string, errors *ValidationErrors) {
+	if value == "" {
+		return // Use ValidateRequired for empty check
+	}
+	n := utf8.RuneCountInString(value)
+	switch {
+	case max > 0 && min > 0 && (n < min || n > max):
+		errors.Add(field, fmt.Sprintf("%s must be between %d and %d characters", fieldName, min, max))
+	case n < min:
+		errors.Add(field, fmt.Sprintf("%s must be at least %d characters", fieldName, min))
+	case max > 0 && n > max:
+		errors.Add(field, fmt.Sprintf("%s must be at most %d characters", fieldName, max))
+	}
+}
+
 // ValidateMoneyAmount validates money amount is positive.
 func ValidateMoneyAmount(field string, money Money, fieldName string, errors *ValidationErrors) {
 	if money.Amount <= 0 {
@@ -303,6 +994,243 @@ func DaysAgo(date string) int {
 	return 0
 }
 
+// =====================================================
+// AUDIT EVENTS
+// =====================================================
+
+// Audit actions recorded by domain services.
+const (
+	AuditCreate       = "create"
+	AuditUpdate       = "update"
+	AuditStatusChange = "status_change"
+)
+
+// AuditChange holds the before and after values of a changed field.
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditEvent describes a change to a domain entity.
+type AuditEvent struct {
+	Actor      string                 `json:"actor,omitempty"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   string                 `json:"entity_id"`
+	Action     string                 `json:"action"`
+	Changes    map[string]AuditChange `json:"changes,omitempty"` // Keyed by JSON field name
+	Timestamp  time.Time              `json:"timestamp"`
+}
+
+// AuditSink receives audit events from domain services.
+type AuditSink interface {
+	Record(event AuditEvent)
+}
+
+// AuditSinkFunc adapts a function to the AuditSink interface.
+type AuditSinkFunc func(event AuditEvent)
+
+// Record calls f(event).
+func (f AuditSinkFunc) Record(event AuditEvent) { f(event) }
+
+type actorKey struct{}
+
+// ContextWithActor returns a context carrying the actor recorded on audit events.
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by ContextWithActor, or "".
+func ActorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// NewAuditEvent builds an audit event, taking the actor from ctx and
+// diffing before and after. Either may be nil for creates and deletes.
+func NewAuditEvent(ctx context.Context, entityType, entityID, action string, before, after interface{}) AuditEvent {
+	return AuditEvent{
+		Actor:      ActorFromContext(ctx),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Changes:    DiffFields(before, after),
+		Timestamp:  time.Now(),
+	}
+}
+
+// AuditSnapshot captures a value's fields as they are now, so later changes
+// through shared maps or pointers do not alter the recorded "before" state.
+func AuditSnapshot(v interface{}) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// DiffFields compares two values field by field, using their JSON field
+// names, and returns the fields that differ.
+func DiffFields(before, after interface{}) map[string]AuditChange {
+	b, a := AuditSnapshot(before), AuditSnapshot(after)
+	changes := make(map[string]AuditChange)
+	for field, value := range a {
+		if old, ok := b[field]; !ok || !reflect.DeepEqual(old, value) {
+			changes[field] = AuditChange{Before: b[field], After: value}
+		}
+	}
+	for field, old := range b {
+		if _, ok := a[field]; !ok {
+			changes[field] = AuditChange{Before: old}
+		}
+	}
+	return changes
+}
+
+// =====================================================
+// IMPORT HELPERS
+// =====================================================
+
+// ImportRow is one data row of a CSV file, with values looked up by header.
+// Header names are matched case-insensitively, with spaces read as
+// underscores, so "Low Stock Level" matches "low_stock_level".
+type ImportRow struct {
+	Line   int // Line the row starts on, counting the header as line 1
+	header map[string]int
+	record []string
+}
+
+// Has reports whether the file has the column.
+func (r ImportRow) Has(column string) bool {
+	_, ok := r.header[column]
+	return ok
+}
+
+// Get returns the trimmed value of column, or "" if the file has no such column.
+func (r ImportRow) Get(column string) string {
+	if i, ok := r.header[column]; ok && i < len(r.record) {
+		return strings.TrimSpace(r.record[i])
+	}
+	return ""
+}
+
+// Int parses column as an integer. An empty value is 0; an invalid one adds
+// an error to errs.
+func (r ImportRow) Int(column string, errs *ValidationErrors) int {
+	value := r.Get(column)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		errs.Add(column, fmt.Sprintf("%q is not a whole number", value))
+	}
+	return n
+}
+
+// Float parses column as a decimal number. An empty value is 0; an invalid
+// one adds an error to errs.
+func (r ImportRow) Float(column string, errs *ValidationErrors) float64 {
+	value := r.Get(column)
+	if value == "" {
+		return 0
+	}
+	f, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+	if err != nil {
+		errs.Add(column, fmt.Sprintf("%q is not a number", value))
+	}
+	return f
+}
+
+// Money parses column with ParseMoney. An empty value is zero in
+// defaultCurrency; an invalid one adds an error to errs.
+func (r ImportRow) Money(column, defaultCurrency string, errs *ValidationErrors) Money {
+	value := r.Get(column)
+	if value == "" {
+		return Money{Currency: strings.ToUpper(defaultCurrency)}
+	}
+	m, err := ParseMoney(value, defaultCurrency)
+	if err != nil {
+		errs.Add(column, err.Error())
+	}
+	return m
+}
+
+// EachCSVRow reads CSV from r one row at a time, calling fn for each row
+// after the header, so files of any size are processed in constant memory.
+// Rows may be shorter than the header; missing values read as "". It stops
+// at the first malformed line or error returned by fn.
+func EachCSVRow(r io.Reader, fn func(row ImportRow) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	names, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	header := make(map[string]int, len(names))
+	for i, name := range names {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		header[strings.ReplaceAll(name, " ", "_")] = i
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue // Blank line
+		}
+		line, _ := reader.FieldPos(0)
+		if err := fn(ImportRow{Line: line, header: header, record: record}); err != nil {
+			return err
+		}
+	}
+}
+
+// EachJSONRecord decodes a JSON array from r one element at a time, calling
+// fn with the 1-based record number and the decoded value. A value of the
+// wrong shape for T is passed to fn with its error, so the caller can report
+// it and carry on; malformed JSON stops the scan.
+func EachJSONRecord[T any](r io.Reader, fn func(record int, item T, err error) error) error {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return errors.New("expected a JSON array")
+	}
+	for record := 1; dec.More(); record++ {
+		var item T
+		err := dec.Decode(&item)
+		var typeErr *json.UnmarshalTypeError
+		if err != nil && !errors.As(err, &typeErr) {
+			return err
+		}
+		if err := fn(record, item, err); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
 // =====================================================
 // CONSTANTS
 // =====================================================
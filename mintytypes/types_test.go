@@ -0,0 +1,363 @@
+package mintytypes
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMoneyMulFloat(t *testing.T) {
+	price := Money{Amount: 5, Currency: CurrencyUSD}
+	tests := []struct {
+		f    float64
+		mode RoundingMode
+		want int64
+	}{
+		{0.5, RoundHalfUp, 3},
+		{0.5, RoundHalfEven, 2},
+		{1.5, RoundHalfEven, 8},
+		{-0.5, RoundHalfUp, -3},
+		{-0.5, RoundHalfEven, -2},
+	}
+	for _, tt := range tests {
+		if got := price.MulFloat(tt.f, tt.mode); got.Amount != tt.want || got.Currency != price.Currency {
+			t.Errorf("MulFloat(%v, %v) = %+v, want %d", tt.f, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	tests := []struct {
+		amount int64
+		ratios []int
+		want   []int64
+	}{
+		{100, []int{1, 1, 1}, []int64{34, 33, 33}},
+		{5, []int{3, 7}, []int64{2, 3}},
+		{1000, []int{0, 1, 1}, []int64{0, 500, 500}},
+		{-100, []int{1, 1, 1}, []int64{-34, -33, -33}},
+		{1, []int{0, 1, 1}, []int64{0, 1, 0}},
+	}
+	for _, tt := range tests {
+		parts, err := Money{Amount: tt.amount, Currency: CurrencyUSD}.Allocate(tt.ratios)
+		if err != nil {
+			t.Fatalf("Allocate(%d, %v): %v", tt.amount, tt.ratios, err)
+		}
+		var sum int64
+		for i, part := range parts {
+			if part.Amount != tt.want[i] || part.Currency != CurrencyUSD {
+				t.Errorf("Allocate(%d, %v)[%d] = %+v, want %d", tt.amount, tt.ratios, i, part, tt.want[i])
+			}
+			sum += part.Amount
+		}
+		if sum != tt.amount {
+			t.Errorf("Allocate(%d, %v) parts sum to %d", tt.amount, tt.ratios, sum)
+		}
+	}
+
+	for _, ratios := range [][]int{nil, {0, 0}, {1, -1}} {
+		if _, err := (Money{Amount: 100}).Allocate(ratios); err == nil {
+			t.Errorf("Allocate(%v) should fail", ratios)
+		}
+	}
+}
+
+func TestParseMoneyRoundTrip(t *testing.T) {
+	amounts := []Money{
+		{Amount: 129900, Currency: CurrencyUSD},
+		{Amount: -2499, Currency: CurrencyUSD},
+		{Amount: 5, Currency: CurrencyEUR},
+		{Amount: 1000, Currency: CurrencyJPY},
+		{Amount: 12345, Currency: "BHD"},
+		{Amount: 123456789, Currency: "KWD"},
+		{Amount: 0, Currency: CurrencyGBP},
+		{Amount: 250000, Currency: CurrencyCAD},
+	}
+	for _, m := range amounts {
+		got, err := ParseMoney(m.Format(), CurrencyUSD)
+		if err != nil {
+			t.Errorf("ParseMoney(%q): %v", m.Format(), err)
+			continue
+		}
+		if got != m {
+			t.Errorf("ParseMoney(%q) = %+v, want %+v", m.Format(), got, m)
+		}
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in, def string
+		want    Money
+	}{
+		{"$1,299.00", CurrencyEUR, Money{Amount: 129900, Currency: CurrencyUSD}},
+		{"24.99 USD", CurrencyEUR, Money{Amount: 2499, Currency: CurrencyUSD}},
+		{"1,000", CurrencyJPY, Money{Amount: 1000, Currency: CurrencyJPY}},
+		{"12.5 BHD", CurrencyUSD, Money{Amount: 12500, Currency: "BHD"}},
+		{"(12.50)", CurrencyUSD, Money{Amount: -1250, Currency: CurrencyUSD}},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in, tt.def)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseMoney(%q, %s) = %+v, %v, want %+v", tt.in, tt.def, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"12,50", "€5 USD", "1.5 JPY", "12.3456 BHD", "", "abc", "1,2345"} {
+		if got, err := ParseMoney(in, CurrencyUSD); err == nil {
+			t.Errorf("ParseMoney(%q) = %+v, want an error", in, got)
+		}
+	}
+}
+
+func TestFormatLocale(t *testing.T) {
+	tests := []struct {
+		m      Money
+		locale string
+		want   string
+	}{
+		{Money{Amount: 129900, Currency: CurrencyEUR}, "de-DE", "1.299,00\u00a0€"},
+		{Money{Amount: 129900, Currency: CurrencyUSD}, "en-US", "$1,299.00"},
+		{Money{Amount: -129900, Currency: CurrencyEUR}, "fr-FR", "-1\u202f299,00\u00a0€"},
+		{Money{Amount: 129900, Currency: CurrencyEUR}, "nl-NL", "€\u00a01.299,00"},
+		{Money{Amount: 100000, Currency: CurrencyJPY}, "ja-JP", "¥100,000"},
+		{Money{Amount: 12345, Currency: "BHD"}, "de-DE", "12,345 BHD"},
+		{Money{Amount: 129900, Currency: CurrencyEUR}, "de_LU", "1.299,00\u00a0€"},
+		{Money{Amount: 129900, Currency: CurrencyEUR}, "xx-YY", "€1,299.00"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.FormatLocale(tt.locale); got != tt.want {
+			t.Errorf("FormatLocale(%s) of %+v = %q, want %q", tt.locale, tt.m, got, tt.want)
+		}
+	}
+	for _, m := range []Money{{Amount: -2499, Currency: CurrencyGBP}, {Amount: 5, Currency: "CHF"}} {
+		if m.Format() != m.FormatLocale("en-US") {
+			t.Errorf("Format() = %q, want the en-US format %q", m.Format(), m.FormatLocale("en-US"))
+		}
+	}
+}
+
+func TestSetCurrencyDecimals(t *testing.T) {
+	if err := SetCurrencyDecimals("xts", 4); err != nil {
+		t.Fatalf("SetCurrencyDecimals: %v", err)
+	}
+	if got, _ := json.Marshal(Money{Amount: 12345, Currency: "XTS"}); string(got) != `{"amount":"1.2345","currency":"XTS"}` {
+		t.Errorf("Money with 4 decimals = %s", got)
+	}
+	for _, decimals := range []int{-1, 19} {
+		if err := SetCurrencyDecimals("XTS", decimals); err == nil {
+			t.Errorf("SetCurrencyDecimals(%d) should fail", decimals)
+		}
+	}
+	if got := CurrencyDecimals("XTS"); got != 4 {
+		t.Errorf("rejected decimals changed the table: %d", got)
+	}
+}
+
+func TestMoneyCmp(t *testing.T) {
+	subtotal := Money{Amount: 9999, Currency: CurrencyUSD}
+	threshold := Money{Amount: 10000, Currency: CurrencyUSD}
+	if c, err := subtotal.Cmp(threshold); err != nil || c != -1 {
+		t.Errorf("Cmp = %d, %v, want -1", c, err)
+	}
+	if below, _ := subtotal.LessThan(threshold); !below {
+		t.Error("LessThan should be true")
+	}
+	if above, _ := threshold.GreaterThan(subtotal); !above {
+		t.Error("GreaterThan should be true")
+	}
+	if equal, _ := threshold.Equal(Money{Amount: 10000, Currency: CurrencyUSD}); !equal {
+		t.Error("Equal should be true")
+	}
+	if _, err := subtotal.Cmp(Money{Amount: 9999, Currency: CurrencyEUR}); err == nil {
+		t.Error("comparing USD to EUR should fail")
+	}
+	if equal, err := subtotal.Equal(Money{Amount: 9999, Currency: CurrencyEUR}); equal || err == nil {
+		t.Errorf("Equal across currencies = %v, %v, want false and an error", equal, err)
+	}
+}
+
+func TestMoneyClamp(t *testing.T) {
+	usd := func(amount int64) Money { return Money{Amount: amount, Currency: CurrencyUSD} }
+	min, max := usd(500), usd(2000)
+	tests := []struct {
+		desc     string
+		m        Money
+		min, max Money
+		want     Money
+	}{
+		{"within", usd(1200), min, max, usd(1200)},
+		{"below", usd(100), min, max, min},
+		{"above", usd(5000), min, max, max},
+		{"on the bounds", usd(2000), min, max, max},
+		{"bounds in another currency are ignored", usd(100), Money{Amount: 500, Currency: CurrencyEUR}, max, usd(100)},
+		{"only the matching bound applies", usd(5000), Money{Amount: 500, Currency: CurrencyEUR}, max, max},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Clamp(tt.min, tt.max); got != tt.want {
+			t.Errorf("%s: Clamp = %+v, want %+v", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyConvert(t *testing.T) {
+	rates := StaticRates(map[string]float64{"USD/EUR": 0.9, "USD/JPY": 150})
+	tests := []struct {
+		m    Money
+		to   string
+		mode RoundingMode
+		want Money
+	}{
+		{Money{Amount: 1000, Currency: CurrencyUSD}, CurrencyEUR, RoundHalfUp, Money{Amount: 900, Currency: CurrencyEUR}},
+		{Money{Amount: 900, Currency: CurrencyEUR}, CurrencyUSD, RoundHalfUp, Money{Amount: 1000, Currency: CurrencyUSD}},
+		{Money{Amount: 1999, Currency: CurrencyUSD}, CurrencyJPY, RoundHalfUp, Money{Amount: 2999, Currency: CurrencyJPY}},
+		{Money{Amount: 1999, Currency: CurrencyUSD}, CurrencyJPY, RoundHalfEven, Money{Amount: 2998, Currency: CurrencyJPY}},
+		{Money{Amount: 42, Currency: CurrencyUSD}, "usd", RoundHalfUp, Money{Amount: 42, Currency: CurrencyUSD}},
+	}
+	for _, tt := range tests {
+		got, err := tt.m.Convert(tt.to, rates, tt.mode)
+		if err != nil || got != tt.want {
+			t.Errorf("Convert(%+v, %s) = %+v, %v, want %+v", tt.m, tt.to, got, err, tt.want)
+		}
+	}
+	if _, err := (Money{Amount: 1, Currency: CurrencyGBP}).Convert(CurrencyEUR, rates, RoundHalfUp); !errors.Is(err, ErrNoExchangeRate) {
+		t.Errorf("Convert without a rate = %v, want ErrNoExchangeRate", err)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{Money{Amount: 1299, Currency: CurrencyUSD}, `{"amount":"12.99","currency":"USD"}`},
+		{Money{Amount: -5, Currency: CurrencyEUR}, `{"amount":"-0.05","currency":"EUR"}`},
+		{Money{Amount: 1000, Currency: CurrencyJPY}, `{"amount":"1000","currency":"JPY"}`},
+		{Money{Amount: 12345, Currency: "BHD"}, `{"amount":"12.345","currency":"BHD"}`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.m)
+		if err != nil || string(data) != tt.want {
+			t.Errorf("Marshal(%+v) = %s, %v, want %s", tt.m, data, err, tt.want)
+		}
+		var back Money
+		if err := json.Unmarshal(data, &back); err != nil || back != tt.m {
+			t.Errorf("Unmarshal(%s) = %+v, %v, want %+v", data, back, err, tt.m)
+		}
+	}
+
+	var legacy Money
+	if err := json.Unmarshal([]byte(`{"amount":1299,"currency":"USD"}`), &legacy); err != nil || legacy.Amount != 1299 {
+		t.Errorf("Unmarshal of minor units = %+v, %v", legacy, err)
+	}
+	for _, in := range []string{`{"amount":"12.999","currency":"USD"}`, `{"amount":"1,299.00","currency":"USD"}`, `{"amount":true}`} {
+		var m Money
+		if err := json.Unmarshal([]byte(in), &m); err == nil {
+			t.Errorf("Unmarshal(%s) = %+v, want an error", in, m)
+		}
+	}
+}
+
+func TestValidators(t *testing.T) {
+	valid := []string{"+14155550123", "+44 20 7946 0958", "(415) 555-0123", "415-555-0123", "415.555.0123", "1 415 555 0123", ""}
+	for _, phone := range valid {
+		var errs ValidationErrors
+		ValidatePhone("phone", phone, "Phone", &errs)
+		if errs.HasErrors() {
+			t.Errorf("ValidatePhone(%q) = %v, want no error", phone, errs)
+		}
+	}
+	for _, phone := range []string{"555-0123", "+0123456789", "415-555-012a", "(015) 555-0123", "+1234567890123456"} {
+		var errs ValidationErrors
+		ValidatePhone("phone", phone, "Phone", &errs)
+		if !errs.HasErrors() {
+			t.Errorf("ValidatePhone(%q) should fail", phone)
+		}
+	}
+
+	for url, ok := range map[string]bool{
+		"https://example.com/track?id=1": true,
+		"http://localhost:8080":          true,
+		"":                               true,
+		"example.com":                    false,
+		"ftp://example.com":              false,
+		"javascript:alert(1)":            false,
+	} {
+		var errs ValidationErrors
+		ValidateURL("website", url, "Website", &errs)
+		if errs.HasErrors() == ok {
+			t.Errorf("ValidateURL(%q) errors = %v, want valid %v", url, errs, ok)
+		}
+	}
+
+	var errs ValidationErrors
+	ValidateRegex("sku", "ABC-1234", "SKU", `^[A-Z]{3}-\d{4}$`, &errs)
+	ValidateRegex("sku", "", "SKU", `^[A-Z]{3}-\d{4}$`, &errs)
+	if errs.HasErrors() {
+		t.Errorf("ValidateRegex should accept a match and an empty value: %v", errs)
+	}
+	ValidateRegex("sku", "abc-12", "SKU", `^[A-Z]{3}-\d{4}$`, &errs)
+	if len(errs) != 1 || errs[0].Field != "sku" {
+		t.Errorf("ValidateRegex errors = %v, want one for sku", errs)
+	}
+}
+
+func TestValidateRangeAndLength(t *testing.T) {
+	var errs ValidationErrors
+	ValidateRange("weight", 0.1, 0.1, 1000, "Weight", &errs)
+	ValidateRange("weight", 1000, 0.1, 1000, "Weight", &errs)
+	ValidateLength("sku", "WBH-001", 3, 32, "SKU", &errs)
+	ValidateLength("sku", "", 3, 32, "SKU", &errs)
+	ValidateLength("name", "Åsa", 3, 0, "Name", &errs)
+	if errs.HasErrors() {
+		t.Fatalf("values within bounds should pass: %v", errs)
+	}
+
+	tests := []struct {
+		validate func(*ValidationErrors)
+		want     string
+	}{
+		{func(e *ValidationErrors) { ValidateRange("weight", 0, 0.1, 1000, "Weight", e) }, "Weight must be between 0.1 and 1000"},
+		{func(e *ValidationErrors) { ValidateRange("weight", math.NaN(), 0.1, 1000, "Weight", e) }, "Weight must be between 0.1 and 1000"},
+		{func(e *ValidationErrors) { ValidateLength("sku", "AB", 3, 32, "SKU", e) }, "SKU must be between 3 and 32 characters"},
+		{func(e *ValidationErrors) { ValidateLength("name", "Al", 3, 0, "Name", e) }, "Name must be at least 3 characters"},
+		{func(e *ValidationErrors) { ValidateLength("code", "ABCDE", 0, 4, "Code", e) }, "Code must be at most 4 characters"},
+	}
+	for _, tt := range tests {
+		var errs ValidationErrors
+		tt.validate(&errs)
+		if len(errs) != 1 || errs[0].Message != tt.want {
+			t.Errorf("errors = %v, want %q", errs, tt.want)
+		}
+	}
+}
+
+func TestValidationErrorsJSON(t *testing.T) {
+	var errs ValidationErrors
+	ValidateRequired("name", "", "Name", &errs)
+	errs.Add("sku", "SKU is required")
+	errs.Add("sku", "SKU must be between 3 and 32 characters")
+
+	data, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":["Name is required"],"sku":["SKU is required","SKU must be between 3 and 32 characters"]}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+	if data, _ := json.Marshal(ValidationErrors(nil)); string(data) != "{}" {
+		t.Errorf("Marshal of no errors = %s, want {}", data)
+	}
+
+	if msg, ok := errs.First("sku"); !ok || msg != "SKU is required" {
+		t.Errorf("First(sku) = %q, %v", msg, ok)
+	}
+	if _, ok := errs.First("email"); ok {
+		t.Error("First(email) should report no error")
+	}
+	if got := errs.AtLine(3).ToMap()["name"]; len(got) != 1 || got[0] != "line 3: Name is required" {
+		t.Errorf("ToMap with lines = %v", got)
+	}
+}
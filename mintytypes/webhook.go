@@ -0,0 +1,33 @@
+package mintytypes
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// =====================================================
+// WEBHOOKS
+// =====================================================
+
+// WebhookEvent is the envelope every webhook delivery carries. The
+// mintyhook package delivers it to receivers.
+type WebhookEvent struct {
+	ID        string      `json:"id"`   // Unique per event; receivers use it to drop duplicates
+	Type      string      `json:"type"` // e.g. "order.shipped"
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// NewWebhookEvent wraps data in an envelope with a new ID and the current
+// time.
+func NewWebhookEvent(eventType string, data interface{}) WebhookEvent {
+	id := make([]byte, 12)
+	rand.Read(id)
+	return WebhookEvent{
+		ID:        "evt_" + hex.EncodeToString(id),
+		Type:      eventType,
+		Timestamp: time.Now().UTC(),
+		Data:      data,
+	}
+}
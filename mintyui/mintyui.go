@@ -133,11 +133,17 @@ func StatusIndicator(theme Theme, status mintyex.Status) mi.H {
 
 // StatsCard creates a statistics display card
 func StatsCard(theme Theme, title, value, description string) mi.H {
+	return TokenStatsCard(mi.DefaultThemeTokens(), title, value, description)
+}
+
+// TokenStatsCard creates a statistics display card styled from design tokens
+func TokenStatsCard(tokens mi.ThemeTokens, title, value, description string) mi.H {
 	return func(b *mi.Builder) mi.Node {
-		cardStyle := "border: 1px solid #e2e8f0; border-radius: 8px; padding: 20px; text-align: center; background: white;"
-		titleStyle := "font-size: 14px; color: #64748b; margin: 0 0 8px 0; text-transform: uppercase; letter-spacing: 0.05em;"
-		valueStyle := "font-size: 32px; font-weight: 700; color: #1e293b; margin: 0 0 8px 0;"
-		descStyle := "font-size: 12px; color: #64748b; margin: 0;"
+		c := tokens.Colors
+		cardStyle := fmt.Sprintf("border: 1px solid %s; border-radius: %s; padding: 20px; text-align: center; background: %s;", c.Border, tokens.Radius.Medium, c.Surface)
+		titleStyle := fmt.Sprintf("font-size: 14px; color: %s; margin: 0 0 8px 0; text-transform: uppercase; letter-spacing: 0.05em;", c.Muted)
+		valueStyle := fmt.Sprintf("font-size: 32px; font-weight: 700; color: %s; margin: 0 0 8px 0;", c.Text)
+		descStyle := fmt.Sprintf("font-size: 12px; color: %s; margin: 0;", c.Muted)
 		
 		return b.Div(mi.Style(cardStyle),
 			b.P(mi.Style(titleStyle), title),
@@ -147,6 +153,20 @@ func StatsCard(theme Theme, title, value, description string) mi.H {
 	}
 }
 
+// TokenBadge creates a badge whose colors come from the token severity palette
+func TokenBadge(tokens mi.ThemeTokens, text, severity string) mi.H {
+	return func(b *mi.Builder) mi.Node {
+		fg, bg := tokens.Severity(severity)
+		style := fmt.Sprintf("display: inline-flex; align-items: center; padding: 2px 10px; border-radius: %s; font-size: 12px; font-weight: 500; color: %s; background: %s;", tokens.Radius.Pill, fg, bg)
+		return b.Span(mi.Style(style), text)
+	}
+}
+
+// TokenStatusIndicator creates a status badge colored by the status severity
+func TokenStatusIndicator(tokens mi.ThemeTokens, status mintyex.Status) mi.H {
+	return TokenBadge(tokens, status.GetDisplay(), status.GetSeverity())
+}
+
 // Dashboard creates a dashboard layout with sidebar and main content
 func Dashboard(theme Theme, title string, sidebar mi.H, content mi.H) mi.H {
 	return func(b *mi.Builder) mi.Node {
@@ -0,0 +1,239 @@
+package minty
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// =====================================================
+// XML AND PLAIN-TEXT OUTPUT
+// =====================================================
+
+// renderWriter carries the render mode chosen by RenderOptions or
+// RenderStream through Node.Render. Element.Render passes it unchanged to
+// its children.
+type renderWriter struct {
+	io.Writer
+	canonical bool // Attributes in canonical order, see WithCanonicalAttributes
+	xml       bool // XML serialization, see WithXML
+	text      bool // Plain text, see WithText
+
+	// Plain-text and indentation state
+	started bool // Some output has been written
+	space   bool // A collapsed space is owed before the next text
+	breaks  int  // Line breaks owed before the next text
+
+	// Indentation state, see WithIndent
+	indent    string // One level of indentation; empty disables it
+	depth     int    // Current indentation level
+	verbatim  int    // Depth inside elements whose content is kept as is, also in plain text
+	breakNext bool   // Inline content owes a line break, after a block
+
+	// Minification state, see WithMinify
+	minified *strings.Builder // The buffered page
+	rawSpans [][2]int         // Byte ranges of minified written by Raw nodes
+
+	// Streaming state, see RenderStream
+	flusher    http.Flusher
+	flushEvery int // Flush after this many elements; zero never flushes mid-render
+	elements   int // Elements rendered since the last flush
+}
+
+// xmlHeader is the XML declaration written by WithXML renders.
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8"?>` + "\n"
+
+// WithXML serializes the tree as XML instead of HTML, so the builder can
+// produce sitemaps, RSS and Atom feeds: the output starts with an XML
+// declaration, every element without children is self-closed, and text and
+// attribute values are escaped by XML rules, with characters XML cannot
+// represent replaced by U+FFFD. Raw nodes are written as they are, e.g. for
+// CDATA sections. WithMinify is ignored. RenderXML is the shorthand for
+// responses.
+//
+//	mi.RenderFile(sitemap(pages), "public/sitemap.xml", mi.WithXML())
+func WithXML() RenderOption {
+	return func(c *renderConfig) { c.xml = true }
+}
+
+// WithText renders the tree as plain text, e.g. the text part of an email:
+// tags are dropped, whitespace collapses as a browser would collapse it,
+// except inside pre and textarea, block elements such as paragraphs and
+// headings start new lines, list items are bulleted and links are followed
+// by their URL in parentheses. Raw nodes, scripts, styles and the document
+// head are left out. WithMinify is ignored. RenderText is the shorthand for
+// responses.
+func WithText() RenderOption {
+	return func(c *renderConfig) { c.text = true }
+}
+
+// RenderXML renders a template as XML, see WithXML. When w is an
+// http.ResponseWriter without a Content-Type, it is set to application/xml;
+// set application/rss+xml or application/atom+xml first for feeds.
+//
+//	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+//	mi.RenderXML(mi.RSS(channel), w)
+func RenderXML(template H, w io.Writer, opts ...RenderOption) error {
+	setDefaultContentType(w, "application/xml; charset=utf-8")
+	return Render(template, w, append(opts, WithXML())...)
+}
+
+// RenderText renders a template as plain text, see WithText. When w is an
+// http.ResponseWriter without a Content-Type, it is set to text/plain.
+func RenderText(template H, w io.Writer, opts ...RenderOption) error {
+	setDefaultContentType(w, "text/plain; charset=utf-8")
+	return Render(template, w, append(opts, WithText())...)
+}
+
+// setDefaultContentType sets the Content-Type of a response that has none.
+func setDefaultContentType(w io.Writer, contentType string) {
+	if rw, ok := w.(http.ResponseWriter); ok && rw.Header().Get("Content-Type") == "" {
+		rw.Header().Set("Content-Type", contentType)
+	}
+}
+
+// xmlEscapeText escapes element content by XML rules.
+func xmlEscapeText(s string) string {
+	return xmlEscape(s, false)
+}
+
+// xmlEscapeAttr escapes an attribute value by XML rules. Tabs and line
+// breaks are escaped too, as attribute normalization would turn them into
+// spaces.
+func xmlEscapeAttr(s string) string {
+	return xmlEscape(s, true)
+}
+
+func xmlEscape(s string, attr bool) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '&':
+			sb.WriteString("&amp;")
+		case r == '<':
+			sb.WriteString("&lt;")
+		case r == '>':
+			sb.WriteString("&gt;")
+		case r == '"' && attr:
+			sb.WriteString("&quot;")
+		case r == '\'' && attr:
+			sb.WriteString("&apos;")
+		case r == '\r' || attr && (r == '\t' || r == '\n'):
+			fmt.Fprintf(&sb, "&#x%X;", r)
+		case !isXMLChar(r):
+			sb.WriteRune(unicode.ReplacementChar)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// isXMLChar reports whether r may appear in an XML 1.0 document.
+func isXMLChar(r rune) bool {
+	return r == '\t' || r == '\n' || r == '\r' ||
+		r >= 0x20 && r <= 0xD7FF ||
+		r >= 0xE000 && r <= 0xFFFD ||
+		r >= 0x10000 && r <= 0x10FFFF
+}
+
+// textSkipped are elements whose content never shows in plain text.
+var textSkipped = map[string]bool{
+	"head": true, "script": true, "style": true, "template": true,
+}
+
+// renderText writes an element as plain text.
+func (e *Element) renderText(w *renderWriter) error {
+	if textSkipped[e.Tag] {
+		return nil
+	}
+	if e.Tag == "br" {
+		if w.started {
+			w.breaks++
+		}
+		w.space = false
+		return nil
+	}
+
+	breaks := 0
+	if blockElements[e.Tag] {
+		breaks = 1
+		switch e.Tag {
+		case "p", "h1", "h2", "h3", "h4", "h5", "h6", "ul", "ol", "table", "pre", "blockquote":
+			breaks = 2
+		}
+		w.lineBreak(breaks)
+	}
+	if e.Tag == "li" {
+		if err := w.writeText("- "); err != nil {
+			return err
+		}
+	}
+	// The whitespace of pre and textarea content is kept
+	verbatim := rawTextElements[e.Tag]
+	if verbatim {
+		w.verbatim++
+	}
+	for _, child := range e.Children {
+		if err := child.Render(w); err != nil {
+			return err
+		}
+	}
+	if verbatim {
+		w.verbatim--
+	}
+	if href := e.Attributes["href"]; e.Tag == "a" && href != "" && !strings.HasPrefix(href, "#") && !strings.HasPrefix(href, "javascript:") {
+		if err := w.writeText(" (" + href + ")"); err != nil {
+			return err
+		}
+	}
+	w.lineBreak(breaks)
+	w.elementRendered()
+	return nil
+}
+
+// lineBreak makes the next text start at least n lines further down.
+func (w *renderWriter) lineBreak(n int) {
+	if w.started && w.breaks < n {
+		w.breaks = n
+	}
+	if n > 0 {
+		w.space = false
+	}
+}
+
+// writeText writes text with whitespace collapsed, after any owed line
+// breaks or space. Inside pre and textarea it writes the text as it is.
+func (w *renderWriter) writeText(s string) error {
+	var sb strings.Builder
+	if w.verbatim > 0 && s != "" {
+		if w.breaks > 0 {
+			sb.WriteString(strings.Repeat("\n", w.breaks))
+		} else if w.space {
+			sb.WriteByte(' ')
+		}
+		w.breaks, w.space, w.started = 0, false, true
+		sb.WriteString(s)
+		_, err := io.WriteString(w.Writer, sb.String())
+		return err
+	}
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			if w.started && w.breaks == 0 {
+				w.space = true
+			}
+			continue
+		}
+		if w.breaks > 0 {
+			sb.WriteString(strings.Repeat("\n", w.breaks))
+		} else if w.space {
+			sb.WriteByte(' ')
+		}
+		w.breaks, w.space, w.started = 0, false, true
+		sb.WriteRune(r)
+	}
+	_, err := io.WriteString(w.Writer, sb.String())
+	return err
+}
@@ -0,0 +1,108 @@
+package minty
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// =====================================================
+// PREFERENCE COOKIES
+// =====================================================
+
+// PrefCookieOptions configures a preference cookie set by SetPrefCookie.
+// Preference cookies are readable from JavaScript, so scripts such as the
+// dark mode toggle can update them; never store anything secret in one.
+type PrefCookieOptions struct {
+	MaxAge   time.Duration // Lifetime, default one year; negative deletes the cookie
+	Path     string        // Default "/"
+	Domain   string        // Default: the current host only
+	Secure   bool          // Send only over HTTPS; set it on sites served over HTTPS
+	SameSite http.SameSite // Default http.SameSiteLaxMode
+}
+
+// prefCookieMaxAge is the default lifetime of a preference cookie.
+const prefCookieMaxAge = 365 * 24 * time.Hour
+
+// SetPrefCookie stores a UI preference, e.g. the theme or a collapsed
+// sidebar, in a cookie so the server can render the page accordingly on
+// the next request. The value is escaped, so any string may be stored.
+//
+//	mi.SetPrefCookie(w, "density", "compact", mi.PrefCookieOptions{Secure: true})
+func SetPrefCookie(w http.ResponseWriter, name, value string, opts PrefCookieOptions) {
+	maxAge := opts.MaxAge
+	if maxAge == 0 {
+		maxAge = prefCookieMaxAge
+	}
+	path := opts.Path
+	if path == "" {
+		path = "/"
+	}
+	sameSite := opts.SameSite
+	if sameSite == 0 {
+		sameSite = http.SameSiteLaxMode
+	}
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    url.PathEscape(value),
+		Path:     path,
+		Domain:   opts.Domain,
+		MaxAge:   int(maxAge / time.Second),
+		Secure:   opts.Secure,
+		SameSite: sameSite,
+	}
+	if maxAge < 0 {
+		cookie.Value, cookie.MaxAge = "", -1
+	}
+	http.SetCookie(w, cookie)
+}
+
+// PrefFromRequest returns the preference stored in the named cookie, or ""
+// when the request has none. It reads values written by SetPrefCookie and
+// by the scripts of DarkMode and ThemeSwitch.
+func PrefFromRequest(r *http.Request, name string) string {
+	if r == nil {
+		return ""
+	}
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	value, err := url.PathUnescape(cookie.Value)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+// prefCookieJS returns a JavaScript statement storing the value of the
+// expression value in the named preference cookie, with the defaults of
+// SetPrefCookie. The cookie is marked secure on HTTPS pages. A null value
+// deletes the cookie.
+func prefCookieJS(name, value string) string {
+	return fmt.Sprintf(`(function(v) { document.cookie = '%[1]s=' + (v === null ? '' : encodeURIComponent(v)) + '; path=/; max-age=' + (v === null ? 0 : %[2]d) + '; samesite=lax' + (location.protocol === 'https:' ? '; secure' : ''); })(%[3]s);`,
+		escapeJSString(name), int(prefCookieMaxAge/time.Second), value)
+}
+
+// prefCookieReadJS returns a JavaScript expression reading the named
+// preference cookie, or null when it is not set.
+func prefCookieReadJS(name string) string {
+	return fmt.Sprintf(`(function() { var m = document.cookie.match(/(?:^|;\s*)%[1]s=([^;]*)/); return m ? decodeURIComponent(m[1]) : null; })()`,
+		regexpJSEscape(name))
+}
+
+// regexpJSEscape escapes a cookie name for a JavaScript regular expression
+// literal.
+func regexpJSEscape(s string) string {
+	var out []byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '.', '*', '+', '?', '^', '$', '{', '}', '(', ')', '|', '[', ']', '\\', '/':
+			out = append(out, '\\', c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return string(out)
+}
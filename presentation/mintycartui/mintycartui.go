@@ -71,6 +71,44 @@ func ProductList(theme mui.Theme, products []mica.Product) mi.H {
 	}
 }
 
+// CategoryTree displays the category hierarchy as a navigable tree
+func CategoryTree(categories []mica.Category, activeID string) mi.H {
+	return mi.Tree(categories, mi.TreeOptions[mica.Category]{
+		Class:     "mica_category_tree",
+		AriaLabel: "Product categories",
+		Label: func(category mica.Category) mi.H {
+			return func(b *mi.Builder) mi.Node {
+				return b.A(mi.Href("/categories/"+category.ID), category.Name,
+					miex.If(category.ProductCount > 0, func(b *mi.Builder) mi.Node {
+						return b.Span(mi.Class("mica_category_count"), fmt.Sprintf(" (%d)", category.ProductCount))
+					})(b),
+				)
+			}
+		},
+		Children: func(category mica.Category) []mica.Category { return category.Children },
+		Attrs: func(category mica.Category) []mi.Attribute {
+			if category.ID == activeID {
+				return []mi.Attribute{mi.Attr("aria-selected", "true")}
+			}
+			return nil
+		},
+		Expanded: func(category mica.Category) bool { return categoryContains(category, activeID) },
+	})
+}
+
+// categoryContains reports whether the category or a descendant has the given ID
+func categoryContains(category mica.Category, id string) bool {
+	if category.ID == id {
+		return true
+	}
+	for _, child := range category.Children {
+		if categoryContains(child, id) {
+			return true
+		}
+	}
+	return false
+}
+
 // AddToCartButton creates an add to cart button
 func AddToCartButton(theme mui.Theme, product mica.Product) mi.H {
 	return mui.DomainButton(theme, Domain, "Add to Cart", "primary",
@@ -0,0 +1,211 @@
+package minty
+
+// =====================================================
+// PRINT / PAGED MEDIA HELPERS
+// =====================================================
+
+// classAppender adds a class to an element without replacing existing ones.
+type classAppender struct {
+	class string
+}
+
+// Apply appends the class to the element's class attribute.
+func (ca classAppender) Apply(element *Element) {
+	if element.Attributes == nil {
+		element.Attributes = make(map[string]string)
+	}
+	if existing := element.Attributes["class"]; existing != "" {
+		element.Attributes["class"] = existing + " " + ca.class
+		return
+	}
+	element.Attributes["class"] = ca.class
+}
+
+// PageBreakBefore forces a page break before the element when printed.
+// The class is appended, so place it after any Class attribute.
+// Requires PrintStyles on the page.
+func PageBreakBefore() Attribute {
+	return classAppender{class: "print-break-before"}
+}
+
+// PageBreakAfter forces a page break after the element when printed.
+// Requires PrintStyles on the page.
+func PageBreakAfter() Attribute {
+	return classAppender{class: "print-break-after"}
+}
+
+// AvoidBreakInside keeps the element on a single printed page where possible.
+// Requires PrintStyles on the page.
+func AvoidBreakInside() Attribute {
+	return classAppender{class: "print-avoid-break"}
+}
+
+// PrintOnly hides the element on screen and shows it when printed.
+func PrintOnly() Attribute {
+	return classAppender{class: "print-only"}
+}
+
+// NoPrint hides the element when printed.
+func NoPrint() Attribute {
+	return classAppender{class: "no-print"}
+}
+
+// printCSS holds the paged media utilities used by the print helpers.
+const printCSS = `.print-only { display: none; }
+@media print {
+  .print-only { display: block; }
+  .no-print { display: none !important; }
+  .print-break-before { break-before: page; page-break-before: always; }
+  .print-break-after { break-after: page; page-break-after: always; }
+  .print-avoid-break { break-inside: avoid; page-break-inside: avoid; }
+  thead { display: table-header-group; }
+  tfoot { display: table-footer-group; }
+  tr { break-inside: avoid; page-break-inside: avoid; }
+}
+`
+
+// PrintStyles emits the print CSS utilities used by PageBreakBefore,
+// PageBreakAfter, AvoidBreakInside, PrintOnly and NoPrint. Table headers
+// repeat on every printed page and rows are never split across pages.
+func PrintStyles() H {
+	return func(b *Builder) Node {
+		return b.Style(Raw(printCSS))
+	}
+}
+
+// =====================================================
+// INVOICE DOCUMENT
+// =====================================================
+
+// InvoiceData holds the preformatted content of a printable invoice.
+type InvoiceData struct {
+	Title     string   // Heading, defaults to "Invoice"
+	Number    string   // Invoice number
+	IssueDate string   // Formatted issue date
+	DueDate   string   // Formatted due date
+	From      []string // Seller name and address lines
+	BillTo    []string // Customer name and address lines
+	Items     []InvoiceLine
+	Totals    []InvoiceTotal // Subtotal, tax, total... the last one is emphasised
+	Notes     string
+}
+
+// InvoiceLine is a single line item on an invoice.
+type InvoiceLine struct {
+	Description string
+	Quantity    string
+	UnitPrice   string
+	Amount      string
+}
+
+// InvoiceTotal is a labelled amount in the invoice totals block.
+type InvoiceTotal struct {
+	Label  string
+	Amount string
+}
+
+// InvoiceDocument arranges an invoice for printing: header and parties,
+// line items table, and totals. Rows and the totals block avoid page breaks,
+// and the table header repeats on each printed page.
+func InvoiceDocument(data InvoiceData) H {
+	return func(b *Builder) Node {
+		title := data.Title
+		if title == "" {
+			title = "Invoice"
+		}
+
+		var meta []interface{}
+		if data.Number != "" {
+			meta = append(meta, b.Dt("Invoice #"), b.Dd(data.Number))
+		}
+		if data.IssueDate != "" {
+			meta = append(meta, b.Dt("Issued"), b.Dd(data.IssueDate))
+		}
+		if data.DueDate != "" {
+			meta = append(meta, b.Dt("Due"), b.Dd(data.DueDate))
+		}
+
+		rows := make([]interface{}, 0, len(data.Items))
+		for _, item := range data.Items {
+			rows = append(rows, b.Tr(AvoidBreakInside(),
+				b.Td(item.Description),
+				b.Td(Class("invoice-num"), item.Quantity),
+				b.Td(Class("invoice-num"), item.UnitPrice),
+				b.Td(Class("invoice-num"), item.Amount),
+			))
+		}
+
+		totals := make([]interface{}, 0, len(data.Totals))
+		for i, total := range data.Totals {
+			rowClass := "invoice-total"
+			if i == len(data.Totals)-1 {
+				rowClass = "invoice-total invoice-grand-total"
+			}
+			totals = append(totals, b.Tr(Class(rowClass),
+				b.Th(Attr("scope", "row"), Attr("colspan", "3"), total.Label),
+				b.Td(Class("invoice-num"), total.Amount),
+			))
+		}
+
+		return b.Article(Class("invoice"),
+			PrintStyles()(b),
+			b.Style(Raw(invoiceCSS)),
+			b.Header(Class("invoice-header"), AvoidBreakInside(),
+				b.H1(title),
+				If(len(meta) > 0, func(b *Builder) Node {
+					return b.Dl(append([]interface{}{Class("invoice-meta")}, meta...)...)
+				})(b),
+			),
+			b.Section(Class("invoice-parties"), AvoidBreakInside(),
+				invoiceParty(b, "From", data.From),
+				invoiceParty(b, "Bill To", data.BillTo),
+			),
+			b.Table(Class("invoice-items"),
+				b.Thead(b.Tr(
+					b.Th(Attr("scope", "col"), "Description"),
+					b.Th(Attr("scope", "col"), Class("invoice-num"), "Qty"),
+					b.Th(Attr("scope", "col"), Class("invoice-num"), "Unit Price"),
+					b.Th(Attr("scope", "col"), Class("invoice-num"), "Amount"),
+				)),
+				b.Tbody(rows...),
+				b.Tfoot(append([]interface{}{AvoidBreakInside()}, totals...)...),
+			),
+			If(data.Notes != "", func(b *Builder) Node {
+				return b.Footer(Class("invoice-notes"), AvoidBreakInside(), b.P(data.Notes))
+			})(b),
+		)
+	}
+}
+
+// invoiceParty renders a labelled address block, or nil if there are no lines.
+func invoiceParty(b *Builder, label string, lines []string) Node {
+	if len(lines) == 0 {
+		return nil
+	}
+	address := make([]interface{}, 0, len(lines)*2)
+	for i, line := range lines {
+		if i > 0 {
+			address = append(address, b.Br())
+		}
+		address = append(address, line)
+	}
+	return b.Div(Class("invoice-party"),
+		b.H2(label),
+		b.Address(address...),
+	)
+}
+
+// invoiceCSS is the base layout for InvoiceDocument on screen and paper.
+const invoiceCSS = `.invoice { max-width: 800px; margin: 0 auto; font-family: system-ui, sans-serif; }
+.invoice-header { display: flex; justify-content: space-between; align-items: flex-start; }
+.invoice-meta { display: grid; grid-template-columns: auto auto; gap: 0.25rem 1rem; margin: 0; }
+.invoice-meta dd { margin: 0; }
+.invoice-parties { display: flex; gap: 2rem; margin: 1.5rem 0; }
+.invoice-party h2 { font-size: 0.875rem; text-transform: uppercase; margin: 0 0 0.25rem 0; }
+.invoice-items { width: 100%; border-collapse: collapse; }
+.invoice-items th, .invoice-items td { padding: 0.5rem; border-bottom: 1px solid #e2e8f0; text-align: left; }
+.invoice-num { text-align: right !important; }
+.invoice-total th { text-align: right; font-weight: normal; }
+.invoice-grand-total th, .invoice-grand-total td { font-weight: 700; border-top: 2px solid #1e293b; }
+@page { margin: 1.5cm; }
+`
@@ -0,0 +1,78 @@
+package minty
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WithFileMode sets the permissions of files written by RenderFile. The
+// default is 0644.
+func WithFileMode(mode os.FileMode) RenderOption {
+	return func(c *renderConfig) { c.fileMode = mode }
+}
+
+// WithMkdirAll makes RenderFile create missing parent directories with the
+// given permissions, e.g. 0755.
+func WithMkdirAll(mode os.FileMode) RenderOption {
+	return func(c *renderConfig) { c.dirMode = mode }
+}
+
+// RenderFile renders a template to the file at path. The output is written
+// to a temporary file in the same directory and renamed over path only once
+// rendering has succeeded, so a failed render never leaves a truncated page
+// behind. A path ending in ".gz" is gzip-compressed.
+//
+//	err := mi.RenderFile(page, "public/about/index.html",
+//	    mi.WithMkdirAll(0755), mi.WithMinify())
+func RenderFile(template H, path string, opts ...RenderOption) (err error) {
+	config := renderConfig{fileMode: 0644}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	dir := filepath.Dir(path)
+	if config.dirMode != 0 {
+		if err := os.MkdirAll(dir, config.dirMode); err != nil {
+			return err
+		}
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	var w io.Writer = tmp
+	var gz *gzip.Writer
+	if strings.HasSuffix(path, ".gz") {
+		gz = gzip.NewWriter(tmp)
+		w = gz
+	}
+	if err = Render(template, w, opts...); err != nil {
+		return err
+	}
+	if gz != nil {
+		if err = gz.Close(); err != nil {
+			return err
+		}
+	}
+	if err = tmp.Chmod(config.fileMode); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
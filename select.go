@@ -0,0 +1,129 @@
+package minty
+
+// SelectOptions configures a Select field.
+type SelectOptions struct {
+	Label       string
+	Name        string
+	ID          string // Defaults to Name
+	Value       string // Value of the selected option
+	Options     []SelectOption
+	Placeholder string // Text of an empty first option, e.g. "Select..."
+	Required    bool   // Also makes the placeholder unselectable
+	Disabled    bool
+	Error       string      // Validation message shown below the select
+	Attrs       []Attribute // Extra attributes for the <select>, e.g. htmx triggers
+}
+
+// Select renders a labelled <select> in the same form-field markup as
+// FormField. The option whose value equals Value is selected, options that
+// share a Group are gathered into one <optgroup> at the position of the
+// group's first option, and an error message is linked to the select with
+// aria-describedby.
+//
+//	mi.Select(mi.SelectOptions{
+//	    Label:       "Status",
+//	    Name:        "status",
+//	    Value:       asset.Status,
+//	    Options:     mi.EnumOptions(statuses, statusLabel),
+//	    Placeholder: "Select...",
+//	    Required:    true,
+//	})
+func Select(opts SelectOptions) H {
+	return func(b *Builder) Node {
+		id := opts.ID
+		if id == "" {
+			id = opts.Name
+		}
+
+		args := []interface{}{ID(id), Name(opts.Name)}
+		if opts.Required {
+			args = append(args, Required())
+		}
+		if opts.Disabled {
+			args = append(args, Disabled())
+		}
+		if opts.Error != "" {
+			args = append(args, Attr("aria-invalid", "true"), Attr("aria-describedby", id+"-error"))
+		}
+		for _, attr := range opts.Attrs {
+			args = append(args, attr)
+		}
+
+		if opts.Placeholder != "" {
+			placeholder := []interface{}{Value(""), opts.Placeholder}
+			if opts.Required {
+				placeholder = append(placeholder, Disabled())
+			}
+			if opts.Value == "" {
+				placeholder = append(placeholder, Selected())
+			}
+			args = append(args, b.Option(placeholder...))
+		}
+		args = append(args, selectOptionNodes(b, opts.Options, opts.Value)...)
+
+		var errorElement Node = NewFragment()
+		if opts.Error != "" {
+			errorElement = b.Div(ID(id+"-error"), ErrorMessage(opts.Error)(b))
+		}
+
+		return b.Div(Class("form-field"),
+			b.Label(For(id), opts.Label),
+			b.Select(args...),
+			errorElement,
+		)
+	}
+}
+
+// selectOptionNodes renders options, gathering grouped ones into optgroups.
+func selectOptionNodes(b *Builder, options []SelectOption, value string) []interface{} {
+	option := func(opt SelectOption) Node {
+		args := []interface{}{Value(opt.Value), opt.Text}
+		if opt.Value == value {
+			args = append(args, Selected())
+		}
+		if opt.Disabled {
+			args = append(args, Disabled())
+		}
+		return b.Option(args...)
+	}
+
+	var nodes []interface{}
+	rendered := make(map[string]bool)
+	for i, opt := range options {
+		if opt.Group == "" {
+			nodes = append(nodes, option(opt))
+			continue
+		}
+		if rendered[opt.Group] {
+			continue
+		}
+		rendered[opt.Group] = true
+		group := []interface{}{Attr("label", opt.Group)}
+		for _, member := range options[i:] {
+			if member.Group == opt.Group {
+				group = append(group, option(member))
+			}
+		}
+		nodes = append(nodes, b.Optgroup(group...))
+	}
+	return nodes
+}
+
+// EnumOptions builds select options from a slice of string-based enum
+// values. label returns the display text of a value; nil uses the value.
+//
+//	type Status string
+//	mi.EnumOptions([]Status{"active", "retired"}, func(s Status) string {
+//	    return strings.Title(string(s))
+//	})
+func EnumOptions[T ~string](values []T, label func(T) string) []SelectOption {
+	options := make([]SelectOption, len(values))
+	for i, v := range values {
+		text := string(v)
+		if label != nil {
+			text = label(v)
+		}
+		options[i] = SelectOption{Value: string(v), Text: text}
+	}
+	return options
+}
@@ -0,0 +1,133 @@
+package minty
+
+import (
+	"strings"
+)
+
+// =====================================================
+// SKELETON LOADING PLACEHOLDERS
+// =====================================================
+
+// SkeletonOptions configures the shapes of a Skeleton placeholder.
+type SkeletonOptions struct {
+	Lines  int    // Text lines (default 3; -1 for none)
+	Avatar bool   // Circle beside the lines, as in a list or comment
+	Block  string // Height of a full-width block above the lines, e.g. "8rem" for an image or chart
+	Repeat int    // Number of times the pattern repeats, for lists (default 1)
+	Label  string // Accessible label, default "Loading…"
+	Class  string // Extra classes for the wrapper
+}
+
+// Skeleton renders shimmering placeholder shapes to show while content
+// loads. Colors follow the theme tokens (--minty-border and
+// --minty-background), switch to a dark palette under the "dark" class or a
+// data-theme/data-bs-theme="dark" attribute, and the shimmer stops for users
+// who prefer reduced motion. Requires SkeletonStyles on the page.
+//
+//	mi.Skeleton(mi.SkeletonOptions{Avatar: true, Lines: 2, Repeat: 5})
+func Skeleton(opts SkeletonOptions) H {
+	return func(b *Builder) Node {
+		return b.Div(skeletonArgs(b, opts, "minty-skeleton")...)
+	}
+}
+
+// SkeletonIndicator renders a Skeleton that is hidden until an htmx request
+// is in flight, following the hx-indicator pattern: point a request's
+// hx-indicator at its id, or place it inside the element issuing the
+// request, and it shows for the duration of the request.
+//
+//	b.Div(mi.HxGet("/claims"), mi.HxTrigger("load"), mi.HxIndicator("#claims-loading"),
+//	    mi.SkeletonIndicator("claims-loading", mi.SkeletonOptions{Lines: 4})(b),
+//	)
+func SkeletonIndicator(id string, opts SkeletonOptions) H {
+	return func(b *Builder) Node {
+		args := skeletonArgs(b, opts, "minty-skeleton minty-skeleton-indicator")
+		return b.Div(append([]interface{}{ID(id)}, args...)...)
+	}
+}
+
+// skeletonArgs builds the wrapper attributes and shapes of a skeleton.
+func skeletonArgs(b *Builder, opts SkeletonOptions, class string) []interface{} {
+	lines := opts.Lines
+	if lines == 0 {
+		lines = 3
+	}
+	repeat := opts.Repeat
+	if repeat < 1 {
+		repeat = 1
+	}
+	label := opts.Label
+	if label == "" {
+		label = "Loading…"
+	}
+
+	args := []interface{}{
+		Class(strings.TrimSpace(class + " " + opts.Class)),
+		Role("status"),
+		AriaLabel(label),
+		Attr("aria-busy", "true"),
+	}
+	for i := 0; i < repeat; i++ {
+		item := []interface{}{Class("minty-skeleton-item"), Attr("aria-hidden", "true")}
+		if opts.Block != "" {
+			item = append(item, b.Div(Class("minty-skeleton-bone minty-skeleton-block"), Style("height: "+opts.Block)))
+		}
+		row := []interface{}{Class("minty-skeleton-row")}
+		if opts.Avatar {
+			row = append(row, b.Div(Class("minty-skeleton-bone minty-skeleton-avatar")))
+		}
+		if lines > 0 {
+			text := []interface{}{Class("minty-skeleton-lines")}
+			for j := 0; j < lines; j++ {
+				text = append(text, b.Div(Class("minty-skeleton-bone minty-skeleton-line")))
+			}
+			row = append(row, b.Div(text...))
+		}
+		if len(row) > 1 {
+			item = append(item, b.Div(row...))
+		}
+		args = append(args, b.Div(item...))
+	}
+	return args
+}
+
+// skeletonCSS holds the skeleton shapes, shimmer and indicator rules.
+const skeletonCSS = `.minty-skeleton {
+  --minty-skeleton-base: var(--minty-border, #e2e8f0);
+  --minty-skeleton-shine: var(--minty-background, #f8fafc);
+  display: flex; flex-direction: column; gap: 1rem;
+}
+.dark .minty-skeleton, [data-theme="dark"] .minty-skeleton, [data-bs-theme="dark"] .minty-skeleton {
+  --minty-skeleton-base: #334155;
+  --minty-skeleton-shine: #475569;
+}
+.minty-skeleton-item { display: flex; flex-direction: column; gap: 0.75rem; }
+.minty-skeleton-row { display: flex; gap: 0.75rem; align-items: flex-start; }
+.minty-skeleton-lines { flex: 1; display: flex; flex-direction: column; gap: 0.5rem; }
+.minty-skeleton-bone {
+  background: linear-gradient(90deg, var(--minty-skeleton-base) 25%, var(--minty-skeleton-shine) 50%, var(--minty-skeleton-base) 75%);
+  background-size: 200% 100%;
+  border-radius: var(--minty-radius-sm, 4px);
+  animation: minty-skeleton-shimmer 1.4s ease-in-out infinite;
+}
+.minty-skeleton-line { height: 0.75rem; }
+.minty-skeleton-line:last-child:not(:first-child) { width: 60%; }
+.minty-skeleton-block { width: 100%; border-radius: var(--minty-radius-md, 8px); }
+.minty-skeleton-avatar { width: 2.5rem; height: 2.5rem; border-radius: 50%; flex-shrink: 0; }
+@keyframes minty-skeleton-shimmer {
+  from { background-position: 200% 0; }
+  to { background-position: -200% 0; }
+}
+@media (prefers-reduced-motion: reduce) {
+  .minty-skeleton-bone { animation: none; }
+}
+.minty-skeleton-indicator { display: none; }
+.htmx-request .minty-skeleton-indicator, .htmx-request.minty-skeleton-indicator { display: flex; }
+`
+
+// SkeletonStyles emits the CSS used by Skeleton and SkeletonIndicator.
+func SkeletonStyles() H {
+	return func(b *Builder) Node {
+		return b.Style(Raw(skeletonCSS))
+	}
+}
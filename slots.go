@@ -0,0 +1,59 @@
+package minty
+
+// =====================================================
+// NAMED SLOTS
+// =====================================================
+
+// Slots holds the templates a component is filled with, keyed by slot
+// name, such as "header", "body" and "footer".
+type Slots map[string]H
+
+// Has reports whether the slot name is filled, so a component can adjust
+// its layout, e.g. leave out a footer element entirely.
+func (s Slots) Has(name string) bool {
+	return s[name] != nil
+}
+
+// Get returns the template in slot name, or one that renders nothing when
+// the slot is absent.
+func (s Slots) Get(name string) H {
+	return s.Or(name, nil)
+}
+
+// Or returns the template in slot name, or fallback when the slot is
+// absent. A nil fallback renders nothing.
+func (s Slots) Or(name string, fallback H) H {
+	if template := s[name]; template != nil {
+		return template
+	}
+	if fallback != nil {
+		return fallback
+	}
+	return func(b *Builder) Node {
+		return NewFragment()
+	}
+}
+
+// Compose builds a template from a component with named slots, in place
+// of passing a growing list of templates positionally. The component reads
+// its slots with Get, Or and Has. (Component, in layout.go, is the
+// simpler class wrapper.)
+//
+//	func Card(slots mi.Slots) mi.H {
+//	    return mi.Compose(func(b *mi.Builder, s mi.Slots) mi.Node {
+//	        return b.Div(mi.Class("card"),
+//	            b.Header(s.Or("header", untitled)(b)),
+//	            s.Get("body")(b),
+//	            mi.If(s.Has("footer"), func(b *mi.Builder) mi.Node {
+//	                return b.Footer(s.Get("footer")(b))
+//	            })(b),
+//	        )
+//	    }, slots)
+//	}
+//
+//	Card(mi.Slots{"header": title, "body": details})
+func Compose(render func(b *Builder, slots Slots) Node, slots Slots) H {
+	return func(b *Builder) Node {
+		return render(b, slots)
+	}
+}
@@ -0,0 +1,147 @@
+package minty
+
+import (
+	"strconv"
+	"strings"
+)
+
+// =====================================================
+// STEPS PROGRESS INDICATOR
+// =====================================================
+
+// ProgressStep is one step of a Steps progress indicator.
+type ProgressStep struct {
+	Label       string
+	Description string // Optional secondary text, hidden on small screens
+	Href        string // Link to the step, used as StepsOptions.Navigate allows
+}
+
+// StepsOptions configures a Steps progress indicator.
+type StepsOptions struct {
+	ID        string      // Optional id for the nav element
+	Class     string      // Extra classes for the nav element
+	Label     string      // Accessible name of the nav, default "Progress"
+	Navigate  string      // Which steps with an Href are links: "" (none), "completed" or "all"
+	LinkAttrs []Attribute // Extra attributes for step links, e.g. hx-target for htmx navigation
+}
+
+// Steps renders a numbered progress indicator for a multi-step flow such as
+// a wizard or checkout. current is the zero-based index of the active step;
+// steps before it are shown as completed with a checkmark, and current
+// equal to len(steps) shows every step completed. The active step carries
+// aria-current="step" and each step's state is spelled out for screen
+// readers. Steps run horizontally and stack vertically, with descriptions
+// hidden, on small screens. Requires StepsStyles on the page.
+//
+// Steps only displays progress; the flow itself is driven by the server or
+// by client state, so it suits multi-page forms as well as wizards:
+//
+//	mi.Steps([]mi.ProgressStep{
+//	    {Label: "Details", Href: "/quote/details"},
+//	    {Label: "Coverage", Href: "/quote/coverage"},
+//	    {Label: "Review"},
+//	}, 1, mi.StepsOptions{Navigate: "completed"})
+func Steps(steps []ProgressStep, current int, opts StepsOptions) H {
+	label := opts.Label
+	if label == "" {
+		label = "Progress"
+	}
+	return func(b *Builder) Node {
+		items := []interface{}{Class("minty-steps-list")}
+		for i, step := range steps {
+			state, status := "upcoming", "not started"
+			switch {
+			case i < current:
+				state, status = "complete", "completed"
+			case i == current:
+				state, status = "current", "current"
+			}
+
+			var marker interface{} = strconv.Itoa(i + 1)
+			if state == "complete" {
+				marker = Icon("check", "minty-step-check")(b)
+			}
+			content := []interface{}{
+				b.Span(Class("minty-step-marker"), Attr("aria-hidden", "true"), marker),
+				b.Span(Class("minty-step-text"),
+					b.Span(Class("minty-step-label"), step.Label),
+					b.Span(Class("minty-steps-sr"), " ("+status+")"),
+					stepDescription(b, step.Description),
+				),
+			}
+
+			item := []interface{}{Class("minty-step minty-step-" + state)}
+			if state == "current" {
+				item = append(item, Attr("aria-current", "step"))
+			}
+			if step.Href != "" && (opts.Navigate == "all" || opts.Navigate == "completed" && state == "complete") {
+				link := []interface{}{Href(step.Href), Class("minty-step-link")}
+				for _, attr := range opts.LinkAttrs {
+					link = append(link, attr)
+				}
+				item = append(item, b.A(append(link, content...)...))
+			} else {
+				item = append(item, b.Span(append([]interface{}{Class("minty-step-link")}, content...)...))
+			}
+			items = append(items, b.Li(item...))
+		}
+
+		nav := []interface{}{Class(strings.TrimSpace("minty-steps " + opts.Class)), AriaLabel(label)}
+		if opts.ID != "" {
+			nav = append(nav, ID(opts.ID))
+		}
+		return b.Nav(append(nav, b.Ol(items...))...)
+	}
+}
+
+// stepDescription renders a step's secondary text, or nil.
+func stepDescription(b *Builder, description string) Node {
+	if description == "" {
+		return nil
+	}
+	return b.Span(Class("minty-step-description"), description)
+}
+
+// stepsCSS lays steps out horizontally with connecting lines, stacking them
+// vertically on small screens.
+const stepsCSS = `.minty-steps-list { display: flex; margin: 0; padding: 0; list-style: none; }
+.minty-step { position: relative; flex: 1; min-width: 0; }
+.minty-step:not(:last-child)::after {
+  content: ""; position: absolute; top: 1rem; left: calc(50% + 1.25rem); right: calc(-50% + 1.25rem);
+  height: 2px; background: var(--minty-border, #e2e8f0);
+}
+.minty-step-complete:not(:last-child)::after { background: var(--minty-primary, #2563eb); }
+.minty-step-link {
+  display: flex; flex-direction: column; align-items: center; gap: 0.5rem;
+  text-align: center; color: var(--minty-muted, #64748b); text-decoration: none;
+}
+a.minty-step-link:hover .minty-step-label { text-decoration: underline; }
+a.minty-step-link:focus-visible { outline: 2px solid var(--minty-primary, #2563eb); outline-offset: 2px; border-radius: var(--minty-radius-sm, 4px); }
+.minty-step-marker {
+  display: flex; align-items: center; justify-content: center; flex-shrink: 0;
+  width: 2rem; height: 2rem; border-radius: 50%; font-weight: 600; font-size: 0.875rem;
+  border: 2px solid var(--minty-border, #e2e8f0); background: var(--minty-surface, #fff);
+}
+.minty-step-check { width: 1rem; height: 1rem; stroke-width: 3; }
+.minty-step-complete .minty-step-marker { border-color: var(--minty-primary, #2563eb); background: var(--minty-primary, #2563eb); color: #fff; }
+.minty-step-current .minty-step-marker { border-color: var(--minty-primary, #2563eb); color: var(--minty-primary, #2563eb); }
+.minty-step-current .minty-step-link, .minty-step-complete .minty-step-link { color: var(--minty-text, #1e293b); }
+.minty-step-current .minty-step-label { font-weight: 600; }
+.minty-step-text { display: flex; flex-direction: column; min-width: 0; }
+.minty-step-label { font-size: 0.875rem; }
+.minty-step-description { font-size: 0.75rem; color: var(--minty-muted, #64748b); }
+.minty-steps-sr { position: absolute; width: 1px; height: 1px; overflow: hidden; clip: rect(0, 0, 0, 0); white-space: nowrap; }
+@media (max-width: 640px) {
+  .minty-steps-list { flex-direction: column; gap: 0.75rem; }
+  .minty-step:not(:last-child)::after { top: 2.25rem; bottom: -0.75rem; left: 0.9375rem; right: auto; width: 2px; height: auto; }
+  .minty-step-link { flex-direction: row; text-align: left; gap: 0.75rem; }
+  .minty-step-description { display: none; }
+}
+`
+
+// StepsStyles emits the CSS used by Steps.
+func StepsStyles() H {
+	return func(b *Builder) Node {
+		return b.Style(Raw(stepsCSS))
+	}
+}
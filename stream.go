@@ -0,0 +1,125 @@
+package minty
+
+import (
+	"io"
+	"net/http"
+)
+
+// =====================================================
+// STREAMING
+// =====================================================
+
+// StreamOptions configures RenderStream, see WithStreamOptions.
+type StreamOptions struct {
+	FlushEvery int // Flush an http.Flusher after every N elements; zero flushes only at the end
+}
+
+// WithStreamOptions sets how RenderStream flushes. Render and the other
+// render functions ignore it.
+func WithStreamOptions(options StreamOptions) RenderOption {
+	return func(c *renderConfig) { c.stream = options }
+}
+
+// RenderStream renders a template like Render with the same options,
+// byte for byte, writing each element as soon as it is complete. Parts of
+// the page built with Stream or StreamEach are only constructed while they
+// render, one node at a time, so a long table never exists as a whole tree
+// in memory. The rest of the template, including the rows of Each, is
+// built up front as usual, so put large or unbounded content in a Stream. A
+// write error stops the render, and the producers of streamed parts see it
+// from emit, so no further rows are built.
+//
+// When w is an http.Flusher, such as an http.ResponseWriter, it is flushed
+// after every FlushEvery elements and once at the end, so clients, including
+// htmx long-polling requests, receive the page as it is produced. WithMinify
+// is ignored, as it needs the whole page before writing any of it.
+//
+//	mi.RenderStream(assetPage(assets), w, mi.WithNonce(nonce),
+//	    mi.WithStreamOptions(mi.StreamOptions{FlushEvery: 50}))
+func RenderStream(template H, w io.Writer, opts ...RenderOption) error {
+	var config renderConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	config.minify = false
+	b := acquireBuilder()
+	defer releaseBuilder(b)
+	if err := renderConfigured(b, template, w, config, true); err != nil {
+		return err
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// elementRendered counts a written element and flushes every flushEvery
+// elements. It is a no-op on a nil renderWriter.
+func (w *renderWriter) elementRendered() {
+	if w == nil || w.flusher == nil || w.flushEvery <= 0 {
+		return
+	}
+	w.elements++
+	if w.elements >= w.flushEvery {
+		w.elements = 0
+		w.flusher.Flush()
+	}
+}
+
+// streamNode renders the nodes its producer emits as they are emitted.
+type streamNode struct {
+	produce func(emit func(Node) error) error
+}
+
+// Render runs the producer, rendering and then dropping each emitted node.
+// After the first error emit keeps returning it without rendering.
+func (s *streamNode) Render(w io.Writer) error {
+	var failed error
+	err := s.produce(func(node Node) error {
+		if failed != nil {
+			return failed
+		}
+		if node != nil {
+			failed = node.Render(w)
+		}
+		return failed
+	})
+	if failed != nil {
+		return failed
+	}
+	return err
+}
+
+// Stream creates a node whose content is produced while it renders: produce
+// builds one node at a time and passes it to emit, which renders it
+// straight away. Stop and return emit's error when it fails. Any other
+// error returned by produce fails the render.
+//
+//	b.Tbody(b.Stream(func(emit func(mi.Node) error) error {
+//	    for rows.Next() {
+//	        if err := emit(assetRow(scan(rows))(b)); err != nil {
+//	            return err
+//	        }
+//	    }
+//	    return rows.Err()
+//	}))
+func (b *Builder) Stream(produce func(emit func(Node) error) error) Node {
+	return &streamNode{produce: produce}
+}
+
+// StreamEach is Each for streamed renders: the node for an item is built
+// when the previous one has been written, rather than all up front.
+//
+//	b.Tbody(mi.StreamEach(assets, assetRow)(b))
+func StreamEach[T any](items []T, renderer func(T) H) H {
+	return func(b *Builder) Node {
+		return b.Stream(func(emit func(Node) error) error {
+			for _, item := range items {
+				if err := emit(renderer(item)(b)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package minty
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ThemeSwitchOptions configures ThemeSwitch and ThemeSwitchScript.
+type ThemeSwitchOptions struct {
+	// DarkMode supplies the class or attribute to set, the localStorage
+	// key and the preference cookie, if any, so the switch coexists with
+	// darkMode.Toggle and darkMode.Script.
+	// Defaults to DarkModeTailwind().
+	DarkMode *DarkMode
+
+	Name        string // Radio group name and fieldset id, default "theme-switch"
+	Class       string // Extra classes for the fieldset
+	Legend      string // Accessible group label, default "Theme"
+	SystemLabel string // Default "System"
+	LightLabel  string // Default "Light"
+	DarkLabel   string // Default "Dark"
+}
+
+func (o ThemeSwitchOptions) withDefaults() ThemeSwitchOptions {
+	if o.DarkMode == nil {
+		o.DarkMode = DarkModeTailwind()
+	}
+	if o.Name == "" {
+		o.Name = "theme-switch"
+	}
+	if o.Legend == "" {
+		o.Legend = "Theme"
+	}
+	if o.SystemLabel == "" {
+		o.SystemLabel = "System"
+	}
+	if o.LightLabel == "" {
+		o.LightLabel = "Light"
+	}
+	if o.DarkLabel == "" {
+		o.DarkLabel = "Dark"
+	}
+	return o
+}
+
+// ThemeSwitchScript applies the stored theme preference before the page is
+// painted and follows OS theme changes while "System" is selected. Place it
+// in the <head>, in place of or alongside darkMode.Script.
+//
+//	opts := mi.ThemeSwitchOptions{DarkMode: darkMode}
+//	b.Head(mi.ThemeSwitchScript(opts)(b))
+//	// ... later, in the page header:
+//	mi.ThemeSwitch(opts)(b)
+func ThemeSwitchScript(opts ThemeSwitchOptions) H {
+	opts = opts.withDefaults()
+	return func(b *Builder) Node {
+		return b.Script(Raw(themeSwitchCoreJS(opts.DarkMode.config)))
+	}
+}
+
+// ThemeSwitch renders a System/Light/Dark radio group. "System" makes the
+// page follow prefers-color-scheme, clearing the stored preference when the
+// DarkMode default is "system"; "Light" and "Dark" store the same values
+// darkMode.Toggle does. The selection stays in
+// sync when the theme is changed elsewhere, e.g. by darkMode.Toggle.
+func ThemeSwitch(opts ThemeSwitchOptions) H {
+	opts = opts.withDefaults()
+	return func(b *Builder) Node {
+		options := []struct{ value, label string }{
+			{"system", opts.SystemLabel},
+			{"light", opts.LightLabel},
+			{"dark", opts.DarkLabel},
+		}
+
+		args := []interface{}{
+			ID(opts.Name),
+			Class(strings.TrimSpace("theme-switch " + opts.Class)),
+			b.Legend(opts.Legend),
+		}
+		for _, o := range options {
+			id := opts.Name + "-" + o.value
+			args = append(args,
+				b.Input(Type("radio"), ID(id), Name(opts.Name), Value(o.value)),
+				b.Label(For(id), o.label),
+			)
+		}
+
+		return NewFragment(
+			b.Fieldset(args...),
+			b.Script(Raw(themeSwitchCoreJS(opts.DarkMode.config)+themeSwitchBindJS(opts.Name))),
+		)
+	}
+}
+
+// themeSwitchCoreJS defines window.mintyTheme once per page and applies the
+// current preference immediately.
+func themeSwitchCoreJS(c DarkModeConfig) string {
+	apply := fmt.Sprintf("html.setAttribute('%s', dark ? '%s' : '%s');",
+		escapeJSString(c.AttrName), escapeJSString(c.DarkValue), escapeJSString(c.LightValue))
+	if c.UseClass {
+		apply = fmt.Sprintf("html.classList.toggle('%s', dark);", escapeJSString(c.ClassName))
+	}
+	return fmt.Sprintf(`(function() {
+    if (window.mintyTheme) return;
+    var key = '%s', fallback = '%s';
+    var media = window.matchMedia('(prefers-color-scheme: dark)');
+    var theme = window.mintyTheme = {
+        get: function() {
+            var saved = %s;
+            return saved === 'light' || saved === 'dark' || saved === 'system' ? saved : fallback;
+        },
+        set: function(value) {
+            if (value !== 'light' && value !== 'dark') value = 'system';
+            // "system" is only stored when it differs from the configured default
+            if (value === 'system' && fallback === 'system') localStorage.removeItem(key);
+            else localStorage.setItem(key, value);
+            %s
+            theme.apply();
+        },
+        apply: function() {
+            var pref = theme.get();
+            var dark = pref === 'dark' || (pref === 'system' && media.matches);
+            var html = document.documentElement;
+            %s
+            if (typeof window.updateDarkModeIcon === 'function') window.updateDarkModeIcon(dark);
+        }
+    };
+    theme.apply();
+    var onChange = function() { if (theme.get() === 'system') theme.apply(); };
+    if (media.addEventListener) media.addEventListener('change', onChange);
+    else if (media.addListener) media.addListener(onChange);
+    window.addEventListener('storage', function(e) { if (e.key === key) theme.apply(); });
+})();
+`, escapeJSString(c.StorageKey), themeSwitchFallback(c.Default), (&DarkMode{config: c}).savedJS(), themeSwitchCookieJS(c), apply)
+}
+
+// themeSwitchCookieJS mirrors a ThemeSwitch change into the DarkMode
+// preference cookie, if one is configured.
+func themeSwitchCookieJS(c DarkModeConfig) string {
+	if c.CookieName == "" {
+		return ""
+	}
+	return prefCookieJS(c.CookieName, "value === 'system' && fallback === 'system' ? null : value")
+}
+
+// themeSwitchFallback is the preference used when nothing is stored.
+func themeSwitchFallback(def string) string {
+	if def == "light" || def == "dark" {
+		return def
+	}
+	return "system"
+}
+
+// themeSwitchBindJS wires a rendered radio group to window.mintyTheme.
+func themeSwitchBindJS(name string) string {
+	return fmt.Sprintf(`(function() {
+    var group = document.getElementById('%s');
+    if (!group) return;
+    function sync() {
+        var current = group.querySelector('input[value="' + window.mintyTheme.get() + '"]');
+        if (current) current.checked = true;
+    }
+    group.addEventListener('change', function(e) {
+        if (e.target.name === '%s') window.mintyTheme.set(e.target.value);
+    });
+    new MutationObserver(sync).observe(document.documentElement, { attributes: true });
+    window.addEventListener('storage', sync);
+    sync();
+})();
+`, escapeJSString(name), escapeJSString(name))
+}
@@ -0,0 +1,170 @@
+package minty
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ThemeTokens holds the design decisions (colors, radii, spacing) shared by
+// core components and theme implementations, so branding lives in one place.
+// Themes and components read tokens instead of hardcoding colors.
+type ThemeTokens struct {
+	Colors     ColorTokens
+	Radius     RadiusTokens
+	Spacing    []string // Spacing scale, smallest first (e.g., "0.25rem", "0.5rem", ...)
+	FontFamily string
+}
+
+// ColorTokens holds the palette. Each severity color has a matching "Soft"
+// variant used as a background for badges, alerts and highlighted rows.
+type ColorTokens struct {
+	Primary   string
+	Secondary string
+	Success   string
+	Warning   string
+	Danger    string
+	Info      string
+
+	PrimarySoft   string
+	SecondarySoft string
+	SuccessSoft   string
+	WarningSoft   string
+	DangerSoft    string
+	InfoSoft      string
+
+	Text       string // Body text
+	Muted      string // Secondary text
+	Border     string // Borders and dividers
+	Surface    string // Card and panel backgrounds
+	Background string // Page background
+}
+
+// RadiusTokens holds border radius sizes.
+type RadiusTokens struct {
+	Small  string
+	Medium string
+	Large  string
+	Pill   string
+}
+
+// DefaultThemeTokens returns the default minty palette.
+func DefaultThemeTokens() ThemeTokens {
+	return ThemeTokens{
+		Colors: ColorTokens{
+			Primary:   "#2563eb",
+			Secondary: "#64748b",
+			Success:   "#166534",
+			Warning:   "#854d0e",
+			Danger:    "#dc2626",
+			Info:      "#0e7490",
+
+			PrimarySoft:   "#dbeafe",
+			SecondarySoft: "#f1f5f9",
+			SuccessSoft:   "#dcfce7",
+			WarningSoft:   "#fef9c3",
+			DangerSoft:    "#fee2e2",
+			InfoSoft:      "#cffafe",
+
+			Text:       "#1e293b",
+			Muted:      "#64748b",
+			Border:     "#e2e8f0",
+			Surface:    "#ffffff",
+			Background: "#f8fafc",
+		},
+		Radius: RadiusTokens{
+			Small:  "4px",
+			Medium: "8px",
+			Large:  "12px",
+			Pill:   "9999px",
+		},
+		Spacing:    []string{"0", "0.25rem", "0.5rem", "0.75rem", "1rem", "1.5rem", "2rem", "3rem"},
+		FontFamily: "system-ui, -apple-system, sans-serif",
+	}
+}
+
+// Severity returns the foreground and soft background colors for a severity
+// ("primary", "secondary", "success", "warning", "danger"/"error", "info").
+// Unknown severities fall back to secondary.
+func (t ThemeTokens) Severity(severity string) (fg, bg string) {
+	c := t.Colors
+	switch severity {
+	case "primary":
+		return c.Primary, c.PrimarySoft
+	case "success":
+		return c.Success, c.SuccessSoft
+	case "warning":
+		return c.Warning, c.WarningSoft
+	case "danger", "error":
+		return c.Danger, c.DangerSoft
+	case "info":
+		return c.Info, c.InfoSoft
+	default:
+		return c.Secondary, c.SecondarySoft
+	}
+}
+
+// Space returns the spacing value at the given step of the scale.
+// Steps outside the scale are clamped to its ends.
+func (t ThemeTokens) Space(step int) string {
+	if len(t.Spacing) == 0 {
+		return "0"
+	}
+	if step < 0 {
+		step = 0
+	}
+	if step >= len(t.Spacing) {
+		step = len(t.Spacing) - 1
+	}
+	return t.Spacing[step]
+}
+
+// CSSVariables renders the tokens as CSS custom properties on :root,
+// e.g. --minty-primary, --minty-radius-md, --minty-space-2.
+func (t ThemeTokens) CSSVariables() string {
+	var css strings.Builder
+	css.WriteString(":root {\n")
+
+	vars := []struct{ name, value string }{
+		{"primary", t.Colors.Primary},
+		{"secondary", t.Colors.Secondary},
+		{"success", t.Colors.Success},
+		{"warning", t.Colors.Warning},
+		{"danger", t.Colors.Danger},
+		{"info", t.Colors.Info},
+		{"primary-soft", t.Colors.PrimarySoft},
+		{"secondary-soft", t.Colors.SecondarySoft},
+		{"success-soft", t.Colors.SuccessSoft},
+		{"warning-soft", t.Colors.WarningSoft},
+		{"danger-soft", t.Colors.DangerSoft},
+		{"info-soft", t.Colors.InfoSoft},
+		{"text", t.Colors.Text},
+		{"muted", t.Colors.Muted},
+		{"border", t.Colors.Border},
+		{"surface", t.Colors.Surface},
+		{"background", t.Colors.Background},
+		{"radius-sm", t.Radius.Small},
+		{"radius-md", t.Radius.Medium},
+		{"radius-lg", t.Radius.Large},
+		{"radius-pill", t.Radius.Pill},
+		{"font-family", t.FontFamily},
+	}
+	for _, v := range vars {
+		if v.value != "" {
+			css.WriteString(fmt.Sprintf("  --minty-%s: %s;\n", v.name, v.value))
+		}
+	}
+	for i, s := range t.Spacing {
+		css.WriteString(fmt.Sprintf("  --minty-space-%d: %s;\n", i, s))
+	}
+
+	css.WriteString("}\n")
+	return css.String()
+}
+
+// ThemeTokensStyle renders the tokens as a <style> element of CSS custom
+// properties, for use in the document head.
+func ThemeTokensStyle(t ThemeTokens) H {
+	return func(b *Builder) Node {
+		return b.Style(Raw(t.CSSVariables()))
+	}
+}
@@ -0,0 +1,32 @@
+package minty
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestThemeTokens(t *testing.T) {
+	tokens := DefaultThemeTokens()
+
+	fg, bg := tokens.Severity("error")
+	if fg != tokens.Colors.Danger || bg != tokens.Colors.DangerSoft {
+		t.Errorf("Severity(error) = %q, %q, want danger colors", fg, bg)
+	}
+	if fg, _ := tokens.Severity("unknown"); fg != tokens.Colors.Secondary {
+		t.Errorf("Severity(unknown) = %q, want secondary", fg)
+	}
+
+	if got := tokens.Space(-1); got != tokens.Spacing[0] {
+		t.Errorf("Space(-1) = %q, want %q", got, tokens.Spacing[0])
+	}
+	if got := tokens.Space(100); got != tokens.Spacing[len(tokens.Spacing)-1] {
+		t.Errorf("Space(100) = %q, want last step", got)
+	}
+
+	css := tokens.CSSVariables()
+	for _, want := range []string{":root {", "--minty-primary: #2563eb;", "--minty-space-2: 0.5rem;"} {
+		if !strings.Contains(css, want) {
+			t.Errorf("CSSVariables() missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package minty
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TreeOptions configures a Tree of nodes of type T.
+type TreeOptions[T any] struct {
+	ID        string
+	Class     string
+	AriaLabel string                   // Accessible name of the tree
+	Label     func(node T) H           // Renders a node's label; required
+	Children  func(node T) []T         // Returns a node's children; nil for a flat list
+	Attrs     func(node T) []Attribute // Optional extra attributes for each treeitem
+	Expanded  func(node T) bool        // Whether a branch starts expanded (default collapsed)
+}
+
+// Tree renders roots as an accessible nested list (role="tree"). Branches
+// toggle with a click, Enter or Space and carry aria-expanded; arrow keys,
+// Home and End move between visible items as in the WAI-ARIA tree pattern.
+//
+//	mi.Tree(categories, mi.TreeOptions[Category]{
+//	    AriaLabel: "Categories",
+//	    Label: func(c Category) mi.H {
+//	        return func(b *mi.Builder) mi.Node { return b.A(mi.Href("/c/"+c.ID), c.Name) }
+//	    },
+//	    Children: func(c Category) []Category { return c.Children },
+//	})
+func Tree[T any](roots []T, opts TreeOptions[T]) H {
+	return func(b *Builder) Node {
+		args := []interface{}{
+			Role("tree"),
+			Class(strings.TrimSpace("minty-tree " + opts.Class)),
+		}
+		if opts.ID != "" {
+			args = append(args, ID(opts.ID))
+		}
+		if opts.AriaLabel != "" {
+			args = append(args, AriaLabel(opts.AriaLabel))
+		}
+		args = append(args, treeItems(b, roots, opts, 1, true)...)
+
+		return NewFragment(
+			b.Style(Raw(treeCSS)),
+			b.Ul(args...),
+			b.Script(Raw(treeJS)),
+		)
+	}
+}
+
+// treeItems renders one level of the tree. Only the first item of the tree
+// is in the tab order; the script moves tabindex as focus moves.
+func treeItems[T any](b *Builder, nodes []T, opts TreeOptions[T], level int, first bool) []interface{} {
+	items := make([]interface{}, 0, len(nodes))
+	for i, node := range nodes {
+		tabIndex := "-1"
+		if first && i == 0 {
+			tabIndex = "0"
+		}
+		item := []interface{}{
+			Role("treeitem"),
+			Attr("aria-level", strconv.Itoa(level)),
+			Attr("tabindex", tabIndex),
+		}
+		if opts.Attrs != nil {
+			for _, attr := range opts.Attrs(node) {
+				item = append(item, attr)
+			}
+		}
+
+		var children []T
+		if opts.Children != nil {
+			children = opts.Children(node)
+		}
+		label := b.Span(Class("minty-tree-label"), opts.Label(node)(b))
+		if len(children) == 0 {
+			items = append(items, b.Li(append(item, label)...))
+			continue
+		}
+
+		expanded := opts.Expanded != nil && opts.Expanded(node)
+		item = append(item, Attr("aria-expanded", strconv.FormatBool(expanded)), label)
+		group := []interface{}{Role("group")}
+		if !expanded {
+			group = append(group, Hidden())
+		}
+		group = append(group, treeItems(b, children, opts, level+1, false)...)
+		items = append(items, b.Li(append(item, b.Ul(group...))...))
+	}
+	return items
+}
+
+// treeCSS adds disclosure markers and focus styling.
+const treeCSS = `.minty-tree, .minty-tree [role="group"] { list-style: none; margin: 0; padding-left: 1.25rem; }
+.minty-tree { padding-left: 0; }
+.minty-tree [role="treeitem"] { cursor: default; }
+.minty-tree [role="treeitem"]:focus { outline: none; }
+.minty-tree [role="treeitem"]:focus > .minty-tree-label { outline: 2px solid currentColor; outline-offset: 1px; }
+.minty-tree [aria-expanded] > .minty-tree-label { cursor: pointer; }
+.minty-tree [aria-expanded] > .minty-tree-label::before { content: "\25B8"; display: inline-block; width: 1em; transition: transform 0.15s; }
+.minty-tree [aria-expanded="true"] > .minty-tree-label::before { transform: rotate(90deg); }
+`
+
+// treeJS installs delegated keyboard and click handling once per page.
+const treeJS = `(function(){
+if (window.MintyTree) return;
+window.MintyTree = true;
+function visible(tree) {
+    return Array.prototype.filter.call(tree.querySelectorAll('[role="treeitem"]'), function(item) {
+        return !item.parentElement.closest('[role="group"][hidden]');
+    });
+}
+function focusItem(item) {
+    var tree = item.closest('[role="tree"]');
+    tree.querySelectorAll('[role="treeitem"][tabindex="0"]').forEach(function(i) { i.setAttribute('tabindex', '-1'); });
+    item.setAttribute('tabindex', '0');
+    item.focus();
+}
+function setExpanded(item, open) {
+    if (!item.hasAttribute('aria-expanded')) return;
+    item.setAttribute('aria-expanded', open ? 'true' : 'false');
+    var group = item.querySelector(':scope > [role="group"]');
+    if (group) group.hidden = !open;
+}
+document.addEventListener('click', function(e) {
+    var label = e.target.closest ? e.target.closest('.minty-tree-label') : null;
+    if (!label) return;
+    var item = label.parentElement;
+    if (item.getAttribute('role') !== 'treeitem') return;
+    setExpanded(item, item.getAttribute('aria-expanded') !== 'true');
+    focusItem(item);
+});
+document.addEventListener('keydown', function(e) {
+    var item = e.target;
+    if (!item.getAttribute || item.getAttribute('role') !== 'treeitem') return;
+    var items = visible(item.closest('[role="tree"]'));
+    var index = items.indexOf(item);
+    var expanded = item.getAttribute('aria-expanded');
+    switch (e.key) {
+    case 'ArrowDown': if (index < items.length - 1) focusItem(items[index + 1]); break;
+    case 'ArrowUp': if (index > 0) focusItem(items[index - 1]); break;
+    case 'Home': focusItem(items[0]); break;
+    case 'End': focusItem(items[items.length - 1]); break;
+    case 'ArrowRight':
+        if (expanded === 'false') setExpanded(item, true);
+        else if (expanded === 'true') focusItem(items[index + 1]);
+        break;
+    case 'ArrowLeft':
+        if (expanded === 'true') setExpanded(item, false);
+        else {
+            var parent = item.parentElement.closest('[role="treeitem"]');
+            if (parent) focusItem(parent);
+        }
+        break;
+    case 'Enter':
+    case ' ':
+        if (expanded === null) return;
+        setExpanded(item, expanded !== 'true');
+        break;
+    default:
+        return;
+    }
+    e.preventDefault();
+});
+})();`
@@ -0,0 +1,92 @@
+package minty
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// =====================================================
+// URL SANITIZATION
+// =====================================================
+
+// allowDataURIs is set by AllowDataURIs.
+var allowDataURIs atomic.Bool
+
+// AllowDataURIs lets Href, Src and the other URL attribute helpers accept
+// data: URIs of images, such as base64-encoded PNGs, for apps that embed
+// them. Other data: URIs, which can carry HTML and scripts, are always
+// rejected. It is off by default and applies to every render.
+func AllowDataURIs(allow bool) {
+	allowDataURIs.Store(allow)
+}
+
+// dataImageTypes are the data: URI media types AllowDataURIs permits.
+// SVG is left out as it can carry scripts when opened as a page.
+var dataImageTypes = []string{
+	"image/png", "image/gif", "image/jpeg", "image/jpg", "image/webp",
+	"image/avif", "image/bmp", "image/x-icon",
+}
+
+// SafeURL returns url, or "#" when it uses a scheme that runs code:
+// javascript:, vbscript: and data:, unless AllowDataURIs permits it. Href,
+// Action and Formaction apply it to their values, and Src and Poster
+// leave the attribute empty instead, so no request is made. Use it for URLs
+// from data set with Attr or rendered by hand.
+//
+//	b.Blockquote(mi.Attr("cite", mi.SafeURL(quote.Source)), quote.Text)
+func SafeURL(url string) string {
+	if isSafeURL(url) {
+		return url
+	}
+	return "#"
+}
+
+// safeSrc returns url, or "" when SafeURL would reject it.
+func safeSrc(url string) string {
+	if isSafeURL(url) {
+		return url
+	}
+	return ""
+}
+
+// isSafeURL reports whether url's scheme is safe to put in a link or a
+// source attribute.
+func isSafeURL(url string) bool {
+	// Browsers ignore control characters and whitespace in the scheme, so
+	// "java\tscript:" is still javascript:
+	var scheme strings.Builder
+	colon := -1
+	for i := 0; i < len(url) && colon < 0; i++ {
+		c := url[i]
+		switch {
+		case c <= ' ' || c == 0x7f:
+		case c == ':':
+			colon = i
+		case c == '/' || c == '?' || c == '#':
+			return true // A relative URL
+		case scheme.Len() == len("javascript"):
+			return true // Longer than any scheme rejected below
+		default:
+			scheme.WriteByte(c)
+		}
+	}
+	if colon < 0 {
+		return true
+	}
+	switch strings.ToLower(scheme.String()) {
+	case "javascript", "vbscript":
+		return false
+	case "data":
+		if !allowDataURIs.Load() {
+			return false
+		}
+		media := strings.ToLower(strings.TrimLeft(url[colon+1:], " \t\n\r"))
+		for _, typ := range dataImageTypes {
+			if strings.HasPrefix(media, typ+";") || strings.HasPrefix(media, typ+",") {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,212 @@
+package minty
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync/atomic"
+)
+
+// =====================================================
+// CURRENT USER
+// =====================================================
+
+// User is the signed-in user as components see it: enough to show who is
+// acting and to decide what they may do.
+type User struct {
+	ID          string
+	Name        string
+	Email       string
+	AvatarURL   string
+	Roles       []string
+	Permissions []string // "*" grants every permission
+}
+
+// Can reports whether the user holds permission. The zero User, used when
+// nobody is signed in, can do nothing.
+func (u User) Can(permission string) bool {
+	for _, p := range u.Permissions {
+		if p == permission || p == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRole reports whether the user has role.
+func (u User) HasRole(role string) bool {
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Initials returns up to two initials of the user's name, or of the email
+// when the name is empty, for avatar placeholders.
+func (u User) Initials() string {
+	words := strings.Fields(u.Name)
+	if len(words) == 0 && u.Email != "" {
+		words = []string{u.Email}
+	}
+	var initials []rune
+	for _, w := range words {
+		if len(initials) == 2 {
+			break
+		}
+		initials = append(initials, []rune(strings.ToUpper(w))[0])
+	}
+	return string(initials)
+}
+
+type userKey struct{}
+
+// WithUser returns a context carrying the current user. Authentication
+// middleware sets it once per request:
+//
+//	next.ServeHTTP(w, r.WithContext(mi.WithUser(r.Context(), user)))
+//
+// Domain services attribute audit events to the actor on their audit
+// context, so pass the same user's ID there:
+//
+//	ctx := mt.ContextWithActor(mi.WithUser(r.Context(), user), user.ID)
+func WithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFrom returns the user stored by WithUser and whether there was one.
+func UserFrom(ctx context.Context) (User, bool) {
+	if ctx == nil {
+		return User{}, false
+	}
+	user, ok := ctx.Value(userKey{}).(User)
+	return user, ok
+}
+
+// RenderContext renders a template with ctx available to components through
+// b.Context, b.User and b.Can. RenderRequest does the same with the
+// request's context.
+//
+//	mi.RenderContext(mi.WithUser(ctx, user), dashboard(stats), w)
+func RenderContext(ctx context.Context, template H, w io.Writer, opts ...RenderOption) error {
+	b := acquireBuilder()
+	defer releaseBuilder(b)
+	b.ctx = ctx
+	return renderWith(b, template, w, opts...)
+}
+
+// Context returns the context passed to RenderContext, the request's
+// context under RenderRequest, or context.Background.
+func (b *Builder) Context() context.Context {
+	if b.ctx != nil {
+		return b.ctx
+	}
+	if b.request != nil {
+		return b.request.Context()
+	}
+	return context.Background()
+}
+
+// User returns the current user from the render context and whether one is
+// signed in.
+func (b *Builder) User() (User, bool) {
+	return UserFrom(b.Context())
+}
+
+// Authorizer decides whether a user may do something, for applications
+// whose permissions live outside User.Permissions, e.g. in a policy engine
+// or per-record ACLs. user is the zero User when nobody is signed in.
+type Authorizer interface {
+	Can(ctx context.Context, user User, permission string) bool
+}
+
+// AuthorizerFunc adapts a function to the Authorizer interface.
+type AuthorizerFunc func(ctx context.Context, user User, permission string) bool
+
+// Can calls f(ctx, user, permission).
+func (f AuthorizerFunc) Can(ctx context.Context, user User, permission string) bool {
+	return f(ctx, user, permission)
+}
+
+// authorizer holds the Authorizer installed by SetAuthorizer.
+var authorizer atomic.Pointer[Authorizer]
+
+// SetAuthorizer installs the Authorizer behind b.Can, IfCan and Gate for
+// every render. Pass nil to remove it. With no authorizer set, permissions
+// are checked with User.Can.
+func SetAuthorizer(a Authorizer) {
+	if a == nil {
+		authorizer.Store(nil)
+		return
+	}
+	authorizer.Store(&a)
+}
+
+type authorizerKey struct{}
+
+// WithAuthorizer returns a context whose renders check permissions with a
+// instead of the one set by SetAuthorizer, e.g. for a tenant with its own
+// policy.
+func WithAuthorizer(ctx context.Context, a Authorizer) context.Context {
+	return context.WithValue(ctx, authorizerKey{}, a)
+}
+
+// Can reports whether the current user holds permission, asking the
+// context's Authorizer, then the one set by SetAuthorizer, then User.Can.
+// It is false when nobody is signed in unless an authorizer says otherwise.
+//
+//	b.Button(mi.AttrIf(!b.Can("claims.approve"), mi.Disabled()), "Approve")
+func (b *Builder) Can(permission string) bool {
+	ctx := b.Context()
+	user, _ := UserFrom(ctx)
+	if a, ok := ctx.Value(authorizerKey{}).(Authorizer); ok && a != nil {
+		return a.Can(ctx, user, permission)
+	}
+	if a := authorizer.Load(); a != nil {
+		return (*a).Can(ctx, user, permission)
+	}
+	return user.Can(permission)
+}
+
+// IfCan renders content only when the current user holds permission, so
+// actions the user may not take are left out instead of failing on click.
+//
+//	mi.IfCan("assets.delete", deleteButton(asset.ID))
+func IfCan(permission string, content H) H {
+	return Gate(permission, content, nil)
+}
+
+// Gate renders allowed when the current user holds permission and denied
+// otherwise. A nil H renders nothing.
+//
+//	mi.Gate("assets.export", exportButton(), disabledExportButton())
+func Gate(permission string, allowed, denied H) H {
+	return func(b *Builder) Node {
+		content := denied
+		if b.Can(permission) {
+			content = allowed
+		}
+		if content == nil {
+			return NewFragment()
+		}
+		return content(b)
+	}
+}
+
+// UserAvatar renders the current user's avatar: their picture when
+// AvatarURL is set and a span with their initials otherwise. It renders
+// nothing when nobody is signed in.
+func UserAvatar(class string) H {
+	return func(b *Builder) Node {
+		user, ok := b.User()
+		if !ok {
+			return NewFragment()
+		}
+		class := strings.TrimSpace("minty-avatar " + class)
+		if user.AvatarURL != "" {
+			return b.Img(Src(user.AvatarURL), Alt(user.Name), Class(class))
+		}
+		return b.Span(Class(class), Title(user.Name), AriaLabel(user.Name), user.Initials())
+	}
+}
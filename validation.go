@@ -95,7 +95,8 @@ func ValidateMaxLength(value, fieldName string, maxLen int) *ValidationError {
 
 // Form field helpers with validation support
 
-// FormField creates a form field with label, input, and error display.
+// FormField creates a form field with label, input, and error display. An
+// error message is linked to the input with aria-describedby.
 func FormField(label, name, fieldType, value, errorMsg string, required bool, attributes ...Attribute) H {
 	return func(b *Builder) Node {
 		attrs := []Attribute{Type(fieldType), Name(name)}
@@ -105,11 +106,14 @@ func FormField(label, name, fieldType, value, errorMsg string, required bool, at
 		if required {
 			attrs = append(attrs, Required())
 		}
+		if errorMsg != "" {
+			attrs = append(attrs, Attr("aria-invalid", "true"), Attr("aria-describedby", name+"-error"))
+		}
 		attrs = append(attrs, attributes...)
 		
 		var errorElement Node = NewFragment()
 		if errorMsg != "" {
-			errorElement = ErrorMessage(errorMsg)(b)
+			errorElement = b.Div(ID(name+"-error"), ErrorMessage(errorMsg)(b))
 		}
 		
 		return b.Div(Class("form-field"),
@@ -133,11 +137,14 @@ func TextareaField(label, name, value, errorMsg string, required bool, rows, col
 		if cols > 0 {
 			attrs = append(attrs, Cols(cols))
 		}
+		if errorMsg != "" {
+			attrs = append(attrs, Attr("aria-invalid", "true"), Attr("aria-describedby", name+"-error"))
+		}
 		attrs = append(attrs, value) // Text content
 		
 		var errorElement Node = NewFragment()
 		if errorMsg != "" {
-			errorElement = ErrorMessage(errorMsg)(b)
+			errorElement = b.Div(ID(name+"-error"), ErrorMessage(errorMsg)(b))
 		}
 		
 		return b.Div(Class("form-field"),
@@ -148,39 +155,25 @@ func TextareaField(label, name, value, errorMsg string, required bool, rows, col
 	}
 }
 
-// SelectField creates a select field with options.
+// SelectField creates a select field with options. See Select for
+// placeholders, option groups and disabled options.
 func SelectField(label, name, value, errorMsg string, required bool, options []SelectOption) H {
-	return func(b *Builder) Node {
-		selectAttrs := []interface{}{Name(name)}
-		if required {
-			selectAttrs = append(selectAttrs, Required())
-		}
-		
-		for _, opt := range options {
-			optAttrs := []interface{}{Value(opt.Value), opt.Text}
-			if opt.Value == value {
-				optAttrs = append(optAttrs, Selected())
-			}
-			selectAttrs = append(selectAttrs, b.Option(optAttrs...))
-		}
-		
-		var errorElement Node = NewFragment()
-		if errorMsg != "" {
-			errorElement = ErrorMessage(errorMsg)(b)
-		}
-		
-		return b.Div(Class("form-field"),
-			b.Label(For(name), label),
-			b.Select(selectAttrs...),
-			errorElement,
-		)
-	}
+	return Select(SelectOptions{
+		Label:    label,
+		Name:     name,
+		Value:    value,
+		Options:  options,
+		Required: required,
+		Error:    errorMsg,
+	})
 }
 
 // SelectOption represents an option in a select field.
 type SelectOption struct {
-	Value string
-	Text  string
+	Value    string
+	Text     string
+	Group    string // Optgroup label; empty for ungrouped options
+	Disabled bool
 }
 
 